@@ -0,0 +1,138 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// A Progress reports the progress of a computation run by
+// [RunCancellable], and tells it when to stop.
+type Progress struct {
+	ctx    context.Context
+	client Client
+	token  ProgressToken
+
+	mu    sync.Mutex
+	ended bool // the progress has ended
+}
+
+// Check returns a non-nil error if the computation should stop because
+// its context is done. Long computations should call it periodically,
+// and return its error, so as not to go on in vain once RunCancellable
+// has returned.
+func (p *Progress) Check() error {
+	return p.ctx.Err()
+}
+
+// Report sends a work-done progress report with the given message and
+// percentage, which is omitted if negative, and then checks the context
+// as Check does. It sends nothing if the request had no work-done token,
+// or once the progress has ended.
+func (p *Progress) Report(message string, percentage int) error {
+	p.mu.Lock()
+	if p.token != nil && !p.ended {
+		report := &WorkDoneProgressReport{Kind: "report", Message: message}
+		if percentage >= 0 {
+			pct := uint32(min(percentage, 100))
+			report.Percentage = &pct
+		}
+		_ = p.client.Progress(p.ctx, &ProgressParams{Token: p.token, Value: report})
+	}
+	p.mu.Unlock()
+	return p.Check()
+}
+
+// RunCancellable calls fn, reporting its work-done progress under the
+// given title if token is non-nil, typically the WorkDoneToken of the
+// request's params, and returns its result. It packages the recommended
+// handling of cancellation for expensive requests such as full semantic
+// tokens:
+//
+//	err := lsp.RunCancellable(ctx, client, params.WorkDoneToken, "Computing tokens", func(ctx context.Context, p *lsp.Progress) error {
+//		for i, decl := range decls {
+//			if err := p.Check(); err != nil {
+//				return err
+//			}
+//			...
+//		}
+//		return nil
+//	})
+//
+// RunCancellable watches ctx while fn runs, in a goroutine of its own,
+// and returns as soon as ctx is done, even if fn does not notice, so that
+// the request is answered at once. It then leaves fn to finish in the
+// background and discards its result, and a panic of fn, which is
+// otherwise propagated; fn should therefore check its context, or
+// Progress.Check, to stop working in vain.
+//
+// If fn fails after ctx is done, or ctx is done before fn returns,
+// RunCancellable returns ErrRequestCancelled when the client cancelled
+// the request, the cause of the cancellation when it is a *ResponseError (so that a server may cancel its own
+// computations with context.WithCancelCause(ctx) and, for instance,
+// ErrContentModified), or else, for example on a server-imposed
+// deadline, ServerCancelledError(true), inviting the client to
+// retrigger the request.
+func RunCancellable(ctx context.Context, client Client, token ProgressToken, title string, fn func(context.Context, *Progress) error) error {
+	p := &Progress{ctx: ctx, client: client, token: token}
+	if token != nil {
+		_ = client.Progress(ctx, &ProgressParams{Token: token, Value: &WorkDoneProgressBegin{Kind: "begin", Title: title}})
+	}
+	type outcome struct {
+		err   error
+		panic any
+	}
+	done := make(chan outcome, 1) // not blocking fn once abandoned
+	go func() {
+		var o outcome
+		defer func() {
+			if o.panic = recover(); o.panic != nil {
+				done <- o
+			}
+		}()
+		o.err = fn(ctx, p)
+		done <- o
+	}()
+	var err error
+	select {
+	case o := <-done:
+		if o.panic != nil {
+			p.end()
+			panic(o.panic)
+		}
+		err = o.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	p.end()
+	if err != nil && ctx.Err() != nil {
+		var rerr *ResponseError
+		switch cause := context.Cause(ctx); {
+		case errors.As(cause, &rerr):
+			err = rerr
+		case errors.Is(cause, context.Canceled):
+			err = ErrRequestCancelled
+		default:
+			err = ServerCancelledError(true)
+		}
+	}
+	if token != nil {
+		end := &WorkDoneProgressEnd{Kind: "end"}
+		if err != nil {
+			end.Message = err.Error()
+		}
+		_ = client.Progress(detach(ctx), &ProgressParams{Token: token, Value: end})
+	}
+	return err
+}
+
+// end ends the progress, after which no report is sent.
+func (p *Progress) end() {
+	p.mu.Lock()
+	p.ended = true
+	p.mu.Unlock()
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"typefox.dev/lsp"
+)
+
+// progressClient records the kinds of the progress notifications it receives.
+type progressClient struct {
+	lsp.Client
+	kinds []string
+}
+
+func (c *progressClient) Progress(_ context.Context, params *lsp.ProgressParams) error {
+	switch v := params.Value.(type) {
+	case *lsp.WorkDoneProgressBegin:
+		c.kinds = append(c.kinds, v.Kind+" "+v.Title)
+	case *lsp.WorkDoneProgressReport:
+		c.kinds = append(c.kinds, fmt.Sprintf("%s %d%%", v.Kind, *v.Percentage))
+	case *lsp.WorkDoneProgressEnd:
+		c.kinds = append(c.kinds, v.Kind)
+	}
+	return nil
+}
+
+func TestRunCancellable(t *testing.T) {
+	work := func(ctx context.Context, p *lsp.Progress) error {
+		for i := 0; i <= 100; i += 50 {
+			if err := p.Report("working", i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("Completes", func(t *testing.T) {
+		client := new(progressClient)
+		if err := lsp.RunCancellable(context.Background(), client, "token", "Tokens", work); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"begin Tokens", "report 0%", "report 50%", "report 100%", "end"}
+		if fmt.Sprint(client.kinds) != fmt.Sprint(want) {
+			t.Errorf("Expected progress %q, got %q", want, client.kinds)
+		}
+	})
+
+	t.Run("NoToken", func(t *testing.T) {
+		client := new(progressClient)
+		if err := lsp.RunCancellable(context.Background(), client, nil, "Tokens", work); err != nil {
+			t.Fatal(err)
+		}
+		if len(client.kinds) != 0 {
+			t.Errorf("Expected no progress without a token, got %q", client.kinds)
+		}
+	})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	modified, cancelModified := context.WithCancelCause(context.Background())
+	cancelModified(lsp.ErrContentModified)
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+	for _, test := range []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"ClientCancelled", cancelled, lsp.ErrRequestCancelled},
+		{"ContentModified", modified, lsp.ErrContentModified},
+		{"ServerCancelled", expired, lsp.ErrServerCancelled},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := new(progressClient)
+			err := lsp.RunCancellable(test.ctx, client, "token", "Tokens", work)
+			if !errors.Is(err, test.want) {
+				t.Errorf("Expected %v, got %v", test.want, err)
+			}
+			if got := client.kinds; len(got) == 0 || got[len(got)-1] != "end" {
+				t.Errorf("Expected the progress to end, got %q", got)
+			}
+		})
+	}
+
+	t.Run("Unchecked", func(t *testing.T) {
+		// A computation that never checks its context does not delay
+		// the reply once the request is cancelled.
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		errc := make(chan error, 1)
+		go func() {
+			errc <- lsp.RunCancellable(ctx, new(progressClient), nil, "Tokens", func(context.Context, *lsp.Progress) error {
+				<-release
+				return nil
+			})
+		}()
+		cancel()
+		select {
+		case err := <-errc:
+			if !errors.Is(err, lsp.ErrRequestCancelled) {
+				t.Errorf("Expected %v, got %v", lsp.ErrRequestCancelled, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("RunCancellable did not return once cancelled")
+		}
+	})
+
+	t.Run("Retrigger", func(t *testing.T) {
+		err := lsp.RunCancellable(expired, new(progressClient), nil, "Tokens", work)
+		rerr, ok := lsp.ErrorFrom(err)
+		if !ok {
+			t.Fatalf("Expected a ResponseError, got %v", err)
+		}
+		if data, ok := rerr.Data.(lsp.DiagnosticServerCancellationData); !ok || !data.RetriggerRequest {
+			t.Errorf("Expected the client to be asked to retrigger, got %+v", rerr.Data)
+		}
+	})
+}
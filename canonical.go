@@ -0,0 +1,52 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MarshalCanonical returns the canonical JSON encoding of v, in which
+// equal values have identical encodings, so that encodings may be
+// compared byte for byte, as in golden files and message traces.
+//
+// The canonical encoding is compact, with the properties of every
+// object in sorted order, whether it comes from a struct, a map (such as
+// the Changes of a WorkspaceEdit) or embedded raw JSON (such as the Data
+// of a Diagnostic or the Arguments of a Command); and strings are not
+// HTML-escaped. Union types encode as their single set case, or null.
+func MarshalCanonical(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(data)
+}
+
+// Canonicalize returns the canonical form, as defined by
+// [MarshalCanonical], of the JSON value data.
+// Numbers keep their original representation.
+func Canonicalize(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: data after top-level value")
+	}
+	// Decoded objects are maps, which encoding/json encodes in key order.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
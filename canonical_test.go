@@ -0,0 +1,88 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	t.Run("SortedKeys", func(t *testing.T) {
+		data := json.RawMessage(`{"z": 1, "a": {"y": true, "b": null}}`)
+		edit := &lsp.WorkspaceEdit{
+			Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+				"file:///b.go": {{Range: rng(0, 0, 0), NewText: "<b>"}},
+				"file:///a.go": {{Range: rng(1, 0, 1), NewText: "a"}},
+			},
+		}
+		diag := lsp.Diagnostic{Range: rng(0, 0, 1), Data: &data}
+
+		got, err := lsp.MarshalCanonical(edit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"changes":{"file:///a.go":[{"newText":"a","range":{"end":{"character":1,"line":1},"start":{"character":0,"line":1}}}],` +
+			`"file:///b.go":[{"newText":"<b>","range":{"end":{"character":0,"line":0},"start":{"character":0,"line":0}}}]}}`
+		if string(got) != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+
+		got, err = lsp.MarshalCanonical(diag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = `{"code":null,"data":{"a":{"b":null,"y":true},"z":1},"message":null,"range":{"end":{"character":1,"line":0},"start":{"character":0,"line":0}}}`
+		if string(got) != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		// The same value, decoded from differently formatted messages.
+		var x, y lsp.Command
+		if err := json.Unmarshal([]byte(`{"title":"t","command":"c","arguments":[{"a":1,"b":2}]}`), &x); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(`{"command":"c","title":"t","arguments":[{ "b": 2, "a": 1 }]}`), &y); err != nil {
+			t.Fatal(err)
+		}
+		cx, err := lsp.MarshalCanonical(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cy, err := lsp.MarshalCanonical(y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(cx) != string(cy) {
+			t.Errorf("Expected equal encodings, got %s and %s", cx, cy)
+		}
+	})
+
+	t.Run("Canonicalize", func(t *testing.T) {
+		for _, test := range []struct {
+			in, want string
+		}{
+			{`1.50`, `1.50`},
+			{` [ "a&b" , {} ] `, `["a&b",{}]`},
+			{`{"b":[],"a":"x"}`, `{"a":"x","b":[]}`},
+		} {
+			got, err := lsp.Canonicalize([]byte(test.in))
+			if err != nil {
+				t.Errorf("Canonicalize(%s) failed: %v", test.in, err)
+			} else if string(got) != test.want {
+				t.Errorf("Expected Canonicalize(%s) = %s, got %s", test.in, test.want, got)
+			}
+		}
+		for _, in := range []string{``, `{`, `1 2`} {
+			if _, err := lsp.Canonicalize([]byte(in)); err == nil {
+				t.Errorf("Expected Canonicalize(%q) to fail", in)
+			}
+		}
+	})
+}
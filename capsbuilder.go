@@ -0,0 +1,264 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A ClientCapabilitiesBuilder builds ClientCapabilities, allocating the
+// nested structs as needed:
+//
+//	caps := lsp.NewClientCapabilities().
+//		WithSnippetSupport().
+//		WithHierarchicalSymbols().
+//		WithDynamicRegistration("textDocument/hover").
+//		Build()
+//
+// Each method modifies the builder and returns it. The With method
+// allows arbitrary changes.
+type ClientCapabilitiesBuilder struct {
+	caps ClientCapabilities
+}
+
+// NewClientCapabilities returns a builder of ClientCapabilities that
+// initially declare no capability.
+func NewClientCapabilities() *ClientCapabilitiesBuilder {
+	return &ClientCapabilitiesBuilder{}
+}
+
+// Build returns the capabilities built so far. They do not share memory
+// with the builder, which may continue to be used.
+func (b *ClientCapabilitiesBuilder) Build() *ClientCapabilities {
+	// ClientCapabilities round-trip through JSON, which makes a deep copy.
+	data, err := json.Marshal(&b.caps)
+	if err != nil {
+		panic(err) // can't happen
+	}
+	caps := new(ClientCapabilities)
+	if err := json.Unmarshal(data, caps); err != nil {
+		panic(err) // can't happen
+	}
+	return caps
+}
+
+// With calls f to modify the capabilities.
+func (b *ClientCapabilitiesBuilder) With(f func(*ClientCapabilities)) *ClientCapabilitiesBuilder {
+	f(&b.caps)
+	return b
+}
+
+// WithSnippetSupport declares that completion items may be snippets.
+func (b *ClientCapabilitiesBuilder) WithSnippetSupport() *ClientCapabilitiesBuilder {
+	b.caps.TextDocument.Completion.CompletionItem.SnippetSupport = true
+	return b
+}
+
+// WithInsertReplaceSupport declares that completion items may have
+// insert/replace edits.
+func (b *ClientCapabilitiesBuilder) WithInsertReplaceSupport() *ClientCapabilitiesBuilder {
+	b.caps.TextDocument.Completion.CompletionItem.InsertReplaceSupport = true
+	return b
+}
+
+// WithLabelDetails declares support for the labelDetails of completion
+// items.
+func (b *ClientCapabilitiesBuilder) WithLabelDetails() *ClientCapabilitiesBuilder {
+	b.caps.TextDocument.Completion.CompletionItem.LabelDetailsSupport = true
+	return b
+}
+
+// WithCompletionItemDefaults declares support for the given properties
+// of the itemDefaults of completion lists, such as "editRange".
+func (b *ClientCapabilitiesBuilder) WithCompletionItemDefaults(properties ...string) *ClientCapabilitiesBuilder {
+	c := &b.caps.TextDocument.Completion
+	if c.CompletionList == nil {
+		c.CompletionList = new(CompletionListCapabilities)
+	}
+	c.CompletionList.ItemDefaults = appendNew(c.CompletionList.ItemDefaults, properties...)
+	return b
+}
+
+// WithHierarchicalSymbols declares that document symbols may be
+// returned as a hierarchy of DocumentSymbols.
+func (b *ClientCapabilitiesBuilder) WithHierarchicalSymbols() *ClientCapabilitiesBuilder {
+	b.caps.TextDocument.DocumentSymbol.HierarchicalDocumentSymbolSupport = true
+	return b
+}
+
+// WithMarkdown declares that hovers, and the documentation of completion
+// items and signatures, may be in Markdown (preferably) or plain text.
+func (b *ClientCapabilitiesBuilder) WithMarkdown() *ClientCapabilitiesBuilder {
+	formats := []MarkupKind{Markdown, PlainText}
+	td := &b.caps.TextDocument
+	if td.Hover == nil {
+		td.Hover = new(HoverClientCapabilities)
+	}
+	td.Hover.ContentFormat = formats
+	td.Completion.CompletionItem.DocumentationFormat = formats
+	if td.SignatureHelp == nil {
+		td.SignatureHelp = new(SignatureHelpClientCapabilities)
+	}
+	if td.SignatureHelp.SignatureInformation == nil {
+		td.SignatureHelp.SignatureInformation = new(ClientSignatureInformationOptions)
+	}
+	td.SignatureHelp.SignatureInformation.DocumentationFormat = formats
+	return b
+}
+
+// WithDefinitionLinks declares that declarations, definitions, type
+// definitions and implementations may be returned as LocationLinks.
+func (b *ClientCapabilitiesBuilder) WithDefinitionLinks() *ClientCapabilitiesBuilder {
+	td := &b.caps.TextDocument
+	td.Declaration = &DeclarationClientCapabilities{LinkSupport: true}
+	td.Definition = &DefinitionClientCapabilities{LinkSupport: true}
+	td.TypeDefinition = &TypeDefinitionClientCapabilities{LinkSupport: true}
+	td.Implementation = &ImplementationClientCapabilities{LinkSupport: true}
+	return b
+}
+
+// WithPullDiagnostics declares support for the textDocument/diagnostic
+// request, and for workspace/diagnostic/refresh.
+func (b *ClientCapabilitiesBuilder) WithPullDiagnostics() *ClientCapabilitiesBuilder {
+	if b.caps.TextDocument.Diagnostic == nil {
+		b.caps.TextDocument.Diagnostic = new(DiagnosticClientCapabilities)
+	}
+	b.caps.Workspace.Diagnostics = &DiagnosticWorkspaceClientCapabilities{RefreshSupport: true}
+	return b
+}
+
+// WithWorkspaceEdit declares that the client applies workspace edits,
+// with DocumentChanges if documentChanges is set, and with the given
+// resource operations.
+func (b *ClientCapabilitiesBuilder) WithWorkspaceEdit(documentChanges bool, operations ...ResourceOperationKind) *ClientCapabilitiesBuilder {
+	b.caps.Workspace.ApplyEdit = true
+	b.caps.Workspace.WorkspaceEdit = &WorkspaceEditClientCapabilities{
+		DocumentChanges:    documentChanges,
+		ResourceOperations: slices.Clone(operations),
+	}
+	return b
+}
+
+// WithWorkspaceFolders declares support for workspace folders.
+func (b *ClientCapabilitiesBuilder) WithWorkspaceFolders() *ClientCapabilitiesBuilder {
+	b.caps.Workspace.WorkspaceFolders = true
+	return b
+}
+
+// WithConfiguration declares support for the workspace/configuration
+// request.
+func (b *ClientCapabilitiesBuilder) WithConfiguration() *ClientCapabilitiesBuilder {
+	b.caps.Workspace.Configuration = true
+	return b
+}
+
+// WithWorkDoneProgress declares support for server-initiated progress.
+func (b *ClientCapabilitiesBuilder) WithWorkDoneProgress() *ClientCapabilitiesBuilder {
+	b.caps.Window.WorkDoneProgress = true
+	return b
+}
+
+// WithPositionEncodings declares the position encodings the client
+// supports, in order of preference.
+func (b *ClientCapabilitiesBuilder) WithPositionEncodings(encodings ...PositionEncodingKind) *ClientCapabilitiesBuilder {
+	if b.caps.General == nil {
+		b.caps.General = new(GeneralClientCapabilities)
+	}
+	b.caps.General.PositionEncodings = slices.Clone(encodings)
+	return b
+}
+
+// WithDynamicRegistration declares that the client supports dynamic
+// registration of the capabilities for the given methods. Several
+// methods may share a capability, such as textDocument/didOpen and
+// textDocument/didClose. It panics if a method cannot be registered.
+func (b *ClientCapabilitiesBuilder) WithDynamicRegistration(methods ...string) *ClientCapabilitiesBuilder {
+	for _, method := range methods {
+		path, ok := dynamicRegistrationPaths[method]
+		if !ok {
+			panic(fmt.Sprintf("method %q does not support dynamic registration", method))
+		}
+		v := reflect.ValueOf(&b.caps).Elem()
+		for _, name := range strings.Split(path, ".") {
+			v = v.FieldByName(name)
+			if v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v.FieldByName("DynamicRegistration").SetBool(true)
+	}
+	return b
+}
+
+// dynamicRegistrationPaths maps each method that may be registered
+// dynamically to the field path, within ClientCapabilities, of the
+// capabilities that declare whether the client supports it.
+var dynamicRegistrationPaths = map[string]string{
+	"textDocument/didOpen":              "TextDocument.Synchronization",
+	"textDocument/didChange":            "TextDocument.Synchronization",
+	"textDocument/didClose":             "TextDocument.Synchronization",
+	"textDocument/didSave":              "TextDocument.Synchronization",
+	"textDocument/willSave":             "TextDocument.Synchronization",
+	"textDocument/willSaveWaitUntil":    "TextDocument.Synchronization",
+	"textDocument/completion":           "TextDocument.Completion",
+	"textDocument/hover":                "TextDocument.Hover",
+	"textDocument/signatureHelp":        "TextDocument.SignatureHelp",
+	"textDocument/declaration":          "TextDocument.Declaration",
+	"textDocument/definition":           "TextDocument.Definition",
+	"textDocument/typeDefinition":       "TextDocument.TypeDefinition",
+	"textDocument/implementation":       "TextDocument.Implementation",
+	"textDocument/references":           "TextDocument.References",
+	"textDocument/documentHighlight":    "TextDocument.DocumentHighlight",
+	"textDocument/documentSymbol":       "TextDocument.DocumentSymbol",
+	"textDocument/codeAction":           "TextDocument.CodeAction",
+	"textDocument/codeLens":             "TextDocument.CodeLens",
+	"textDocument/documentLink":         "TextDocument.DocumentLink",
+	"textDocument/documentColor":        "TextDocument.ColorProvider",
+	"textDocument/formatting":           "TextDocument.Formatting",
+	"textDocument/rangeFormatting":      "TextDocument.RangeFormatting",
+	"textDocument/rangesFormatting":     "TextDocument.RangeFormatting",
+	"textDocument/onTypeFormatting":     "TextDocument.OnTypeFormatting",
+	"textDocument/rename":               "TextDocument.Rename",
+	"textDocument/foldingRange":         "TextDocument.FoldingRange",
+	"textDocument/selectionRange":       "TextDocument.SelectionRange",
+	"textDocument/prepareCallHierarchy": "TextDocument.CallHierarchy",
+	"textDocument/semanticTokens":       "TextDocument.SemanticTokens",
+	"textDocument/linkedEditingRange":   "TextDocument.LinkedEditingRange",
+	"textDocument/moniker":              "TextDocument.Moniker",
+	"textDocument/prepareTypeHierarchy": "TextDocument.TypeHierarchy",
+	"textDocument/inlineValue":          "TextDocument.InlineValue",
+	"textDocument/inlayHint":            "TextDocument.InlayHint",
+	"textDocument/diagnostic":           "TextDocument.Diagnostic",
+	"textDocument/inlineCompletion":     "TextDocument.InlineCompletion",
+	"notebookDocument/sync":             "NotebookDocument.Synchronization",
+	"workspace/didChangeConfiguration":  "Workspace.DidChangeConfiguration",
+	"workspace/didChangeWatchedFiles":   "Workspace.DidChangeWatchedFiles",
+	"workspace/symbol":                  "Workspace.Symbol",
+	"workspace/executeCommand":          "Workspace.ExecuteCommand",
+	"workspace/didCreateFiles":          "Workspace.FileOperations",
+	"workspace/willCreateFiles":         "Workspace.FileOperations",
+	"workspace/didRenameFiles":          "Workspace.FileOperations",
+	"workspace/willRenameFiles":         "Workspace.FileOperations",
+	"workspace/didDeleteFiles":          "Workspace.FileOperations",
+	"workspace/willDeleteFiles":         "Workspace.FileOperations",
+	"workspace/textDocumentContent":     "Workspace.TextDocumentContent",
+}
+
+// appendNew appends to slice the elements of elems it does not contain.
+func appendNew[T comparable](slice []T, elems ...T) []T {
+	for _, e := range elems {
+		if !slices.Contains(slice, e) {
+			slice = append(slice, e)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestClientCapabilitiesBuilder(t *testing.T) {
+	t.Run("Chain", func(t *testing.T) {
+		caps := lsp.NewClientCapabilities().
+			WithSnippetSupport().
+			WithHierarchicalSymbols().
+			WithDynamicRegistration("textDocument/hover", "textDocument/didOpen", "workspace/willRenameFiles").
+			WithWorkspaceEdit(true, lsp.Create, lsp.Rename).
+			WithPositionEncodings(lsp.UTF8, lsp.UTF16).
+			Build()
+
+		td := caps.TextDocument
+		if !td.Completion.CompletionItem.SnippetSupport {
+			t.Errorf("Expected snippet support")
+		}
+		if !td.DocumentSymbol.HierarchicalDocumentSymbolSupport {
+			t.Errorf("Expected hierarchical symbol support")
+		}
+		if td.Hover == nil || !td.Hover.DynamicRegistration {
+			t.Errorf("Expected dynamic registration of hover, got %+v", td.Hover)
+		}
+		if td.Synchronization == nil || !td.Synchronization.DynamicRegistration {
+			t.Errorf("Expected dynamic registration of synchronization, got %+v", td.Synchronization)
+		}
+		if fo := caps.Workspace.FileOperations; fo == nil || !fo.DynamicRegistration {
+			t.Errorf("Expected dynamic registration of file operations, got %+v", fo)
+		}
+		if td.Definition != nil {
+			t.Errorf("Expected no definition capabilities, got %+v", td.Definition)
+		}
+		if we := caps.Workspace.WorkspaceEdit; !caps.Workspace.ApplyEdit || we == nil || !we.DocumentChanges || len(we.ResourceOperations) != 2 {
+			t.Errorf("Expected workspace edit support, got %+v", we)
+		}
+		if g := caps.General; g == nil || len(g.PositionEncodings) != 2 || g.PositionEncodings[0] != lsp.UTF8 {
+			t.Errorf("Expected position encodings [utf-8 utf-16], got %+v", g)
+		}
+	})
+
+	t.Run("BuildCopies", func(t *testing.T) {
+		b := lsp.NewClientCapabilities().WithMarkdown()
+		first := b.Build()
+		first.TextDocument.Hover.ContentFormat[0] = lsp.PlainText
+		b.WithDefinitionLinks()
+		second := b.Build()
+		if got := second.TextDocument.Hover.ContentFormat[0]; got != lsp.Markdown {
+			t.Errorf("Expected %s, got %s", lsp.Markdown, got)
+		}
+		if first.TextDocument.Definition != nil {
+			t.Errorf("Expected earlier capabilities to be unaffected, got %+v", first.TextDocument.Definition)
+		}
+		if d := second.TextDocument.Definition; d == nil || !d.LinkSupport {
+			t.Errorf("Expected definition link support, got %+v", d)
+		}
+	})
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic for a method that cannot be registered")
+			}
+		}()
+		lsp.NewClientCapabilities().WithDynamicRegistration("initialize")
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ServerSupports reports whether a server with the given capabilities
+// declares that it handles method, a method sent by the client.
+//
+// Methods that every server must handle, such as shutdown, and those
+// whose support is not declared by the capabilities, such as
+// workspace/didChangeConfiguration, are reported as supported. Methods
+// that a server may only support by registering them dynamically are
+// reported as unsupported, as are unknown methods.
+func ServerSupports(caps *ServerCapabilities, method string) bool {
+	info, ok := methods[method]
+	if !ok || !info.toServer {
+		return false
+	}
+	supports, ok := serverMethodSupport[method]
+	if !ok {
+		return true // not governed by a capability
+	}
+	return caps != nil && supports(caps)
+}
+
+// UnsupportedMethods returns the methods, among those a client intends
+// to call, that a server with the given capabilities does not support,
+// according to [ServerSupports].
+func UnsupportedMethods(caps *ServerCapabilities, methods []string) []string {
+	var unsupported []string
+	for _, method := range methods {
+		if !ServerSupports(caps, method) {
+			unsupported = append(unsupported, method)
+		}
+	}
+	return unsupported
+}
+
+// A CapabilityGap describes a way in which a server provides less than
+// another.
+type CapabilityGap struct {
+	Method string // the method concerned
+	Detail string // what is missing; empty if the method is unsupported
+}
+
+func (g CapabilityGap) String() string {
+	if g.Detail == "" {
+		return g.Method + ": not supported"
+	}
+	return g.Method + ": " + g.Detail
+}
+
+// CapabilityGaps reports the ways in which a server with capabilities
+// caps provides less than one with capabilities want, as when replacing
+// one server with another behind a proxy: the methods that want
+// supports but caps does not, and, for methods both support, missing
+// trigger characters, code action kinds, commands, semantic token
+// types and modifiers, incremental synchronization and the like.
+// Gaps are reported in order of method.
+func CapabilityGaps(want, caps *ServerCapabilities) []CapabilityGap {
+	var gaps []CapabilityGap
+	for _, method := range slices.Sorted(maps.Keys(serverMethodSupport)) {
+		if !ServerSupports(want, method) {
+			continue
+		}
+		if !ServerSupports(caps, method) {
+			gaps = append(gaps, CapabilityGap{Method: method})
+			continue
+		}
+		missing := func(what string, want, have []string) {
+			var ms []string
+			for _, s := range want {
+				if !slices.Contains(have, s) {
+					ms = append(ms, s)
+				}
+			}
+			if len(ms) > 0 {
+				gaps = append(gaps, CapabilityGap{Method: method, Detail: fmt.Sprintf("missing %s %q", what, ms)})
+			}
+		}
+		switch method {
+		case "textDocument/didChange":
+			if want.TextDocumentSync.Change == Incremental && caps.TextDocumentSync.Change != Incremental {
+				gaps = append(gaps, CapabilityGap{Method: method, Detail: "no incremental synchronization"})
+			}
+		case "textDocument/didSave":
+			if want.TextDocumentSync.Save.IncludeText && !caps.TextDocumentSync.Save.IncludeText {
+				gaps = append(gaps, CapabilityGap{Method: method, Detail: "text not included on save"})
+			}
+		case "textDocument/completion":
+			missing("trigger characters", want.CompletionProvider.TriggerCharacters, caps.CompletionProvider.TriggerCharacters)
+			missing("commit characters", want.CompletionProvider.AllCommitCharacters, caps.CompletionProvider.AllCommitCharacters)
+		case "textDocument/signatureHelp":
+			missing("trigger characters", want.SignatureHelpProvider.TriggerCharacters, caps.SignatureHelpProvider.TriggerCharacters)
+			missing("retrigger characters", want.SignatureHelpProvider.RetriggerCharacters, caps.SignatureHelpProvider.RetriggerCharacters)
+		case "textDocument/codeAction":
+			missing("code action kinds", asStrings(want.CodeActionProvider.CodeActionKinds), asStrings(caps.CodeActionProvider.CodeActionKinds))
+		case "textDocument/onTypeFormatting":
+			wantChars := append([]string{want.DocumentOnTypeFormattingProvider.FirstTriggerCharacter}, want.DocumentOnTypeFormattingProvider.MoreTriggerCharacter...)
+			haveChars := append([]string{caps.DocumentOnTypeFormattingProvider.FirstTriggerCharacter}, caps.DocumentOnTypeFormattingProvider.MoreTriggerCharacter...)
+			missing("trigger characters", wantChars, haveChars)
+		case "textDocument/semanticTokens/full":
+			wantLegend, haveLegend := want.SemanticTokensProvider.Legend, caps.SemanticTokensProvider.Legend
+			missing("token types", wantLegend.TokenTypes, haveLegend.TokenTypes)
+			missing("token modifiers", wantLegend.TokenModifiers, haveLegend.TokenModifiers)
+		case "workspace/executeCommand":
+			missing("commands", want.ExecuteCommandProvider.Commands, caps.ExecuteCommandProvider.Commands)
+		}
+	}
+	if positionEncoding(want) != positionEncoding(caps) {
+		gaps = append(gaps, CapabilityGap{
+			Method: "initialize",
+			Detail: fmt.Sprintf("position encoding %s, want %s", positionEncoding(caps), positionEncoding(want)),
+		})
+	}
+	return gaps
+}
+
+// positionEncoding returns the position encoding of a server.
+func positionEncoding(caps *ServerCapabilities) PositionEncodingKind {
+	if caps == nil || caps.PositionEncoding == nil {
+		return UTF16
+	}
+	return *caps.PositionEncoding
+}
+
+func asStrings[S ~string](values []S) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+	return strs
+}
+
+// serverMethodSupport maps each method sent by the client whose support
+// a server declares in its capabilities to a function reporting whether
+// the capabilities declare it.
+var serverMethodSupport = map[string]func(*ServerCapabilities) bool{
+	"textDocument/didOpen":  func(c *ServerCapabilities) bool { return c.TextDocumentSync != nil && c.TextDocumentSync.OpenClose },
+	"textDocument/didClose": func(c *ServerCapabilities) bool { return c.TextDocumentSync != nil && c.TextDocumentSync.OpenClose },
+	"textDocument/didChange": func(c *ServerCapabilities) bool {
+		return c.TextDocumentSync != nil && c.TextDocumentSync.Change != None
+	},
+	"textDocument/didSave":  func(c *ServerCapabilities) bool { return c.TextDocumentSync != nil && c.TextDocumentSync.Save != nil },
+	"textDocument/willSave": func(c *ServerCapabilities) bool { return c.TextDocumentSync != nil && c.TextDocumentSync.WillSave },
+	"textDocument/willSaveWaitUntil": func(c *ServerCapabilities) bool {
+		return c.TextDocumentSync != nil && c.TextDocumentSync.WillSaveWaitUntil
+	},
+
+	"notebookDocument/didOpen":   func(c *ServerCapabilities) bool { return c.NotebookDocumentSync != nil },
+	"notebookDocument/didChange": func(c *ServerCapabilities) bool { return c.NotebookDocumentSync != nil },
+	"notebookDocument/didSave":   func(c *ServerCapabilities) bool { return c.NotebookDocumentSync != nil },
+	"notebookDocument/didClose":  func(c *ServerCapabilities) bool { return c.NotebookDocumentSync != nil },
+
+	"textDocument/completion": func(c *ServerCapabilities) bool { return c.CompletionProvider != nil },
+	"completionItem/resolve": func(c *ServerCapabilities) bool {
+		return c.CompletionProvider != nil && c.CompletionProvider.ResolveProvider
+	},
+	"textDocument/hover":             func(c *ServerCapabilities) bool { return c.HoverProvider != nil },
+	"textDocument/signatureHelp":     func(c *ServerCapabilities) bool { return c.SignatureHelpProvider != nil },
+	"textDocument/declaration":       func(c *ServerCapabilities) bool { return c.DeclarationProvider != nil },
+	"textDocument/definition":        func(c *ServerCapabilities) bool { return c.DefinitionProvider != nil },
+	"textDocument/typeDefinition":    func(c *ServerCapabilities) bool { return c.TypeDefinitionProvider != nil },
+	"textDocument/implementation":    func(c *ServerCapabilities) bool { return c.ImplementationProvider != nil },
+	"textDocument/references":        func(c *ServerCapabilities) bool { return c.ReferencesProvider != nil },
+	"textDocument/documentHighlight": func(c *ServerCapabilities) bool { return c.DocumentHighlightProvider != nil },
+	"textDocument/documentSymbol":    func(c *ServerCapabilities) bool { return c.DocumentSymbolProvider != nil },
+	"textDocument/codeAction":        func(c *ServerCapabilities) bool { return c.CodeActionProvider != nil },
+	"codeAction/resolve": func(c *ServerCapabilities) bool {
+		return c.CodeActionProvider != nil && c.CodeActionProvider.ResolveProvider
+	},
+	"textDocument/codeLens": func(c *ServerCapabilities) bool { return c.CodeLensProvider != nil },
+	"codeLens/resolve": func(c *ServerCapabilities) bool {
+		return c.CodeLensProvider != nil && c.CodeLensProvider.ResolveProvider
+	},
+	"textDocument/documentLink": func(c *ServerCapabilities) bool { return c.DocumentLinkProvider != nil },
+	"documentLink/resolve": func(c *ServerCapabilities) bool {
+		return c.DocumentLinkProvider != nil && c.DocumentLinkProvider.ResolveProvider
+	},
+	"textDocument/documentColor":     func(c *ServerCapabilities) bool { return c.ColorProvider != nil },
+	"textDocument/colorPresentation": func(c *ServerCapabilities) bool { return c.ColorProvider != nil },
+	"textDocument/formatting":        func(c *ServerCapabilities) bool { return c.DocumentFormattingProvider != nil },
+	"textDocument/rangeFormatting":   func(c *ServerCapabilities) bool { return c.DocumentRangeFormattingProvider != nil },
+	"textDocument/rangesFormatting": func(c *ServerCapabilities) bool {
+		return c.DocumentRangeFormattingProvider != nil && c.DocumentRangeFormattingProvider.RangesSupport
+	},
+	"textDocument/onTypeFormatting": func(c *ServerCapabilities) bool { return c.DocumentOnTypeFormattingProvider != nil },
+	"textDocument/rename":           func(c *ServerCapabilities) bool { return c.RenameProvider != nil },
+	"textDocument/prepareRename": func(c *ServerCapabilities) bool {
+		return c.RenameProvider != nil && c.RenameProvider.PrepareProvider
+	},
+	"textDocument/foldingRange":         func(c *ServerCapabilities) bool { return c.FoldingRangeProvider != nil },
+	"textDocument/selectionRange":       func(c *ServerCapabilities) bool { return c.SelectionRangeProvider != nil },
+	"textDocument/prepareCallHierarchy": func(c *ServerCapabilities) bool { return c.CallHierarchyProvider != nil },
+	"callHierarchy/incomingCalls":       func(c *ServerCapabilities) bool { return c.CallHierarchyProvider != nil },
+	"callHierarchy/outgoingCalls":       func(c *ServerCapabilities) bool { return c.CallHierarchyProvider != nil },
+	"textDocument/linkedEditingRange":   func(c *ServerCapabilities) bool { return c.LinkedEditingRangeProvider != nil },
+	"textDocument/semanticTokens/full": func(c *ServerCapabilities) bool {
+		if c.SemanticTokensProvider == nil || c.SemanticTokensProvider.Full == nil {
+			return false
+		}
+		full := c.SemanticTokensProvider.Full
+		return full.SemanticTokensFullDelta != nil || full.Bool != nil && *full.Bool
+	},
+	"textDocument/semanticTokens/full/delta": func(c *ServerCapabilities) bool {
+		if c.SemanticTokensProvider == nil || c.SemanticTokensProvider.Full == nil {
+			return false
+		}
+		delta := c.SemanticTokensProvider.Full.SemanticTokensFullDelta
+		return delta != nil && delta.Delta
+	},
+	"textDocument/semanticTokens/range": func(c *ServerCapabilities) bool {
+		if c.SemanticTokensProvider == nil || c.SemanticTokensProvider.Range == nil {
+			return false
+		}
+		rng := c.SemanticTokensProvider.Range
+		return rng.PRangeESemanticTokensOptions != nil || rng.Bool != nil && *rng.Bool
+	},
+	"textDocument/moniker":              func(c *ServerCapabilities) bool { return c.MonikerProvider != nil },
+	"textDocument/prepareTypeHierarchy": func(c *ServerCapabilities) bool { return c.TypeHierarchyProvider != nil },
+	"typeHierarchy/supertypes":          func(c *ServerCapabilities) bool { return c.TypeHierarchyProvider != nil },
+	"typeHierarchy/subtypes":            func(c *ServerCapabilities) bool { return c.TypeHierarchyProvider != nil },
+	"textDocument/inlineValue":          func(c *ServerCapabilities) bool { return c.InlineValueProvider != nil },
+	"textDocument/inlayHint":            func(c *ServerCapabilities) bool { return c.InlayHintProvider != nil },
+	"inlayHint/resolve": func(c *ServerCapabilities) bool {
+		return c.InlayHintProvider != nil && c.InlayHintProvider.ResolveProvider
+	},
+	"textDocument/diagnostic": func(c *ServerCapabilities) bool { return c.DiagnosticProvider != nil },
+	"workspace/diagnostic": func(c *ServerCapabilities) bool {
+		switch p := c.DiagnosticProvider; {
+		case p == nil:
+			return false
+		case p.DiagnosticOptions != nil:
+			return p.DiagnosticOptions.WorkspaceDiagnostics
+		case p.DiagnosticRegistrationOptions != nil:
+			return p.DiagnosticRegistrationOptions.WorkspaceDiagnostics
+		}
+		return false
+	},
+	"textDocument/inlineCompletion": func(c *ServerCapabilities) bool { return c.InlineCompletionProvider != nil },
+	"workspace/symbol":              func(c *ServerCapabilities) bool { return c.WorkspaceSymbolProvider != nil },
+	"workspaceSymbol/resolve": func(c *ServerCapabilities) bool {
+		return c.WorkspaceSymbolProvider != nil && c.WorkspaceSymbolProvider.ResolveProvider
+	},
+	"workspace/executeCommand": func(c *ServerCapabilities) bool { return c.ExecuteCommandProvider != nil },
+	"workspace/didChangeWorkspaceFolders": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.WorkspaceFolders != nil && c.Workspace.WorkspaceFolders.ChangeNotifications != ""
+	},
+	"workspace/textDocumentContent": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.TextDocumentContent != nil
+	},
+	"workspace/didCreateFiles": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.FileOperations != nil && c.Workspace.FileOperations.DidCreate != nil
+	},
+	"workspace/willCreateFiles": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.FileOperations != nil && c.Workspace.FileOperations.WillCreate != nil
+	},
+	"workspace/didRenameFiles": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.FileOperations != nil && c.Workspace.FileOperations.DidRename != nil
+	},
+	"workspace/willRenameFiles": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.FileOperations != nil && c.Workspace.FileOperations.WillRename != nil
+	},
+	"workspace/didDeleteFiles": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.FileOperations != nil && c.Workspace.FileOperations.DidDelete != nil
+	},
+	"workspace/willDeleteFiles": func(c *ServerCapabilities) bool {
+		return c.Workspace != nil && c.Workspace.FileOperations != nil && c.Workspace.FileOperations.WillDelete != nil
+	},
+}
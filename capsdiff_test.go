@@ -0,0 +1,95 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestServerSupports(t *testing.T) {
+	caps := lsp.SyncOnlyServerCapabilities(func(caps *lsp.ServerCapabilities) {
+		caps.HoverProvider = &lsp.HoverOptions{}
+		caps.CompletionProvider = &lsp.CompletionOptions{}
+	})
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"initialize", true},
+		{"shutdown", true},
+		{"workspace/didChangeConfiguration", true},
+		{"textDocument/didOpen", true},
+		{"textDocument/didSave", true},
+		{"textDocument/willSave", false},
+		{"textDocument/hover", true},
+		{"textDocument/completion", true},
+		{"completionItem/resolve", false},
+		{"textDocument/definition", false},
+		{"textDocument/semanticTokens/full", false},
+		{"workspace/applyEdit", false}, // sent by the server
+		{"textDocument/unknown", false},
+	}
+	for _, test := range tests {
+		if got := lsp.ServerSupports(caps, test.method); got != test.want {
+			t.Errorf("ServerSupports(%s) = %v, want %v", test.method, got, test.want)
+		}
+	}
+
+	methods := []string{"textDocument/hover", "textDocument/definition", "textDocument/references"}
+	want := []string{"textDocument/definition", "textDocument/references"}
+	if diff := cmp.Diff(want, lsp.UnsupportedMethods(caps, methods)); diff != "" {
+		t.Errorf("UnsupportedMethods mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCapabilityGaps(t *testing.T) {
+	full := lsp.FullServerCapabilities(func(caps *lsp.ServerCapabilities) {
+		caps.CompletionProvider.TriggerCharacters = []string{".", ":"}
+		caps.CodeActionProvider.CodeActionKinds = []lsp.CodeActionKind{lsp.QuickFix, lsp.SourceOrganizeImports}
+		caps.ExecuteCommandProvider = &lsp.ExecuteCommandOptions{Commands: []string{"test.run"}}
+	})
+
+	t.Run("Same", func(t *testing.T) {
+		if gaps := lsp.CapabilityGaps(full, full); len(gaps) > 0 {
+			t.Errorf("Expected no gaps, got %v", gaps)
+		}
+		if gaps := lsp.CapabilityGaps(lsp.SyncOnlyServerCapabilities(), full); len(gaps) > 0 {
+			t.Errorf("Expected no gaps from a superset, got %v", gaps)
+		}
+	})
+
+	t.Run("Gaps", func(t *testing.T) {
+		utf8 := lsp.UTF8
+		caps := lsp.FullServerCapabilities(func(caps *lsp.ServerCapabilities) {
+			caps.PositionEncoding = &utf8
+			caps.TextDocumentSync.Change = lsp.Full
+			caps.HoverProvider = nil
+			caps.CompletionProvider.TriggerCharacters = []string{"."}
+			caps.CodeActionProvider.CodeActionKinds = []lsp.CodeActionKind{lsp.QuickFix}
+			caps.InlayHintProvider.ResolveProvider = false
+			caps.SemanticTokensProvider.Legend.TokenTypes = caps.SemanticTokensProvider.Legend.TokenTypes[1:]
+		})
+		var got []string
+		for _, gap := range lsp.CapabilityGaps(full, caps) {
+			got = append(got, gap.String())
+		}
+		want := []string{
+			`inlayHint/resolve: not supported`,
+			`textDocument/codeAction: missing code action kinds ["source.organizeImports"]`,
+			`textDocument/completion: missing trigger characters [":"]`,
+			`textDocument/didChange: no incremental synchronization`,
+			`textDocument/hover: not supported`,
+			`textDocument/semanticTokens/full: missing token types ["namespace"]`,
+			`workspace/executeCommand: not supported`,
+			`initialize: position encoding utf-8, want utf-16`,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("CapabilityGaps mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+// This file defines ready-made client capabilities, so that server tests
+// may exercise the combinations that clients commonly send, and templates
+// of server capabilities.
+
+// MinimalClientCapabilities returns a builder of the capabilities of a
+// client that declares nothing: it supports only what the protocol
+// requires of every client, such as text document synchronization,
+// UTF-16 positions and plain text.
+func MinimalClientCapabilities() *ClientCapabilitiesBuilder {
+	return NewClientCapabilities()
+}
+
+// VSCodeLikeClientCapabilities returns a builder of the capabilities
+// declared by a recent version of Visual Studio Code, with UTF-16
+// positions, Markdown, snippets, dynamic registration of every
+// capability, workspace edits with resource operations, and refresh
+// requests, but without the proposed features of the protocol.
+func VSCodeLikeClientCapabilities() *ClientCapabilitiesBuilder {
+	b := NewClientCapabilities().
+		WithSnippetSupport().
+		WithInsertReplaceSupport().
+		WithLabelDetails().
+		WithCompletionItemDefaults("commitCharacters", "editRange", "insertTextFormat", "insertTextMode").
+		WithHierarchicalSymbols().
+		WithMarkdown().
+		WithDefinitionLinks().
+		WithPullDiagnostics().
+		WithWorkspaceEdit(true, Create, Rename, Delete).
+		WithWorkspaceFolders().
+		WithConfiguration().
+		WithWorkDoneProgress().
+		WithPositionEncodings(UTF16)
+	for method := range dynamicRegistrationPaths {
+		switch method {
+		case "textDocument/inlineCompletion", "textDocument/rangesFormatting", "workspace/textDocumentContent":
+			// proposed
+		default:
+			b.WithDynamicRegistration(method)
+		}
+	}
+	return b.With(func(caps *ClientCapabilities) {
+		failureHandling := TextOnlyTransactional
+		ws := &caps.Workspace
+		ws.WorkspaceEdit.FailureHandling = &failureHandling
+		ws.WorkspaceEdit.NormalizesLineEndings = true
+		ws.WorkspaceEdit.ChangeAnnotationSupport = &ChangeAnnotationsSupportOptions{GroupsOnLabel: true}
+		ws.DidChangeWatchedFiles.RelativePatternSupport = true
+		ws.Symbol.SymbolKind = &ClientSymbolKindOptions{ValueSet: allSymbolKinds()}
+		ws.Symbol.TagSupport = &ClientSymbolTagOptions{ValueSet: []SymbolTag{DeprecatedSymbol}}
+		ws.Symbol.ResolveSupport = &ClientSymbolResolveOptions{Properties: []string{"location.range"}}
+		ws.SemanticTokens = &SemanticTokensWorkspaceClientCapabilities{RefreshSupport: true}
+		ws.CodeLens = &CodeLensWorkspaceClientCapabilities{RefreshSupport: true}
+		ws.InlineValue = &InlineValueWorkspaceClientCapabilities{RefreshSupport: true}
+		ws.InlayHint = &InlayHintWorkspaceClientCapabilities{RefreshSupport: true}
+		ws.FoldingRange = &FoldingRangeWorkspaceClientCapabilities{RefreshSupport: true}
+		fo := ws.FileOperations
+		fo.DidCreate, fo.WillCreate = true, true
+		fo.DidRename, fo.WillRename = true, true
+		fo.DidDelete, fo.WillDelete = true, true
+
+		td := &caps.TextDocument
+		td.Synchronization.WillSave = true
+		td.Synchronization.WillSaveWaitUntil = true
+		td.Synchronization.DidSave = true
+
+		c := &td.Completion
+		c.ContextSupport = true
+		c.InsertTextMode = AdjustIndentation
+		c.CompletionItem.CommitCharactersSupport = true
+		c.CompletionItem.DeprecatedSupport = true
+		c.CompletionItem.PreselectSupport = true
+		c.CompletionItem.TagSupport = &CompletionItemTagOptions{ValueSet: []CompletionItemTag{ComplDeprecated}}
+		c.CompletionItem.ResolveSupport = &ClientCompletionItemResolveOptions{Properties: []string{"documentation", "detail", "additionalTextEdits"}}
+		c.CompletionItem.InsertTextModeSupport = &ClientCompletionItemInsertTextModeOptions{ValueSet: []InsertTextMode{AsIs, AdjustIndentation}}
+		c.CompletionItemKind = &ClientCompletionItemOptionsKind{ValueSet: allCompletionItemKinds()}
+
+		td.SignatureHelp.ContextSupport = true
+		td.SignatureHelp.SignatureInformation.ParameterInformation = &ClientSignatureParameterInformationOptions{LabelOffsetSupport: true}
+		td.SignatureHelp.SignatureInformation.ActiveParameterSupport = true
+
+		td.DocumentSymbol.SymbolKind = &ClientSymbolKindOptions{ValueSet: allSymbolKinds()}
+		td.DocumentSymbol.TagSupport = &ClientSymbolTagOptions{ValueSet: []SymbolTag{DeprecatedSymbol}}
+		td.DocumentSymbol.LabelSupport = true
+
+		ca := &td.CodeAction
+		ca.CodeActionLiteralSupport.CodeActionKind.ValueSet = []CodeActionKind{
+			Empty, QuickFix, Refactor, RefactorExtract, RefactorInline, RefactorRewrite, Source, SourceOrganizeImports,
+		}
+		ca.IsPreferredSupport = true
+		ca.DisabledSupport = true
+		ca.DataSupport = true
+		ca.ResolveSupport = &ClientCodeActionResolveOptions{Properties: []string{"edit"}}
+
+		td.DocumentLink.TooltipSupport = true
+		behavior := Identifier
+		td.Rename.PrepareSupport = true
+		td.Rename.PrepareSupportDefaultBehavior = &behavior
+		td.Rename.HonorsChangeAnnotations = true
+		td.FoldingRange.RangeLimit = 5000
+		td.FoldingRange.LineFoldingOnly = true
+		td.FoldingRange.FoldingRangeKind = &ClientFoldingRangeKindOptions{ValueSet: []FoldingRangeKind{Comment, Imports, Region}}
+
+		pd := &td.PublishDiagnostics
+		pd.RelatedInformation = true
+		pd.TagSupport = &ClientDiagnosticsTagOptions{ValueSet: []DiagnosticTag{Unnecessary, Deprecated}}
+		pd.CodeDescriptionSupport = true
+		pd.DataSupport = true
+		td.Diagnostic.DiagnosticsCapabilities = pd.DiagnosticsCapabilities
+
+		st := &td.SemanticTokens
+		st.Requests.Range = &ClientSemanticTokensRequestOptionsRange{Bool: ptrTo(true)}
+		st.Requests.Full = &ClientSemanticTokensRequestOptionsFull{
+			ClientSemanticTokensRequestFullDelta: &ClientSemanticTokensRequestFullDelta{Delta: true},
+		}
+		st.TokenTypes = allSemanticTokenTypes()
+		st.TokenModifiers = allSemanticTokenModifiers()
+		st.Formats = []TokenFormat{Relative}
+		st.ServerCancelSupport = true
+		st.AugmentsSyntaxTokens = true
+
+		td.InlayHint.ResolveSupport = &ClientInlayHintResolveOptions{
+			Properties: []string{"tooltip", "textEdits", "label.tooltip", "label.location", "label.command"},
+		}
+
+		caps.Window.ShowMessage = &ShowMessageRequestClientCapabilities{
+			MessageActionItem: &ClientShowMessageActionItemOptions{AdditionalPropertiesSupport: true},
+		}
+		caps.Window.ShowDocument = &ShowDocumentClientCapabilities{Support: true}
+		caps.General.StaleRequestSupport = &StaleRequestSupportOptions{
+			Cancel: true,
+			RetryOnContentModified: []string{
+				"textDocument/semanticTokens/full",
+				"textDocument/semanticTokens/range",
+				"textDocument/semanticTokens/full/delta",
+			},
+		}
+		caps.General.RegularExpressions = &RegularExpressionsClientCapabilities{Engine: "ECMAScript", Version: "ES2020"}
+		caps.General.Markdown = &MarkdownClientCapabilities{Parser: "marked", Version: "1.1.0"}
+	})
+}
+
+// EverythingClientCapabilities returns a builder of capabilities that
+// declare support for every feature of the protocol, including the
+// proposed ones, every position encoding (preferring UTF-8), and no
+// restrictions such as line folding only.
+func EverythingClientCapabilities() *ClientCapabilitiesBuilder {
+	b := VSCodeLikeClientCapabilities().
+		WithPositionEncodings(UTF8, UTF16, UTF32).
+		WithDynamicRegistration("textDocument/inlineCompletion", "textDocument/rangesFormatting", "workspace/textDocumentContent").
+		WithCompletionItemDefaults("data")
+	return b.With(func(caps *ClientCapabilities) {
+		ws := &caps.Workspace
+		ws.WorkspaceEdit.MetadataSupport = true
+		ws.WorkspaceEdit.SnippetEditSupport = true
+		ws.Diagnostics.RefreshSupport = true
+
+		td := &caps.TextDocument
+		td.Filters = &TextDocumentFilterClientCapabilities{RelativePatternSupport: true}
+		td.Completion.CompletionList.ApplyKindSupport = true
+		td.SignatureHelp.SignatureInformation.NoActiveParameterSupport = true
+		td.CodeAction.HonorsChangeAnnotations = true
+		td.CodeAction.DocumentationSupport = true
+		td.CodeAction.TagSupport = &CodeActionTagOptions{ValueSet: []CodeActionTag{LLMGenerated}}
+		td.CodeAction.CodeActionLiteralSupport.CodeActionKind.ValueSet = appendNew(
+			td.CodeAction.CodeActionLiteralSupport.CodeActionKind.ValueSet, RefactorMove, SourceFixAll, Notebook)
+		td.CodeLens.ResolveSupport = &ClientCodeLensResolveOptions{Properties: []string{"command"}}
+		td.RangeFormatting.RangesSupport = true
+		td.FoldingRange.RangeLimit = 0
+		td.FoldingRange.LineFoldingOnly = false
+		td.FoldingRange.FoldingRange = &ClientFoldingRangeOptions{CollapsedText: true}
+		td.PublishDiagnostics.VersionSupport = true
+		td.Diagnostic.RelatedDocumentSupport = true
+		td.Diagnostic.MarkupMessageSupport = true
+		td.SemanticTokens.OverlappingTokenSupport = true
+		td.SemanticTokens.MultilineTokenSupport = true
+		caps.NotebookDocument.Synchronization.ExecutionSummarySupport = true
+	})
+}
+
+// SyncOnlyServerCapabilities returns the capabilities of a server that
+// only synchronizes text documents: it is notified of the opening,
+// incremental changes, saving and closing of documents, and provides no
+// language feature. The overrides are applied in order.
+func SyncOnlyServerCapabilities(overrides ...func(*ServerCapabilities)) *ServerCapabilities {
+	caps := &ServerCapabilities{
+		TextDocumentSync: &TextDocumentSyncOptions{
+			OpenClose: true,
+			Change:    Incremental,
+			Save:      &SaveOptions{},
+		},
+	}
+	for _, override := range overrides {
+		override(caps)
+	}
+	return caps
+}
+
+// FullServerCapabilities returns a template of the capabilities of a
+// server that synchronizes text documents as [SyncOnlyServerCapabilities]
+// does and provides every language feature of the protocol with default
+// options and resolve requests, semantic tokens of the standard types
+// and modifiers, and support for workspace folders. Excluded are the
+// features for notebooks, those that need options only the server can
+// choose (on-type formatting and commands), and the proposed ones. The overrides are applied in order,
+// typically to remove the features the server does not provide, and to
+// set trigger characters and the like:
+//
+//	caps := lsp.FullServerCapabilities(func(caps *lsp.ServerCapabilities) {
+//		caps.CompletionProvider.TriggerCharacters = []string{"."}
+//		caps.InlineValueProvider = nil
+//	})
+func FullServerCapabilities(overrides ...func(*ServerCapabilities)) *ServerCapabilities {
+	caps := SyncOnlyServerCapabilities()
+	caps.CompletionProvider = &CompletionOptions{ResolveProvider: true}
+	caps.HoverProvider = &HoverOptions{}
+	caps.SignatureHelpProvider = &SignatureHelpOptions{}
+	caps.DeclarationProvider = &DeclarationRegistrationOptions{}
+	caps.DefinitionProvider = &DefinitionOptions{}
+	caps.TypeDefinitionProvider = &TypeDefinitionRegistrationOptions{}
+	caps.ImplementationProvider = &ImplementationRegistrationOptions{}
+	caps.ReferencesProvider = &ReferenceOptions{}
+	caps.DocumentHighlightProvider = &DocumentHighlightOptions{}
+	caps.DocumentSymbolProvider = &DocumentSymbolOptions{}
+	caps.CodeActionProvider = &CodeActionOptions{ResolveProvider: true}
+	caps.CodeLensProvider = &CodeLensOptions{ResolveProvider: true}
+	caps.DocumentLinkProvider = &DocumentLinkOptions{ResolveProvider: true}
+	caps.ColorProvider = &DocumentColorRegistrationOptions{}
+	caps.WorkspaceSymbolProvider = &WorkspaceSymbolOptions{ResolveProvider: true}
+	caps.DocumentFormattingProvider = &DocumentFormattingOptions{}
+	caps.DocumentRangeFormattingProvider = &DocumentRangeFormattingOptions{}
+	caps.RenameProvider = &RenameOptions{PrepareProvider: true}
+	caps.FoldingRangeProvider = &FoldingRangeRegistrationOptions{}
+	caps.SelectionRangeProvider = &SelectionRangeRegistrationOptions{}
+	caps.CallHierarchyProvider = &CallHierarchyRegistrationOptions{}
+	caps.LinkedEditingRangeProvider = &LinkedEditingRangeRegistrationOptions{}
+	caps.SemanticTokensProvider = &SemanticTokensRegistrationOptions{
+		SemanticTokensOptions: SemanticTokensOptions{
+			Legend: SemanticTokensLegend{
+				TokenTypes:     allSemanticTokenTypes(),
+				TokenModifiers: allSemanticTokenModifiers(),
+			},
+			Range: &SemanticTokensOptionsRange{Bool: ptrTo(true)},
+			Full:  &SemanticTokensOptionsFull{SemanticTokensFullDelta: &SemanticTokensFullDelta{Delta: true}},
+		},
+	}
+	caps.MonikerProvider = &MonikerRegistrationOptions{}
+	caps.TypeHierarchyProvider = &TypeHierarchyRegistrationOptions{}
+	caps.InlineValueProvider = &InlineValueRegistrationOptions{}
+	caps.InlayHintProvider = &InlayHintRegistrationOptions{InlayHintOptions: InlayHintOptions{ResolveProvider: true}}
+	caps.DiagnosticProvider = &ServerCapabilitiesDiagnosticProvider{
+		DiagnosticOptions: &DiagnosticOptions{InterFileDependencies: true, WorkspaceDiagnostics: true},
+	}
+	caps.Workspace = &WorkspaceOptions{
+		WorkspaceFolders: &WorkspaceFolders5Gn{Supported: true},
+	}
+	for _, override := range overrides {
+		override(caps)
+	}
+	return caps
+}
+
+// NewInitializeResult returns the result of an initialize request for a
+// server with the given name and version (which may be empty) and
+// capabilities.
+func NewInitializeResult(name, version string, caps *ServerCapabilities) *InitializeResult {
+	result := &InitializeResult{Capabilities: *caps}
+	if name != "" {
+		result.ServerInfo = &ServerInfo{Name: name, Version: version}
+	}
+	return result
+}
+
+func allSymbolKinds() []SymbolKind {
+	var kinds []SymbolKind
+	for k := File; k <= TypeParameter; k++ {
+		kinds = append(kinds, k)
+	}
+	return kinds
+}
+
+func allCompletionItemKinds() []CompletionItemKind {
+	var kinds []CompletionItemKind
+	for k := TextCompletion; k <= TypeParameterCompletion; k++ {
+		kinds = append(kinds, k)
+	}
+	return kinds
+}
+
+func allSemanticTokenTypes() []string {
+	return []string{
+		"namespace", "type", "class", "enum", "interface", "struct", "typeParameter",
+		"parameter", "variable", "property", "enumMember", "event", "function", "method",
+		"macro", "keyword", "modifier", "comment", "string", "number", "regexp",
+		"operator", "decorator", "label",
+	}
+}
+
+func allSemanticTokenModifiers() []string {
+	return []string{
+		"declaration", "definition", "readonly", "static", "deprecated",
+		"abstract", "async", "modification", "documentation", "defaultLibrary",
+	}
+}
+
+func ptrTo[T any](v T) *T { return &v }
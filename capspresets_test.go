@@ -0,0 +1,117 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+func TestClientCapabilitiesPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		caps    *lsp.ClientCapabilities
+		version string
+	}{
+		{"Minimal", lsp.MinimalClientCapabilities().Build(), lsp.Version3_15},
+		{"VSCodeLike", lsp.VSCodeLikeClientCapabilities().Build(), lsp.Version3_18},
+		{"Everything", lsp.EverythingClientCapabilities().Build(), lsp.Version3_18},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := lsp.ClientVersion(test.caps); got != test.version {
+				t.Errorf("Expected version %s, got %s", test.version, got)
+			}
+			params := &lsp.ParamInitialize{XInitializeParams: lsp.XInitializeParams{Capabilities: *test.caps}}
+			call, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "initialize", params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range lsp.ValidateRequest(call, false) {
+				t.Errorf("Unexpected violation: %v", v)
+			}
+			lsptest.CheckGolden(t, filepath.Join("testdata", "capabilities", strings.ToLower(test.name)+".json"), test.caps)
+		})
+	}
+
+	t.Run("Differences", func(t *testing.T) {
+		vscode := lsp.VSCodeLikeClientCapabilities().Build()
+		all := lsp.EverythingClientCapabilities().Build()
+		if !vscode.TextDocument.Completion.CompletionItem.SnippetSupport || !vscode.TextDocument.Hover.DynamicRegistration {
+			t.Errorf("Expected VS Code to support snippets and dynamic registration of hover")
+		}
+		if vscode.TextDocument.InlineCompletion != nil {
+			t.Errorf("Expected VS Code not to declare inline completion, got %+v", vscode.TextDocument.InlineCompletion)
+		}
+		if got := vscode.General.PositionEncodings; len(got) != 1 || got[0] != lsp.UTF16 {
+			t.Errorf("Expected VS Code to support only UTF-16, got %v", got)
+		}
+		if all.TextDocument.InlineCompletion == nil || !all.TextDocument.InlineCompletion.DynamicRegistration {
+			t.Errorf("Expected everything to include inline completion")
+		}
+		if all.TextDocument.FoldingRange.LineFoldingOnly {
+			t.Errorf("Expected everything not to restrict folding to lines")
+		}
+		if got := all.General.PositionEncodings; len(got) != 3 || got[0] != lsp.UTF8 {
+			t.Errorf("Expected everything to prefer UTF-8, got %v", got)
+		}
+	})
+}
+
+func TestServerCapabilitiesPresets(t *testing.T) {
+	tests := []struct {
+		name string
+		caps *lsp.ServerCapabilities
+	}{
+		{"SyncOnly", lsp.SyncOnlyServerCapabilities()},
+		{"Full", lsp.FullServerCapabilities()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := lsp.NewInitializeResult("test-server", "1.0.0", test.caps)
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range lsp.ValidateResult("initialize", data) {
+				t.Errorf("Unexpected violation: %v", v)
+			}
+			lsptest.CheckGolden(t, filepath.Join("testdata", "capabilities", "server_"+strings.ToLower(test.name)+".json"), test.caps)
+		})
+	}
+
+	t.Run("Overrides", func(t *testing.T) {
+		caps := lsp.FullServerCapabilities(
+			func(caps *lsp.ServerCapabilities) {
+				caps.CompletionProvider.TriggerCharacters = []string{"."}
+				caps.HoverProvider = nil
+			},
+			func(caps *lsp.ServerCapabilities) {
+				caps.CompletionProvider.TriggerCharacters = append(caps.CompletionProvider.TriggerCharacters, ":")
+			},
+		)
+		if got := caps.CompletionProvider.TriggerCharacters; len(got) != 2 || got[0] != "." || got[1] != ":" {
+			t.Errorf("Expected trigger characters [. :], got %v", got)
+		}
+		if caps.HoverProvider != nil {
+			t.Errorf("Expected no hover provider, got %+v", caps.HoverProvider)
+		}
+		if !lsp.FullServerCapabilities().TextDocumentSync.OpenClose {
+			t.Errorf("Expected full capabilities to include synchronization")
+		}
+	})
+
+	t.Run("NoServerInfo", func(t *testing.T) {
+		if result := lsp.NewInitializeResult("", "", lsp.SyncOnlyServerCapabilities()); result.ServerInfo != nil {
+			t.Errorf("Expected no server info, got %+v", result.ServerInfo)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ClientTimeouts bounds the time a server waits for the client to reply
+// to its requests. A zero duration means no limit.
+type ClientTimeouts struct {
+	Configuration      time.Duration // workspace/configuration
+	ApplyEdit          time.Duration // workspace/applyEdit
+	ShowMessageRequest time.Duration // window/showMessageRequest
+}
+
+// A TimeoutError reports that the client did not reply to a request
+// within the time allowed by [ClientTimeouts]. It matches
+// context.DeadlineExceeded under [errors.Is], whereas the errors the
+// client replies with are ResponseErrors; see [ErrorFrom].
+type TimeoutError struct {
+	Method  string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s: no reply from the client within %v", e.Method, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// WithClientTimeouts returns a Client that invokes client, failing the
+// requests listed in timeouts with a *TimeoutError when the client does
+// not reply in time. If client is a [ClientDispatcher], it then sends
+// $/cancelRequest for the abandoned request.
+func WithClientTimeouts(client Client, timeouts ClientTimeouts) Client {
+	return &timeoutClient{Client: client, timeouts: timeouts}
+}
+
+type timeoutClient struct {
+	Client
+	timeouts ClientTimeouts
+}
+
+func (c *timeoutClient) Configuration(ctx context.Context, params *ParamConfiguration) (result []LSPAny, err error) {
+	err = withTimeout(ctx, "workspace/configuration", c.timeouts.Configuration, func(ctx context.Context) error {
+		result, err = c.Client.Configuration(ctx, params)
+		return err
+	})
+	return result, err
+}
+
+func (c *timeoutClient) ApplyEdit(ctx context.Context, params *ApplyWorkspaceEditParams) (result *ApplyWorkspaceEditResult, err error) {
+	err = withTimeout(ctx, "workspace/applyEdit", c.timeouts.ApplyEdit, func(ctx context.Context) error {
+		result, err = c.Client.ApplyEdit(ctx, params)
+		return err
+	})
+	return result, err
+}
+
+func (c *timeoutClient) ShowMessageRequest(ctx context.Context, params *ShowMessageRequestParams) (result *MessageActionItem, err error) {
+	err = withTimeout(ctx, "window/showMessageRequest", c.timeouts.ShowMessageRequest, func(ctx context.Context) error {
+		result, err = c.Client.ShowMessageRequest(ctx, params)
+		return err
+	})
+	return result, err
+}
+
+// withTimeout calls f with a context that expires after timeout, if it
+// is positive, and reports the expiry as a *TimeoutError for method.
+func withTimeout(ctx context.Context, method string, timeout time.Duration, f func(context.Context) error) error {
+	if timeout <= 0 {
+		return f(ctx)
+	}
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := f(tctx)
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{Method: method, Timeout: timeout}
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestClientTimeouts(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	cancelled := make(chan struct{})
+	handler := jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case "workspace/applyEdit":
+			<-release
+			return &lsp.ApplyWorkspaceEditResult{Applied: true}, nil
+		case "workspace/configuration":
+			return nil, lsp.Errorf(lsp.RequestFailed, "no configuration")
+		case "window/showMessageRequest":
+			return &lsp.MessageActionItem{Title: "OK"}, nil
+		case "$/cancelRequest":
+			close(cancelled)
+		}
+		return nil, nil
+	})
+	_, server := connectPair(t, handler, nil)
+	client := lsp.WithClientTimeouts(lsp.ClientDispatcher(server), lsp.ClientTimeouts{
+		ApplyEdit:     10 * time.Millisecond,
+		Configuration: time.Minute,
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		_, err := client.ApplyEdit(ctx, &lsp.ApplyWorkspaceEditParams{})
+		var terr *lsp.TimeoutError
+		if !errors.As(err, &terr) || terr.Method != "workspace/applyEdit" {
+			t.Fatalf("Expected a timeout of workspace/applyEdit, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected the timeout to match context.DeadlineExceeded")
+		}
+		close(release)
+		select {
+		case <-cancelled:
+		case <-time.After(10 * time.Second):
+			t.Errorf("Expected $/cancelRequest after the timeout")
+		}
+	})
+
+	t.Run("ClientError", func(t *testing.T) {
+		_, err := client.Configuration(ctx, &lsp.ParamConfiguration{})
+		var terr *lsp.TimeoutError
+		if err == nil || errors.As(err, &terr) {
+			t.Fatalf("Expected an error from the client, got %v", err)
+		}
+		if rerr, ok := lsp.ErrorFrom(err); !ok || !errors.Is(rerr, lsp.ErrRequestFailed) {
+			t.Errorf("Expected RequestFailed, got %v", err)
+		}
+	})
+
+	t.Run("NoTimeout", func(t *testing.T) {
+		item, err := client.ShowMessageRequest(ctx, &lsp.ShowMessageRequestParams{Type: lsp.Info, Message: "hello"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item == nil || item.Title != "OK" {
+			t.Errorf("Expected action OK, got %+v", item)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The lsp-journal command reconstructs a document from its journal, as
+// recorded by an lsp.ChangeJournal, and prints its content at a version.
+//
+// Usage:
+//
+//	lsp-journal [-version n] [-list] file
+//
+// Without -version, it prints the content at the last recorded version.
+// With -list, it prints the recorded versions, one per line, instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"typefox.dev/lsp"
+)
+
+var (
+	version = flag.Int("version", -1, "version of the document to print (default: the last)")
+	list    = flag.Bool("list", false, "list the recorded versions")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("lsp-journal: ")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: lsp-journal [-version n] [-list] file\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	entries, err := lsp.ReadJournal(f)
+	f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *list {
+		for _, entry := range entries {
+			switch {
+			case entry.Closed:
+				fmt.Printf("closed\n")
+			case entry.Error != "":
+				fmt.Printf("%d (%d changes, failed: %s)\n", entry.Version, len(entry.Changes), entry.Error)
+			case entry.Text != nil:
+				fmt.Printf("%d (content of %s)\n", entry.Version, entry.URI)
+			default:
+				fmt.Printf("%d (%d changes)\n", entry.Version, len(entry.Changes))
+			}
+		}
+		return
+	}
+
+	v := int32(*version)
+	if v < 0 {
+		for _, entry := range entries {
+			if !entry.Closed && entry.Error == "" {
+				v = entry.Version
+			}
+		}
+	}
+	content, err := lsp.ReplayJournal(entries, v)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(content)
+}
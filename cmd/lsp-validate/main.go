@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The lsp-validate command reports the messages of a recorded LSP session
+// whose structure does not conform to the protocol: unknown methods,
+// methods sent in the wrong direction, properties of the wrong type,
+// missing required properties and unknown properties.
+//
+// Usage:
+//
+//	lsp-validate [-client file] [-server file]
+//
+// Each file holds the messages sent by one side of the session, framed
+// with Content-Length headers as on the wire; "-" denotes standard input,
+// so a live stdio stream may be piped through tee into lsp-validate.
+// When both sides are given, responses are validated against the result
+// type of the request they answer.
+//
+// The exit status is 1 if any violation was found.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+var (
+	clientFile = flag.String("client", "", "file of messages sent by the client (- for stdin)")
+	serverFile = flag.String("server", "", "file of messages sent by the server (- for stdin)")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("lsp-validate: ")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: lsp-validate [-client file] [-server file]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 0 || *clientFile == "" && *serverFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *clientFile == "-" && *serverFile == "-" {
+		log.Fatal("at most one side may be read from standard input")
+	}
+
+	var sides []*side
+	if *clientFile != "" {
+		sides = append(sides, &side{name: "client", file: *clientFile})
+	}
+	if *serverFile != "" {
+		sides = append(sides, &side{name: "server", file: *serverFile, fromServer: true})
+	}
+	for _, s := range sides {
+		if err := s.read(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Requests are indexed by the side that sent them, so that responses
+	// (sent by the other side) can be validated against their method.
+	requests := make(map[bool]map[jsonrpc2.ID]string)
+	for _, s := range sides {
+		calls := make(map[jsonrpc2.ID]string)
+		for _, msg := range s.msgs {
+			if req, ok := msg.(*jsonrpc2.Request); ok && req.IsCall() {
+				calls[req.ID] = req.Method
+			}
+		}
+		requests[s.fromServer] = calls
+	}
+
+	failed := false
+	for _, s := range sides {
+		for i, msg := range s.msgs {
+			var vs []lsp.Violation
+			var what string
+			switch msg := msg.(type) {
+			case *jsonrpc2.Request:
+				what = msg.Method
+				vs = lsp.ValidateRequest(msg, s.fromServer)
+			case *jsonrpc2.Response:
+				method, ok := requests[!s.fromServer][msg.ID]
+				if !ok || msg.Error != nil {
+					continue
+				}
+				what = "response to " + method
+				vs = lsp.ValidateResult(method, msg.Result)
+			}
+			for _, v := range vs {
+				fmt.Printf("%s message #%d (%s): %s\n", s.name, i+1, what, v)
+				failed = true
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// A side holds the messages sent by one side of the session.
+type side struct {
+	name       string
+	file       string
+	fromServer bool
+	msgs       []jsonrpc2.Message
+}
+
+func (s *side) read() error {
+	var r io.Reader = os.Stdin
+	if s.file != "-" {
+		f, err := os.Open(s.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	reader := jsonrpc2.HeaderFramer().Reader(r)
+	for {
+		msg, _, err := reader.Read(context.Background())
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s message #%d: %v", s.name, len(s.msgs)+1, err)
+		}
+		s.msgs = append(s.msgs, msg)
+	}
+}
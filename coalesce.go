@@ -0,0 +1,153 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// coalescedMethods are the methods whose calls CoalesceRequests
+// coalesces by default: those that only read the state of the server.
+var coalescedMethods = []string{
+	"textDocument/hover",
+	"textDocument/definition",
+	"textDocument/typeDefinition",
+	"textDocument/implementation",
+	"textDocument/references",
+	"textDocument/documentHighlight",
+	"textDocument/documentSymbol",
+	"textDocument/foldingRange",
+	"textDocument/codeLens",
+	"textDocument/documentLink",
+	"textDocument/inlayHint",
+	"textDocument/semanticTokens/full",
+	"textDocument/diagnostic",
+}
+
+// CoalesceRequests returns a handler that invokes handler once for
+// identical concurrent calls, and sends its result to all of them. Calls
+// are identical if they have the same method and parameters, and are
+// about the same version of their document. They are coalesced only
+// for the given methods, which default to methods that only read the
+// state of the server, such as textDocument/hover.
+//
+// To let later calls join it, a coalesced call is handled in its own
+// goroutine, concurrently with the messages that follow it, so handler
+// must be safe for concurrent use; it must reply before returning. The
+// shared call is cancelled once all of its callers are. The connection
+// must be the one whose messages are handled, so CoalesceRequests is
+// typically called by a [jsonrpc2.Binder]:
+//
+//	handler := lsp.CoalesceRequests(conn, lsp.ServerHandler(server))
+func CoalesceRequests(conn *jsonrpc2.Connection, handler jsonrpc2.Handler, methods ...string) jsonrpc2.Handler {
+	if len(methods) == 0 {
+		methods = coalescedMethods
+	}
+	c := &coalescer{
+		conn:     conn,
+		handler:  handler,
+		methods:  make(map[string]bool),
+		versions: make(map[DocumentURI]int32),
+		flights:  make(map[flightKey]*flight),
+	}
+	for _, m := range methods {
+		c.methods[m] = true
+	}
+	return jsonrpc2.HandlerFunc(c.handle)
+}
+
+type coalescer struct {
+	conn    *jsonrpc2.Connection
+	handler jsonrpc2.Handler
+	methods map[string]bool
+
+	mu       sync.Mutex
+	versions map[DocumentURI]int32 // of open documents
+	flights  map[flightKey]*flight
+}
+
+type flightKey struct {
+	method  string
+	version int32
+	params  string
+}
+
+// A flight is the shared handling of identical calls.
+type flight struct {
+	callers []jsonrpc2.ID
+	waiting int // callers not cancelled
+	cancel  context.CancelFunc
+}
+
+func (c *coalescer) handle(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+	switch req.Method {
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didClose":
+		var params versionedParams
+		if UnmarshalJSON(req.Params, &params) == nil {
+			c.mu.Lock()
+			if v := params.TextDocument.Version; v != nil {
+				c.versions[params.TextDocument.URI] = *v
+			} else if req.Method == "textDocument/didClose" {
+				delete(c.versions, params.TextDocument.URI)
+			}
+			c.mu.Unlock()
+		}
+	}
+	if !req.IsCall() || !c.methods[req.Method] {
+		return c.handler.Handle(ctx, req)
+	}
+
+	var params versionedParams
+	UnmarshalJSON(req.Params, &params) // params are compared as a whole
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, req.Params); err != nil {
+		return c.handler.Handle(ctx, req)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := flightKey{req.Method, c.versions[params.TextDocument.URI], compact.String()}
+	f := c.flights[key]
+	if f == nil || f.waiting == 0 { // a flight without callers is cancelled
+		fctx, cancel := context.WithCancel(detach(ctx))
+		f = &flight{cancel: cancel}
+		c.flights[key] = f
+		go c.fly(fctx, key, f, req)
+	}
+	f.callers = append(f.callers, req.ID)
+	f.waiting++
+	context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if f.waiting--; f.waiting == 0 {
+			f.cancel()
+		}
+	})
+	return nil, jsonrpc2.ErrAsyncResponse
+}
+
+// fly handles req for the callers of f.
+func (c *coalescer) fly(ctx context.Context, key flightKey, f *flight, req *jsonrpc2.Request) {
+	result, err := c.handler.Handle(ctx, req)
+	if errors.Is(err, jsonrpc2.ErrAsyncResponse) {
+		result, err = nil, fmt.Errorf("%w: %q deferred the reply to a coalesced call", jsonrpc2.ErrInternal, req.Method)
+	}
+	c.mu.Lock()
+	if c.flights[key] == f {
+		delete(c.flights, key)
+	}
+	callers := f.callers
+	c.mu.Unlock()
+	f.cancel()
+	for _, id := range callers {
+		c.conn.Respond(id, result, err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// flightServer answers hovers, once released, with the number of hovers
+// it computed, and definitions at once.
+type flightServer struct {
+	lsp.Server
+	release chan struct{}
+	hovers  atomic.Int32
+}
+
+func (s *flightServer) Hover(ctx context.Context, _ *lsp.HoverParams) (*lsp.Hover, error) {
+	n := s.hovers.Add(1)
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &lsp.Hover{Contents: lsp.MarkupContent{Value: fmt.Sprint(n)}}, nil
+}
+
+func (s *flightServer) Definition(context.Context, *lsp.DefinitionParams) ([]lsp.DefinitionLink, error) {
+	return nil, nil
+}
+
+func (s *flightServer) DidChange(context.Context, *lsp.DidChangeTextDocumentParams) error {
+	return nil
+}
+
+// coalescingBinder binds connections handled by CoalesceRequests.
+type coalescingBinder struct{ server lsp.Server }
+
+func (b coalescingBinder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	return jsonrpc2.ConnectionOptions{Handler: lsp.CoalesceRequests(conn, lsp.ServerHandler(b.server), "textDocument/hover")}, nil
+}
+
+func TestCoalesceRequests(t *testing.T) {
+	ctx := context.Background()
+	server := &flightServer{release: make(chan struct{})}
+	cc, sc := net.Pipe()
+	client, err := jsonrpc2.Dial(ctx, pipeDialer{cc}, jsonrpc2.ConnectionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, coalescingBinder{server})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = conn.Close()
+	})
+
+	hover := func(line uint32) *jsonrpc2.AsyncCall {
+		return client.Call(ctx, "textDocument/hover", &lsp.HoverParams{
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+				Position:     lsp.Position{Line: line},
+			},
+		})
+	}
+	calls := []*jsonrpc2.AsyncCall{
+		hover(0),
+		hover(0),
+		hover(1),
+	}
+	if err := client.Notify(ctx, "textDocument/didChange", &lsp.DidChangeTextDocumentParams{
+		TextDocument: lsp.VersionedTextDocumentIdentifier{TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: "file:///a.go"}, Version: 2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	calls = append(calls, hover(0), hover(0))
+	// Calls are handled in order, so the hovers have been received once
+	// a later definition is answered.
+	if err := client.Call(ctx, "textDocument/definition", &lsp.DefinitionParams{}).Await(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	close(server.release)
+
+	var got []string
+	for _, call := range calls {
+		var h lsp.Hover
+		if err := call.Await(ctx, &h); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, h.Contents.Value)
+	}
+	if n := server.hovers.Load(); n != 3 {
+		t.Errorf("Expected 3 hovers to be computed, got %d", n)
+	}
+	// The results of coalesced calls are the same.
+	if got[0] != got[1] || got[3] != got[4] || got[0] == got[2] || got[0] == got[3] || got[2] == got[3] {
+		t.Errorf("Expected results of the form [a a b c c], got %v", got)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"unicode/utf8"
+)
+
+// Kind returns how the completion was triggered. A client that does not
+// send the context of completions (it does not declare
+// completion.contextSupport) sends a zero context, whose kind is
+// Invoked.
+func (c CompletionContext) Kind() CompletionTriggerKind {
+	if c.TriggerKind == 0 {
+		return Invoked
+	}
+	return c.TriggerKind
+}
+
+// TriggeredBy reports whether the completion was triggered by one of the
+// given characters, or by any character if none is given.
+func (c CompletionContext) TriggeredBy(chars ...string) bool {
+	return c.TriggerKind == TriggerCharacter && (len(chars) == 0 || slices.Contains(chars, c.TriggerCharacter))
+}
+
+// IsRetrigger reports whether the completion was re-triggered because
+// the previous completion list was incomplete; see
+// [CompletionRetriggers].
+func (c CompletionContext) IsRetrigger() bool {
+	return c.TriggerKind == TriggerForIncompleteCompletions
+}
+
+// CompletionCharacters are the characters that trigger completion, and
+// those that commit the selected completion item, as a server declares
+// them once, both to its capabilities and to its handling of completion
+// requests:
+//
+//	var chars = lsp.CompletionCharacters{Trigger: []string{"."}, Commit: []string{"("}}
+//	caps.CompletionProvider = chars.Options()
+//	...
+//	if params.Context.TriggeredBy(chars.Trigger...) { ... }
+type CompletionCharacters struct {
+	// Trigger lists the characters, typed after a word or on their own,
+	// that trigger completion, such as "." for selectors. The characters
+	// of identifiers need not be listed, as typing them triggers
+	// completion anyway.
+	Trigger []string
+	// Commit lists the characters that commit the selected item of any
+	// completion list, such as "(" for functions.
+	Commit []string
+}
+
+// Validate reports an error if a character is not exactly one
+// character.
+func (c CompletionCharacters) Validate() error {
+	for _, chars := range [][]string{c.Trigger, c.Commit} {
+		for _, ch := range chars {
+			if utf8.RuneCountInString(ch) != 1 {
+				return fmt.Errorf("invalid trigger or commit character %q", ch)
+			}
+		}
+	}
+	return nil
+}
+
+// Options returns completion options that declare the characters.
+func (c CompletionCharacters) Options() *CompletionOptions {
+	return &CompletionOptions{
+		TriggerCharacters:   slices.Clone(c.Trigger),
+		AllCommitCharacters: slices.Clone(c.Commit),
+	}
+}
+
+// Triggered returns the character that triggered a completion, and
+// whether it is one of the trigger characters. A client triggers
+// completion with a character the server did not declare if it was
+// registered by another server, or by an earlier registration.
+func (c CompletionCharacters) Triggered(ctx CompletionContext) (string, bool) {
+	if !ctx.TriggeredBy(c.Trigger...) {
+		return "", false
+	}
+	return ctx.TriggerCharacter, true
+}
+
+// CompletionRetriggers remember the incomplete completion lists returned
+// by a server, along with state of type T that allows resuming their
+// computation, such as the candidates before filtering. As the user
+// types further, the client re-triggers the completion of an incomplete
+// list, and the server may then resume from that state, rather than
+// compute the list anew. Its zero value is ready to use.
+//
+// Only the last incomplete list of each document is remembered.
+type CompletionRetriggers[T any] struct {
+	mu   sync.Mutex
+	last map[DocumentURI]retrigger[T]
+}
+
+type retrigger[T any] struct {
+	pos   Position
+	state T
+}
+
+// Record records the list returned for a completion request, with the
+// state of its computation. A list that is complete forgets any earlier
+// list of the document.
+func (r *CompletionRetriggers[T]) Record(params *CompletionParams, list *CompletionList, state T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	uri := params.TextDocument.URI
+	if list == nil || !list.IsIncomplete {
+		delete(r.last, uri)
+		return
+	}
+	if r.last == nil {
+		r.last = make(map[DocumentURI]retrigger[T])
+	}
+	r.last[uri] = retrigger[T]{pos: params.Position, state: state}
+}
+
+// Retriggered returns the state of the incomplete list that a completion
+// request re-triggers, if any: the request must be a re-trigger, in the
+// same document, and on the same line, at or after the position of the
+// incomplete list.
+func (r *CompletionRetriggers[T]) Retriggered(params *CompletionParams) (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last, ok := r.last[params.TextDocument.URI]
+	if !ok || !params.Context.IsRetrigger() || last.pos.Line != params.Position.Line || params.Position.Character < last.pos.Character {
+		var zero T
+		return zero, false
+	}
+	return last.state, true
+}
+
+// Forget forgets the incomplete list of a document, as when it is
+// closed.
+func (r *CompletionRetriggers[T]) Forget(uri DocumentURI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.last, uri)
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestCompletionContext(t *testing.T) {
+	invoked := lsp.CompletionContext{TriggerKind: lsp.Invoked}
+	dot := lsp.CompletionContext{TriggerKind: lsp.TriggerCharacter, TriggerCharacter: "."}
+	retrigger := lsp.CompletionContext{TriggerKind: lsp.TriggerForIncompleteCompletions}
+
+	if got := (lsp.CompletionContext{}).Kind(); got != lsp.Invoked {
+		t.Errorf("Expected a zero context to be invoked, got %v", got)
+	}
+	if !dot.TriggeredBy() || !dot.TriggeredBy(".", ":") || dot.TriggeredBy(":") || invoked.TriggeredBy() {
+		t.Errorf("Unexpected TriggeredBy")
+	}
+	if !retrigger.IsRetrigger() || dot.IsRetrigger() {
+		t.Errorf("Unexpected IsRetrigger")
+	}
+
+	chars := lsp.CompletionCharacters{Trigger: []string{".", "@"}, Commit: []string{"("}}
+	if err := chars.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	want := &lsp.CompletionOptions{TriggerCharacters: []string{".", "@"}, AllCommitCharacters: []string{"("}}
+	if diff := cmp.Diff(want, chars.Options()); diff != "" {
+		t.Errorf("Unexpected options (-want +got):\n%s", diff)
+	}
+	if ch, ok := chars.Triggered(dot); !ok || ch != "." {
+		t.Errorf("Expected completion triggered by %q, got %q, %v", ".", ch, ok)
+	}
+	if _, ok := chars.Triggered(lsp.CompletionContext{TriggerKind: lsp.TriggerCharacter, TriggerCharacter: ":"}); ok {
+		t.Errorf("Expected an undeclared character not to trigger completion")
+	}
+	if err := (lsp.CompletionCharacters{Trigger: []string{"->"}}).Validate(); err == nil {
+		t.Errorf("Expected an error for a trigger of two characters")
+	}
+}
+
+func TestCompletionRetriggers(t *testing.T) {
+	params := func(kind lsp.CompletionTriggerKind, uri lsp.DocumentURI, line, char uint32) *lsp.CompletionParams {
+		return &lsp.CompletionParams{
+			Context: lsp.CompletionContext{TriggerKind: kind},
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+				Position:     lsp.Position{Line: line, Character: char},
+			},
+		}
+	}
+	var r lsp.CompletionRetriggers[[]string]
+	r.Record(params(lsp.Invoked, "file:///a.go", 3, 4), &lsp.CompletionList{IsIncomplete: true}, []string{"Println", "Printf"})
+
+	for _, test := range []struct {
+		name   string
+		params *lsp.CompletionParams
+		want   bool
+	}{
+		{"Retrigger", params(lsp.TriggerForIncompleteCompletions, "file:///a.go", 3, 6), true},
+		{"SamePosition", params(lsp.TriggerForIncompleteCompletions, "file:///a.go", 3, 4), true},
+		{"Invoked", params(lsp.Invoked, "file:///a.go", 3, 6), false},
+		{"Before", params(lsp.TriggerForIncompleteCompletions, "file:///a.go", 3, 2), false},
+		{"OtherLine", params(lsp.TriggerForIncompleteCompletions, "file:///a.go", 4, 6), false},
+		{"OtherDocument", params(lsp.TriggerForIncompleteCompletions, "file:///b.go", 3, 6), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			state, ok := r.Retriggered(test.params)
+			if ok != test.want {
+				t.Fatalf("Expected %v, got %v", test.want, ok)
+			}
+			if ok && len(state) != 2 {
+				t.Errorf("Expected the recorded state, got %v", state)
+			}
+		})
+	}
+
+	// A complete list forgets the incomplete one.
+	r.Record(params(lsp.TriggerForIncompleteCompletions, "file:///a.go", 3, 6), &lsp.CompletionList{}, nil)
+	if _, ok := r.Retriggered(params(lsp.TriggerForIncompleteCompletions, "file:///a.go", 3, 7)); ok {
+		t.Errorf("Expected no incomplete list after a complete one")
+	}
+}
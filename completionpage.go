@@ -0,0 +1,197 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A CompletionMatcher scores how well the text of a completion item (its
+// filterText, or else its label) matches the prefix typed before the
+// position of completion. Items with higher scores rank first, and
+// items with a score of zero or less are excluded.
+type CompletionMatcher func(prefix, text string) float64
+
+// PrefixMatcher is a CompletionMatcher that matches the texts that start
+// with the prefix, ignoring case, and scores those that match its case
+// higher.
+func PrefixMatcher(prefix, text string) float64 {
+	switch {
+	case strings.HasPrefix(text, prefix):
+		return 2
+	case len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix):
+		return 1
+	}
+	return 0
+}
+
+// defaultCompletionLimit is the default maximum number of items of a
+// completion list returned by a CompletionPager.
+const defaultCompletionLimit = 100
+
+// A CompletionPager caps the completion lists of a server, for documents
+// with too many candidates to send them all, and implements the protocol
+// of incomplete lists on their behalf:
+//
+//	pager := &lsp.CompletionPager{Matcher: fuzzyMatch}
+//	...
+//	return pager.Complete(params, m, func(prefix string) ([]lsp.CompletionItem, error) {
+//		return candidates(pkg, pos), nil
+//	})
+//
+// A list with more matching items than the limit is capped, and marked
+// incomplete, so that the client requests completion again as the user
+// types further, rather than filter the capped list itself. The pager
+// then filters the candidates it computed first with the longer prefix,
+// rather than compute them anew, and extends the edits of the items to
+// the new position. A list within the limit is complete, and the client
+// filters it itself.
+//
+// Its zero value is ready to use.
+type CompletionPager struct {
+	// Limit is the maximum number of items of a list, or 100 if zero.
+	Limit int
+	// Matcher scores the items against the typed prefix. If nil, it is
+	// PrefixMatcher.
+	Matcher CompletionMatcher
+	// IsWordChar reports whether a character is part of the words being
+	// completed, which extend back from the position of completion. If
+	// nil, words are made of letters, digits and underscores.
+	IsWordChar func(rune) bool
+
+	retriggers CompletionRetriggers[*pagedCompletion]
+}
+
+// A pagedCompletion holds the candidates of an incomplete list.
+type pagedCompletion struct {
+	start Position // of the word being completed
+	pos   Position // of the request that computed the candidates
+	items []CompletionItem
+}
+
+// Complete returns the completion list of the position of params, in the
+// document whose content is m. Unless the request re-triggers an
+// incomplete list of the same word, it calls candidates with the prefix
+// typed before the position, to compute the candidate items, which need
+// not be filtered by the prefix; their edits, if any, must end at the
+// position. The items that match the prefix are ranked by score, then
+// in the order of the candidates, and capped.
+func (p *CompletionPager) Complete(params *CompletionParams, m *Mapper, candidates func(prefix string) ([]CompletionItem, error)) (*CompletionList, error) {
+	offset, err := m.PositionOffset(params.Position)
+	if err != nil {
+		return nil, err
+	}
+	start := offset
+	for start > 0 {
+		r, size := utf8.DecodeLastRune(m.Content[:start])
+		if !p.isWordChar(r) {
+			break
+		}
+		start -= size
+	}
+	startPos, err := m.OffsetPosition(start)
+	if err != nil {
+		return nil, err
+	}
+	prefix := string(m.Content[start:offset])
+
+	paged, ok := p.retriggers.Retriggered(params)
+	if !ok || paged.start != startPos {
+		items, err := candidates(prefix)
+		if err != nil {
+			return nil, err
+		}
+		paged = &pagedCompletion{start: startPos, pos: params.Position, items: items}
+	}
+
+	matcher := p.Matcher
+	if matcher == nil {
+		matcher = PrefixMatcher
+	}
+	type scored struct {
+		score float64
+		item  *CompletionItem
+	}
+	var matches []scored
+	for i := range paged.items {
+		item := &paged.items[i]
+		text := item.FilterText
+		if text == "" {
+			text = item.Label
+		}
+		if score := matcher(prefix, text); score > 0 {
+			matches = append(matches, scored{score, item})
+		}
+	}
+	slices.SortStableFunc(matches, func(x, y scored) int {
+		switch {
+		case x.score > y.score:
+			return -1
+		case x.score < y.score:
+			return +1
+		}
+		return 0
+	})
+
+	limit := p.Limit
+	if limit == 0 {
+		limit = defaultCompletionLimit
+	}
+	list := &CompletionList{Items: []CompletionItem{}}
+	if len(matches) > limit {
+		matches = matches[:limit]
+		list.IsIncomplete = true
+	}
+	delta := int(params.Position.Character) - int(paged.pos.Character)
+	for _, match := range matches {
+		item := *match.item
+		if delta != 0 && item.TextEdit != nil {
+			item.TextEdit = item.TextEdit.extend(paged.pos, delta)
+		}
+		list.Items = append(list.Items, item)
+	}
+	p.retriggers.Record(params, list, paged)
+	return list, nil
+}
+
+// Forget forgets the candidates of the incomplete list of a document, as
+// when it is closed.
+func (p *CompletionPager) Forget(uri DocumentURI) {
+	p.retriggers.Forget(uri)
+}
+
+func (p *CompletionPager) isWordChar(r rune) bool {
+	if p.IsWordChar != nil {
+		return p.IsWordChar(r)
+	}
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// extend returns a copy of the edit, whose ranges are extended by delta
+// characters if they end at or after pos, on its line, as when delta
+// characters were typed at pos.
+func (e *CompletionItemTextEdit) extend(pos Position, delta int) *CompletionItemTextEdit {
+	shift := func(p Position) Position {
+		if p.Line == pos.Line && p.Character >= pos.Character {
+			p.Character = uint32(int(p.Character) + delta)
+		}
+		return p
+	}
+	switch {
+	case e.TextEdit != nil:
+		edit := *e.TextEdit
+		edit.Range.End = shift(edit.Range.End)
+		return &CompletionItemTextEdit{TextEdit: &edit}
+	case e.InsertReplaceEdit != nil:
+		edit := *e.InsertReplaceEdit
+		edit.Insert.End = shift(edit.Insert.End)
+		edit.Replace.End = shift(edit.Replace.End)
+		return &CompletionItemTextEdit{InsertReplaceEdit: &edit}
+	}
+	return e
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestCompletionPager(t *testing.T) {
+	const uri = "file:///a.txt"
+	computed := 0
+	candidates := func(prefix string) ([]lsp.CompletionItem, error) {
+		computed++
+		var items []lsp.CompletionItem
+		for i := range 150 {
+			label := fmt.Sprintf("print%03d", i)
+			items = append(items, lsp.CompletionItem{
+				Label: label,
+				TextEdit: &lsp.CompletionItemTextEdit{TextEdit: &lsp.TextEdit{
+					Range:   lsp.Range{Start: lsp.Position{Line: 1}, End: lsp.Position{Line: 1, Character: uint32(len(prefix))}},
+					NewText: label,
+				}},
+			})
+		}
+		items = append(items, lsp.CompletionItem{Label: "Print"})
+		return items, nil
+	}
+	pager := &lsp.CompletionPager{Limit: 10}
+	complete := func(kind lsp.CompletionTriggerKind, text string) *lsp.CompletionList {
+		t.Helper()
+		params := &lsp.CompletionParams{
+			Context: lsp.CompletionContext{TriggerKind: kind},
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+				Position:     lsp.Position{Line: 1, Character: uint32(len(text))},
+			},
+		}
+		m := lsp.NewMapper(uri, []byte("x := 1\n"+text+"\n"))
+		list, err := pager.Complete(params, m, candidates)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return list
+	}
+	labels := func(list *lsp.CompletionList) string {
+		var labels []string
+		for _, item := range list.Items {
+			labels = append(labels, item.Label)
+		}
+		return strings.Join(labels, " ")
+	}
+
+	list := complete(lsp.Invoked, "pri")
+	if !list.IsIncomplete || len(list.Items) != 10 || computed != 1 {
+		t.Fatalf("Expected 10 items of an incomplete list, got %d, %v (computed %d times)", len(list.Items), list.IsIncomplete, computed)
+	}
+	if list.Items[0].Label != "print000" {
+		t.Errorf("Expected items matching the case of the prefix first, got %s", list.Items[0].Label)
+	}
+
+	list = complete(lsp.TriggerForIncompleteCompletions, "print1")
+	if !list.IsIncomplete || computed != 1 {
+		t.Errorf("Expected an incomplete list filtered from the first candidates, got %v (computed %d times)", list.IsIncomplete, computed)
+	}
+	if got, want := list.Items[0].TextEdit.TextEdit.Range.End, (lsp.Position{Line: 1, Character: 6}); got != want {
+		t.Errorf("Expected the edit to end at %v, got %v", want, got)
+	}
+
+	list = complete(lsp.TriggerForIncompleteCompletions, "print14")
+	want := "print140 print141 print142 print143 print144 print145 print146 print147 print148 print149"
+	if diff := cmp.Diff(want, labels(list)); diff != "" || list.IsIncomplete {
+		t.Errorf("Expected a complete list, got %v (-want +got):\n%s", list.IsIncomplete, diff)
+	}
+
+	// A complete list is not re-triggered.
+	complete(lsp.TriggerForIncompleteCompletions, "print149")
+	if computed != 2 {
+		t.Errorf("Expected the candidates to be computed anew, computed %d times", computed)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A ConfigService requests the settings of a server from the client,
+// with workspace/configuration, and caches them until the client
+// notifies that they changed. As the settings of a multi-root workspace
+// may differ from folder to folder, it requests and caches them for
+// each workspace folder, whose URI is the scopeUri of the request:
+//
+//	config := lsp.NewConfigService[Settings](client, "gopls")
+//	handler := config.Handler(lsp.ServerHandler(server))
+//	...
+//	settings, err := config.ConfigFor(ctx, params.TextDocument.URI)
+//
+// The settings are decoded by [DecodeSettings], so those the client
+// does not set have their defaults. If the client does not support
+// workspace/configuration, all settings have their defaults.
+type ConfigService[T any] struct {
+	client  Client
+	section string
+
+	mu          sync.Mutex
+	unsupported bool               // the client does not support workspace/configuration
+	folders     []DocumentURI      // normalized as by workspaceRoot
+	cache       map[DocumentURI]*T // by folder, or "" for the global settings
+	generation  int                // incremented on each invalidation
+}
+
+// NewConfigService returns a ConfigService requesting the given
+// configuration section, such as "gopls", from client. The settings are
+// decoded into values of T, a struct type with the tags described by
+// [SettingsContribution].
+func NewConfigService[T any](client Client, section string) *ConfigService[T] {
+	return &ConfigService[T]{
+		client:  client,
+		section: section,
+		cache:   make(map[DocumentURI]*T),
+	}
+}
+
+// Initialize records the workspace folders and the capabilities of the
+// client from the parameters of an initialize request.
+func (s *ConfigService[T]) Initialize(params *ParamInitialize) error {
+	folders, err := WorkspaceRoots(params)
+	s.mu.Lock()
+	s.unsupported = !params.Capabilities.Workspace.Configuration
+	s.mu.Unlock()
+	s.SetFolders(folders)
+	return err
+}
+
+// SetFolders replaces the workspace folders, and forgets the settings
+// cached for the folders that are removed.
+func (s *ConfigService[T]) SetFolders(folders []WorkspaceFolder) {
+	var uris []DocumentURI
+	for _, f := range folders {
+		if uri, err := workspaceRoot(f.URI); err == nil && !slices.Contains(uris, uri) {
+			uris = append(uris, uri)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.folders = uris
+	for uri := range s.cache {
+		if uri != "" && !slices.Contains(uris, uri) {
+			delete(s.cache, uri)
+		}
+	}
+}
+
+// Update applies a change of the workspace folders.
+func (s *ConfigService[T]) Update(change WorkspaceFoldersChangeEvent) {
+	s.mu.Lock()
+	folders := slices.Clone(s.folders)
+	s.mu.Unlock()
+	var updated []WorkspaceFolder
+	for _, uri := range folders {
+		if !slices.ContainsFunc(change.Removed, func(f WorkspaceFolder) bool {
+			removed, err := workspaceRoot(f.URI)
+			return err == nil && removed == uri
+		}) {
+			updated = append(updated, WorkspaceFolder{URI: string(uri)})
+		}
+	}
+	s.SetFolders(append(updated, change.Added...))
+}
+
+// Invalidate forgets all the cached settings, as when the client
+// notifies workspace/didChangeConfiguration.
+func (s *ConfigService[T]) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.cache)
+	s.generation++
+}
+
+// Config returns the global settings, which are not specific to a
+// workspace folder.
+func (s *ConfigService[T]) Config(ctx context.Context) (*T, error) {
+	return s.config(ctx, "")
+}
+
+// ConfigFor returns the settings that apply to uri: those of the
+// innermost workspace folder that encloses it, or the global settings
+// if none does. The returned settings are shared, and must not be
+// modified.
+func (s *ConfigService[T]) ConfigFor(ctx context.Context, uri DocumentURI) (*T, error) {
+	return s.config(ctx, s.folderOf(uri))
+}
+
+// folderOf returns the innermost workspace folder that encloses uri, or
+// "" if none does.
+func (s *ConfigService[T]) folderOf(uri DocumentURI) DocumentURI {
+	if uriScheme(uri) != fileScheme {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var folder DocumentURI
+	for _, f := range s.folders {
+		if len(f) > len(folder) && f.Encloses(uri) {
+			folder = f
+		}
+	}
+	return folder
+}
+
+// config returns the settings of a folder, or the global settings if
+// folder is "", requesting them if they are not cached.
+func (s *ConfigService[T]) config(ctx context.Context, folder DocumentURI) (*T, error) {
+	s.mu.Lock()
+	settings, ok := s.cache[folder]
+	unsupported := s.unsupported
+	generation := s.generation
+	s.mu.Unlock()
+	if ok {
+		return settings, nil
+	}
+
+	var value LSPAny
+	if !unsupported {
+		item := ConfigurationItem{Section: s.section}
+		if folder != "" {
+			scope := URI(folder)
+			item.ScopeURI = &scope
+		}
+		values, err := s.client.Configuration(ctx, &ParamConfiguration{Items: []ConfigurationItem{item}})
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, fmt.Errorf("workspace/configuration: got %d values for 1 item", len(values))
+		}
+		value = values[0]
+	}
+	settings = new(T)
+	if err := DecodeSettings(value, settings); err != nil {
+		return nil, fmt.Errorf("settings of %q: %v", s.section, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Settings requested before an invalidation may be stale.
+	if s.generation == generation && (folder == "" || slices.Contains(s.folders, folder)) {
+		s.cache[folder] = settings
+	}
+	return settings, nil
+}
+
+// Handler returns a handler that invokes handler, after calling
+// Initialize with the parameters of the initialize request, Update with
+// those of workspace/didChangeWorkspaceFolders notifications, and
+// Invalidate on workspace/didChangeConfiguration notifications.
+func (s *ConfigService[T]) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case "initialize":
+			var params ParamInitialize
+			if err := UnmarshalJSON(req.Params, &params); err == nil {
+				if err := s.Initialize(&params); err != nil {
+					event.Error(ctx, "invalid workspace folders", err, RequestLabels(ctx)...)
+				}
+			}
+		case "workspace/didChangeWorkspaceFolders":
+			var params DidChangeWorkspaceFoldersParams
+			if err := UnmarshalJSON(req.Params, &params); err == nil {
+				s.Update(params.Event)
+			}
+		case "workspace/didChangeConfiguration":
+			s.Invalidate()
+		}
+		return handler.Handle(ctx, req)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// configClient answers workspace/configuration with the settings of
+// each scope, and records the scopes requested.
+type configClient struct {
+	lsp.Client
+	settings  map[lsp.URI]lsp.LSPAny
+	requested []lsp.URI
+}
+
+func (c *configClient) Configuration(_ context.Context, params *lsp.ParamConfiguration) ([]lsp.LSPAny, error) {
+	var values []lsp.LSPAny
+	for _, item := range params.Items {
+		var scope lsp.URI
+		if item.ScopeURI != nil {
+			scope = *item.ScopeURI
+		}
+		c.requested = append(c.requested, scope)
+		values = append(values, c.settings[scope])
+	}
+	return values, nil
+}
+
+func TestConfigService(t *testing.T) {
+	ctx := context.Background()
+	client := &configClient{settings: map[lsp.URI]lsp.LSPAny{
+		"":                 map[string]any{"level": "debug"},
+		"file:///home/a":   map[string]any{"gofumpt": false},
+		"file:///home/a/b": map[string]any{"ui.matcher": "caseSensitive"},
+	}}
+	config := lsp.NewConfigService[testSettings](client, "test")
+	var handled []string
+	handler := config.Handler(jsonrpc2.HandlerFunc(func(_ context.Context, req *jsonrpc2.Request) (any, error) {
+		handled = append(handled, req.Method)
+		return nil, nil
+	}))
+	notify := func(method string, params any) {
+		data, err := json.Marshal(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := jsonrpc2.NewNotification(method, json.RawMessage(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler.Handle(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	initialize := &lsp.ParamInitialize{}
+	initialize.Capabilities.Workspace.Configuration = true
+	initialize.WorkspaceFolders = []lsp.WorkspaceFolder{{URI: "file:///home/a/"}, {URI: "file:///home/a/b"}}
+	notify("initialize", initialize)
+
+	type summary struct {
+		Gofumpt bool
+		Level   string
+		Matcher string
+	}
+	configFor := func(uri lsp.DocumentURI) summary {
+		t.Helper()
+		s, err := config.ConfigFor(ctx, uri)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return summary{s.Gofumpt, s.Level, s.UI.Matcher}
+	}
+	for _, test := range []struct {
+		uri  lsp.DocumentURI
+		want summary
+	}{
+		{"file:///home/a/x.go", summary{false, "info", "fuzzy"}},
+		{"file:///home/a/b/y.go", summary{true, "info", "caseSensitive"}},
+		{"file:///home/a/x2.go", summary{false, "info", "fuzzy"}},
+		{"file:///home/c/z.go", summary{true, "debug", "fuzzy"}},
+		{"untitled:Untitled-1", summary{true, "debug", "fuzzy"}},
+	} {
+		if got := configFor(test.uri); got != test.want {
+			t.Errorf("ConfigFor(%s): expected %+v, got %+v", test.uri, test.want, got)
+		}
+	}
+	// The settings of each folder are requested once.
+	if diff := cmp.Diff([]lsp.URI{"file:///home/a", "file:///home/a/b", ""}, client.requested); diff != "" {
+		t.Errorf("Unexpected requests (-want +got):\n%s", diff)
+	}
+
+	t.Run("DidChangeConfiguration", func(t *testing.T) {
+		client.requested = nil
+		client.settings["file:///home/a"] = map[string]any{"level": "off"}
+		notify("workspace/didChangeConfiguration", &lsp.DidChangeConfigurationParams{})
+		if got, want := configFor("file:///home/a/x.go"), (summary{true, "off", "fuzzy"}); got != want {
+			t.Errorf("Expected %+v, got %+v", want, got)
+		}
+		if diff := cmp.Diff([]lsp.URI{"file:///home/a"}, client.requested); diff != "" {
+			t.Errorf("Unexpected requests (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("DidChangeWorkspaceFolders", func(t *testing.T) {
+		notify("workspace/didChangeWorkspaceFolders", &lsp.DidChangeWorkspaceFoldersParams{Event: lsp.WorkspaceFoldersChangeEvent{
+			Removed: []lsp.WorkspaceFolder{{URI: "file:///home/a/b/"}},
+		}})
+		// The files of the removed folder belong to the enclosing one.
+		if got, want := configFor("file:///home/a/b/y.go"), (summary{true, "off", "fuzzy"}); got != want {
+			t.Errorf("Expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		config := lsp.NewConfigService[testSettings](client, "test")
+		if err := config.Initialize(&lsp.ParamInitialize{}); err != nil {
+			t.Fatal(err)
+		}
+		client.requested = nil
+		s, err := config.Config(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Level != "info" || len(client.requested) != 0 {
+			t.Errorf("Expected the default settings without requests, got %+v and %v", s, client.requested)
+		}
+	})
+
+	if diff := cmp.Diff([]string{"initialize", "workspace/didChangeConfiguration", "workspace/didChangeWorkspaceFolders"}, handled); diff != "" {
+		t.Errorf("Unexpected handled methods (-want +got):\n%s", diff)
+	}
+}
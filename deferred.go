@@ -0,0 +1,111 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// ErrAlreadyReplied is returned by [Replier.Reply] when the call has
+// already been answered.
+var ErrAlreadyReplied = errors.New("reply already sent")
+
+// A Replier sends the response to a call whose handler deferred it with
+// [DeferReply].
+type Replier struct {
+	conn    *jsonrpc2.Connection
+	req     *jsonrpc2.Request
+	replied atomic.Bool
+}
+
+// Reply sends the response to the call, as if the handler had returned
+// result and err; results and errors are converted as by
+// [ServerHandler], so that a nil result is sent as null. Only the
+// first reply is sent: Reply returns ErrAlreadyReplied if the call was
+// already answered, by Reply or by the handler returning normally.
+//
+// Reply may be called from any goroutine. It must eventually be called
+// even if the call is cancelled, for example with ErrRequestCancelled.
+func (r *Replier) Reply(result any, err error) error {
+	if !r.replied.CompareAndSwap(false, true) {
+		return ErrAlreadyReplied
+	}
+	return r.conn.Respond(r.req.ID, replyResult(r.req, result), replyError(err))
+}
+
+type asyncKey struct{}
+
+// asyncCall is the state of a call handled by the handler returned by
+// AsyncReplies.
+type asyncCall struct {
+	conn    *jsonrpc2.Connection
+	req     *jsonrpc2.Request
+	replier atomic.Pointer[Replier]
+}
+
+// AsyncReplies returns a handler that invokes handler, allowing it to
+// defer its replies to calls with [DeferReply]. The connection must be
+// the one whose messages are handled, so AsyncReplies is typically
+// called by a [jsonrpc2.Binder]:
+//
+//	func (b binder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+//		return jsonrpc2.ConnectionOptions{
+//			Handler: lsp.AsyncReplies(conn, lsp.ServerHandler(b.server)),
+//		}, nil
+//	}
+//
+// The connection sends exactly one reply to each call: if a handler
+// both defers its reply and returns normally, the first reply wins.
+func AsyncReplies(conn *jsonrpc2.Connection, handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if !req.IsCall() {
+			return handler.Handle(ctx, req)
+		}
+		call := &asyncCall{conn: conn, req: req}
+		result, err := handler.Handle(context.WithValue(ctx, asyncKey{}, call), req)
+		r := call.replier.Load()
+		switch {
+		case r == nil && errors.Is(err, jsonrpc2.ErrAsyncResponse):
+			return nil, fmt.Errorf("%w: %q returned ErrAsyncResponse without deferring its reply", jsonrpc2.ErrInternal, req.Method)
+		case r == nil:
+			return result, err
+		case errors.Is(err, jsonrpc2.ErrAsyncResponse):
+			return nil, jsonrpc2.ErrAsyncResponse // the connection requires this exact error
+		case !r.replied.CompareAndSwap(false, true):
+			return nil, jsonrpc2.ErrAsyncResponse // already replied
+		}
+		return result, err
+	})
+}
+
+// DeferReply returns a Replier for the call being handled in ctx. The
+// handler may then return jsonrpc2.ErrAsyncResponse at once, and reply
+// later, from any goroutine:
+//
+//	func (s *server) Hover(ctx context.Context, params *lsp.HoverParams) (*lsp.Hover, error) {
+//		r, ok := lsp.DeferReply(ctx)
+//		if !ok {
+//			return s.hover(ctx, params)
+//		}
+//		s.queue <- func() { r.Reply(s.hover(ctx, params)) }
+//		return nil, jsonrpc2.ErrAsyncResponse
+//	}
+//
+// DeferReply reports false if the message being handled is a
+// notification, or if the handler was not installed with [AsyncReplies].
+// Calling DeferReply again for the same call returns the same Replier.
+func DeferReply(ctx context.Context) (*Replier, bool) {
+	call, ok := ctx.Value(asyncKey{}).(*asyncCall)
+	if !ok {
+		return nil, false
+	}
+	call.replier.CompareAndSwap(nil, &Replier{conn: call.conn, req: call.req})
+	return call.replier.Load(), true
+}
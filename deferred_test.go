@@ -0,0 +1,149 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// deferringServer answers hovers according to its mode.
+type deferringServer struct {
+	lsp.Server
+	mode   string
+	second chan error // the result of replying twice
+}
+
+func (s *deferringServer) Hover(ctx context.Context, params *lsp.HoverParams) (*lsp.Hover, error) {
+	hover := func(value string) *lsp.Hover {
+		return &lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.PlainText, Value: value}}
+	}
+	if s.mode == "undeferred" {
+		return nil, jsonrpc2.ErrAsyncResponse // without calling DeferReply
+	}
+	r, ok := lsp.DeferReply(ctx)
+	if !ok {
+		return hover("direct"), nil
+	}
+	switch s.mode {
+	case "later":
+		go r.Reply(hover("later"), nil)
+		return nil, jsonrpc2.ErrAsyncResponse
+	case "error":
+		go r.Reply(nil, lsp.ErrContentModified)
+		return nil, jsonrpc2.ErrAsyncResponse
+	case "twice":
+		if err := r.Reply(hover("first"), nil); err != nil {
+			return nil, err
+		}
+		s.second <- r.Reply(hover("second"), nil)
+		return hover("returned"), nil
+	}
+	return hover("unused"), nil
+}
+
+// Shutdown defers its reply, which has no result.
+func (s *deferringServer) Shutdown(ctx context.Context) error {
+	r, ok := lsp.DeferReply(ctx)
+	if !ok {
+		return nil
+	}
+	go r.Reply(nil, nil)
+	return jsonrpc2.ErrAsyncResponse
+}
+
+// asyncBinder binds connections handled by AsyncReplies.
+type asyncBinder struct{ server lsp.Server }
+
+func (b asyncBinder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	return jsonrpc2.ConnectionOptions{Handler: lsp.AsyncReplies(conn, lsp.ServerHandler(b.server))}, nil
+}
+
+func TestDeferredReplies(t *testing.T) {
+	ctx := context.Background()
+	connect := func(t *testing.T, binder jsonrpc2.Binder) lsp.Server {
+		cc, sc := net.Pipe()
+		client, err := jsonrpc2.Dial(ctx, pipeDialer{cc}, jsonrpc2.ConnectionOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		server, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, binder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = client.Close()
+			_ = server.Close()
+		})
+		return lsp.ServerDispatcher(client)
+	}
+	hover := func(t *testing.T, server lsp.Server) (string, error) {
+		h, err := server.Hover(ctx, &lsp.HoverParams{})
+		if err != nil {
+			return "", err
+		}
+		return h.Contents.Value, nil
+	}
+
+	for _, test := range []struct {
+		mode, want string
+	}{
+		{"later", "later"},
+		{"twice", "first"},
+	} {
+		t.Run(test.mode, func(t *testing.T) {
+			srv := &deferringServer{mode: test.mode, second: make(chan error, 1)}
+			got, err := hover(t, connect(t, asyncBinder{srv}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("Expected reply %q, got %q", test.want, got)
+			}
+			if test.mode == "twice" {
+				if err := <-srv.second; !errors.Is(err, lsp.ErrAlreadyReplied) {
+					t.Errorf("Expected ErrAlreadyReplied from the second reply, got %v", err)
+				}
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := hover(t, connect(t, asyncBinder{&deferringServer{mode: "error"}}))
+		if rerr, ok := lsp.ErrorFrom(err); !ok || !errors.Is(rerr, lsp.ErrContentModified) {
+			t.Errorf("Expected ContentModified, got %v", err)
+		}
+	})
+
+	t.Run("NoResult", func(t *testing.T) {
+		// A deferred reply without a result is null, as a direct one.
+		if err := connect(t, asyncBinder{&deferringServer{}}).Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown failed: %v", err)
+		}
+	})
+
+	t.Run("undeferred", func(t *testing.T) {
+		_, err := hover(t, connect(t, asyncBinder{&deferringServer{mode: "undeferred"}}))
+		if rerr, ok := lsp.ErrorFrom(err); !ok || !errors.Is(rerr, lsp.ErrInternal) {
+			t.Errorf("Expected an internal error, got %v", err)
+		}
+	})
+
+	t.Run("NotInstalled", func(t *testing.T) {
+		opts := jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(&deferringServer{mode: "later"})}
+		got, err := hover(t, connect(t, opts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "direct" {
+			t.Errorf("Expected a direct reply, got %q", got)
+		}
+	})
+}
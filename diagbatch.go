@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"slices"
+)
+
+// A DiagnosticsBatch accumulates the diagnostics of an analysis pass
+// over many documents, and publishes them. It reuses the memory of its
+// diagnostics, and of their related information, from one pass to the
+// next, which reduces the garbage produced by servers that publish
+// thousands of diagnostics per pass:
+//
+//	for {
+//		for _, d := range analyze() {
+//			d.RelatedInformation = batch.Related(d.RelatedInformation...)
+//			batch.Add(d.URI, d.Diagnostic)
+//		}
+//		err := batch.Publish(ctx, client)
+//		...
+//	}
+//
+// Publish sends a notification for every document that has diagnostics
+// in the current pass, or had some in the previous one, so that the
+// diagnostics of documents that became clean are cleared.
+//
+// Since its memory is reused, the client passed to Publish must not
+// retain the parameters of its notifications, which is the case of the
+// Client returned by [ClientDispatcher]; nor may the caller retain the
+// slices returned by Related after Publish.
+//
+// The zero value is an empty batch ready to use. A DiagnosticsBatch is
+// not safe for concurrent use.
+type DiagnosticsBatch struct {
+	docs    map[DocumentURI]*batchDocument
+	uris    []DocumentURI
+	related []DiagnosticRelatedInformation // arena for Related
+}
+
+type batchDocument struct {
+	version     int32
+	diagnostics []Diagnostic
+	published   bool // had diagnostics in the previous pass
+}
+
+// Add adds a diagnostic of the document with the given URI to the batch.
+func (b *DiagnosticsBatch) Add(uri DocumentURI, d Diagnostic) {
+	doc := b.document(uri)
+	doc.diagnostics = append(doc.diagnostics, d)
+}
+
+// SetVersion sets the version of the document to which the diagnostics
+// of the batch apply. By default, the version is omitted.
+func (b *DiagnosticsBatch) SetVersion(uri DocumentURI, version int32) {
+	b.document(uri).version = version
+}
+
+// Related returns a copy of info, for use as the related information of
+// a diagnostic of the batch, allocated in memory that is reused after
+// Publish.
+func (b *DiagnosticsBatch) Related(info ...DiagnosticRelatedInformation) []DiagnosticRelatedInformation {
+	if len(info) == 0 {
+		return nil
+	}
+	if len(b.related)+len(info) > cap(b.related) {
+		// Start a new chunk; the previous one remains in use by the
+		// diagnostics of this pass.
+		b.related = make([]DiagnosticRelatedInformation, 0, max(2*cap(b.related), len(info), 64))
+	}
+	start := len(b.related)
+	b.related = append(b.related, info...)
+	return b.related[start:len(b.related):len(b.related)]
+}
+
+// Len returns the number of diagnostics in the batch.
+func (b *DiagnosticsBatch) Len() int {
+	n := 0
+	for _, doc := range b.docs {
+		n += len(doc.diagnostics)
+	}
+	return n
+}
+
+// Publish sends the diagnostics of the batch to client, in order of
+// URI, and empties the batch for the next pass. It stops at the first
+// error, and the batch is emptied regardless.
+func (b *DiagnosticsBatch) Publish(ctx context.Context, client Client) error {
+	defer b.reset()
+	b.uris = b.uris[:0]
+	for uri, doc := range b.docs {
+		if len(doc.diagnostics) > 0 || doc.published {
+			b.uris = append(b.uris, uri)
+		}
+	}
+	slices.Sort(b.uris)
+	var params PublishDiagnosticsParams
+	for _, uri := range b.uris {
+		doc := b.docs[uri]
+		if doc.diagnostics == nil {
+			doc.diagnostics = []Diagnostic{} // encoded as [], not null
+		}
+		params = PublishDiagnosticsParams{URI: uri, Version: doc.version, Diagnostics: doc.diagnostics}
+		if err := client.PublishDiagnostics(ctx, &params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reset empties the batch, keeping its memory.
+func (b *DiagnosticsBatch) reset() {
+	for uri, doc := range b.docs {
+		if len(doc.diagnostics) == 0 && !doc.published {
+			delete(b.docs, uri) // clean in this pass and the previous one
+			continue
+		}
+		doc.published = len(doc.diagnostics) > 0
+		clear(doc.diagnostics) // drop references for the garbage collector
+		doc.diagnostics = doc.diagnostics[:0]
+		doc.version = 0
+	}
+	clear(b.related)
+	b.related = b.related[:0]
+}
+
+func (b *DiagnosticsBatch) document(uri DocumentURI) *batchDocument {
+	if b.docs == nil {
+		b.docs = make(map[DocumentURI]*batchDocument)
+	}
+	doc, ok := b.docs[uri]
+	if !ok {
+		doc = new(batchDocument)
+		b.docs[uri] = doc
+	}
+	return doc
+}
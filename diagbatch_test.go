@@ -0,0 +1,109 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+// publishRecorder records the diagnostics published to it, as JSON.
+type publishRecorder struct {
+	lsp.Client
+	published []string
+}
+
+func (c *publishRecorder) PublishDiagnostics(_ context.Context, params *lsp.PublishDiagnosticsParams) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	c.published = append(c.published, string(data))
+	return nil
+}
+
+func TestDiagnosticsBatch(t *testing.T) {
+	ctx := context.Background()
+	diag := func(line uint32, message string) lsp.Diagnostic {
+		return lsp.Diagnostic{Range: rng(line, 0, 1), Message: lsp.DiagnosticMessage{String: &message}}
+	}
+	var batch lsp.DiagnosticsBatch
+	client := new(publishRecorder)
+	publish := func(t *testing.T, want ...string) {
+		t.Helper()
+		client.published = nil
+		if err := batch.Publish(ctx, client); err != nil {
+			t.Fatal(err)
+		}
+		if len(client.published) != len(want) {
+			t.Fatalf("Expected %d notifications, got %d: %q", len(want), len(client.published), client.published)
+		}
+		for i := range want {
+			if client.published[i] != want[i] {
+				t.Errorf("Notification %d:\nwant %s\ngot  %s", i, want[i], client.published[i])
+			}
+		}
+	}
+
+	t.Run("FirstPass", func(t *testing.T) {
+		d := diag(1, "unused")
+		d.RelatedInformation = batch.Related(lsp.DiagnosticRelatedInformation{
+			Location: lsp.Location{URI: "file:///b.go", Range: rng(0, 0, 1)},
+			Message:  "declared here",
+		})
+		batch.Add("file:///a.go", d)
+		batch.Add("file:///b.go", diag(2, "undefined"))
+		batch.SetVersion("file:///b.go", 3)
+		if got := batch.Len(); got != 2 {
+			t.Errorf("Expected 2 diagnostics, got %d", got)
+		}
+		publish(t,
+			`{"uri":"file:///a.go","diagnostics":[{"range":{"start":{"line":1,"character":0},"end":{"line":1,"character":1}},"code":null,"message":"unused","relatedInformation":[{"location":{"uri":"file:///b.go","range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}}},"message":"declared here"}]}]}`,
+			`{"uri":"file:///b.go","version":3,"diagnostics":[{"range":{"start":{"line":2,"character":0},"end":{"line":2,"character":1}},"code":null,"message":"undefined"}]}`)
+		if got := batch.Len(); got != 0 {
+			t.Errorf("Expected an empty batch after Publish, got %d diagnostics", got)
+		}
+	})
+
+	t.Run("Clears", func(t *testing.T) {
+		batch.Add("file:///a.go", diag(4, "again"))
+		publish(t,
+			`{"uri":"file:///a.go","diagnostics":[{"range":{"start":{"line":4,"character":0},"end":{"line":4,"character":1}},"code":null,"message":"again"}]}`,
+			`{"uri":"file:///b.go","diagnostics":[]}`)
+		publish(t, `{"uri":"file:///a.go","diagnostics":[]}`)
+		publish(t)
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		message := "reused"
+		info := lsp.DiagnosticRelatedInformation{Message: "related"}
+		pass := func() {
+			for i := range 100 {
+				d := lsp.Diagnostic{Range: rng(uint32(i), 0, 1), Message: lsp.DiagnosticMessage{String: &message}}
+				d.RelatedInformation = batch.Related(info, info)
+				batch.Add("file:///c.go", d)
+			}
+			if err := batch.Publish(ctx, discardClient{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		pass() // grow the buffers
+		if allocs := testing.AllocsPerRun(10, pass); allocs > 2 {
+			t.Errorf("Expected the batch to reuse its memory, got %v allocations per pass", allocs)
+		}
+	})
+}
+
+// discardClient discards the diagnostics published to it.
+type discardClient struct {
+	lsp.Client
+}
+
+func (discardClient) PublishDiagnostics(context.Context, *lsp.PublishDiagnosticsParams) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NewCodeDescription returns the description of a diagnostic code, whose
+// documentation is at href, which must be an absolute URL.
+func NewCodeDescription(href string) (*CodeDescription, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsAbs() {
+		return nil, fmt.Errorf("code description %q is not an absolute URL", href)
+	}
+	return &CodeDescription{Href: URI(href)}, nil
+}
+
+// DiagnosticCodes map the codes of the diagnostics of a server to the
+// URLs of their documentation, so that every diagnostic with a code
+// links to its explanation:
+//
+//	var codes lsp.DiagnosticCodes
+//	codes.Pattern = "https://example.com/errors/{code}"
+//	...
+//	d := lsp.Diagnostic{Code: lsp.DiagnosticCodeFromString("E042"), ...}
+//	codes.Describe(&d)
+//
+// Codes are registered, or derived from the Pattern. An integer code
+// and a string code of the same digits, such as 42 and "42", are the
+// same. Clients show the links if they declare
+// publishDiagnostics.codeDescriptionSupport; others ignore them.
+//
+// Its zero value is ready to use. DiagnosticCodes are safe for
+// concurrent use.
+type DiagnosticCodes struct {
+	// Pattern, if not empty, is the URL of the documentation of the
+	// codes that are not registered, in which "{code}" stands for the
+	// code, escaped as a path segment.
+	Pattern string
+
+	mu   sync.Mutex
+	urls map[string]URI // by codeKey
+}
+
+// codeKey returns the key of a code, and whether it has one.
+func codeKey(code DiagnosticCode) (string, bool) {
+	switch {
+	case code.Int32 != nil:
+		return strconv.FormatInt(int64(*code.Int32), 10), true
+	case code.String != nil && *code.String != "":
+		return *code.String, true
+	}
+	return "", false
+}
+
+// Register registers the URL of the documentation of a code. It reports
+// an error if href is not an absolute URL, or code is empty.
+func (c *DiagnosticCodes) Register(code DiagnosticCode, href string) error {
+	key, ok := codeKey(code)
+	if !ok {
+		return fmt.Errorf("empty diagnostic code")
+	}
+	desc, err := NewCodeDescription(href)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.urls == nil {
+		c.urls = make(map[string]URI)
+	}
+	c.urls[key] = desc.Href
+	return nil
+}
+
+// Lookup returns the description of a code: the URL registered for it,
+// or else derived from the Pattern.
+func (c *DiagnosticCodes) Lookup(code DiagnosticCode) (*CodeDescription, bool) {
+	key, ok := codeKey(code)
+	if !ok {
+		return nil, false
+	}
+	c.mu.Lock()
+	href, ok := c.urls[key]
+	c.mu.Unlock()
+	if ok {
+		return &CodeDescription{Href: href}, true
+	}
+	if c.Pattern == "" {
+		return nil, false
+	}
+	return &CodeDescription{Href: URI(strings.ReplaceAll(c.Pattern, "{code}", url.PathEscape(key)))}, true
+}
+
+// Describe sets the description of the code of a diagnostic, unless it
+// has one or its code has none.
+func (c *DiagnosticCodes) Describe(d *Diagnostic) {
+	if d.CodeDescription != nil {
+		return
+	}
+	if desc, ok := c.Lookup(d.Code); ok {
+		d.CodeDescription = desc
+	}
+}
+
+// DescribeAll describes the codes of the diagnostics, as Describe does.
+func (c *DiagnosticCodes) DescribeAll(diagnostics []Diagnostic) {
+	for i := range diagnostics {
+		c.Describe(&diagnostics[i])
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestDiagnosticCodes(t *testing.T) {
+	var codes lsp.DiagnosticCodes
+	if err := codes.Register(lsp.DiagnosticCodeFromInt32(42), "https://example.com/answer"); err != nil {
+		t.Fatal(err)
+	}
+	if err := codes.Register(lsp.DiagnosticCodeFromString("E1"), "errors/E1"); err == nil {
+		t.Errorf("Expected an error for a relative URL")
+	}
+	if err := codes.Register(lsp.DiagnosticCode{}, "https://example.com"); err == nil {
+		t.Errorf("Expected an error for an empty code")
+	}
+
+	diags := []lsp.Diagnostic{
+		{Code: lsp.DiagnosticCodeFromInt32(42)},
+		{Code: lsp.DiagnosticCodeFromString("42")},
+		{Code: lsp.DiagnosticCodeFromString("E1")},
+		{},
+		{Code: lsp.DiagnosticCodeFromInt32(42), CodeDescription: &lsp.CodeDescription{Href: "https://other"}},
+	}
+	codes.DescribeAll(diags)
+	href := func(d lsp.Diagnostic) lsp.URI {
+		if d.CodeDescription == nil {
+			return ""
+		}
+		return d.CodeDescription.Href
+	}
+	for i, want := range []lsp.URI{"https://example.com/answer", "https://example.com/answer", "", "", "https://other"} {
+		if got := href(diags[i]); got != want {
+			t.Errorf("Diagnostic %d: expected %q, got %q", i, want, got)
+		}
+	}
+
+	codes.Pattern = "https://example.com/errors/{code}"
+	if desc, ok := codes.Lookup(lsp.DiagnosticCodeFromString("E1/x")); !ok || desc.Href != "https://example.com/errors/E1%2Fx" {
+		t.Errorf("Expected a URL derived from the pattern, got %v, %v", desc, ok)
+	}
+	if _, err := lsp.NewCodeDescription("://bad"); err == nil {
+		t.Errorf("Expected an error for an invalid URL")
+	}
+}
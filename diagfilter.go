@@ -0,0 +1,182 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// DiagnosticFilterSettings are the settings of a [DiagnosticFilter], as
+// the user configures them in the client. They may be a group of the
+// settings of a server, decoded by [DecodeSettings]:
+//
+//	type Settings struct {
+//		Diagnostics lsp.DiagnosticFilterSettings `json:"diagnostics"`
+//	}
+//
+// Severities are named "error", "warning", "information" and "hint".
+type DiagnosticFilterSettings struct {
+	// MinimumSeverity is the least severe severity of the diagnostics
+	// that are published; "warning" suppresses information and hints.
+	// If empty, all diagnostics are published.
+	MinimumSeverity string `json:"minimumSeverity" enum:"error,warning,information,hint" default:"hint" description:"The least severe diagnostics to report."`
+	// Severities maps diagnostic codes to the severity of their
+	// diagnostics, overriding that of the server, or to "off" to
+	// suppress them.
+	Severities map[string]string `json:"severities" description:"The severities of diagnostics by code, or \"off\" to suppress them."`
+}
+
+// A DiagnosticFilter filters and adjusts the diagnostics a server
+// publishes, according to the settings of the user and the capabilities
+// of the client:
+//
+//	filter := new(lsp.DiagnosticFilter)
+//	filter.SetClientCapabilities(&params.Capabilities) // on initialize
+//	client = filter.Client(client)
+//	...
+//	filter.SetSettings(settings.Diagnostics) // on configuration change
+//
+// Diagnostics whose code has a severity in the settings get that
+// severity, or are dropped if it is "off"; then those less severe than
+// the minimum severity are dropped. Diagnostics without a severity count
+// as errors, as most clients show them. Finally, the tags the client
+// does not declare in publishDiagnostics.tagSupport are removed, such as
+// the deprecated tag for clients that would otherwise ignore the
+// diagnostic, or show it as a plain one.
+//
+// Its zero value publishes all diagnostics unchanged, until its client
+// capabilities are set. A DiagnosticFilter is safe for concurrent use.
+type DiagnosticFilter struct {
+	mu          sync.Mutex
+	minimum     DiagnosticSeverity            // or 0 for all
+	severities  map[string]DiagnosticSeverity // by codeKey; 0 for off
+	tags        []DiagnosticTag               // supported by the client
+	tagsChecked bool                          // tags is set
+}
+
+// diagnosticSeverities maps the names of severities in settings to
+// their values.
+var diagnosticSeverities = map[string]DiagnosticSeverity{
+	"error":       SeverityError,
+	"warning":     SeverityWarning,
+	"information": SeverityInformation,
+	"hint":        SeverityHint,
+}
+
+// SetSettings sets the settings of the filter, which apply to the
+// diagnostics published afterwards. It reports an error, and leaves the
+// settings unchanged, if a severity is unknown.
+func (f *DiagnosticFilter) SetSettings(settings DiagnosticFilterSettings) error {
+	var minimum DiagnosticSeverity
+	if settings.MinimumSeverity != "" {
+		s, ok := diagnosticSeverities[settings.MinimumSeverity]
+		if !ok {
+			return fmt.Errorf("unknown minimum severity %q", settings.MinimumSeverity)
+		}
+		minimum = s
+	}
+	severities := make(map[string]DiagnosticSeverity, len(settings.Severities))
+	for code, name := range settings.Severities {
+		s, ok := diagnosticSeverities[name]
+		if !ok && name != "off" {
+			return fmt.Errorf("unknown severity %q of code %q", name, code)
+		}
+		severities[code] = s
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.minimum = minimum
+	f.severities = severities
+	return nil
+}
+
+// SetClientCapabilities sets the capabilities of the client, of which
+// the filter removes the diagnostic tags the client does not support.
+func (f *DiagnosticFilter) SetClientCapabilities(caps *ClientCapabilities) {
+	var tags []DiagnosticTag
+	if pd := caps.TextDocument.PublishDiagnostics; pd.TagSupport != nil {
+		tags = slices.Clone(pd.TagSupport.ValueSet)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tags = tags
+	f.tagsChecked = true
+}
+
+// Filter returns the diagnostics that remain after filtering, adjusted
+// as described above. The diagnostics are not modified; the result
+// shares their memory if they are unchanged.
+func (f *DiagnosticFilter) Filter(diagnostics []Diagnostic) []Diagnostic {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.minimum == 0 && len(f.severities) == 0 && !f.tagsChecked {
+		return diagnostics
+	}
+	var result []Diagnostic
+	changed := false
+	for _, d := range diagnostics {
+		keep, adjusted := f.filter(&d)
+		changed = changed || !keep || adjusted
+		if keep {
+			result = append(result, d)
+		}
+	}
+	if !changed {
+		return diagnostics
+	}
+	if result == nil {
+		result = []Diagnostic{} // encoded as [], not null
+	}
+	return result
+}
+
+// filter filters and adjusts a diagnostic, and reports whether it is
+// kept and whether it was adjusted.
+func (f *DiagnosticFilter) filter(d *Diagnostic) (keep, adjusted bool) {
+	if key, ok := codeKey(d.Code); ok {
+		if s, ok := f.severities[key]; ok {
+			if s == 0 {
+				return false, false // off
+			}
+			adjusted = d.Severity != s
+			d.Severity = s
+		}
+	}
+	if severity := cmp.Or(d.Severity, SeverityError); f.minimum != 0 && severity > f.minimum {
+		return false, false
+	}
+	unsupported := func(t DiagnosticTag) bool { return !slices.Contains(f.tags, t) }
+	if f.tagsChecked && slices.ContainsFunc(d.Tags, unsupported) {
+		d.Tags = slices.DeleteFunc(slices.Clone(d.Tags), unsupported)
+		if len(d.Tags) == 0 {
+			d.Tags = nil
+		}
+		adjusted = true
+	}
+	return true, adjusted
+}
+
+// Client returns a Client that invokes client, and filters the
+// diagnostics of the textDocument/publishDiagnostics notifications the
+// server sends it. A document whose diagnostics are all filtered out is
+// published with none, which clears those previously published.
+func (f *DiagnosticFilter) Client(client Client) Client {
+	return &diagnosticFilterClient{Client: client, filter: f}
+}
+
+type diagnosticFilterClient struct {
+	Client
+	filter *DiagnosticFilter
+}
+
+func (c *diagnosticFilterClient) PublishDiagnostics(ctx context.Context, params *PublishDiagnosticsParams) error {
+	p := *params
+	p.Diagnostics = c.filter.Filter(params.Diagnostics)
+	return c.Client.PublishDiagnostics(ctx, &p)
+}
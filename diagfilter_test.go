@@ -0,0 +1,68 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestDiagnosticFilter(t *testing.T) {
+	ctx := context.Background()
+	diag := func(code string, severity lsp.DiagnosticSeverity, tags ...lsp.DiagnosticTag) lsp.Diagnostic {
+		return lsp.Diagnostic{Code: lsp.DiagnosticCodeFromString(code), Severity: severity, Message: lsp.DiagnosticMessage{String: &code}, Tags: tags}
+	}
+	diagnostics := []lsp.Diagnostic{
+		diag("unused", lsp.SeverityHint, lsp.Unnecessary),
+		diag("deprecated", lsp.SeverityWarning, lsp.Deprecated),
+		diag("shadow", lsp.SeverityInformation),
+		diag("vet", lsp.SeverityWarning),
+		diag("undefined", 0),
+	}
+
+	var filter lsp.DiagnosticFilter
+	if got := filter.Filter(diagnostics); &got[0] != &diagnostics[0] {
+		t.Errorf("Expected the zero filter to return the diagnostics unchanged")
+	}
+
+	var settings struct {
+		Diagnostics lsp.DiagnosticFilterSettings `json:"diagnostics"`
+	}
+	err := lsp.DecodeSettings(map[string]any{
+		"diagnostics.minimumSeverity": "warning",
+		"diagnostics.severities":      map[string]any{"shadow": "error", "vet": "off"},
+	}, &settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := filter.SetSettings(settings.Diagnostics); err != nil {
+		t.Fatal(err)
+	}
+	caps := &lsp.ClientCapabilities{}
+	caps.TextDocument.PublishDiagnostics.TagSupport = &lsp.ClientDiagnosticsTagOptions{ValueSet: []lsp.DiagnosticTag{lsp.Unnecessary}}
+	filter.SetClientCapabilities(caps)
+
+	client := new(publishRecorder)
+	err = filter.Client(client).PublishDiagnostics(ctx, &lsp.PublishDiagnosticsParams{URI: "file:///a.go", Diagnostics: diagnostics})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"uri":"file:///a.go","diagnostics":[` +
+		`{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"severity":2,"code":"deprecated","message":"deprecated"},` +
+		`{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"severity":1,"code":"shadow","message":"shadow"},` +
+		`{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"code":"undefined","message":"undefined"}]}`
+	if len(client.published) != 1 || client.published[0] != want {
+		t.Errorf("Unexpected notifications:\nwant %s\ngot  %q", want, client.published)
+	}
+	if len(diagnostics[1].Tags) != 1 || diagnostics[2].Severity != lsp.SeverityInformation {
+		t.Errorf("Expected the diagnostics not to be modified, got %+v", diagnostics)
+	}
+
+	if err := filter.SetSettings(lsp.DiagnosticFilterSettings{Severities: map[string]string{"vet": "fatal"}}); err == nil {
+		t.Errorf("Expected an error for an unknown severity")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A RelatedInformationBuilder builds the related information of a
+// diagnostic from its causes, located by byte offsets in the documents
+// of Mappers, and attaches it to the diagnostic:
+//
+//	var related lsp.RelatedInformationBuilder
+//	related.Conflict(m, prev.Start, prev.End, "x").
+//		Because(m, imp.Start, imp.End, "imported by this declaration")
+//	err := related.Attach(&diag, caps)
+//
+// The causes form chains: a cause added by Because explains the
+// previous one. Clients show the related information as a flat list in
+// the order of the chains; those that do not support it get it appended
+// to the message of the diagnostic, where the causes of causes are
+// indented.
+//
+// The zero value is an empty builder ready to use. The first error of
+// converting offsets is reported when the information is built, and
+// the causes added afterwards are ignored.
+type RelatedInformationBuilder struct {
+	info   []DiagnosticRelatedInformation
+	depths []int // of info, 0 for the direct causes
+	err    error
+}
+
+// Add adds a direct cause of the diagnostic, in the document of m
+// between the start and end offsets.
+func (b *RelatedInformationBuilder) Add(m *Mapper, start, end int, message string) *RelatedInformationBuilder {
+	return b.add(0, m, start, end, message)
+}
+
+// Declaration adds the declaration of name as a direct cause of the
+// diagnostic, as for a diagnostic about its uses.
+func (b *RelatedInformationBuilder) Declaration(m *Mapper, start, end int, name string) *RelatedInformationBuilder {
+	return b.Add(m, start, end, fmt.Sprintf("%s declared here", name))
+}
+
+// Conflict adds another declaration of name that conflicts with the
+// one of the diagnostic as a direct cause of the diagnostic.
+func (b *RelatedInformationBuilder) Conflict(m *Mapper, start, end int, name string) *RelatedInformationBuilder {
+	return b.Add(m, start, end, fmt.Sprintf("other declaration of %s", name))
+}
+
+// Because adds a cause of the cause added last. It panics if there is
+// none.
+func (b *RelatedInformationBuilder) Because(m *Mapper, start, end int, message string) *RelatedInformationBuilder {
+	if len(b.depths) == 0 && b.err == nil {
+		panic("lsp: Because without a cause")
+	}
+	depth := 0
+	if n := len(b.depths); n > 0 {
+		depth = b.depths[n-1] + 1
+	}
+	return b.add(depth, m, start, end, message)
+}
+
+func (b *RelatedInformationBuilder) add(depth int, m *Mapper, start, end int, message string) *RelatedInformationBuilder {
+	if b.err != nil {
+		return b
+	}
+	loc, err := m.OffsetLocation(start, end)
+	if err != nil {
+		b.err = fmt.Errorf("related information %q: %v", message, err)
+		return b
+	}
+	b.info = append(b.info, DiagnosticRelatedInformation{Location: loc, Message: message})
+	b.depths = append(b.depths, depth)
+	return b
+}
+
+// Build returns the related information, or the first error of
+// converting offsets.
+func (b *RelatedInformationBuilder) Build() ([]DiagnosticRelatedInformation, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.info, nil
+}
+
+// Flatten returns the causes as lines to append to the message of a
+// diagnostic, one per cause, of the form "a.go:3:5: x declared here",
+// with the line and character counted from 1, and indented by a tab per
+// level of the chain. In Markdown, the lines are nested list items.
+func (b *RelatedInformationBuilder) Flatten(markdown bool) string {
+	var buf strings.Builder
+	for i, info := range b.info {
+		pos := info.Location.Range.Start
+		line := fmt.Sprintf("%s:%d:%d: %s", info.Location.URI.Base(), pos.Line+1, pos.Character+1, info.Message)
+		if markdown {
+			fmt.Fprintf(&buf, "\n%s- %s", strings.Repeat("  ", b.depths[i]), line)
+		} else {
+			fmt.Fprintf(&buf, "\n%s%s", strings.Repeat("\t", b.depths[i]), line)
+		}
+	}
+	return buf.String()
+}
+
+// Attach sets the related information of the diagnostic d, if the
+// client supports it according to caps, or else appends it to its
+// message, as Flatten does; a nil caps means support. It returns the
+// first error of converting offsets, leaving d unchanged.
+func (b *RelatedInformationBuilder) Attach(d *Diagnostic, caps *ClientCapabilities) error {
+	info, err := b.Build()
+	if err != nil || len(info) == 0 {
+		return err
+	}
+	if caps == nil || caps.TextDocument.PublishDiagnostics.RelatedInformation {
+		d.RelatedInformation = append(d.RelatedInformation, info...)
+		return nil
+	}
+	switch msg := &d.Message; {
+	case msg.MarkupContent != nil:
+		c := *msg.MarkupContent
+		if c.Kind == Markdown {
+			c.Value += "\n" + b.Flatten(true)
+		} else {
+			c.Value += b.Flatten(false)
+		}
+		msg.MarkupContent = &c
+	default:
+		var s string
+		if msg.String != nil {
+			s = *msg.String
+		}
+		s += b.Flatten(false)
+		msg.String = &s
+	}
+	return nil
+}
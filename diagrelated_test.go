@@ -0,0 +1,80 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestRelatedInformationBuilder(t *testing.T) {
+	const src = "package p\n\nvar x int\n\nimport \"x\"\n"
+	m := lsp.NewMapper("file:///p/a.go", []byte(src))
+	offset := func(s string) (int, int) {
+		i := strings.Index(src, s)
+		return i, i + len(s)
+	}
+	build := func() *lsp.RelatedInformationBuilder {
+		var b lsp.RelatedInformationBuilder
+		start, _ := offset("x int")
+		b.Conflict(m, start, start+1, "x")
+		start, end := offset(`"x"`)
+		b.Because(m, start, end, "imported here")
+		start, end = offset("package p")
+		return b.Add(m, start, end, "in package p")
+	}
+	message := "x redeclared"
+
+	t.Run("Attach", func(t *testing.T) {
+		d := lsp.Diagnostic{Message: lsp.DiagnosticMessage{String: &message}}
+		if err := build().Attach(&d, nil); err != nil {
+			t.Fatal(err)
+		}
+		want := []lsp.DiagnosticRelatedInformation{
+			{Location: lsp.Location{URI: "file:///p/a.go", Range: rng(2, 4, 5)}, Message: "other declaration of x"},
+			{Location: lsp.Location{URI: "file:///p/a.go", Range: rng(4, 7, 10)}, Message: "imported here"},
+			{Location: lsp.Location{URI: "file:///p/a.go", Range: rng(0, 0, 9)}, Message: "in package p"},
+		}
+		if diff := cmp.Diff(want, d.RelatedInformation); diff != "" {
+			t.Errorf("Unexpected related information (-want +got):\n%s", diff)
+		}
+		if *d.Message.String != message {
+			t.Errorf("Expected the message to be unchanged, got %q", *d.Message.String)
+		}
+	})
+
+	t.Run("Flatten", func(t *testing.T) {
+		caps := &lsp.ClientCapabilities{}
+		d := lsp.Diagnostic{Message: lsp.DiagnosticMessage{String: &message}}
+		if err := build().Attach(&d, caps); err != nil {
+			t.Fatal(err)
+		}
+		want := "x redeclared\na.go:3:5: other declaration of x\n\ta.go:5:8: imported here\na.go:1:1: in package p"
+		if d.RelatedInformation != nil || *d.Message.String != want {
+			t.Errorf("Expected the message %q and no related information, got %q, %v", want, *d.Message.String, d.RelatedInformation)
+		}
+
+		d = lsp.Diagnostic{Message: lsp.DiagnosticMessage{MarkupContent: &lsp.MarkupContent{Kind: lsp.Markdown, Value: "`x` redeclared"}}}
+		if err := build().Attach(&d, caps); err != nil {
+			t.Fatal(err)
+		}
+		want = "`x` redeclared\n\n- a.go:3:5: other declaration of x\n  - a.go:5:8: imported here\n- a.go:1:1: in package p"
+		if got := d.Message.MarkupContent.Value; got != want {
+			t.Errorf("Expected the message %q, got %q", want, got)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var b lsp.RelatedInformationBuilder
+		b.Add(m, 0, len(src)+1, "beyond").Because(m, 0, 1, "ignored")
+		d := lsp.Diagnostic{Message: lsp.DiagnosticMessage{String: &message}}
+		if err := b.Attach(&d, nil); err == nil || d.RelatedInformation != nil {
+			t.Errorf("Expected an error and no related information, got %v, %v", err, d.RelatedInformation)
+		}
+	})
+}
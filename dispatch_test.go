@@ -0,0 +1,171 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// hoverServer implements only Hover; calling any other method panics.
+type hoverServer struct {
+	lsp.Server
+}
+
+func (hoverServer) Hover(_ context.Context, params *lsp.HoverParams) (*lsp.Hover, error) {
+	return &lsp.Hover{
+		Contents: lsp.MarkupContent{Kind: lsp.PlainText, Value: string(params.TextDocument.URI)},
+	}, nil
+}
+
+type pipeDialer struct{ rwc io.ReadWriteCloser }
+
+func (d pipeDialer) Dial(context.Context) (io.ReadWriteCloser, error) { return d.rwc, nil }
+
+// connectPair returns the two ends of an in-memory jsonrpc2 connection,
+// whose incoming messages are handled by the given handlers.
+// Both connections are closed when the test finishes.
+func connectPair(t testing.TB, clientHandler, serverHandler jsonrpc2.Handler) (client, server *jsonrpc2.Connection) {
+	ctx := context.Background()
+	cc, sc := net.Pipe()
+	client, err := jsonrpc2.Dial(ctx, pipeDialer{cc}, jsonrpc2.ConnectionOptions{Handler: clientHandler})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err = jsonrpc2.Dial(ctx, pipeDialer{sc}, jsonrpc2.ConnectionOptions{Handler: serverHandler})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+	return client, server
+}
+
+func newHoverCall(t testing.TB) *jsonrpc2.Request {
+	req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "textDocument/hover", &lsp.HoverParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test.go"},
+			Position:     lsp.Position{Line: 1, Character: 2},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestServerHandlerDispatch(t *testing.T) {
+	ctx := context.Background()
+	handler := lsp.ServerHandler(hoverServer{})
+
+	t.Run("KnownMethod", func(t *testing.T) {
+		resp, err := handler(ctx, newHoverCall(t))
+		if err != nil {
+			t.Fatalf("Hover failed: %v", err)
+		}
+		hover, ok := resp.(*lsp.Hover)
+		if !ok {
+			t.Fatalf("Expected *lsp.Hover, got %T", resp)
+		}
+		if hover.Contents.Value != "file:///test.go" {
+			t.Errorf("Expected hover for file:///test.go, got %q", hover.Contents.Value)
+		}
+	})
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(2), "$/unknown", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler(ctx, req); !errors.Is(err, jsonrpc2.ErrMethodNotFound) {
+			t.Errorf("Expected ErrMethodNotFound, got %v", err)
+		}
+	})
+
+	t.Run("NoResult", func(t *testing.T) {
+		// The result of a request without one is null, not missing.
+		client, _ := connectPair(t, nil, lsp.ServerHandler(shutdownServer{}))
+		if err := lsp.ShutdownServer(ctx, client); err != nil {
+			t.Errorf("Shutdown failed: %v", err)
+		}
+	})
+}
+
+// shutdownServer implements only Shutdown and Exit.
+type shutdownServer struct {
+	lsp.Server
+}
+
+func (shutdownServer) Shutdown(context.Context) error { return nil }
+func (shutdownServer) Exit(context.Context) error     { return nil }
+
+// createClient implements only WorkDoneProgressCreate.
+type createClient struct {
+	lsp.Client
+}
+
+func (createClient) WorkDoneProgressCreate(context.Context, *lsp.WorkDoneProgressCreateParams) error {
+	return nil
+}
+
+func TestNullResult(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		name    string
+		handler jsonrpc2.Handler
+		method  string
+	}{
+		{"ServerHandler", lsp.ServerHandler(shutdownServer{}), "shutdown"},
+		{"ClientHandler", lsp.ClientHandler(createClient{}), "window/workDoneProgress/create"},
+		{"ServerBuilder", lsp.NewServer("test", "1").Handler(), "shutdown"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// A call without a result is answered with null, which
+			// jsonrpc2 sends, rather than nil, which it reports as an
+			// internal error.
+			call, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), test.method, json.RawMessage(`{"token":1}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := test.handler.Handle(ctx, call)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if raw, ok := resp.(json.RawMessage); !ok || string(raw) != "null" {
+				t.Errorf("Expected the result null, got %#v", resp)
+			}
+		})
+	}
+
+	// Notifications have no response at all.
+	exit, err := jsonrpc2.NewNotification("exit", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := lsp.ServerHandler(shutdownServer{}).Handle(ctx, exit); resp != nil || err != nil {
+		t.Errorf("Expected no result for a notification, got %v, %v", resp, err)
+	}
+}
+
+func BenchmarkServerHandlerDispatch(b *testing.B) {
+	ctx := context.Background()
+	handler := lsp.ServerHandler(hoverServer{})
+	req := newHoverCall(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := handler(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A Cache holds values that a server computes from the content of
+// documents, such as their symbols, semantic tokens or folding ranges,
+// for the version of each document they were computed from. The values
+// of a document are keyed by K, which is struct{} for a single value
+// per document:
+//
+//	var symbols lsp.Cache[struct{}, []lsp.DocumentSymbol]
+//	...
+//	return symbols.GetOrCompute(uri, version, struct{}{}, func() ([]lsp.DocumentSymbol, error) {
+//		return computeSymbols(doc)
+//	})
+//
+// The values of a document are dropped when a newer version of it is
+// used, and when it changes or closes, if the cache observes the text
+// synchronization notifications of the connection, as its Handler does.
+// Values that depend on state other than the content of their document,
+// such as the files on disk, must be invalidated by the server.
+//
+// The zero value is an empty cache ready to use. A Cache is safe for
+// concurrent use.
+type Cache[K comparable, V any] struct {
+	mu   sync.Mutex
+	docs map[DocumentURI]*cachedDocument[K, V]
+}
+
+type cachedDocument[K comparable, V any] struct {
+	version int32
+	values  map[K]V
+}
+
+// Get returns the value of the key for the given version of a document,
+// and whether it is cached.
+func (c *Cache[K, V]) Get(uri DocumentURI, version int32, key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if doc, ok := c.docs[uri]; ok && doc.version == version {
+		v, ok := doc.values[key]
+		return v, ok
+	}
+	var zero V
+	return zero, false
+}
+
+// Put caches the value of the key for the given version of a document,
+// dropping the values of the previous versions. It ignores the values of
+// versions older than those cached, such as values whose computation
+// raced with a change of the document.
+func (c *Cache[K, V]) Put(uri DocumentURI, version int32, key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	doc, ok := c.docs[uri]
+	switch {
+	case ok && version < doc.version:
+		return
+	case !ok || version > doc.version:
+		if c.docs == nil {
+			c.docs = make(map[DocumentURI]*cachedDocument[K, V])
+		}
+		doc = &cachedDocument[K, V]{version: version, values: make(map[K]V)}
+		c.docs[uri] = doc
+	}
+	doc.values[key] = value
+}
+
+// GetOrCompute returns the value of the key for the given version of a
+// document, calling compute and caching its result if it is not cached.
+// Errors are not cached. Concurrent calls for the same value may each
+// compute it.
+func (c *Cache[K, V]) GetOrCompute(uri DocumentURI, version int32, key K, compute func() (V, error)) (V, error) {
+	if v, ok := c.Get(uri, version, key); ok {
+		return v, nil
+	}
+	v, err := compute()
+	if err != nil {
+		return v, err
+	}
+	c.Put(uri, version, key, v)
+	return v, nil
+}
+
+// Invalidate drops the values of the document uri, or all values if uri
+// is empty.
+func (c *Cache[K, V]) Invalidate(uri DocumentURI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if uri == "" {
+		clear(c.docs)
+	} else {
+		delete(c.docs, uri)
+	}
+}
+
+// Handler returns a handler that invokes handler, and drops the values
+// of the documents that change or close, as their notifications arrive,
+// before handler sees them. The values of the previous versions of a
+// changed document that are computed afterwards are not cached.
+func (c *Cache[K, V]) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case "textDocument/didChange":
+			var params versionedParams
+			if UnmarshalJSON(req.Params, &params) == nil && params.TextDocument.Version != nil {
+				c.setVersion(params.TextDocument.URI, *params.TextDocument.Version)
+			}
+		case "textDocument/didClose":
+			var params versionedParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				c.Invalidate(params.TextDocument.URI)
+			}
+		}
+		return handler.Handle(ctx, req)
+	})
+}
+
+// setVersion drops the values of a document older than version.
+func (c *Cache[K, V]) setVersion(uri DocumentURI, version int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if doc, ok := c.docs[uri]; !ok || doc.version < version {
+		if c.docs == nil {
+			c.docs = make(map[DocumentURI]*cachedDocument[K, V])
+		}
+		c.docs[uri] = &cachedDocument[K, V]{version: version, values: make(map[K]V)}
+	}
+}
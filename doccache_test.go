@@ -0,0 +1,86 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	const uri = "file:///a.go"
+	var cache lsp.Cache[string, int]
+	computed := 0
+	get := func(version int32, key string) int {
+		t.Helper()
+		v, err := cache.GetOrCompute(uri, version, key, func() (int, error) {
+			computed++
+			return computed, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	if get(1, "symbols") != 1 || get(1, "symbols") != 1 || get(1, "tokens") != 2 {
+		t.Errorf("Expected the values of version 1 to be cached, computed %d times", computed)
+	}
+	if get(2, "symbols") != 3 {
+		t.Errorf("Expected the value of version 2 to be computed")
+	}
+	cache.Put(uri, 1, "symbols", 100)
+	if _, ok := cache.Get(uri, 1, "symbols"); ok {
+		t.Errorf("Expected the value of an older version not to be cached")
+	}
+	if _, ok := cache.Get(uri, 2, "tokens"); ok {
+		t.Errorf("Expected the values of version 1 to be dropped")
+	}
+
+	wantErr := errors.New("failed")
+	if _, err := cache.GetOrCompute(uri, 2, "folding", func() (int, error) { return 0, wantErr }); err != wantErr {
+		t.Errorf("Expected error %v, got %v", wantErr, err)
+	}
+	if _, ok := cache.Get(uri, 2, "folding"); ok {
+		t.Errorf("Expected errors not to be cached")
+	}
+
+	handler := cache.Handler(jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) {
+		return json.RawMessage("null"), nil
+	}))
+	notify := func(method string, params any) {
+		t.Helper()
+		req, err := jsonrpc2.NewNotification(method, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler.Handle(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notify("textDocument/didChange", &lsp.DidChangeTextDocumentParams{
+		TextDocument: lsp.VersionedTextDocumentIdentifier{TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: uri}, Version: 3},
+	})
+	if _, ok := cache.Get(uri, 2, "symbols"); ok {
+		t.Errorf("Expected the values to be dropped on change")
+	}
+	cache.Put(uri, 2, "symbols", 100)
+	if _, ok := cache.Get(uri, 2, "symbols"); ok {
+		t.Errorf("Expected the value of a version older than the change not to be cached")
+	}
+	if get(3, "symbols") != 4 {
+		t.Errorf("Expected the value of version 3 to be computed")
+	}
+	notify("textDocument/didClose", &lsp.DidCloseTextDocumentParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	if _, ok := cache.Get(uri, 3, "symbols"); ok {
+		t.Errorf("Expected the values to be dropped on close")
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// notebookCellScheme is the scheme of the URIs of notebook cells.
+const notebookCellScheme = "vscode-notebook-cell"
+
+// MatchDocumentSelector reports whether selector selects the document
+// with the given URI and language, that is, whether one of its filters
+// matches the document. A nil selector selects nothing; in registration
+// options, a null document selector stands for the selector of the
+// client, which the caller must substitute.
+func MatchDocumentSelector(selector DocumentSelector, uri DocumentURI, language LanguageKind) bool {
+	for _, f := range selector {
+		if MatchDocumentFilter(f, uri, language) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchDocumentFilter reports whether the filter f matches the document
+// with the given URI and language. Each of the language, scheme and
+// pattern of a text document filter must match if it is set, where the
+// language "*" matches every language.
+//
+// As the notebook of a cell is not known from its URI, a notebook cell
+// filter matches by language the documents whose URI has the scheme of
+// notebook cells.
+func MatchDocumentFilter(f DocumentFilter, uri DocumentURI, language LanguageKind) bool {
+	switch {
+	case f.TextDocumentFilter != nil:
+		var (
+			filterLanguage, scheme string
+			pattern                *GlobPattern
+		)
+		switch tf := f.TextDocumentFilter; {
+		case tf.TextDocumentFilterLanguage != nil:
+			filterLanguage, scheme, pattern = tf.TextDocumentFilterLanguage.Language, tf.TextDocumentFilterLanguage.Scheme, tf.TextDocumentFilterLanguage.Pattern
+		case tf.TextDocumentFilterPattern != nil:
+			filterLanguage, scheme, pattern = tf.TextDocumentFilterPattern.Language, tf.TextDocumentFilterPattern.Scheme, &tf.TextDocumentFilterPattern.Pattern
+		case tf.TextDocumentFilterScheme != nil:
+			filterLanguage, scheme, pattern = tf.TextDocumentFilterScheme.Language, tf.TextDocumentFilterScheme.Scheme, tf.TextDocumentFilterScheme.Pattern
+		default:
+			return false
+		}
+		if !matchLanguage(filterLanguage, language) {
+			return false
+		}
+		if scheme != "" && scheme != uriScheme(uri) {
+			return false
+		}
+		return pattern == nil || MatchGlobPattern(*pattern, uri)
+	case f.NotebookCellTextDocumentFilter != nil:
+		return uriScheme(uri) == notebookCellScheme && matchLanguage(f.NotebookCellTextDocumentFilter.Language, language)
+	}
+	return false
+}
+
+func matchLanguage(filter string, language LanguageKind) bool {
+	return filter == "" || filter == "*" || LanguageKind(filter) == language
+}
+
+// MatchGlobPattern reports whether the glob pattern p matches the path
+// of uri. A plain pattern matches the whole path, so that it usually
+// starts with "**/"; a relative pattern matches the path relative to its
+// base URI, which must enclose uri.
+//
+// Patterns are those of the specification: "/" separates segments, "*"
+// matches any sequence of characters within a segment, "?" matches one
+// character, "**" matches any sequence of segments, including none,
+// "{a,b}" matches either alternative, and "[a-z]" and "[!a-z]" match a
+// character in or out of a range. An empty or malformed pattern matches
+// nothing.
+func MatchGlobPattern(p GlobPattern, uri DocumentURI) bool {
+	name := uriPath(uri)
+	switch {
+	case p.Pattern != nil:
+		return MatchGlob(*p.Pattern, name)
+	case p.RelativePattern != nil:
+		base := p.RelativePattern.BaseURI
+		if uriScheme(base) != uriScheme(uri) {
+			return false
+		}
+		dir := strings.TrimSuffix(uriPath(base), "/")
+		rel, ok := strings.CutPrefix(name, dir+"/")
+		return ok && MatchGlob(p.RelativePattern.Pattern, rel)
+	}
+	return false
+}
+
+// MatchGlob reports whether the glob pattern matches the slash-separated
+// name, as described by [MatchGlobPattern]. Leading slashes of the
+// pattern and name are ignored.
+func MatchGlob(pattern, name string) bool {
+	if pattern == "" {
+		return false
+	}
+	segments := strings.Split(strings.TrimLeft(name, "/"), "/")
+	for _, alt := range expandBraces(strings.TrimLeft(pattern, "/")) {
+		alt = strings.ReplaceAll(alt, "[!", "[^")
+		if matchSegments(strings.Split(alt, "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether the segments of a pattern match the
+// segments of a name.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := range len(name) + 1 {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// expandBraces returns the alternatives of a pattern with groups such as
+// "{a,b}", which may be nested.
+func expandBraces(pattern string) []string {
+	start, depth := -1, 0
+	var commas []int
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '[':
+			// Braces within a character class are literal.
+			if i+2 < len(pattern) {
+				if end := strings.IndexByte(pattern[i+2:], ']'); end >= 0 {
+					i += end + 2
+				}
+			}
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case ',':
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			if depth--; depth > 0 {
+				continue
+			}
+			prefix, suffix := pattern[:start], pattern[i+1:]
+			var alts []string
+			from := start + 1
+			for _, comma := range append(commas, i) {
+				for _, alt := range expandBraces(pattern[from:comma]) {
+					alts = append(alts, expandBraces(prefix+alt+suffix)...)
+				}
+				from = comma + 1
+			}
+			return alts
+		}
+	}
+	return []string{pattern}
+}
+
+// uriScheme returns the scheme of uri.
+func uriScheme(uri DocumentURI) string {
+	scheme, _, _ := strings.Cut(string(uri), ":")
+	return strings.ToLower(scheme)
+}
+
+// uriPath returns the unescaped path of uri, or its opaque part, such as
+// "Untitled-1" in "untitled:Untitled-1".
+func uriPath(uri DocumentURI) string {
+	u, err := url.Parse(string(uri))
+	if err != nil {
+		return ""
+	}
+	if u.Opaque != "" {
+		return u.Opaque
+	}
+	return u.Path
+}
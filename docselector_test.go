@@ -0,0 +1,81 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestMatchGlob(t *testing.T) {
+	for _, test := range []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"**/*.go", "/home/user/a.go", true},
+		{"**/*.go", "a.go", true},
+		{"**/*.go", "/home/user/a.go.txt", false},
+		{"*.go", "/home/user/a.go", false},
+		{"/home/*/a.go", "/home/user/a.go", true},
+		{"home/**", "/home/user/a.go", true},
+		{"home/**/a.go", "/home/a.go", true},
+		{"**/*.{ts,js}", "/src/a.js", true},
+		{"**/*.{ts,js}", "/src/a.tsx", false},
+		{"**/{src,test/{unit,e2e}}/*.ts", "/p/test/e2e/a.ts", true},
+		{"**/{src,test/{unit,e2e}}/*.ts", "/p/test/a.ts", false},
+		{"**/a?.go", "/a1.go", true},
+		{"**/a[0-9].go", "/a1.go", true},
+		{"**/a[!0-9].go", "/a1.go", false},
+		{"**/a[!0-9].go", "/ab.go", true},
+		{"**/a[.go", "/a[.go", false}, // malformed
+		{"/w/[{]a,b[}]/*.go", "/w/{a,b}/c.go", true},
+		{"", "/a.go", false},
+	} {
+		if got := lsp.MatchGlob(test.pattern, test.name); got != test.want {
+			t.Errorf("MatchGlob(%q, %q): expected %v, got %v", test.pattern, test.name, test.want, got)
+		}
+	}
+}
+
+func TestMatchDocumentSelector(t *testing.T) {
+	// Filters are decoded from JSON, as received from a peer.
+	var selector lsp.DocumentSelector
+	err := json.Unmarshal([]byte(`[
+		{"language": "go", "scheme": "file"},
+		{"pattern": "**/*.mod"},
+		{"scheme": "untitled", "language": "markdown"},
+		{"pattern": {"baseUri": "file:///ws", "pattern": "docs/**/*.txt"}},
+		{"notebook": "jupyter-notebook", "language": "python"}
+	]`), &selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		uri      lsp.DocumentURI
+		language lsp.LanguageKind
+		want     bool
+	}{
+		{"file:///ws/a.go", lsp.LangGo, true},
+		{"untitled:Untitled-1", lsp.LangGo, false},
+		{"file:///ws/go.mod", "", true},
+		{"file:///ws/go.sum", "", false},
+		{"untitled:Untitled-1", lsp.LangMarkdown, true},
+		{"file:///ws/a.md", lsp.LangMarkdown, false},
+		{"file:///ws/docs/a/b.txt", lsp.LangPlaintext, true},
+		{"file:///ws/a.txt", lsp.LangPlaintext, false},
+		{"file:///other/docs/a.txt", lsp.LangPlaintext, false},
+		{"vscode-notebook-cell:/ws/a.ipynb#1", lsp.LangPython, true},
+		{"file:///ws/a.py", lsp.LangPython, false},
+	} {
+		if got := lsp.MatchDocumentSelector(selector, test.uri, test.language); got != test.want {
+			t.Errorf("MatchDocumentSelector(%s, %q): expected %v, got %v", test.uri, test.language, test.want, got)
+		}
+	}
+	if lsp.MatchDocumentSelector(nil, "file:///a.go", lsp.LangGo) {
+		t.Error("Expected a nil selector to select nothing")
+	}
+}
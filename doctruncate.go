@@ -0,0 +1,164 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultDocumentationLimit is the default maximum length, in bytes, of
+// the hovers and documentation truncated by a [DocumentationLimit].
+const DefaultDocumentationLimit = 64 << 10
+
+// A DocumentationLimit truncates hovers and the documentation of
+// completion items that are too large for clients to render without
+// freezing, such as generated API references, and appends to truncated
+// Markdown a link to a command that shows the full documentation:
+//
+//	limit := lsp.DocumentationLimit{Command: "mylang.showDocumentation"}
+//	limit.TruncateHover(hover, params.TextDocument.URI, params.Position)
+//
+// The command is executed by the server, with the arguments given to
+// the truncation, which identify the documentation; it may, for
+// example, open it with window/showDocument. Clients only follow the
+// command links of content they trust, and of commands the server
+// declares in its capabilities.
+type DocumentationLimit struct {
+	// MaxBytes is the maximum length of content, including the ellipsis
+	// and link appended to it, or DefaultDocumentationLimit if zero.
+	MaxBytes int
+	// Command is the command of the link to the full documentation, or
+	// "" for no link.
+	Command string
+	// LinkText is the text of the link, or "View full documentation" if
+	// empty.
+	LinkText string
+}
+
+// TruncateHover truncates the contents of a hover, as TruncateContent
+// does.
+func (l DocumentationLimit) TruncateHover(h *Hover, args ...any) bool {
+	return h != nil && l.TruncateContent(&h.Contents, args...)
+}
+
+// TruncateCompletionItem truncates the documentation of a completion
+// item, as TruncateContent does.
+func (l DocumentationLimit) TruncateCompletionItem(item *CompletionItem, args ...any) bool {
+	switch doc := item.Documentation; {
+	case doc == nil:
+		return false
+	case doc.MarkupContent != nil:
+		return l.TruncateContent(doc.MarkupContent, args...)
+	case doc.String != nil:
+		c := MarkupContent{Kind: PlainText, Value: *doc.String}
+		if !l.TruncateContent(&c) {
+			return false
+		}
+		doc.String = &c.Value
+		return true
+	}
+	return false
+}
+
+// TruncateContent truncates content longer than the limit, preferably at
+// the end of a line, or else of a word, and appends an ellipsis. To
+// Markdown, it also appends a link to the command, invoked with args;
+// a code block that the truncation leaves open is closed first. It
+// reports whether the content was truncated.
+func (l DocumentationLimit) TruncateContent(c *MarkupContent, args ...any) bool {
+	limit := l.MaxBytes
+	if limit == 0 {
+		limit = DefaultDocumentationLimit
+	}
+	if len(c.Value) <= limit {
+		return false
+	}
+	suffix := "…"
+	if c.Kind == Markdown {
+		suffix = "\n\n…"
+		if link := l.link(args); link != "" {
+			suffix += " " + link
+		}
+	}
+	budget := limit - len(suffix)
+	if c.Kind == Markdown {
+		budget -= len("\n```\n") // in case a code block must be closed
+	}
+	text := truncateText(c.Value, budget)
+	if c.Kind == Markdown {
+		if fence := openFence(text); fence != "" {
+			if !strings.HasSuffix(text, "\n") {
+				text += "\n"
+			}
+			text += fence
+		}
+	}
+	c.Value = text + suffix
+	return true
+}
+
+// link returns the Markdown link to the command of l with args, or "" if
+// there is none.
+func (l DocumentationLimit) link(args []any) string {
+	if l.Command == "" {
+		return ""
+	}
+	target := "command:" + l.Command
+	if len(args) > 0 {
+		data, err := json.Marshal(args)
+		if err != nil {
+			return ""
+		}
+		target += "?" + url.PathEscape(string(data))
+	}
+	text := l.LinkText
+	if text == "" {
+		text = "View full documentation"
+	}
+	return "[" + text + "](" + target + ")"
+}
+
+// truncateText returns the longest prefix of text of at most n bytes
+// that ends with a line, if that keeps at least half of them, or else a
+// word, or else a character.
+func truncateText(text string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if len(text) <= n {
+		return text
+	}
+	for n > 0 && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	text = text[:n]
+	if i := strings.LastIndexByte(text, '\n'); i >= n/2 {
+		return text[:i+1]
+	}
+	if i := strings.LastIndexAny(text, " \t"); i >= n/2 {
+		return text[:i]
+	}
+	return text
+}
+
+// openFence returns the fence that closes the code block of Markdown
+// that is left open at its end, if any.
+func openFence(markdown string) string {
+	var fence string
+	for line := range strings.Lines(markdown) {
+		f := fenceRE.FindStringSubmatch(line)
+		switch {
+		case f == nil:
+		case fence == "":
+			fence = f[1]
+		case f[1][0] == fence[0] && len(f[1]) >= len(fence) && strings.TrimSpace(line[len(f[0]):]) == "":
+			fence = ""
+		}
+	}
+	return fence
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"strings"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestDocumentationLimit(t *testing.T) {
+	limit := lsp.DocumentationLimit{MaxBytes: 200, Command: "test.showDoc"}
+
+	t.Run("Short", func(t *testing.T) {
+		h := &lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.Markdown, Value: "short"}}
+		if limit.TruncateHover(h) || h.Contents.Value != "short" {
+			t.Errorf("Expected short content to be left alone, got %q", h.Contents.Value)
+		}
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		h := &lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.Markdown, Value: "func F()\n\n```go\n" + strings.Repeat("x := 1\n", 50) + "```\n"}}
+		if !limit.TruncateHover(h, "file:///a.go") {
+			t.Fatalf("Expected the hover to be truncated")
+		}
+		got := h.Contents.Value
+		if len(got) > 200 {
+			t.Errorf("Expected at most 200 bytes, got %d: %q", len(got), got)
+		}
+		want := "x := 1\n```\n\n… [View full documentation](command:test.showDoc?%5B%22file:%2F%2F%2Fa.go%22%5D)"
+		if !strings.HasSuffix(got, want) {
+			t.Errorf("Expected the code block closed and a link, got %q", got)
+		}
+	})
+
+	t.Run("PlainText", func(t *testing.T) {
+		doc := strings.Repeat("word ", 50)
+		item := &lsp.CompletionItem{Documentation: &lsp.CompletionItemDocumentation{String: &doc}}
+		if !limit.TruncateCompletionItem(item) {
+			t.Fatalf("Expected the documentation to be truncated")
+		}
+		got := *item.Documentation.String
+		if len(got) > 200 || !strings.HasSuffix(got, "word…") {
+			t.Errorf("Expected text truncated at a word, without a link, got %q", got)
+		}
+	})
+
+	t.Run("UTF8", func(t *testing.T) {
+		c := lsp.MarkupContent{Kind: lsp.PlainText, Value: strings.Repeat("é", 100)}
+		(lsp.DocumentationLimit{MaxBytes: 10}).TruncateContent(&c)
+		if c.Value != "ééé…" {
+			t.Errorf("Expected truncation at a character, got %q", c.Value)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON of DocumentFilter tries NotebookCellTextDocumentFilter
+// before TextDocumentFilter, and UnmarshalJSON of TextDocumentFilter
+// tries TextDocumentFilterLanguage first. As their fields are optional
+// to Go, unmarshaling as these types never fails, and every filter would
+// lose its scheme and pattern. This file has custom JSON unmarshalers
+// for them, that fail if their required field is missing.
+
+// UnmarshalJSON unmarshals NotebookCellTextDocumentFilter with an extra
+// check on the presence of the "notebook" property.
+func (f *NotebookCellTextDocumentFilter) UnmarshalJSON(data []byte) error {
+	type filter NotebookCellTextDocumentFilter // without methods
+	var required struct {
+		Notebook json.RawMessage `json:"notebook"`
+	}
+	if err := json.Unmarshal(data, &required); err != nil {
+		return err
+	}
+	if required.Notebook == nil {
+		return fmt.Errorf("not NotebookCellTextDocumentFilter")
+	}
+	return json.Unmarshal(data, (*filter)(f))
+}
+
+// UnmarshalJSON unmarshals TextDocumentFilterLanguage with an extra
+// check on the presence of the "language" property.
+func (f *TextDocumentFilterLanguage) UnmarshalJSON(data []byte) error {
+	type filter TextDocumentFilterLanguage // without methods
+	var required struct {
+		Language *string `json:"language"`
+	}
+	if err := json.Unmarshal(data, &required); err != nil {
+		return err
+	}
+	if required.Language == nil {
+		return fmt.Errorf("not TextDocumentFilterLanguage")
+	}
+	return json.Unmarshal(data, (*filter)(f))
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Downlevel returns a Server that invokes server and reshapes its results
+// for clients that do not support recent additions to the protocol, so
+// that handlers may always use them without checking the capabilities of
+// the client:
+//
+//   - completionList.itemDefaults (3.17) are applied to each item, for
+//     the defaults the client does not declare in
+//     completion.completionList.itemDefaults; likewise for
+//     completionList.applyKind (3.18) and completionItem.textEditText;
+//   - completionItem.labelDetails (3.17) are moved into the detail of the
+//     item if it has none;
+//   - the relatedDocuments of a document diagnostic report (3.17) are
+//     dropped.
+//
+// The capabilities of the client are those of the initialize request
+// handled by the returned Server.
+func Downlevel(server Server) Server {
+	return &downlevelServer{Server: server}
+}
+
+type downlevelServer struct {
+	Server
+	caps atomic.Pointer[ClientCapabilities]
+}
+
+func (s *downlevelServer) Initialize(ctx context.Context, params *ParamInitialize) (*InitializeResult, error) {
+	s.caps.Store(&params.Capabilities)
+	return s.Server.Initialize(ctx, params)
+}
+
+func (s *downlevelServer) Completion(ctx context.Context, params *CompletionParams) (*CompletionList, error) {
+	list, err := s.Server.Completion(ctx, params)
+	if caps := s.caps.Load(); list != nil && caps != nil {
+		downlevelCompletionList(list, &caps.TextDocument.Completion)
+	}
+	return list, err
+}
+
+func (s *downlevelServer) ResolveCompletionItem(ctx context.Context, params *CompletionItem) (*CompletionItem, error) {
+	item, err := s.Server.ResolveCompletionItem(ctx, params)
+	if caps := s.caps.Load(); item != nil && caps != nil {
+		downlevelCompletionItem(item, &caps.TextDocument.Completion)
+	}
+	return item, err
+}
+
+func (s *downlevelServer) Diagnostic(ctx context.Context, params *DocumentDiagnosticParams) (*DocumentDiagnosticReport, error) {
+	report, err := s.Server.Diagnostic(ctx, params)
+	if caps := s.caps.Load(); report != nil && caps != nil {
+		if dc := caps.TextDocument.Diagnostic; dc == nil || !dc.RelatedDocumentSupport {
+			if r := report.RelatedFullDocumentDiagnosticReport; r != nil {
+				r.RelatedDocuments = nil
+			}
+			if r := report.RelatedUnchangedDocumentDiagnosticReport; r != nil {
+				r.RelatedDocuments = nil
+			}
+		}
+	}
+	return report, err
+}
+
+// downlevelCompletionList applies to the items of list the defaults that
+// the client cannot apply itself, according to caps.
+func downlevelCompletionList(list *CompletionList, caps *CompletionClientCapabilities) {
+	var supported []string
+	applyKindSupport := false
+	if caps.CompletionList != nil {
+		supported = caps.CompletionList.ItemDefaults
+		applyKindSupport = caps.CompletionList.ApplyKindSupport
+	}
+	merge := func(kind func(*CompletionItemApplyKinds) *ApplyKind) bool {
+		if list.ApplyKind == nil {
+			return false
+		}
+		k := kind(list.ApplyKind)
+		return k != nil && *k == Merge
+	}
+	mergeCommitChars := merge(func(k *CompletionItemApplyKinds) *ApplyKind { return k.CommitCharacters })
+	mergeItemData := merge(func(k *CompletionItemApplyKinds) *ApplyKind { return k.Data })
+
+	// Defaults whose semantics the client would get wrong are applied
+	// here and removed from the list.
+	local := func(name string, mergeKind bool) bool {
+		return !slices.Contains(supported, name) || mergeKind && !applyKindSupport
+	}
+	if d := list.ItemDefaults; d != nil {
+		if d.CommitCharacters != nil && local("commitCharacters", mergeCommitChars) {
+			for i := range list.Items {
+				item := &list.Items[i]
+				if mergeCommitChars {
+					item.CommitCharacters = mergeStrings(d.CommitCharacters, item.CommitCharacters)
+				} else if item.CommitCharacters == nil {
+					item.CommitCharacters = d.CommitCharacters
+				}
+			}
+			d.CommitCharacters = nil
+		}
+		if d.EditRange != nil && local("editRange", false) {
+			for i := range list.Items {
+				applyEditRange(&list.Items[i], d.EditRange, caps.CompletionItem.InsertReplaceSupport)
+			}
+			d.EditRange = nil
+		}
+		if d.InsertTextFormat != nil && local("insertTextFormat", false) {
+			for i := range list.Items {
+				if list.Items[i].InsertTextFormat == nil {
+					list.Items[i].InsertTextFormat = d.InsertTextFormat
+				}
+			}
+			d.InsertTextFormat = nil
+		}
+		if d.InsertTextMode != nil && local("insertTextMode", false) {
+			for i := range list.Items {
+				if list.Items[i].InsertTextMode == nil {
+					list.Items[i].InsertTextMode = d.InsertTextMode
+				}
+			}
+			d.InsertTextMode = nil
+		}
+		if d.Data != nil && local("data", mergeItemData) {
+			for i := range list.Items {
+				item := &list.Items[i]
+				if mergeItemData {
+					item.Data = mergeData(d.Data, item.Data)
+				} else if item.Data == nil {
+					item.Data = d.Data
+				}
+			}
+			d.Data = nil
+		}
+		if d.CommitCharacters == nil && d.EditRange == nil && d.InsertTextFormat == nil && d.InsertTextMode == nil && d.Data == nil {
+			list.ItemDefaults = nil
+		}
+	}
+	if !applyKindSupport {
+		list.ApplyKind = nil
+	}
+	for i := range list.Items {
+		downlevelCompletionItem(&list.Items[i], caps)
+	}
+}
+
+// downlevelCompletionItem removes from item the properties the client
+// does not support, according to caps.
+func downlevelCompletionItem(item *CompletionItem, caps *CompletionClientCapabilities) {
+	if item.LabelDetails != nil && !caps.CompletionItem.LabelDetailsSupport {
+		if item.Detail == "" {
+			item.Detail = strings.TrimSpace(item.LabelDetails.Detail + " " + item.LabelDetails.Description)
+		}
+		item.LabelDetails = nil
+	}
+}
+
+// applyEditRange sets the text edit of item, if it has none, from the
+// default edit range of its list.
+func applyEditRange(item *CompletionItem, rng *CompletionItemDefaultsEditRange, insertReplaceSupport bool) {
+	if item.TextEdit != nil {
+		return
+	}
+	text := item.TextEditText
+	if text == "" {
+		text = item.Label
+	}
+	item.TextEditText = ""
+	switch {
+	case rng.Range != nil:
+		item.TextEdit = &CompletionItemTextEdit{TextEdit: &TextEdit{Range: *rng.Range, NewText: text}}
+	case rng.EditRangeWithInsertReplace != nil && insertReplaceSupport:
+		r := rng.EditRangeWithInsertReplace
+		item.TextEdit = &CompletionItemTextEdit{InsertReplaceEdit: &InsertReplaceEdit{NewText: text, Insert: r.Insert, Replace: r.Replace}}
+	case rng.EditRangeWithInsertReplace != nil:
+		item.TextEdit = &CompletionItemTextEdit{TextEdit: &TextEdit{Range: rng.EditRangeWithInsertReplace.Insert, NewText: text}}
+	}
+}
+
+// mergeStrings returns the union of defaults and values, in order.
+func mergeStrings(defaults, values []string) []string {
+	merged := slices.Clone(defaults)
+	for _, v := range values {
+		if !slices.Contains(merged, v) {
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
+
+// mergeData returns the shallow merge of the data of a completion item
+// into the default data of its list, if both are objects;
+// otherwise it returns the item's data if present.
+func mergeData(defaults, data any) any {
+	d, ok1 := defaults.(map[string]any)
+	m, ok2 := data.(map[string]any)
+	if !ok1 || !ok2 {
+		if data != nil {
+			return data
+		}
+		return defaults
+	}
+	merged := make(map[string]any, len(d)+len(m))
+	maps.Copy(merged, d)
+	maps.Copy(merged, m)
+	return merged
+}
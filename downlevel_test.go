@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// modernServer returns results using 3.17 features regardless of the client.
+type modernServer struct {
+	lsp.Server
+}
+
+func (modernServer) Initialize(context.Context, *lsp.ParamInitialize) (*lsp.InitializeResult, error) {
+	return &lsp.InitializeResult{}, nil
+}
+
+var editRange = lsp.Range{Start: lsp.Position{Line: 1, Character: 0}, End: lsp.Position{Line: 1, Character: 3}}
+
+func (modernServer) Completion(context.Context, *lsp.CompletionParams) (*lsp.CompletionList, error) {
+	snippet := lsp.SnippetTextFormat
+	return &lsp.CompletionList{
+		ItemDefaults: &lsp.CompletionItemDefaults{
+			CommitCharacters: []string{"."},
+			EditRange:        &lsp.CompletionItemDefaultsEditRange{Range: &editRange},
+			InsertTextFormat: &snippet,
+		},
+		Items: []lsp.CompletionItem{
+			{Label: "foo", LabelDetails: &lsp.CompletionItemLabelDetails{Detail: "(x int)", Description: "pkg"}},
+			{Label: "bar", TextEditText: "bar()", CommitCharacters: []string{"("}},
+		},
+	}, nil
+}
+
+func (modernServer) Diagnostic(context.Context, *lsp.DocumentDiagnosticParams) (*lsp.DocumentDiagnosticReport, error) {
+	return &lsp.DocumentDiagnosticReport{
+		RelatedFullDocumentDiagnosticReport: &lsp.RelatedFullDocumentDiagnosticReport{
+			RelatedDocuments: map[lsp.DocumentURI]any{"file:///b.go": nil},
+		},
+	}, nil
+}
+
+func TestDownlevel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OldClient", func(t *testing.T) {
+		server := lsp.Downlevel(modernServer{})
+		if _, err := server.Initialize(ctx, &lsp.ParamInitialize{}); err != nil {
+			t.Fatal(err)
+		}
+		list, err := server.Completion(ctx, &lsp.CompletionParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		snippet := lsp.SnippetTextFormat
+		want := &lsp.CompletionList{
+			Items: []lsp.CompletionItem{{
+				Label:            "foo",
+				Detail:           "(x int) pkg",
+				InsertTextFormat: &snippet,
+				TextEdit:         &lsp.CompletionItemTextEdit{TextEdit: &lsp.TextEdit{Range: editRange, NewText: "foo"}},
+				CommitCharacters: []string{"."},
+			}, {
+				Label:            "bar",
+				InsertTextFormat: &snippet,
+				TextEdit:         &lsp.CompletionItemTextEdit{TextEdit: &lsp.TextEdit{Range: editRange, NewText: "bar()"}},
+				CommitCharacters: []string{"("},
+			}},
+		}
+		if diff := cmp.Diff(want, list); diff != "" {
+			t.Errorf("Completion mismatch (-want +got):\n%s", diff)
+		}
+
+		report, err := server.Diagnostic(ctx, &lsp.DocumentDiagnosticParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if docs := report.RelatedFullDocumentDiagnosticReport.RelatedDocuments; docs != nil {
+			t.Errorf("Expected related documents to be dropped, got %v", docs)
+		}
+	})
+
+	t.Run("PartialSupport", func(t *testing.T) {
+		server := lsp.Downlevel(modernServer{})
+		params := &lsp.ParamInitialize{}
+		params.Capabilities.TextDocument.Completion = lsp.CompletionClientCapabilities{
+			CompletionItem: lsp.ClientCompletionItemOptions{LabelDetailsSupport: true},
+			CompletionList: &lsp.CompletionListCapabilities{ItemDefaults: []string{"editRange", "insertTextFormat"}},
+		}
+		if _, err := server.Initialize(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+		list, err := server.Completion(ctx, &lsp.CompletionParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := list.ItemDefaults; d == nil || d.EditRange == nil || d.InsertTextFormat == nil || d.CommitCharacters != nil {
+			t.Errorf("Expected only the supported defaults to remain, got %+v", d)
+		}
+		if list.Items[0].LabelDetails == nil || list.Items[0].TextEdit != nil {
+			t.Errorf("Expected the first item to be unchanged but for its commit characters, got %+v", list.Items[0])
+		}
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"slices"
+)
+
+// An EditPolicy tells what [ApplyEdits] does with an edit whose range is
+// out of the bounds of the content, or overlaps another edit.
+type EditPolicy int
+
+const (
+	// EditStrict rejects all edits if one is invalid.
+	EditStrict EditPolicy = iota
+	// EditClamp clamps the ranges of edits to the bounds of the
+	// content, as [Mapper.ClampedRangeOffsets] does, but still rejects
+	// all edits if two overlap.
+	EditClamp
+	// EditSkip applies the valid edits only, and reports the others.
+	// Of two overlapping edits, the one that starts first is applied.
+	EditSkip
+)
+
+// A SkippedEdit is an edit that ApplyEdits skipped, with the policy
+// EditSkip.
+type SkippedEdit struct {
+	Index int // of the edit in the list
+	Edit  TextEdit
+	Err   error // why it was skipped
+}
+
+// ApplyEdits returns the content of m after applying the edits, whose
+// ranges refer to the content of m, and must not overlap, except for
+// insertions at the same position, which are applied in order. The
+// policy tells what happens to invalid edits; with EditSkip, ApplyEdits
+// returns those it skipped, in order.
+//
+// Servers that proxy edits from external tools, which may compute them
+// against a slightly different content, may prefer to apply what they
+// can, rather than reject the whole batch.
+func ApplyEdits(m *Mapper, edits []TextEdit, policy EditPolicy) ([]byte, []SkippedEdit, error) {
+	type offsetEdit struct {
+		index      int
+		start, end int
+	}
+	var skipped []SkippedEdit
+	offsetEdits := make([]offsetEdit, 0, len(edits))
+	for i, e := range edits {
+		var start, end int
+		if policy == EditClamp {
+			start, end = m.ClampedRangeOffsets(e.Range)
+		} else {
+			var err error
+			if start, end, err = m.RangeOffsets(e.Range); err != nil {
+				if policy == EditSkip {
+					skipped = append(skipped, SkippedEdit{i, e, err})
+					continue
+				}
+				return nil, nil, fmt.Errorf("edit %d: %v", i, err)
+			}
+		}
+		offsetEdits = append(offsetEdits, offsetEdit{i, start, end})
+	}
+	slices.SortStableFunc(offsetEdits, func(a, b offsetEdit) int { return a.start - b.start })
+
+	content := make([]byte, 0, len(m.Content))
+	last := 0
+	for _, e := range offsetEdits {
+		if e.start < last {
+			err := fmt.Errorf("edit %d overlaps another at offset %d", e.index, e.start)
+			if policy == EditSkip {
+				skipped = append(skipped, SkippedEdit{e.index, edits[e.index], err})
+				continue
+			}
+			return nil, nil, err
+		}
+		content = append(content, m.Content[last:e.start]...)
+		content = append(content, edits[e.index].NewText...)
+		last = e.end
+	}
+	content = append(content, m.Content[last:]...)
+	slices.SortFunc(skipped, func(a, b SkippedEdit) int { return a.Index - b.Index })
+	return content, skipped, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"slices"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestApplyEdits(t *testing.T) {
+	m := lsp.NewMapper("file:///a.txt", []byte("one\ntwo\n"))
+	edit := func(line, char, endLine, endChar uint32, text string) lsp.TextEdit {
+		return lsp.TextEdit{
+			Range:   lsp.Range{Start: lsp.Position{Line: line, Character: char}, End: lsp.Position{Line: endLine, Character: endChar}},
+			NewText: text,
+		}
+	}
+	edits := []lsp.TextEdit{
+		edit(1, 0, 1, 3, "TWO"),
+		edit(0, 0, 0, 0, "<"),
+		edit(0, 0, 0, 0, "<"),
+		edit(0, 3, 0, 9, ">"), // past the end of the line
+		edit(0, 2, 1, 1, "!"), // overlaps
+	}
+
+	for _, test := range []struct {
+		name    string
+		policy  lsp.EditPolicy
+		edits   []lsp.TextEdit
+		want    string // or "" for an error
+		skipped []int
+	}{
+		{"Strict", lsp.EditStrict, edits[:3], "<<one\nTWO\n", nil},
+		{"StrictOutOfBounds", lsp.EditStrict, edits[:4], "", nil},
+		{"Clamp", lsp.EditClamp, edits[:4], "<<one>\nTWO\n", nil},
+		{"ClampOverlap", lsp.EditClamp, edits, "", nil},
+		{"Skip", lsp.EditSkip, edits, "<<on!wo\n", []int{0, 3}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, skipped, err := lsp.ApplyEdits(m, test.edits, test.policy)
+			if test.want == "" {
+				if err == nil {
+					t.Fatalf("Expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("Expected %q, got %q", test.want, got)
+			}
+			var indexes []int
+			for _, s := range skipped {
+				if s.Err == nil || s.Edit != test.edits[s.Index] {
+					t.Errorf("Unexpected skipped edit %+v", s)
+				}
+				indexes = append(indexes, s.Index)
+			}
+			if !slices.Equal(indexes, test.skipped) {
+				t.Errorf("Expected the edits %v to be skipped, got %v", test.skipped, indexes)
+			}
+		})
+	}
+}
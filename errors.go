@@ -0,0 +1,173 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A ResponseError is an LSP response error: a code, a human-readable message,
+// and optional structured data.
+//
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#responseMessage
+//
+// A handler may return a *ResponseError (or an error wrapping one) and the
+// response sent to the peer carries its code and message, and also its
+// Data when the handler was installed with [ServerHandler] or
+// [ClientHandler]. Errors received from the peer are converted back
+// by [ErrorFrom].
+//
+// Two ResponseErrors match under [errors.Is] if their codes are equal,
+// so a handler's error may be tested against the sentinels below:
+//
+//	if errors.Is(err, lsp.ErrContentModified) { ... }
+type ResponseError struct {
+	Code    int64
+	Message string
+	Data    any
+}
+
+// Sentinel errors for every code defined by the specification.
+// They are intended for use with [errors.Is]; construct errors to
+// return from handlers with [NewError] or [Errorf].
+var (
+	ErrParse                = NewError(ParseError, "parse error")
+	ErrInvalidRequest       = NewError(InvalidRequest, "invalid request")
+	ErrMethodNotFound       = NewError(MethodNotFound, "method not found")
+	ErrInvalidParams        = NewError(InvalidParams, "invalid params")
+	ErrInternal             = NewError(InternalError, "internal error")
+	ErrServerNotInitialized = NewError(ServerNotInitialized, "server not initialized")
+	ErrUnknown              = NewError(UnknownErrorCode, "unknown error")
+	ErrRequestFailed        = NewError(RequestFailed, "request failed")
+	ErrServerCancelled      = NewError(ServerCancelled, "server cancelled")
+	ErrContentModified      = NewError(ContentModified, "content modified")
+	ErrRequestCancelled     = NewError(RequestCancelled, "request cancelled")
+)
+
+// RequestCancelledError should be used when a request is cancelled early.
+//
+// Deprecated: use [ErrRequestCancelled].
+var RequestCancelledError error = &ResponseError{Code: int64(RequestCancelled), Message: "JSON RPC cancelled"}
+
+// NewError returns a ResponseError with the given code and message.
+func NewError[C ErrorCodes | LSPErrorCodes](code C, message string) *ResponseError {
+	return &ResponseError{Code: int64(code), Message: message}
+}
+
+// Errorf returns a ResponseError with the given code and a message formatted
+// according to format.
+func Errorf[C ErrorCodes | LSPErrorCodes](code C, format string, args ...any) *ResponseError {
+	return NewError(code, fmt.Sprintf(format, args...))
+}
+
+// WithData returns a copy of e with its Data field set to data.
+func (e *ResponseError) WithData(data any) *ResponseError {
+	clone := *e
+	clone.Data = data
+	return &clone
+}
+
+func (e *ResponseError) Error() string { return e.Message }
+
+// Is reports whether target is an *ResponseError with the same code as e.
+func (e *ResponseError) Is(target error) bool {
+	t, ok := target.(*ResponseError)
+	return ok && t.Code == e.Code
+}
+
+// Unwrap returns the equivalent jsonrpc2 error, through which the
+// jsonrpc2 connection learns the code to put on the wire.
+func (e *ResponseError) Unwrap() error {
+	return jsonrpc2.NewError(e.Code, e.Message)
+}
+
+// ServerCancelledError returns a ServerCancelled error whose data tells
+// the client whether it should retrigger the request.
+func ServerCancelledError(retrigger bool) *ResponseError {
+	return ErrServerCancelled.WithData(DiagnosticServerCancellationData{RetriggerRequest: retrigger})
+}
+
+// wireError returns the jsonrpc2 error that carries e on the wire, with
+// the given message.
+//
+// The jsonrpc2 package only forwards Data for errors of its own
+// (unexported) type, which decodes from the wire representation.
+func (e *ResponseError) wireError(message string) error {
+	werr := jsonrpc2.NewError(e.Code, message)
+	if e.Data == nil {
+		return werr
+	}
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return werr
+	}
+	wire, _ := json.Marshal(struct {
+		Data json.RawMessage `json:"data"`
+	}{data})
+	if err := json.Unmarshal(wire, werr); err != nil {
+		return jsonrpc2.NewError(e.Code, message)
+	}
+	return werr
+}
+
+// shouldRetrigger reports whether err is a ServerCancelled error
+// received from the peer that does not forbid retriggering the request.
+func shouldRetrigger(err error) bool {
+	rerr, ok := ErrorFrom(err)
+	if !ok || rerr.Code != int64(ServerCancelled) {
+		return false
+	}
+	var data DiagnosticServerCancellationData
+	switch d := rerr.Data.(type) {
+	case nil:
+		return true
+	case DiagnosticServerCancellationData:
+		data = d
+	case json.RawMessage:
+		data.RetriggerRequest = true
+		if err := json.Unmarshal(d, &data); err != nil {
+			return true
+		}
+	}
+	return data.RetriggerRequest
+}
+
+// ErrorFrom returns the *ResponseError in err's chain, if any.
+//
+// Errors returned by calls to the peer are converted as well, including
+// any data they carry (as a json.RawMessage), so the code of a failed
+// Call can be inspected without depending on jsonrpc2 internals.
+func ErrorFrom(err error) (*ResponseError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var lspErr *ResponseError
+	if errors.As(err, &lspErr) {
+		return lspErr, true
+	}
+	// jsonrpc2 does not export its wire error type, but it marshals to
+	// the wire representation of a response error.
+	data, merr := json.Marshal(err)
+	if merr != nil {
+		return nil, false
+	}
+	var wire struct {
+		Code    int64           `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(data, &wire) != nil || wire.Code == 0 {
+		return nil, false
+	}
+	e := &ResponseError{Code: wire.Code, Message: wire.Message}
+	if len(wire.Data) > 0 {
+		e.Data = wire.Data
+	}
+	return e, true
+}
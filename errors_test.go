@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestResponseError(t *testing.T) {
+	t.Run("Is", func(t *testing.T) {
+		err := fmt.Errorf("hover: %w", lsp.Errorf(lsp.ContentModified, "document %s changed", "a.go"))
+		if !errors.Is(err, lsp.ErrContentModified) {
+			t.Errorf("Expected %v to match ErrContentModified", err)
+		}
+		if errors.Is(err, lsp.ErrRequestCancelled) {
+			t.Errorf("Expected %v not to match ErrRequestCancelled", err)
+		}
+	})
+
+	t.Run("WithData", func(t *testing.T) {
+		err := lsp.ErrServerCancelled.WithData(map[string]bool{"retriggerRequest": true})
+		if lsp.ErrServerCancelled.Data != nil {
+			t.Error("WithData modified the sentinel")
+		}
+		if !errors.Is(err, lsp.ErrServerCancelled) {
+			t.Errorf("Expected %v to match ErrServerCancelled", err)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		handler := jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) {
+			return nil, lsp.Errorf(lsp.RequestFailed, "no package for file")
+		})
+		client, _ := connectPair(t, nil, handler)
+		err := client.Call(context.Background(), "textDocument/hover", nil).Await(context.Background(), nil)
+		rerr, ok := lsp.ErrorFrom(err)
+		if !ok {
+			t.Fatalf("ErrorFrom(%v) failed", err)
+		}
+		if rerr.Code != int64(lsp.RequestFailed) || rerr.Message != "no package for file" {
+			t.Errorf("Got error %d %q, want %d %q", rerr.Code, rerr.Message, lsp.RequestFailed, "no package for file")
+		}
+	})
+
+	t.Run("FromPlainError", func(t *testing.T) {
+		if _, ok := lsp.ErrorFrom(errors.New("plain")); ok {
+			t.Error("ErrorFrom succeeded on a plain error")
+		}
+		if _, ok := lsp.ErrorFrom(json.Unmarshal([]byte("{"), new(any))); ok {
+			t.Error("ErrorFrom succeeded on a JSON syntax error")
+		}
+	})
+}
+
+// errServer fails every Hover request with err.
+type errServer struct {
+	lsp.Server
+	err error
+}
+
+func (s errServer) Hover(context.Context, *lsp.HoverParams) (*lsp.Hover, error) {
+	return nil, s.err
+}
+
+func TestContextErrorMapping(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *lsp.ResponseError
+	}{
+		{"Canceled", context.Canceled, lsp.ErrRequestCancelled},
+		{"WrappedCanceled", fmt.Errorf("type checking: %w", context.Canceled), lsp.ErrRequestCancelled},
+		{"DeadlineExceeded", context.DeadlineExceeded, lsp.ErrRequestFailed},
+		{"Explicit", fmt.Errorf("%w: %w", lsp.ErrServerCancelled, context.DeadlineExceeded), lsp.ErrServerCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := lsp.ServerHandler(errServer{err: tt.err})
+			_, err := handler(context.Background(), newHoverCall(t))
+			if rerr, ok := lsp.ErrorFrom(err); !ok || rerr.Code != tt.want.Code {
+				t.Errorf("Got %v, want code %d", err, tt.want.Code)
+			}
+		})
+	}
+}
+
+// flakyServer cancels the first failures Hover requests it receives.
+type flakyServer struct {
+	lsp.Server
+	failures  int
+	retrigger bool
+	calls     int
+}
+
+func (s *flakyServer) Hover(context.Context, *lsp.HoverParams) (*lsp.Hover, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, lsp.ServerCancelledError(s.retrigger)
+	}
+	return &lsp.Hover{}, nil
+}
+
+func TestServerCancelledRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		retries   int
+		failures  int
+		retrigger bool
+		wantCalls int
+		wantErr   bool
+	}{
+		{"NoRetries", 0, 1, true, 1, true},
+		{"Recovers", 2, 2, true, 3, false},
+		{"GivesUp", 2, 5, true, 3, true},
+		{"NotRetriggerable", 2, 1, false, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakyServer{failures: tt.failures, retrigger: tt.retrigger}
+			client, _ := connectPair(t, nil, lsp.ServerHandler(flaky))
+			server := lsp.ServerDispatcher(client, lsp.RetryServerCancelled(tt.retries))
+			_, err := server.Hover(context.Background(), &lsp.HoverParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Hover error = %v, want error %t", err, tt.wantErr)
+			}
+			if flaky.calls != tt.wantCalls {
+				t.Errorf("Server received %d calls, want %d", flaky.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A Framer is a [jsonrpc2.Framer] of messages framed by the base
+// protocol, which reads them with a [MessageReader] and writes them as a
+// [MessageWriter] does, with the settings of its fields:
+//
+//	conn, err := jsonrpc2.Dial(ctx, dialer, jsonrpc2.ConnectionOptions{
+//		Framer:  lsp.Framer{Lenient: true, ContentType: lsp.DefaultContentType},
+//		Handler: handler,
+//	})
+//
+// Unlike [jsonrpc2.HeaderFramer], it checks the Content-Type field of
+// the messages it reads, and bounds their size. Its zero value reads
+// headers strictly, as [MessageReader] does; set Lenient to tolerate
+// unknown fields and the other deviations of some peers.
+type Framer struct {
+	// ContentType, if not empty, is sent as the Content-Type field of
+	// each message written.
+	ContentType string
+	// MaxHeaderSize and MaxContentLength are the limits of the messages
+	// read, as in MessageReader.
+	MaxHeaderSize    int
+	MaxContentLength int64
+	// Lenient enables the tolerance of malformed headers of
+	// MessageReader.
+	Lenient bool
+}
+
+// Reader returns a reader of the messages in r.
+func (f Framer) Reader(r io.Reader) jsonrpc2.Reader {
+	mr := NewMessageReader(r)
+	mr.MaxHeaderSize = f.MaxHeaderSize
+	mr.MaxContentLength = f.MaxContentLength
+	mr.Lenient = f.Lenient
+	return framerReader{mr}
+}
+
+// Writer returns a writer of messages to w.
+func (f Framer) Writer(w io.Writer) jsonrpc2.Writer {
+	return framerWriter{w, f.ContentType}
+}
+
+type framerReader struct {
+	r *MessageReader
+}
+
+func (r framerReader) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	data, err := r.r.Read()
+	if err != nil {
+		return nil, 0, err
+	}
+	msg, err := jsonrpc2.DecodeMessage(data)
+	return msg, r.r.size, err
+}
+
+type framerWriter struct {
+	w           io.Writer
+	contentType string
+}
+
+func (w framerWriter) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	data, err := jsonrpc2.EncodeMessage(msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal message: %w", err)
+	}
+	e := getEncoder()
+	defer putEncoder(e)
+	e.body.Reset()
+	e.body.Write(data)
+	n, err := e.write(w.w, w.contentType)
+	return int64(n), err
+}
+
+// An NDJSONFramer is a [jsonrpc2.Framer] of messages framed as
+// newline-delimited JSON, one message per line, rather than by the base
+// protocol. Some tools prefer it, such as test harnesses, the replay of
+// logs, and simple proxies, but LSP clients and servers do not support
+// it unless configured to. Blank lines between messages are ignored,
+// and so is a carriage return before a line feed.
+type NDJSONFramer struct {
+	// MaxMessageSize is the maximum length of a line, or
+	// DefaultMaxContentLength if zero.
+	MaxMessageSize int64
+}
+
+// Reader returns a reader of the messages in r.
+func (f NDJSONFramer) Reader(r io.Reader) jsonrpc2.Reader {
+	limit := f.MaxMessageSize
+	if limit <= 0 {
+		limit = DefaultMaxContentLength
+	}
+	return &ndjsonReader{in: bufio.NewReader(r), limit: limit}
+}
+
+// Writer returns a writer of messages to w.
+func (f NDJSONFramer) Writer(w io.Writer) jsonrpc2.Writer {
+	return ndjsonWriter{w}
+}
+
+type ndjsonReader struct {
+	in    *bufio.Reader
+	limit int64
+	line  []byte
+}
+
+func (r *ndjsonReader) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	for {
+		line, err := r.readLine()
+		total += int64(len(line))
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return nil, total, err
+		}
+		// The last line need not be terminated.
+		if content := bytes.TrimSpace(line); len(content) > 0 {
+			msg, err := jsonrpc2.DecodeMessage(content)
+			return msg, total, err
+		}
+		if err != nil {
+			return nil, total, err
+		}
+	}
+}
+
+// readLine returns the next line, including its line feed, provided it
+// is no longer than the limit.
+func (r *ndjsonReader) readLine() ([]byte, error) {
+	r.line = r.line[:0]
+	for {
+		chunk, err := r.in.ReadSlice('\n')
+		if int64(len(r.line)+len(chunk)) > r.limit {
+			return nil, fmt.Errorf("%w: line exceeds the limit of %d bytes", ErrMessageTooLarge, r.limit)
+		}
+		r.line = append(r.line, chunk...)
+		if err != bufio.ErrBufferFull {
+			return r.line, err
+		}
+	}
+}
+
+type ndjsonWriter struct {
+	w io.Writer
+}
+
+func (w ndjsonWriter) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	// Encoded messages never contain line feeds, which JSON escapes in
+	// strings.
+	data, err := jsonrpc2.EncodeMessage(msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal message: %w", err)
+	}
+	n, err := w.w.Write(append(data, '\n'))
+	return int64(n), err
+}
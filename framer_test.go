@@ -0,0 +1,132 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestFramer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		framer := lsp.Framer{ContentType: lsp.DefaultContentType}
+		var buf bytes.Buffer
+		call, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "textDocument/hover", map[string]int{"line": 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		written, err := framer.Writer(&buf).Write(ctx, call)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Content-Type: " + lsp.DefaultContentType + "\r\n"; !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the header to contain %q, got %q", want, buf.String())
+		}
+		if written != int64(buf.Len()) {
+			t.Errorf("Expected %d bytes written, got %d", buf.Len(), written)
+		}
+		r := framer.Reader(&buf)
+		msg, read, err := r.Read(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req, ok := msg.(*jsonrpc2.Request); !ok || req.Method != "textDocument/hover" || req.ID != call.ID {
+			t.Errorf("Expected the call back, got %#v", msg)
+		}
+		if read != written {
+			t.Errorf("Expected %d bytes read, got %d", written, read)
+		}
+		if _, _, err := r.Read(ctx); err != io.EOF {
+			t.Errorf("Expected EOF, got %v", err)
+		}
+	})
+
+	t.Run("UnknownFields", func(t *testing.T) {
+		in := "Content-Length: 2\r\nX-Trace: 1\r\n\r\n[]"
+		if _, _, err := (lsp.Framer{}).Reader(strings.NewReader(in)).Read(ctx); !errors.Is(err, lsp.ErrInvalidHeader) {
+			t.Errorf("Expected ErrInvalidHeader, got %v", err)
+		}
+		// The content is not a message, but the header is accepted.
+		if _, _, err := (lsp.Framer{Lenient: true}).Reader(strings.NewReader(in)).Read(ctx); err == nil || errors.Is(err, lsp.ErrInvalidHeader) {
+			t.Errorf("Expected a decoding error, got %v", err)
+		}
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		in := "Content-Length: 100\r\n\r\n"
+		if _, _, err := (lsp.Framer{MaxContentLength: 10}).Reader(strings.NewReader(in)).Read(ctx); !errors.Is(err, lsp.ErrMessageTooLarge) {
+			t.Errorf("Expected ErrMessageTooLarge, got %v", err)
+		}
+	})
+}
+
+func TestParseContentType(t *testing.T) {
+	for _, test := range []struct {
+		value, mediaType, charset string
+	}{
+		{lsp.DefaultContentType, "application/vscode-jsonrpc", "utf-8"},
+		{"application/json", "application/json", "utf-8"},
+		{"Application/JSON; Charset=UTF8", "application/json", "UTF8"},
+	} {
+		mediaType, charset, err := lsp.ParseContentType(test.value)
+		if err != nil || mediaType != test.mediaType || charset != test.charset {
+			t.Errorf("ParseContentType(%q) = %q, %q, %v, want %q, %q", test.value, mediaType, charset, err, test.mediaType, test.charset)
+		}
+	}
+	if _, _, err := lsp.ParseContentType("application/json; charset"); !errors.Is(err, lsp.ErrInvalidHeader) {
+		t.Errorf("Expected ErrInvalidHeader, got %v", err)
+	}
+}
+
+func TestNDJSONFramer(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	var framer lsp.NDJSONFramer
+	w := framer.Writer(&buf)
+	call, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "workspace/executeCommand", json.RawMessage("{\n\"command\": \"a\\nb\"\n}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := w.Write(ctx, call); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", lines, buf.String())
+	}
+	// Blank lines, carriage returns and a missing final line feed are
+	// tolerated.
+	in := "\r\n" + strings.ReplaceAll(buf.String(), "\n", "\r\n\n")
+	in = strings.TrimSuffix(in, "\n")
+	r := framer.Reader(strings.NewReader(in))
+	for i := range 2 {
+		msg, _, err := r.Read(ctx)
+		if err != nil {
+			t.Fatalf("Message %d: %v", i, err)
+		}
+		if req, ok := msg.(*jsonrpc2.Request); !ok || req.Method != "workspace/executeCommand" || string(req.Params) != `{"command":"a\nb"}` {
+			t.Errorf("Message %d: expected the call back, got %#v", i, msg)
+		}
+	}
+	if _, _, err := r.Read(ctx); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	small := lsp.NDJSONFramer{MaxMessageSize: 10}
+	if _, _, err := small.Reader(&buf).Read(ctx); err == nil {
+		t.Errorf("Expected an error for a line exceeding the limit")
+	}
+}
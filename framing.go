@@ -0,0 +1,438 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// This file implements the base protocol framing of messages: a header
+// part, of which the Content-Length field is required, followed by the
+// JSON content.
+//
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#baseProtocol
+
+// An encoder encodes the content of messages; encoders are pooled so that
+// encoding does not allocate in the steady state.
+type encoder struct {
+	body  bytes.Buffer
+	enc   *json.Encoder
+	frame []byte
+}
+
+// maxPooledSize is the size above which buffers are not returned to the
+// pool, so that one large message does not pin its memory.
+const maxPooledSize = 1 << 20
+
+var encoderPool = sync.Pool{
+	New: func() any {
+		e := new(encoder)
+		e.enc = json.NewEncoder(&e.body)
+		return e
+	},
+}
+
+func getEncoder() *encoder { return encoderPool.Get().(*encoder) }
+
+func putEncoder(e *encoder) {
+	if e.body.Cap() <= maxPooledSize && cap(e.frame) <= maxPooledSize {
+		encoderPool.Put(e)
+	}
+}
+
+// encode encodes msg as JSON into e.body, as json.Marshal would.
+func (e *encoder) encode(msg any) error {
+	e.body.Reset()
+	if err := e.enc.Encode(msg); err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	e.body.Truncate(e.body.Len() - 1) // newline added by Encode
+	return nil
+}
+
+// appendFrame appends to dst the framing of the content in e.body,
+// with a Content-Type field unless contentType is empty.
+func (e *encoder) appendFrame(dst []byte, contentType string) []byte {
+	dst = append(dst, "Content-Length: "...)
+	dst = strconv.AppendInt(dst, int64(e.body.Len()), 10)
+	if contentType != "" {
+		dst = append(dst, "\r\nContent-Type: "...)
+		dst = append(dst, contentType...)
+	}
+	dst = append(dst, "\r\n\r\n"...)
+	return append(dst, e.body.Bytes()...)
+}
+
+// EncodeMessage is a utility to encode LSP protocol messages to JSON,
+// preceded by the Content-Length header of the base protocol.
+func EncodeMessage(msg any) ([]byte, error) {
+	e := getEncoder()
+	defer putEncoder(e)
+	if err := e.encode(msg); err != nil {
+		return nil, err
+	}
+	return e.appendFrame(make([]byte, 0, len("Content-Length: \r\n\r\n")+20+e.body.Len()), ""), nil
+}
+
+// AppendMessage appends the encoding of msg, as by [EncodeMessage], to
+// dst, and returns the extended buffer. It does not allocate if dst has
+// enough capacity.
+func AppendMessage(dst []byte, msg any) ([]byte, error) {
+	e := getEncoder()
+	defer putEncoder(e)
+	if err := e.encode(msg); err != nil {
+		return dst, err
+	}
+	return e.appendFrame(dst, ""), nil
+}
+
+// WriteMessage writes the encoding of msg, as by [EncodeMessage], to w
+// in a single call to its Write method. It reuses its buffers, so that
+// writing high-frequency notifications such as $/progress allocates
+// only what encoding the message itself requires.
+func WriteMessage(w io.Writer, msg any) error {
+	_, err := writeMessage(w, msg, "")
+	return err
+}
+
+func writeMessage(w io.Writer, msg any, contentType string) (int, error) {
+	e := getEncoder()
+	defer putEncoder(e)
+	if err := e.encode(msg); err != nil {
+		return 0, err
+	}
+	return e.write(w, contentType)
+}
+
+// write writes the framing of the content in e.body to w, in a single
+// call to its Write method.
+func (e *encoder) write(w io.Writer, contentType string) (int, error) {
+	e.frame = e.appendFrame(e.frame[:0], contentType)
+	return w.Write(e.frame)
+}
+
+// DefaultContentType is the default value of the Content-Type field of
+// messages, which implies it when absent.
+const DefaultContentType = "application/vscode-jsonrpc; charset=utf-8"
+
+// A MessageWriter writes messages framed by the base protocol.
+type MessageWriter struct {
+	// ContentType, if not empty, is sent as the Content-Type field of
+	// each message, such as DefaultContentType. Messages are always
+	// encoded in UTF-8, so it must not specify another charset.
+	ContentType string
+
+	w io.Writer
+}
+
+// NewMessageWriter returns a MessageWriter that writes to w. Initially,
+// it sends no Content-Type field, as WriteMessage.
+func NewMessageWriter(w io.Writer) *MessageWriter {
+	return &MessageWriter{w: w}
+}
+
+// Write writes msg encoded as JSON to w, in a single call to its Write
+// method.
+func (w *MessageWriter) Write(msg any) error {
+	_, err := writeMessage(w.w, msg, w.ContentType)
+	return err
+}
+
+// Errors reported when reading messages.
+var (
+	ErrInvalidHeader   = errors.New("invalid message header")
+	ErrHeaderTooLarge  = errors.New("message header too large")
+	ErrMessageTooLarge = errors.New("message content too large")
+
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+)
+
+// Default limits of a MessageReader.
+const (
+	DefaultMaxHeaderSize    = 4 << 10
+	DefaultMaxContentLength = 64 << 20
+)
+
+// initialContentSize bounds the memory allocated up front for the content
+// of a message, so that a peer announcing a large Content-Length must
+// actually send the data before it is allocated.
+const initialContentSize = 1 << 20
+
+// A MessageReader reads the content of messages framed by the base
+// protocol, as written by [WriteMessage], without decoding it.
+//
+// The limits may be changed before the first call to Read.
+type MessageReader struct {
+	// MaxHeaderSize is the maximum size of the header part of a
+	// message, including line terminators. If zero,
+	// DefaultMaxHeaderSize is used.
+	MaxHeaderSize int
+	// MaxContentLength is the maximum length of the content of a
+	// message. If zero, DefaultMaxContentLength is used.
+	MaxContentLength int64
+	// Lenient enables tolerance of the malformed headers that some
+	// peers produce: field names in any case, lines terminated by a
+	// line feed only, and unknown fields, which are ignored.
+	Lenient bool
+
+	in   byteReader
+	line []byte
+	size int64 // of the last message, header included
+}
+
+// A byteReader is a reader that can read single bytes efficiently.
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// NewMessageReader returns a MessageReader that reads from r. It buffers
+// its input, so it may read more data from r than the messages it
+// returns.
+func NewMessageReader(r io.Reader) *MessageReader {
+	return &MessageReader{in: bufio.NewReader(r)}
+}
+
+// DecodeMessage reads a single message from r and returns its content.
+// It reads no further than the end of the message, so that it may be
+// called repeatedly to read consecutive messages; [NewMessageReader]
+// is more efficient for that purpose unless r is buffered.
+func DecodeMessage(r io.Reader) ([]byte, error) {
+	in, ok := r.(byteReader)
+	if !ok {
+		in = &unbufferedReader{r: r}
+	}
+	mr := &MessageReader{in: in}
+	return mr.Read()
+}
+
+// Read reads the next message and returns its content. It returns
+// io.EOF if the input ends before the message starts, and an error
+// wrapping io.ErrUnexpectedEOF if it ends within the message. Malformed
+// headers are reported as errors wrapping ErrInvalidHeader, Content-Type
+// fields denoting anything but JSON encoded in UTF-8 as errors wrapping
+// ErrUnsupportedContentType, and messages exceeding the limits of r as errors wrapping ErrHeaderTooLarge or
+// ErrMessageTooLarge; in all these cases the input cannot be
+// resynchronized and the reader should not be used any further.
+func (r *MessageReader) Read() ([]byte, error) {
+	length, err := r.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	if maxContent := r.maxContentLength(); length > maxContent {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrMessageTooLarge, length, maxContent)
+	}
+	return r.readContent(length)
+}
+
+func (r *MessageReader) maxContentLength() int64 {
+	if r.MaxContentLength <= 0 {
+		return DefaultMaxContentLength
+	}
+	return r.MaxContentLength
+}
+
+// readHeader reads the header part of the next message, and returns the
+// length of its content.
+func (r *MessageReader) readHeader() (int64, error) {
+	maxHeader := r.MaxHeaderSize
+	if maxHeader <= 0 {
+		maxHeader = DefaultMaxHeaderSize
+	}
+
+	length := int64(-1)
+	size := 0
+	for {
+		line, err := r.readLine(maxHeader - size)
+		if err != nil {
+			switch {
+			case err == ErrHeaderTooLarge:
+				err = fmt.Errorf("%w: exceeds the limit of %d bytes", err, maxHeader)
+			case err == io.EOF && (size > 0 || len(line) > 0):
+				err = fmt.Errorf("reading header: %w", io.ErrUnexpectedEOF)
+			}
+			return 0, err
+		}
+		size += len(line)
+		line = line[:len(line)-1]
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		} else if !r.Lenient {
+			return 0, fmt.Errorf("%w: line %q not terminated by CRLF", ErrInvalidHeader, line)
+		}
+		if len(line) == 0 {
+			break // end of header
+		}
+		name, value, ok := bytes.Cut(line, []byte(":"))
+		if !ok {
+			return 0, fmt.Errorf("%w: malformed line %q", ErrInvalidHeader, line)
+		}
+		value = bytes.TrimSpace(value)
+		if r.Lenient {
+			name = canonicalFieldName(bytes.TrimSpace(name))
+		}
+		switch string(name) {
+		case "Content-Length":
+			if length >= 0 {
+				return 0, fmt.Errorf("%w: duplicate Content-Length", ErrInvalidHeader)
+			}
+			if length, err = parseContentLength(value); err != nil {
+				return 0, err
+			}
+		case "Content-Type":
+			if err := checkContentType(string(value)); err != nil {
+				return 0, err
+			}
+		default:
+			if !r.Lenient {
+				return 0, fmt.Errorf("%w: unknown field %q", ErrInvalidHeader, name)
+			}
+		}
+	}
+	if length < 0 {
+		return 0, fmt.Errorf("%w: missing Content-Length", ErrInvalidHeader)
+	}
+	r.size = int64(size) + length
+	return length, nil
+}
+
+// canonicalFieldName returns the canonical name of the known header
+// fields that name denotes in any case, and name itself otherwise.
+func canonicalFieldName(name []byte) []byte {
+	for _, known := range [...]string{"Content-Length", "Content-Type"} {
+		if len(name) == len(known) && strings.EqualFold(string(name), known) {
+			return []byte(known)
+		}
+	}
+	return name
+}
+
+// checkContentType reports an error if the value of a Content-Type field
+// denotes anything but JSON encoded in UTF-8.
+func checkContentType(value string) error {
+	mediaType, charset, err := ParseContentType(value)
+	if err != nil {
+		return err
+	}
+	switch mediaType {
+	case "application/vscode-jsonrpc":
+	case "application/json":
+		// The default of older versions of vscode-jsonrpc.
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, mediaType)
+	}
+	// The specification recommends accepting "utf8" for backwards
+	// compatibility.
+	if !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "utf8") {
+		return fmt.Errorf("%w: charset %q, only UTF-8 is supported", ErrUnsupportedContentType, charset)
+	}
+	return nil
+}
+
+// ParseContentType parses the value of a Content-Type field, such as
+// DefaultContentType, and returns its media type, in lower case, and its
+// charset, which is "utf-8" if the value has none. It reports a
+// malformed value as an error wrapping ErrInvalidHeader.
+func ParseContentType(value string) (mediaType, charset string, err error) {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid Content-Type %q: %v", ErrInvalidHeader, value, err)
+	}
+	charset, ok := params["charset"]
+	if !ok {
+		charset = "utf-8"
+	}
+	return mediaType, charset, nil
+}
+
+// readLine returns the next line of the header, including its line
+// terminator, provided it is no longer than max bytes.
+func (r *MessageReader) readLine(max int) ([]byte, error) {
+	line := r.line[:0]
+	defer func() { r.line = line[:0] }()
+	for {
+		if len(line) >= max {
+			return nil, ErrHeaderTooLarge
+		}
+		b, err := r.in.ReadByte()
+		if err != nil {
+			return line, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			return line, nil
+		}
+	}
+}
+
+// parseContentLength parses the value of a Content-Length field, which
+// must consist of decimal digits only.
+func parseContentLength(value []byte) (int64, error) {
+	for _, b := range value {
+		if b < '0' || b > '9' {
+			return 0, fmt.Errorf("%w: invalid Content-Length %q", ErrInvalidHeader, value)
+		}
+	}
+	length, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid Content-Length %q", ErrInvalidHeader, value)
+	}
+	return length, nil
+}
+
+// readContent reads content of the given length.
+func (r *MessageReader) readContent(length int64) ([]byte, error) {
+	var data []byte
+	if length <= initialContentSize {
+		data = make([]byte, length)
+		if _, err := io.ReadFull(r.in, data); err != nil {
+			return nil, contentError(err)
+		}
+		return data, nil
+	}
+	var buf bytes.Buffer
+	buf.Grow(initialContentSize)
+	if _, err := io.CopyN(&buf, r.in, length); err != nil {
+		return nil, contentError(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func contentError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("reading content: %w", err)
+}
+
+// An unbufferedReader reads single bytes from a reader without reading
+// ahead.
+type unbufferedReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (r *unbufferedReader) Read(p []byte) (int, error) { return r.r.Read(p) }
+
+func (r *unbufferedReader) ReadByte() (byte, error) {
+	for {
+		n, err := r.r.Read(r.buf[:])
+		if n == 1 {
+			return r.buf[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+func TestEncodeMessage(t *testing.T) {
+	notification, err := jsonrpc2.NewNotification("$/progress", progressParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := []any{
+		notification,
+		&lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.Markdown, Value: "<b>" + mixed + "</b>"}},
+		[]int{},
+	}
+	for _, msg := range msgs {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(data), data)
+
+		got, err := lsp.EncodeMessage(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("EncodeMessage: expected %q, got %q", want, got)
+		}
+
+		prefix := []byte("prefix")
+		got, err = lsp.AppendMessage(prefix, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "prefix"+want {
+			t.Errorf("AppendMessage: expected %q, got %q", "prefix"+want, got)
+		}
+
+		var buf bytes.Buffer
+		if err := lsp.WriteMessage(&buf, msg); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != want {
+			t.Errorf("WriteMessage: expected %q, got %q", want, buf.String())
+		}
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		if _, err := lsp.EncodeMessage(make(chan int)); err == nil {
+			t.Errorf("Expected an error for an unencodable message")
+		}
+		if err := lsp.WriteMessage(io.Discard, func() {}); err == nil {
+			t.Errorf("Expected an error for an unencodable message")
+		}
+	})
+}
+
+func progressParams() *lsp.ProgressParams {
+	return &lsp.ProgressParams{
+		Token: "indexing",
+		Value: &lsp.WorkDoneProgressReport{Kind: "report", Message: "file 42 of 1000", Percentage: new(uint32(4))},
+	}
+}
+
+func BenchmarkEncodeMessage(b *testing.B) {
+	msg, err := jsonrpc2.NewNotification("$/progress", progressParams())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := lsp.EncodeMessage(msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Append", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for b.Loop() {
+			buf, err = lsp.AppendMessage(buf[:0], msg)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Write", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := lsp.WriteMessage(io.Discard, msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestMessageReader(t *testing.T) {
+	var stream bytes.Buffer
+	msgs := []any{progressParams(), mixed, []int{1, 2, 3}}
+	for _, msg := range msgs {
+		if err := lsp.WriteMessage(&stream, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := stream.Bytes()
+
+	t.Run("Stream", func(t *testing.T) {
+		r := lsp.NewMessageReader(bytes.NewReader(data))
+		for _, msg := range msgs {
+			want, _ := json.Marshal(msg)
+			got, err := r.Read()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		}
+		if _, err := r.Read(); err != io.EOF {
+			t.Errorf("Expected EOF, got %v", err)
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		// DecodeMessage must not read beyond the end of each message.
+		r := io.MultiReader(bytes.NewReader(data)) // not an io.ByteReader
+		for _, msg := range msgs {
+			want, _ := json.Marshal(msg)
+			got, err := lsp.DecodeMessage(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		}
+		if _, err := lsp.DecodeMessage(r); err != io.EOF {
+			t.Errorf("Expected EOF, got %v", err)
+		}
+	})
+
+	t.Run("ContentType", func(t *testing.T) {
+		tests := []struct {
+			contentType string
+			want        error
+		}{
+			{lsp.DefaultContentType, nil},
+			{"application/vscode-jsonrpc; charset=utf8", nil},
+			{"Application/VSCode-JSONRPC; Charset=UTF-8", nil},
+			{"application/vscode-jsonrpc", nil},
+			{"application/json", nil},
+			{"application/vscode-jsonrpc; charset=utf-16", lsp.ErrUnsupportedContentType},
+			{"text/plain; charset=utf-8", lsp.ErrUnsupportedContentType},
+			{"application/vscode-jsonrpc; charset", lsp.ErrInvalidHeader},
+		}
+		for _, test := range tests {
+			in := "Content-Length: 2\r\nContent-Type: " + test.contentType + "\r\n\r\n{}"
+			got, err := lsp.DecodeMessage(strings.NewReader(in))
+			if !errors.Is(err, test.want) {
+				t.Errorf("%s: expected error %v, got %v", test.contentType, test.want, err)
+			} else if err == nil && string(got) != "{}" {
+				t.Errorf("%s: expected {}, got %s", test.contentType, got)
+			}
+		}
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := lsp.NewMessageWriter(&buf)
+		w.ContentType = lsp.DefaultContentType
+		if err := w.Write(mixed); err != nil {
+			t.Fatal(err)
+		}
+		want := "Content-Length: 12\r\nContent-Type: " + lsp.DefaultContentType + "\r\n\r\n\"" + mixed + "\""
+		if buf.String() != want {
+			t.Errorf("Expected %q, got %q", want, buf.String())
+		}
+		got, err := lsp.DecodeMessage(&buf)
+		if err != nil || string(got) != `"`+mixed+`"` {
+			t.Errorf("Expected %q, got %q, %v", mixed, got, err)
+		}
+	})
+
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"MissingLength", "Content-Type: application/vscode-jsonrpc\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"DuplicateLength", "Content-Length: 2\r\nContent-Length: 2\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"NegativeLength", "Content-Length: -2\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"BadLength", "Content-Length: 0x2\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"HugeLength", "Content-Length: 99999999999999999999\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"NoColon", "Content-Length 2\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"NoCR", "Content-Length: 2\n\n{}", lsp.ErrInvalidHeader},
+		{"UnknownField", "Content-Length: 2\r\nX-Trace: 1\r\n\r\n{}", lsp.ErrInvalidHeader},
+		{"TruncatedHeader", "Content-Length: 2\r\n", io.ErrUnexpectedEOF},
+		{"TruncatedLine", "Content-Len", io.ErrUnexpectedEOF},
+		{"TruncatedContent", "Content-Length: 10\r\n\r\n{}", io.ErrUnexpectedEOF},
+		{"LargeContent", "Content-Length: 100\r\n\r\n{}", lsp.ErrMessageTooLarge},
+		{"LargeHeader", "Content-Length: 2\r\n" + strings.Repeat("x", 100), lsp.ErrHeaderTooLarge},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := lsp.NewMessageReader(strings.NewReader(test.in))
+			r.MaxHeaderSize = 64
+			r.MaxContentLength = 50
+			if _, err := r.Read(); !errors.Is(err, test.want) {
+				t.Errorf("Expected error %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestMessageReaderLenient(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"Lowercase", "content-length: 2\r\ncontent-type: application/json\r\n\r\n{}"},
+		{"MissingCR", "Content-Length: 2\n\n{}"},
+		{"MixedTerminators", "Content-Length: 2\nContent-Type: application/json\r\n\n{}"},
+		{"UnknownFields", "X-Trace: 1\r\nContent-Length: 2\r\nUser-Agent: harness\r\n\r\n{}"},
+		{"Spaces", "CONTENT-LENGTH :  2 \r\n\r\n{}"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in + test.in
+			strict := lsp.NewMessageReader(strings.NewReader(in))
+			if _, err := strict.Read(); !errors.Is(err, lsp.ErrInvalidHeader) {
+				t.Errorf("Expected strict error %v, got %v", lsp.ErrInvalidHeader, err)
+			}
+			r := lsp.NewMessageReader(strings.NewReader(in))
+			r.Lenient = true
+			for range 2 {
+				if got, err := r.Read(); err != nil || string(got) != "{}" {
+					t.Errorf("Expected {}, got %s, %v", got, err)
+				}
+			}
+		})
+	}
+
+	t.Run("StillInvalid", func(t *testing.T) {
+		for _, in := range []string{
+			"content-length: x\n\n{}",
+			"Content-Length 2\n\n{}",
+			"X-Trace: 1\n\n{}",
+		} {
+			r := lsp.NewMessageReader(strings.NewReader(in))
+			r.Lenient = true
+			if _, err := r.Read(); !errors.Is(err, lsp.ErrInvalidHeader) {
+				t.Errorf("%q: expected error %v, got %v", in, lsp.ErrInvalidHeader, err)
+			}
+		}
+	})
+}
+
+func BenchmarkMessageReader(b *testing.B) {
+	var stream bytes.Buffer
+	if err := lsp.WriteMessage(&stream, progressParams()); err != nil {
+		b.Fatal(err)
+	}
+	in := bytes.NewReader(stream.Bytes())
+	r := lsp.NewMessageReader(in)
+	b.ReportAllocs()
+	for b.Loop() {
+		in.Seek(0, io.SeekStart)
+		if _, err := r.Read(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func FuzzMessageReader(f *testing.F) {
+	lsptest.FuzzFraming(f, messageFraming(false))
+}
+
+func FuzzMessageReaderLenient(f *testing.F) {
+	lsptest.FuzzFraming(f, messageFraming(true))
+}
+
+func messageFraming(lenient bool) lsptest.Framing {
+	return lsptest.Framing{
+		Write: func(w io.Writer, content []byte) error {
+			return lsp.WriteMessage(w, json.RawMessage(content))
+		},
+		NewReader: func(r io.Reader) func() ([]byte, error) {
+			mr := lsp.NewMessageReader(r)
+			mr.Lenient = lenient
+			return mr.Read
+		},
+	}
+}
@@ -7,6 +7,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"sort"
 )
 
@@ -15,8 +16,10 @@ import (
 //   - WithF methods for every field of every struct (fluent optional building)
 //   - union wrappers: a method M.U() (or package-level func UFromF) for every
 //     member M of every union type U, returning U with that case set.
+//   - nil-safe GetF accessors for the capability structs (see genGetters).
 func genBuilders(model *Model) {
 	genUnionWrappers()
+	genGetters(model)
 }
 
 func genUnionWrappers() {
@@ -45,3 +48,103 @@ func genUnionWrappers() {
 		}
 	}
 }
+
+// genGetters generates a nil-safe accessor x.GetF() for every field F of
+// every struct reachable from ClientCapabilities and ServerCapabilities,
+// including the fields promoted from embedded structs. A getter on a nil
+// receiver returns the zero value, so that chains such as
+// caps.GetTextDocument().GetCompletion().GetCompletionItem().GetSnippetSupport()
+// never panic. Struct-valued fields are returned by pointer, and pointers to
+// other types are dereferenced.
+func genGetters(model *Model) {
+	structs := make(map[string][]getterField) // by gopls name
+	for _, s := range model.Structures {
+		structs[goName(s.Name)] = nil
+	}
+	for _, nt := range genTypes {
+		if nt.kind == "literal" {
+			structs[goplsName(nt.typ)] = nil
+		}
+	}
+	unions := make(map[string]bool)
+	for _, nt := range genTypes {
+		if nt.kind == "or" {
+			if _, collapsed := goplsType[typeNames[nt.typ]]; !collapsed {
+				unions[goplsName(nt.typ)] = true
+			}
+		}
+	}
+	for _, s := range model.Structures {
+		structs[goName(s.Name)] = structureFields(model, s, map[string]bool{})
+	}
+	for _, nt := range genTypes {
+		if nt.kind == "literal" {
+			structs[goplsName(nt.typ)] = propFields(nt.name, nt.properties, map[string]bool{})
+		}
+	}
+
+	seen := map[string]bool{"ClientCapabilities": true, "ServerCapabilities": true}
+	queue := []string{"ClientCapabilities", "ServerCapabilities"}
+	for len(queue) > 0 {
+		nm := queue[0]
+		queue = queue[1:]
+		for _, f := range structs[nm] {
+			_, isStruct := structs[f.tp]
+			var b bytes.Buffer
+			fmt.Fprintf(&b, "// Get%s returns the %s field of x, or the zero value if x is nil.\n", f.name, f.name)
+			switch {
+			case (isStruct || unions[f.tp]) && !f.star:
+				fmt.Fprintf(&b, "func (x *%s) Get%s() *%s {\n\tif x == nil {\n\t\treturn nil\n\t}\n\treturn &x.%s\n}\n\n", nm, f.name, f.tp, f.name)
+			case isStruct || unions[f.tp]:
+				fmt.Fprintf(&b, "func (x *%s) Get%s() *%s {\n\tif x == nil {\n\t\treturn nil\n\t}\n\treturn x.%s\n}\n\n", nm, f.name, f.tp, f.name)
+			case f.star:
+				fmt.Fprintf(&b, "func (x *%s) Get%s() %s {\n\tif x == nil || x.%s == nil {\n\t\tvar zero %s\n\t\treturn zero\n\t}\n\treturn *x.%s\n}\n\n", nm, f.name, f.tp, f.name, f.tp, f.name)
+			default:
+				fmt.Fprintf(&b, "func (x *%s) Get%s() %s {\n\tif x == nil {\n\t\tvar zero %s\n\t\treturn zero\n\t}\n\treturn x.%s\n}\n\n", nm, f.name, f.tp, f.tp, f.name)
+			}
+			builders[nm+"\x00get\x00"+f.name] = b.String()
+			if isStruct && !seen[f.tp] {
+				seen[f.tp] = true
+				queue = append(queue, f.tp)
+			}
+		}
+	}
+}
+
+// A getterField is a field of a generated struct, possibly promoted from
+// an embedded struct.
+type getterField struct {
+	name string // Go field name
+	tp   string // Go type, without the star
+	star bool
+}
+
+// structureFields returns the fields of s followed by those promoted from
+// the structs it embeds, omitting any field hidden by one in names.
+func structureFields(model *Model, s *Structure, names map[string]bool) []getterField {
+	fields := propFields(goName(s.Name), s.Properties, names)
+	for _, ex := range append(slices.Clone(s.Extends), s.Mixins...) {
+		for _, e := range model.Structures {
+			if e.Name == ex.Name {
+				fields = append(fields, structureFields(model, e, names)...)
+			}
+		}
+	}
+	return fields
+}
+
+// propFields returns the fields of a struct named structName with the given
+// properties, omitting, and then adding to names, any field already in names.
+func propFields(structName string, props []NameType, names map[string]bool) []getterField {
+	var fields []getterField
+	for _, p := range props {
+		name := goName(p.Name)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		tp, _, star := propType(structName, p)
+		fields = append(fields, getterField{name, tp, star})
+	}
+	return fields
+}
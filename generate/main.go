@@ -35,8 +35,9 @@ const vscodeRepo = "https://github.com/microsoft/vscode-languageserver-node"
 var lspGitRef = "release/protocol/3.18.1"
 
 var (
-	repodir   = flag.String("d", "", "directory containing clone of "+vscodeRepo)
-	outputdir = flag.String("o", ".", "output directory")
+	repodir    = flag.String("d", "", "directory containing clone of "+vscodeRepo)
+	outputdir  = flag.String("o", ".", "output directory")
+	schemaFile = flag.String("schema", "", "write a JSON Schema of the protocol to this file instead of Go code")
 	// PJW: not for real code
 	lineNumbers = flag.Bool("l", false, "add line numbers to generated output")
 )
@@ -77,6 +78,11 @@ func processinline() {
 	// We need to hard-code this version until the next release tag is created.
 	model.Version.Version = "3.18.0"
 
+	if *schemaFile != "" {
+		writeschema(model, *schemaFile)
+		return
+	}
+
 	findTypeNames(model)
 	generateOutput(model)
 	pruneUnusedTypes(handwrittenSource())
@@ -89,6 +95,7 @@ func processinline() {
 	writeprotocol()
 	writejsons()
 	writebuilders()
+	writemethods()
 
 	checkTables()
 }
@@ -97,7 +104,7 @@ func processinline() {
 // output directory is hand-written and may reference generated types.
 var generatedFiles = map[string]bool{
 	"tsclient.go": true, "tsserver.go": true, "tsprotocol.go": true,
-	"tsjson.go": true, "tsbuilders.go": true,
+	"tsjson.go": true, "tsbuilders.go": true, "tsmethods.go": true,
 }
 
 // handwrittenSource returns the concatenated contents of every hand-written .go
@@ -132,6 +139,7 @@ func writeclient() {
 	out.WriteString(
 		`import (
 	"context"
+	"encoding/json"
 
 	"golang.org/x/exp/jsonrpc2"
 )
@@ -141,13 +149,23 @@ func writeclient() {
 		out.WriteString(cdecls[k])
 	}
 	out.WriteString("}\n\n")
-	out.WriteString(`func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (interface{}, error) {
-	switch req.Method {
+	out.WriteString(`// clientMethods maps each method handled by a Client to a function
+// that decodes the params of the request and invokes the Client.
+var clientMethods = map[string]func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error){
 `)
 	for _, k := range ccases.keys() {
 		out.WriteString(ccases[k])
 	}
-	out.WriteString(("\tdefault:\n\t\treturn nil, jsonrpc2.ErrMethodNotFound\n\t}\n}\n\n"))
+	out.WriteString(`}
+
+func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (interface{}, error) {
+	if dispatch, ok := clientMethods[req.Method]; ok {
+		return dispatch(ctx, client, req.Params)
+	}
+	return nil, jsonrpc2.ErrMethodNotFound
+}
+
+`)
 	for _, k := range cfuncs.keys() {
 		out.WriteString(cfuncs[k])
 	}
@@ -160,6 +178,7 @@ func writeserver() {
 	out.WriteString(
 		`import (
 	"context"
+	"encoding/json"
 
 	"golang.org/x/exp/jsonrpc2"
 )
@@ -171,13 +190,23 @@ func writeserver() {
 	out.WriteString(`
 }
 
-func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (interface{}, error) {
-	switch req.Method {
+// serverMethods maps each method handled by a Server to a function
+// that decodes the params of the request and invokes the Server.
+var serverMethods = map[string]func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error){
 `)
 	for _, k := range scases.keys() {
 		out.WriteString(scases[k])
 	}
-	out.WriteString(("\tdefault:\n\t\treturn nil, jsonrpc2.ErrMethodNotFound\n\t}\n}\n\n"))
+	out.WriteString(`}
+
+func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (interface{}, error) {
+	if dispatch, ok := serverMethods[req.Method]; ok {
+		return dispatch(ctx, server, req.Params)
+	}
+	return nil, jsonrpc2.ErrMethodNotFound
+}
+
+`)
 	for _, k := range sfuncs.keys() {
 		out.WriteString(sfuncs[k])
 	}
@@ -254,6 +283,46 @@ func writebuilders() {
 	formatTo("tsbuilders.go", out.Bytes())
 }
 
+func writemethods() {
+	out := new(bytes.Buffer)
+	fmt.Fprintln(out, fileHdr)
+	out.WriteString(`import "reflect"
+
+// The names of the methods of the protocol.
+const (
+`)
+	for _, k := range mconsts.keys() {
+		out.WriteString(mconsts[k])
+	}
+	out.WriteString(`)
+
+// The types of the requests of the protocol, for CallMethod.
+var (
+`)
+	for _, k := range mdescs.keys() {
+		out.WriteString(mdescs[k])
+	}
+	out.WriteString(`)
+
+// methodInfo describes a method of the protocol.
+type methodInfo struct {
+	toServer     bool         // sent by the client, handled by a Server
+	toClient     bool         // sent by the server, handled by a Client
+	notification bool         // a notification, not a request
+	params       reflect.Type // the type of the params, or nil
+	result       reflect.Type // the type of the result of a request, or nil
+}
+
+// methods describes every method of the protocol, keyed by method name.
+var methods = map[string]methodInfo{
+`)
+	for _, k := range minfos.keys() {
+		out.WriteString(minfos[k])
+	}
+	out.WriteString("}\n")
+	formatTo("tsmethods.go", out.Bytes())
+}
+
 // formatTo formats the Go source and writes it to *outputdir/basename.
 func formatTo(basename string, src []byte) {
 	formatted, err := format.Source(src)
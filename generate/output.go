@@ -30,6 +30,11 @@ var (
 	jsons = make(sortedMap[string])
 	// tsbuilders has 1 section (constructors, WithX methods, union wrappers)
 	builders = make(sortedMap[string])
+	// tsmethods has 3 sections (the method name constants, the request
+	// descriptors and the method table)
+	mconsts = make(sortedMap[string])
+	mdescs  = make(sortedMap[string])
+	minfos  = make(sortedMap[string])
 )
 
 func generateOutput(model *Model) {
@@ -37,6 +42,7 @@ func generateOutput(model *Model) {
 		genDecl(model, r.Method, r.Params, r.Result, r.Direction)
 		genCase(model, r.Method, r.Params, r.Result, r.Direction)
 		genFunc(model, r.Method, r.Params, r.Result, r.Direction, false)
+		genMethodInfo(r.Method, r.Params, r.Result, r.Direction, false)
 	}
 	for _, n := range model.Notifications {
 		if n.Method == "$/cancelRequest" {
@@ -45,6 +51,7 @@ func generateOutput(model *Model) {
 		genDecl(model, n.Method, n.Params, nil, n.Direction)
 		genCase(model, n.Method, n.Params, nil, n.Direction)
 		genFunc(model, n.Method, n.Params, nil, n.Direction, true)
+		genMethodInfo(n.Method, n.Params, nil, n.Direction, true)
 	}
 	genStructs(model)
 	genAliases(model)
@@ -91,9 +98,11 @@ func genDecl(model *Model, method string, param, result *Type, dir string) {
 	}
 }
 
+// genCase generates the entry of the method table used by the dispatcher:
+// a function that decodes the params of method and invokes the handler.
 func genCase(_ *Model, method string, param, result *Type, dir string) {
 	out := new(bytes.Buffer)
-	fmt.Fprintf(out, "\tcase %q:\n", method)
+	fmt.Fprintf(out, "\t%q: func(ctx context.Context, %%[1]s %%[2]s, raw json.RawMessage) (interface{}, error) {\n", method)
 	var p string
 	fname := methodName(method)
 	if notNil(param) {
@@ -104,30 +113,30 @@ func genCase(_ *Model, method string, param, result *Type, dir string) {
 			nm = "ParamConfiguration" // gopls compatibility
 		}
 		fmt.Fprintf(out, "\t\tvar params %s\n", nm)
-		fmt.Fprintf(out, "\t\tif err := UnmarshalJSON(req.Params, &params); err != nil {\n")
+		fmt.Fprintf(out, "\t\tif err := UnmarshalJSON(raw, &params); err != nil {\n")
 		fmt.Fprintf(out, "\t\t\treturn nil, err\n\t\t}\n")
 		p = ", &params"
 	}
 	if notNil(result) {
-		fmt.Fprintf(out, "\t\tresp, err := %%s.%s(ctx%s)\n", fname, p)
+		fmt.Fprintf(out, "\t\tresp, err := %%[1]s.%s(ctx%s)\n", fname, p)
 		out.WriteString("\t\tif err != nil {\n")
 		out.WriteString("\t\t\treturn nil, err\n")
 		out.WriteString("\t\t}\n")
 		out.WriteString("\t\treturn resp, nil\n")
 	} else {
-		fmt.Fprintf(out, "\t\terr := %%s.%s(ctx%s)\n", fname, p)
+		fmt.Fprintf(out, "\t\terr := %%[1]s.%s(ctx%s)\n", fname, p)
 		out.WriteString("\t\treturn nil, err\n")
 	}
-	out.WriteString("\n")
+	out.WriteString("\t},\n")
 	msg := out.String()
 	switch dir {
 	case "clientToServer":
-		scases[method] = fmt.Sprintf(msg, "server")
+		scases[method] = fmt.Sprintf(msg, "server", "Server")
 	case "serverToClient":
-		ccases[method] = fmt.Sprintf(msg, "client")
+		ccases[method] = fmt.Sprintf(msg, "client", "Client")
 	case "both":
-		scases[method] = fmt.Sprintf(msg, "server")
-		ccases[method] = fmt.Sprintf(msg, "client")
+		scases[method] = fmt.Sprintf(msg, "server", "Server")
+		ccases[method] = fmt.Sprintf(msg, "client", "Client")
 	default:
 		log.Fatalf("impossible direction %q", dir)
 	}
@@ -208,6 +217,62 @@ func genFunc(_ *Model, method string, param, result *Type, dir string, isnotify
 	}
 }
 
+// genMethodInfo generates the entry of the method table describing method:
+// its direction, whether it is a notification, and its Go param and result types;
+// the constant of its name; and the typed descriptor of a request.
+func genMethodInfo(method string, param, result *Type, dir string, isnotify bool) {
+	var fields []string
+	ptype, rtype := "struct{}", "struct{}" // of the descriptor of a request
+	switch dir {
+	case "clientToServer":
+		fields = append(fields, "toServer: true")
+	case "serverToClient":
+		fields = append(fields, "toClient: true")
+	case "both":
+		fields = append(fields, "toServer: true", "toClient: true")
+	default:
+		log.Fatalf("impossible direction %q", dir)
+	}
+	if isnotify {
+		fields = append(fields, "notification: true")
+	}
+	if notNil(param) {
+		nm := goplsName(param)
+		if method == "workspace/configuration" { // gopls compatibility, see genCase
+			nm = "ParamConfiguration"
+		}
+		fields = append(fields, fmt.Sprintf("params: reflect.TypeFor[%s]()", nm))
+		ptype = "*" + nm
+	}
+	if notNil(result) {
+		tp := goplsName(result)
+		if !hasNilValue(tp) {
+			tp = "*" + tp
+		}
+		if method == "workspace/configuration" { // gopls compatibility, see genDecl
+			tp = "[]LSPAny"
+		}
+		fields = append(fields, fmt.Sprintf("result: reflect.TypeFor[%s]()", tp))
+		rtype = tp
+	}
+	minfos[method] = fmt.Sprintf("\t%q: {%s},\n", method, strings.Join(fields, ", "))
+	mconsts[method] = fmt.Sprintf("\t%s = %q\n", methodConstName(method), method)
+	if !isnotify {
+		mdescs[method] = fmt.Sprintf("\t%sMethod = RequestType[%s, %s]{Name: %q}\n", methodName(method), ptype, rtype, method)
+	}
+}
+
+// methodConstName returns the name of the constant of a method name:
+// Method followed by its capitalized segments, without the $/ prefix of
+// protocol-specific methods, as in MethodTextDocumentHover.
+func methodConstName(method string) string {
+	name := "Method"
+	for _, seg := range strings.Split(strings.TrimPrefix(method, "$/"), "/") {
+		name += strings.ToUpper(seg[:1]) + seg[1:]
+	}
+	return name
+}
+
 func genStructs(model *Model) {
 	structures := make(map[string]*Structure) // for expanding Extends
 	for _, s := range model.Structures {
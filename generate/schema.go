@@ -0,0 +1,195 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// This file implements the -schema mode, which writes a JSON Schema
+// (draft 2020-12) describing the protocol, for use by components not
+// written in Go.
+//
+// Every structure, enumeration and type alias of the specification
+// becomes an entry of $defs under its own name. The params and result of
+// each method become entries named "method/params" and "method/result",
+// e.g. "textDocument/hover/params"; in a JSON pointer the slashes are
+// escaped, as in "#/$defs/textDocument~1hover~1params".
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"strings"
+)
+
+// schema is a JSON Schema, or a fragment of one.
+type schema = map[string]any
+
+// writeschema writes the JSON Schema of model to the named file.
+func writeschema(model *Model, filename string) {
+	data, err := json.MarshalIndent(modelSchema(model), "", "\t")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// modelSchema returns the JSON Schema of model.
+func modelSchema(model *Model) schema {
+	structs := make(map[string]*Structure)
+	for _, s := range model.Structures {
+		structs[s.Name] = s
+	}
+	defs := make(schema)
+	for _, s := range model.Structures {
+		defs[s.Name] = structSchema(s, structs)
+	}
+	for _, e := range model.Enumerations {
+		defs[e.Name] = enumSchema(e)
+	}
+	for _, a := range model.TypeAliases {
+		defs[a.Name] = annotate(typeSchema(a.Type), a.Documentation, a.Deprecated)
+	}
+	for _, r := range model.Requests {
+		if r.Params != nil {
+			defs[r.Method+"/params"] = typeSchema(r.Params)
+		}
+		if r.Result != nil {
+			defs[r.Method+"/result"] = typeSchema(r.Result)
+		}
+	}
+	for _, n := range model.Notifications {
+		if n.Params != nil {
+			defs[n.Method+"/params"] = typeSchema(n.Params)
+		}
+	}
+	return schema{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "Language Server Protocol " + model.Version.Version,
+		"$defs":   defs,
+	}
+}
+
+// structSchema returns the schema of a structure, whose properties
+// include those of the structures it extends or mixes in.
+func structSchema(s *Structure, structs map[string]*Structure) schema {
+	props := make(schema)
+	var required []string
+	var add func(s *Structure)
+	add = func(s *Structure) {
+		for _, t := range append(s.Extends, s.Mixins...) {
+			if base, ok := structs[t.Name]; ok {
+				add(base)
+			}
+		}
+		for _, p := range s.Properties {
+			props[p.Name] = annotate(typeSchema(p.Type), p.Documentation, p.Deprecated)
+			if !p.Optional && !slices.Contains(required, p.Name) {
+				required = append(required, p.Name)
+			}
+		}
+	}
+	add(s)
+	return annotate(objectSchema(props, required), s.Documentation, "")
+}
+
+func objectSchema(props schema, required []string) schema {
+	sch := schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		sch["required"] = required
+	}
+	return sch
+}
+
+// enumSchema returns the schema of an enumeration. If the enumeration
+// supports custom values, only the type of its values is constrained.
+func enumSchema(e *Enumeration) schema {
+	sch := typeSchema(e.Type)
+	if !e.SupportsCustomValues {
+		var values []any
+		for _, v := range e.Values {
+			values = append(values, v.Value)
+		}
+		sch["enum"] = values
+	}
+	return annotate(sch, e.Documentation, "")
+}
+
+// typeSchema returns the schema of a type of the specification.
+func typeSchema(t *Type) schema {
+	switch t.Kind {
+	case "base":
+		switch t.Name {
+		case "integer":
+			return schema{"type": "integer", "minimum": math.MinInt32, "maximum": math.MaxInt32}
+		case "uinteger":
+			return schema{"type": "integer", "minimum": 0, "maximum": math.MaxInt32}
+		case "decimal":
+			return schema{"type": "number"}
+		case "boolean":
+			return schema{"type": "boolean"}
+		case "null":
+			return schema{"type": "null"}
+		case "string", "DocumentUri", "URI", "RegExp":
+			return schema{"type": "string"}
+		}
+	case "reference":
+		if t.Name == "LSPAny" {
+			return schema{} // any value
+		}
+		return schema{"$ref": "#/$defs/" + t.Name}
+	case "array":
+		return schema{"type": "array", "items": typeSchema(t.Element)}
+	case "map":
+		return schema{"type": "object", "additionalProperties": typeSchema(t.Value.(*Type))}
+	case "and":
+		return schema{"allOf": itemSchemas(t.Items)}
+	case "or":
+		return schema{"anyOf": itemSchemas(t.Items)}
+	case "tuple":
+		return schema{
+			"type":        "array",
+			"prefixItems": itemSchemas(t.Items),
+			"minItems":    len(t.Items),
+			"maxItems":    len(t.Items),
+		}
+	case "stringLiteral":
+		return schema{"const": t.Value}
+	case "literal":
+		props := make(schema)
+		var required []string
+		for _, p := range t.Value.(ParseLiteral).Properties {
+			props[p.Name] = annotate(typeSchema(p.Type), p.Documentation, p.Deprecated)
+			if !p.Optional {
+				required = append(required, p.Name)
+			}
+		}
+		return objectSchema(props, required)
+	}
+	log.Fatalf("unexpected type %s %q (line %d)", t.Kind, t.Name, t.Line)
+	return nil
+}
+
+func itemSchemas(items []*Type) []schema {
+	var schemas []schema
+	for _, t := range items {
+		schemas = append(schemas, typeSchema(t))
+	}
+	return schemas
+}
+
+// annotate adds the documentation and deprecation notice of a
+// declaration to its schema.
+func annotate(sch schema, doc, deprecated string) schema {
+	if doc = strings.TrimSpace(doc); doc != "" {
+		sch["description"] = doc
+	}
+	if deprecated != "" {
+		sch["deprecated"] = true
+	}
+	return sch
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const schemaTestModel = `{
+	"metaData": {"version": "3.18.0"},
+	"requests": [{
+		"method": "textDocument/hover",
+		"messageDirection": "clientToServer",
+		"params": {"kind": "reference", "name": "HoverParams"},
+		"result": {"kind": "or", "items": [
+			{"kind": "reference", "name": "Hover"},
+			{"kind": "base", "name": "null"}
+		]}
+	}],
+	"notifications": [{
+		"method": "exit",
+		"messageDirection": "clientToServer"
+	}],
+	"structures": [{
+		"name": "Position",
+		"properties": [
+			{"name": "line", "type": {"kind": "base", "name": "uinteger"}},
+			{"name": "character", "type": {"kind": "base", "name": "uinteger"}}
+		]
+	}, {
+		"name": "HoverParams",
+		"extends": [{"kind": "reference", "name": "TextDocumentPositionParams"}],
+		"properties": [
+			{"name": "workDoneToken", "type": {"kind": "base", "name": "string"}, "optional": true}
+		]
+	}, {
+		"name": "TextDocumentPositionParams",
+		"properties": [
+			{"name": "position", "type": {"kind": "reference", "name": "Position"}}
+		]
+	}, {
+		"name": "Hover",
+		"documentation": "The result of a hover request.",
+		"properties": [
+			{"name": "contents", "type": {"kind": "literal", "value": {"properties": [
+				{"name": "kind", "type": {"kind": "stringLiteral", "value": "markdown"}}
+			]}}}
+		]
+	}],
+	"enumerations": [{
+		"name": "MarkupKind",
+		"type": {"kind": "base", "name": "string"},
+		"values": [{"name": "PlainText", "value": "plaintext"}, {"name": "Markdown", "value": "markdown"}]
+	}],
+	"typeAliases": [{
+		"name": "Pattern",
+		"deprecated": "use RelativePattern",
+		"type": {"kind": "base", "name": "string"}
+	}]
+}`
+
+func TestModelSchema(t *testing.T) {
+	var model Model
+	if err := json.Unmarshal([]byte(schemaTestModel), &model); err != nil {
+		t.Fatal(err)
+	}
+	// Compare the JSON encodings, which is what users see.
+	data, err := json.Marshal(modelSchema(&model))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	var want any
+	if err := json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Language Server Protocol 3.18.0",
+		"$defs": {
+			"textDocument/hover/params": {"$ref": "#/$defs/HoverParams"},
+			"textDocument/hover/result": {"anyOf": [{"$ref": "#/$defs/Hover"}, {"type": "null"}]},
+			"Position": {
+				"type": "object",
+				"properties": {
+					"line": {"type": "integer", "minimum": 0, "maximum": 2147483647},
+					"character": {"type": "integer", "minimum": 0, "maximum": 2147483647}
+				},
+				"required": ["line", "character"]
+			},
+			"HoverParams": {
+				"type": "object",
+				"properties": {
+					"position": {"$ref": "#/$defs/Position"},
+					"workDoneToken": {"type": "string"}
+				},
+				"required": ["position"]
+			},
+			"TextDocumentPositionParams": {
+				"type": "object",
+				"properties": {"position": {"$ref": "#/$defs/Position"}},
+				"required": ["position"]
+			},
+			"Hover": {
+				"description": "The result of a hover request.",
+				"type": "object",
+				"properties": {
+					"contents": {
+						"type": "object",
+						"properties": {"kind": {"const": "markdown"}},
+						"required": ["kind"]
+					}
+				},
+				"required": ["contents"]
+			},
+			"MarkupKind": {"type": "string", "enum": ["plaintext", "markdown"]},
+			"Pattern": {"type": "string", "deprecated": true}
+		}
+	}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("modelSchema mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestGetters(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var caps *lsp.ClientCapabilities
+		if caps.GetTextDocument().GetCompletion().GetCompletionItem().GetSnippetSupport() {
+			t.Errorf("Expected no snippet support")
+		}
+		if got := caps.GetGeneral().GetPositionEncodings(); got != nil {
+			t.Errorf("Expected no position encodings, got %v", got)
+		}
+		var server *lsp.ServerCapabilities
+		if got := server.GetCompletionProvider().GetTriggerCharacters(); got != nil {
+			t.Errorf("Expected no trigger characters, got %v", got)
+		}
+		if got := server.GetTextDocumentSync().GetChange(); got != lsp.None {
+			t.Errorf("Expected %v, got %v", lsp.None, got)
+		}
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		caps := new(lsp.ClientCapabilities)
+		if caps.GetTextDocument().GetHover().GetDynamicRegistration() {
+			t.Errorf("Expected no dynamic registration of hover")
+		}
+		if got := caps.GetWorkspace().GetWorkspaceEdit().GetFailureHandling(); got != "" {
+			t.Errorf("Expected no failure handling, got %q", got)
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		caps := lsp.NewClientCapabilities().
+			WithSnippetSupport().
+			WithDynamicRegistration("textDocument/hover").
+			WithPositionEncodings(lsp.UTF8).
+			Build()
+		if !caps.GetTextDocument().GetCompletion().GetCompletionItem().GetSnippetSupport() {
+			t.Errorf("Expected snippet support")
+		}
+		if !caps.GetTextDocument().GetHover().GetDynamicRegistration() {
+			t.Errorf("Expected dynamic registration of hover")
+		}
+		if got := caps.GetGeneral().GetPositionEncodings(); len(got) != 1 || got[0] != lsp.UTF8 {
+			t.Errorf("Expected [%s], got %v", lsp.UTF8, got)
+		}
+		server := lsp.FullServerCapabilities()
+		if got := server.GetTextDocumentSync().GetChange(); got != lsp.Incremental {
+			t.Errorf("Expected %v, got %v", lsp.Incremental, got)
+		}
+		// Fields promoted from embedded structs have getters too.
+		if server.GetCompletionProvider().GetWorkDoneProgress() {
+			t.Errorf("Expected no work done progress for completion")
+		}
+	})
+}
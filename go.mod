@@ -4,10 +4,8 @@ go 1.26.0
 
 require (
 	github.com/google/go-cmp v0.7.0
+	golang.org/x/exp/event v0.0.0-20260112195511-716be5621a96
 	golang.org/x/exp/jsonrpc2 v0.0.0-20260212183809-81e46e3db34a
 )
 
-require (
-	golang.org/x/exp/event v0.0.0-20260112195511-716be5621a96 // indirect
-	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
-)
+require golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
@@ -0,0 +1,208 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// A DocumentHistory retains the shape of the changes that produced the
+// last versions of a document, so as to translate positions and ranges
+// between any two of them. A server that receives a request about an
+// older version than its own, such as a code action for diagnostics it
+// published earlier, may thus still locate its positions.
+//
+// A DocumentHistory is fed the content changes of the didChange
+// notifications of its document:
+//
+//	h := lsp.NewDocumentHistory(params.TextDocument.Version, 10, lsp.UTF16)
+//	...
+//	h.Record(params.TextDocument.Version, params.ContentChanges)
+//	...
+//	rng, err := h.TranslateRange(rng, oldVersion, h.Version())
+//
+// Changes that replace the whole content cannot be translated through:
+// positions are only translated between versions after the last such
+// change.
+type DocumentHistory struct {
+	limit    int
+	encoding PositionEncodingKind
+
+	mu     sync.Mutex
+	oldest int32         // the oldest retained version
+	steps  []historyStep // that lead from oldest to the current version
+}
+
+// A historyStep is the shape of the changes that produced a version.
+type historyStep struct {
+	version int32
+	edits   []shapeEdit
+}
+
+// A shapeEdit replaces the text between start and from with text that
+// spans from start to to.
+type shapeEdit struct {
+	start, from, to Position
+}
+
+// NewDocumentHistory returns the history of a document opened at the
+// given version, which retains the last limit versions, including the
+// current one. Positions are in the given encoding, typically that negotiated
+// by the initialize request.
+func NewDocumentHistory(version int32, limit int, encoding PositionEncodingKind) *DocumentHistory {
+	switch encoding {
+	case UTF8, UTF32:
+	default:
+		encoding = UTF16
+	}
+	return &DocumentHistory{limit: max(limit, 1), encoding: encoding, oldest: version}
+}
+
+// Version returns the current version of the document.
+func (h *DocumentHistory) Version() int32 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.version()
+}
+
+func (h *DocumentHistory) version() int32 {
+	if len(h.steps) == 0 {
+		return h.oldest
+	}
+	return h.steps[len(h.steps)-1].version
+}
+
+// Oldest returns the oldest version whose positions can be translated.
+func (h *DocumentHistory) Oldest() int32 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.oldest
+}
+
+// Record records the content changes of a didChange notification that
+// produced the given version. A change of the whole content discards
+// the earlier versions.
+func (h *DocumentHistory) Record(version int32, changes []TextDocumentContentChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	step := historyStep{version: version}
+	for _, change := range changes {
+		if change.Range == nil {
+			h.oldest, h.steps, step.edits = version, nil, nil
+			continue
+		}
+		start := change.Range.Start
+		step.edits = append(step.edits, shapeEdit{
+			start: start,
+			from:  change.Range.End,
+			to:    h.textEnd(start, change.Text),
+		})
+	}
+	if h.oldest == version {
+		return // all changed
+	}
+	h.steps = append(h.steps, step)
+	if n := len(h.steps) - (h.limit - 1); n > 0 {
+		h.oldest = h.steps[n-1].version
+		h.steps = append(h.steps[:0], h.steps[n:]...)
+	}
+}
+
+// textEnd returns the position of the end of text inserted at start.
+func (h *DocumentHistory) textEnd(start Position, text string) Position {
+	last := text
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		start.Line += uint32(strings.Count(text, "\n"))
+		start.Character = 0
+		last = text[i+1:]
+	}
+	switch h.encoding {
+	case UTF8:
+		start.Character += uint32(len(last))
+	case UTF32:
+		start.Character += uint32(utf8.RuneCountInString(last))
+	default:
+		start.Character += uint32(UTF16Len([]byte(last)))
+	}
+	return start
+}
+
+// TranslatePosition translates a position in the version from of the
+// document to the version to, which may be older. A position within
+// text that was replaced translates to the start of its replacement.
+func (h *DocumentHistory) TranslatePosition(p Position, from, to int32) (Position, error) {
+	rng, err := h.TranslateRange(Range{Start: p, End: p}, from, to)
+	return rng.Start, err
+}
+
+// TranslateRange translates a range in the version from of the document
+// to the version to, which may be older. Where the range starts or ends
+// within text that was replaced, it grows to include its replacement.
+func (h *DocumentHistory) TranslateRange(r Range, from, to int32) (Range, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, err := h.index(from)
+	if err != nil {
+		return Range{}, err
+	}
+	j, err := h.index(to)
+	if err != nil {
+		return Range{}, err
+	}
+	// Versions up to steps[i-1] precede from; apply the later steps
+	// forwards, or the earlier ones backwards, in reverse order.
+	for ; i < j; i++ {
+		for _, e := range h.steps[i].edits {
+			r = e.translate(r, e.from, e.to)
+		}
+	}
+	for ; i > j; i-- {
+		edits := h.steps[i-1].edits
+		for k := len(edits) - 1; k >= 0; k-- {
+			e := edits[k]
+			r = e.translate(r, e.to, e.from)
+		}
+	}
+	return r, nil
+}
+
+// index returns the number of steps that lead to version.
+func (h *DocumentHistory) index(version int32) (int, error) {
+	if version == h.oldest {
+		return 0, nil
+	}
+	for i, step := range h.steps {
+		if step.version == version {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("version %d not retained (versions %d-%d are)", version, h.oldest, h.version())
+}
+
+// translate translates r through the replacement of the text between
+// e.start and oldEnd by text between e.start and newEnd.
+func (e shapeEdit) translate(r Range, oldEnd, newEnd Position) Range {
+	move := func(p Position, end bool) Position {
+		switch {
+		case ComparePosition(p, e.start) < 0:
+			return p
+		case ComparePosition(p, oldEnd) < 0, ComparePosition(p, oldEnd) == 0 && ComparePosition(p, e.start) == 0:
+			// Within the replaced text, or at an insertion.
+			if end {
+				return newEnd
+			}
+			return e.start
+		case p.Line == oldEnd.Line:
+			return Position{Line: newEnd.Line, Character: newEnd.Character + p.Character - oldEnd.Character}
+		default:
+			p.Line = p.Line + newEnd.Line - oldEnd.Line
+			return p
+		}
+	}
+	return Range{Start: move(r.Start, false), End: move(r.End, true)}
+}
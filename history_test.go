@@ -0,0 +1,76 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestDocumentHistory(t *testing.T) {
+	pos := func(line, char uint32) lsp.Position { return lsp.Position{Line: line, Character: char} }
+	change := func(start, end lsp.Position, text string) lsp.TextDocumentContentChangeEvent {
+		return lsp.TextDocumentContentChangeEvent{Range: &lsp.Range{Start: start, End: end}, Text: text}
+	}
+
+	// Version 1: "func f() {\n\treturn\n}\n"
+	h := lsp.NewDocumentHistory(1, 3, lsp.UTF16)
+	// Version 2: "// doc\nfunc f() {\n\treturn\n}\n"
+	h.Record(2, []lsp.TextDocumentContentChangeEvent{change(pos(0, 0), pos(0, 0), "// doc\n")})
+	// Version 3: "// doc\nfunc g(x int) {\n\treturn\n}\n"
+	h.Record(3, []lsp.TextDocumentContentChangeEvent{
+		change(pos(1, 5), pos(1, 6), "g"),
+		change(pos(1, 7), pos(1, 7), "x int"),
+	})
+
+	for _, test := range []struct {
+		from, to int32
+		in, want lsp.Range
+	}{
+		// "return" moves down one line.
+		{1, 3, lsp.Range{Start: pos(1, 1), End: pos(1, 7)}, lsp.Range{Start: pos(2, 1), End: pos(2, 7)}},
+		{3, 1, lsp.Range{Start: pos(2, 1), End: pos(2, 7)}, lsp.Range{Start: pos(1, 1), End: pos(1, 7)}},
+		// "{" moves right by the length of "x int".
+		{1, 3, lsp.Range{Start: pos(0, 9), End: pos(0, 10)}, lsp.Range{Start: pos(1, 14), End: pos(1, 15)}},
+		{3, 2, lsp.Range{Start: pos(1, 14), End: pos(1, 15)}, lsp.Range{Start: pos(1, 9), End: pos(1, 10)}},
+		// The name "f", replaced, becomes "g".
+		{2, 3, lsp.Range{Start: pos(1, 5), End: pos(1, 6)}, lsp.Range{Start: pos(1, 5), End: pos(1, 6)}},
+		// The parameters, inserted, vanish.
+		{3, 2, lsp.Range{Start: pos(1, 7), End: pos(1, 12)}, lsp.Range{Start: pos(1, 7), End: pos(1, 7)}},
+		{2, 2, lsp.Range{Start: pos(5, 5), End: pos(6, 6)}, lsp.Range{Start: pos(5, 5), End: pos(6, 6)}},
+	} {
+		got, err := h.TranslateRange(test.in, test.from, test.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("TranslateRange(%v, %d, %d): expected %v, got %v", test.in, test.from, test.to, test.want, got)
+		}
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		h.Record(4, []lsp.TextDocumentContentChangeEvent{change(pos(0, 0), pos(0, 0), "x")})
+		if h.Oldest() != 2 || h.Version() != 4 {
+			t.Errorf("Expected versions 2-4, got %d-%d", h.Oldest(), h.Version())
+		}
+		if _, err := h.TranslatePosition(pos(0, 0), 1, 4); err == nil {
+			t.Error("Expected an error translating from a discarded version")
+		}
+		if got, err := h.TranslatePosition(pos(0, 3), 2, 4); err != nil || got != pos(0, 4) {
+			t.Errorf("Expected 0:4, got %v, %v", got, err)
+		}
+	})
+
+	t.Run("FullChange", func(t *testing.T) {
+		h.Record(5, []lsp.TextDocumentContentChangeEvent{{Text: "new"}})
+		if h.Oldest() != 5 || h.Version() != 5 {
+			t.Errorf("Expected version 5 only, got %d-%d", h.Oldest(), h.Version())
+		}
+		if _, err := h.TranslatePosition(pos(0, 0), 4, 5); err == nil {
+			t.Error("Expected an error translating across a full change")
+		}
+	})
+}
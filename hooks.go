@@ -0,0 +1,202 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// Hooks observe the messages exchanged over connections, in both
+// directions, for integrations such as metrics or traffic logs that do
+// not modify them. Hooks are installed by wrapping the framer of a
+// connection:
+//
+//	hooks := lsp.NewHooks(1000)
+//	hooks.OnRequest(func(e lsp.HookEvent) { ... })
+//	conn, err := jsonrpc2.Dial(ctx, dialer, jsonrpc2.ConnectionOptions{
+//		Framer:  hooks.Framer(nil),
+//		Handler: handler,
+//	})
+//
+// The hooks are called in the order in which the messages were observed,
+// from a single goroutine fed by a bounded queue, so that a slow hook
+// never blocks a connection. When the queue is full, messages are
+// dropped and counted by [Hooks.Dropped].
+type Hooks struct {
+	queue   chan HookEvent
+	done    chan struct{}
+	dropped atomic.Int64
+
+	closeMu sync.RWMutex // held for writing by Close
+	closed  bool
+
+	mu                                    sync.Mutex
+	onRequest, onResponse, onNotification []func(HookEvent)
+}
+
+// A HookEvent describes a message observed by [Hooks].
+type HookEvent struct {
+	Incoming bool               // read from the peer, rather than written to it
+	Time     time.Time          // when the message was read or written
+	Size     int64              // size of the message on the wire, in bytes
+	Request  *jsonrpc2.Request  // the call or notification, if any
+	Response *jsonrpc2.Response // the response, if any
+}
+
+// NewHooks returns Hooks whose queue holds up to size messages.
+func NewHooks(size int) *Hooks {
+	h := &Hooks{
+		queue: make(chan HookEvent, size),
+		done:  make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// OnRequest registers f to be called for each call, that is, each
+// request that expects a response.
+func (h *Hooks) OnRequest(f func(HookEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onRequest = append(h.onRequest, f)
+}
+
+// OnResponse registers f to be called for each response.
+func (h *Hooks) OnResponse(f func(HookEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onResponse = append(h.onResponse, f)
+}
+
+// OnNotification registers f to be called for each notification.
+func (h *Hooks) OnNotification(f func(HookEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onNotification = append(h.onNotification, f)
+}
+
+// Dropped returns the number of messages that were not passed to the
+// hooks because the queue was full.
+func (h *Hooks) Dropped() int64 {
+	return h.dropped.Load()
+}
+
+// Close stops the observation of messages, and waits until the hooks
+// have been called for the messages already queued.
+func (h *Hooks) Close() {
+	h.closeMu.Lock()
+	if !h.closed {
+		h.closed = true
+		close(h.queue)
+	}
+	h.closeMu.Unlock()
+	<-h.done
+}
+
+// Framer returns a framer that encodes messages as f does, and passes
+// each message it reads or writes to the hooks. If f is nil,
+// [jsonrpc2.HeaderFramer] is used.
+func (h *Hooks) Framer(f jsonrpc2.Framer) jsonrpc2.Framer {
+	if f == nil {
+		f = jsonrpc2.HeaderFramer()
+	}
+	return hooksFramer{f, h}
+}
+
+// observe queues the event for msg, without blocking.
+func (h *Hooks) observe(msg jsonrpc2.Message, n int64, incoming bool) {
+	e := HookEvent{Incoming: incoming, Time: time.Now(), Size: n}
+	switch msg := msg.(type) {
+	case *jsonrpc2.Request:
+		e.Request = msg
+	case *jsonrpc2.Response:
+		e.Response = msg
+	default:
+		return
+	}
+	h.closeMu.RLock()
+	defer h.closeMu.RUnlock()
+	if h.closed {
+		return
+	}
+	select {
+	case h.queue <- e:
+	default:
+		h.dropped.Add(1)
+	}
+}
+
+// run calls the hooks for each queued event, until Close.
+func (h *Hooks) run() {
+	defer close(h.done)
+	for e := range h.queue {
+		h.mu.Lock()
+		var hooks []func(HookEvent)
+		switch {
+		case e.Response != nil:
+			hooks = h.onResponse
+		case e.Request.IsCall():
+			hooks = h.onRequest
+		default:
+			hooks = h.onNotification
+		}
+		h.mu.Unlock()
+		for _, f := range hooks {
+			f(e)
+		}
+	}
+}
+
+type hooksFramer struct {
+	framer jsonrpc2.Framer
+	hooks  *Hooks
+}
+
+func (f hooksFramer) Reader(r io.Reader) jsonrpc2.Reader {
+	return hooksReader{f.framer.Reader(r), f.hooks}
+}
+
+func (f hooksFramer) Writer(w io.Writer) jsonrpc2.Writer {
+	return hooksWriter{f.framer.Writer(w), f.hooks}
+}
+
+func (f hooksFramer) wrapped() jsonrpc2.Framer { return f.framer }
+
+func (f hooksFramer) rewrap(framer jsonrpc2.Framer) jsonrpc2.Framer {
+	f.framer = framer
+	return f
+}
+
+type hooksReader struct {
+	reader jsonrpc2.Reader
+	hooks  *Hooks
+}
+
+func (r hooksReader) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	msg, n, err := r.reader.Read(ctx)
+	if err == nil {
+		r.hooks.observe(msg, n, true)
+	}
+	return msg, n, err
+}
+
+type hooksWriter struct {
+	writer jsonrpc2.Writer
+	hooks  *Hooks
+}
+
+func (w hooksWriter) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	n, err := w.writer.Write(ctx, msg)
+	if err == nil {
+		w.hooks.observe(msg, n, false)
+	}
+	return n, err
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Traffic", func(t *testing.T) {
+		hooks := lsp.NewHooks(100)
+		var got []string
+		record := func(kind string) func(lsp.HookEvent) {
+			return func(e lsp.HookEvent) {
+				dir := "out"
+				if e.Incoming {
+					dir = "in"
+				}
+				if e.Size <= 0 || e.Time.IsZero() {
+					t.Errorf("Expected the size and time of %s %s", dir, kind)
+				}
+				method := ""
+				if e.Request != nil {
+					method = " " + e.Request.Method
+				}
+				got = append(got, dir+" "+kind+method)
+			}
+		}
+		hooks.OnRequest(record("request"))
+		hooks.OnResponse(record("response"))
+		hooks.OnNotification(record("notification"))
+
+		cc, sc := net.Pipe()
+		client, err := jsonrpc2.Dial(ctx, pipeDialer{cc}, jsonrpc2.ConnectionOptions{Framer: hooks.Framer(nil)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+		handler := jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+			if !req.IsCall() {
+				return nil, nil // ignore notifications
+			}
+			return lsp.ServerHandler(hoverServer{})(ctx, req)
+		})
+		server, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, jsonrpc2.ConnectionOptions{Handler: handler})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer server.Close()
+
+		if _, err := lsp.ServerDispatcher(client).Hover(ctx, &lsp.HoverParams{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Notify(ctx, "$/setTrace", &lsp.SetTraceParams{Value: lsp.Off}); err != nil {
+			t.Fatal(err)
+		}
+		hooks.Close()
+		want := []string{"out request textDocument/hover", "in response", "out notification $/setTrace"}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("NonBlocking", func(t *testing.T) {
+		hooks := lsp.NewHooks(1)
+		block := make(chan struct{})
+		hooks.OnNotification(func(lsp.HookEvent) { <-block })
+
+		var buf bytes.Buffer
+		w := hooks.Framer(nil).Writer(&buf)
+		for i := 0; i < 10; i++ {
+			msg, err := jsonrpc2.NewNotification("$/progress", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(ctx, msg); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// At most one message is being delivered, and one is queued.
+		if got := hooks.Dropped(); got < 8 {
+			t.Errorf("Expected at least 8 dropped messages, got %d", got)
+		}
+		close(block)
+		hooks.Close()
+	})
+}
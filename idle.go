@@ -0,0 +1,182 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// idleShutdownTimeout bounds the shutdown sequence of an idle connection.
+const idleShutdownTimeout = 5 * time.Second
+
+// WithIdleTimeout returns a binder that binds connections as b does, and
+// closes each connection once no message has been read from or written
+// to it for the given duration, while no call is outstanding in either
+// direction. This keeps a shared daemon from leaking the sessions of
+// editors that crashed without closing their connections:
+//
+//	l, err := jsonrpc2.NetListener(ctx, "tcp", addr, jsonrpc2.NetListenOptions{})
+//	srv, err := jsonrpc2.Serve(ctx, l, lsp.WithIdleTimeout(binder, 30*time.Minute, nil))
+//
+// If shutdown is not nil, it is called before closing the connection,
+// with a context that expires after a few seconds, to let the peer
+// know; for example, a client passes [ShutdownServer], and a server may
+// send a window/logMessage notification.
+func WithIdleTimeout(b jsonrpc2.Binder, timeout time.Duration, shutdown func(context.Context, *jsonrpc2.Connection) error) jsonrpc2.Binder {
+	return idleBinder{b, timeout, shutdown}
+}
+
+// ShutdownServer asks the server at the other end of conn to shut down,
+// with a shutdown request followed by an exit notification.
+func ShutdownServer(ctx context.Context, conn *jsonrpc2.Connection) error {
+	if err := conn.Call(ctx, "shutdown", nil).Await(ctx, nil); err != nil {
+		return err
+	}
+	return conn.Notify(ctx, "exit", nil)
+}
+
+type idleBinder struct {
+	binder   jsonrpc2.Binder
+	timeout  time.Duration
+	shutdown func(context.Context, *jsonrpc2.Connection) error
+}
+
+func (b idleBinder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	opts, err := b.binder.Bind(ctx, conn)
+	if err != nil {
+		return opts, err
+	}
+	if opts.Framer == nil {
+		opts.Framer = jsonrpc2.HeaderFramer()
+	}
+	c := &idleConn{
+		ctx:      detach(ctx),
+		conn:     conn,
+		timeout:  b.timeout,
+		shutdown: b.shutdown,
+		last:     time.Now(),
+	}
+	c.timer = time.AfterFunc(b.timeout, c.expire)
+	opts.Framer = idleFramer{opts.Framer, c}
+	return opts, nil
+}
+
+// An idleConn tracks the activity of a connection.
+type idleConn struct {
+	ctx      context.Context
+	conn     *jsonrpc2.Connection
+	timeout  time.Duration
+	shutdown func(context.Context, *jsonrpc2.Connection) error
+	timer    *time.Timer
+
+	mu          sync.Mutex
+	last        time.Time // time of the last message
+	outstanding int       // calls awaiting a response, in either direction
+	done        bool      // closing or closed
+}
+
+// observe records the activity of reading or writing msg.
+func (c *idleConn) observe(msg jsonrpc2.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last = time.Now()
+	switch msg := msg.(type) {
+	case *jsonrpc2.Request:
+		if msg.IsCall() {
+			c.outstanding++
+		}
+	case *jsonrpc2.Response:
+		if c.outstanding > 0 {
+			c.outstanding--
+		}
+	}
+	if !c.done && c.outstanding == 0 {
+		c.timer.Reset(c.timeout)
+	}
+}
+
+// stop stops tracking the connection, once it can no longer be read.
+func (c *idleConn) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = true
+	c.timer.Stop()
+}
+
+// expire closes the connection if it has been idle for long enough.
+func (c *idleConn) expire() {
+	c.mu.Lock()
+	if c.done || c.outstanding > 0 {
+		c.mu.Unlock()
+		return
+	}
+	if idle := time.Since(c.last); idle < c.timeout {
+		c.timer.Reset(c.timeout - idle)
+		c.mu.Unlock()
+		return
+	}
+	c.done = true
+	c.mu.Unlock()
+
+	if c.shutdown != nil {
+		ctx, cancel := context.WithTimeout(c.ctx, idleShutdownTimeout)
+		_ = c.shutdown(ctx, c.conn)
+		cancel()
+	}
+	_ = c.conn.Close()
+}
+
+type idleFramer struct {
+	framer jsonrpc2.Framer
+	conn   *idleConn
+}
+
+func (f idleFramer) Reader(r io.Reader) jsonrpc2.Reader {
+	return idleReader{f.framer.Reader(r), f.conn}
+}
+
+func (f idleFramer) Writer(w io.Writer) jsonrpc2.Writer {
+	return idleWriter{f.framer.Writer(w), f.conn}
+}
+
+func (f idleFramer) wrapped() jsonrpc2.Framer { return f.framer }
+
+func (f idleFramer) rewrap(framer jsonrpc2.Framer) jsonrpc2.Framer {
+	f.framer = framer
+	return f
+}
+
+type idleReader struct {
+	reader jsonrpc2.Reader
+	conn   *idleConn
+}
+
+func (r idleReader) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	msg, n, err := r.reader.Read(ctx)
+	if err != nil {
+		r.conn.stop()
+	} else {
+		r.conn.observe(msg)
+	}
+	return msg, n, err
+}
+
+type idleWriter struct {
+	writer jsonrpc2.Writer
+	conn   *idleConn
+}
+
+func (w idleWriter) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	n, err := w.writer.Write(ctx, msg)
+	if err == nil {
+		w.conn.observe(msg)
+	}
+	return n, err
+}
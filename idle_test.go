@@ -0,0 +1,128 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// methodRecorder is a handler that records the methods it receives, and
+// replies to calls after the given delay.
+type methodRecorder struct {
+	delay   time.Duration
+	mu      sync.Mutex
+	methods []string
+}
+
+func (r *methodRecorder) Handle(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+	r.mu.Lock()
+	r.methods = append(r.methods, req.Method)
+	r.mu.Unlock()
+	if !req.IsCall() {
+		return nil, nil
+	}
+	time.Sleep(r.delay)
+	return json.RawMessage("null"), nil
+}
+
+func (r *methodRecorder) Methods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.methods)
+}
+
+// closedWithin reports whether conn is closed within d.
+func closedWithin(conn *jsonrpc2.Connection, d time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		conn.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+func TestWithIdleTimeout(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	ctx := context.Background()
+
+	dial := func(t *testing.T, rwc net.Conn, b jsonrpc2.Binder) *jsonrpc2.Connection {
+		conn, err := jsonrpc2.Dial(ctx, pipeDialer{rwc}, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	logIdle := func(ctx context.Context, conn *jsonrpc2.Connection) error {
+		return conn.Notify(ctx, "window/logMessage", &lsp.LogMessageParams{Type: lsp.Info, Message: "idle"})
+	}
+
+	t.Run("Server", func(t *testing.T) {
+		cc, sc := net.Pipe()
+		client := &methodRecorder{}
+		dial(t, cc, jsonrpc2.ConnectionOptions{Handler: client})
+		server := dial(t, sc, lsp.WithIdleTimeout(jsonrpc2.ConnectionOptions{Handler: &methodRecorder{}}, timeout, logIdle))
+		if !closedWithin(server, 20*timeout) {
+			t.Fatalf("Expected the idle connection to be closed")
+		}
+		if got := client.Methods(); !slices.Equal(got, []string{"window/logMessage"}) {
+			t.Errorf("Expected [window/logMessage], got %v", got)
+		}
+	})
+
+	t.Run("Client", func(t *testing.T) {
+		cc, sc := net.Pipe()
+		server := &methodRecorder{}
+		client := dial(t, cc, lsp.WithIdleTimeout(jsonrpc2.ConnectionOptions{Handler: &methodRecorder{}}, timeout, lsp.ShutdownServer))
+		dial(t, sc, jsonrpc2.ConnectionOptions{Handler: server})
+		if !closedWithin(client, 20*timeout) {
+			t.Fatalf("Expected the idle connection to be closed")
+		}
+		if got := server.Methods(); !slices.Equal(got, []string{"shutdown", "exit"}) {
+			t.Errorf("Expected [shutdown exit], got %v", got)
+		}
+	})
+
+	t.Run("Traffic", func(t *testing.T) {
+		cc, sc := net.Pipe()
+		client := dial(t, cc, jsonrpc2.ConnectionOptions{Handler: &methodRecorder{}})
+		server := dial(t, sc, lsp.WithIdleTimeout(jsonrpc2.ConnectionOptions{Handler: &methodRecorder{}}, timeout, nil))
+		for range 10 {
+			if err := client.Notify(ctx, "$/ping", nil); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(timeout / 5)
+		}
+		if closedWithin(server, 0) {
+			t.Fatalf("Expected a connection with traffic to stay open")
+		}
+	})
+
+	t.Run("OutstandingCall", func(t *testing.T) {
+		cc, sc := net.Pipe()
+		client := dial(t, cc, jsonrpc2.ConnectionOptions{Handler: &methodRecorder{}})
+		server := dial(t, sc, lsp.WithIdleTimeout(jsonrpc2.ConnectionOptions{Handler: &methodRecorder{delay: 4 * timeout}}, timeout, nil))
+		if err := client.Call(ctx, "slow", nil).Await(ctx, nil); err != nil {
+			t.Fatalf("Expected the slow call to succeed, got %v", err)
+		}
+		if !closedWithin(server, 20*timeout) {
+			t.Fatalf("Expected the idle connection to be closed after the call")
+		}
+	})
+}
@@ -0,0 +1,326 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// An Indexer indexes the files of the workspace folders in the
+// background, as servers that answer workspace/symbol requests from an
+// index do, reporting its progress to the client:
+//
+//	indexer := lsp.NewIndexer(client, func(ctx context.Context, uri lsp.DocumentURI, content []byte) error {
+//		return index.Add(uri, content)
+//	})
+//	indexer.Initialize(params)   // on initialize
+//	indexer.Start(ctx)           // on initialized
+//	indexer.Update(params.Event) // on workspace/didChangeWorkspaceFolders
+//	defer indexer.Close()
+//
+// The folders are indexed one at a time, in the background, each with a
+// work-done progress that the server creates if the client supports it.
+// The indexing of a folder that is removed from the workspace is
+// cancelled, and a folder that is added is queued.
+//
+// The settings of an Indexer must not be changed after Start.
+type Indexer struct {
+	// FS returns the file system of a folder, in which the indexer
+	// walks its files; for example, one that overlays the documents
+	// open in the editor on the files on disk. If nil, it is the
+	// directory of the folder.
+	FS func(folder DocumentURI) (fs.FS, error)
+	// Skip reports whether a file or directory, with a slash-separated
+	// path relative to its folder, is not indexed. If nil, directories
+	// whose name starts with a dot, such as .git, are skipped.
+	Skip func(path string, d fs.DirEntry) bool
+	// MaxLoad is the fraction of the time spent indexing, between 0 and
+	// 1, so that indexing leaves the CPU to the requests of the user.
+	// The indexer pauses after each file for as long as needed. If zero,
+	// it does not pause.
+	MaxLoad float64
+	// Title is the title of the progress of indexing a folder, followed
+	// by the name of the folder. If empty, it is "Indexing".
+	Title string
+
+	client Client
+	index  func(ctx context.Context, uri DocumentURI, content []byte) error
+
+	mu            sync.Mutex
+	progress      bool          // the client supports server-initiated progress
+	folders       []DocumentURI // of the workspace
+	ctx           context.Context
+	stop          context.CancelFunc
+	queue         []DocumentURI // folders waiting to be indexed
+	current       DocumentURI   // folder being indexed, if any
+	cancelCurrent context.CancelFunc
+	done          chan struct{} // closed when the queue is empty, if running
+}
+
+// indexTokens numbers the progress tokens of indexers.
+var indexTokens atomic.Int64
+
+// NewIndexer returns an Indexer that calls index with the URI and the
+// content of each file of the workspace folders. The context passed to
+// index is cancelled when the indexing of the folder of the file is. An
+// error of index does not stop the indexing of the other files; the
+// number of files that failed is reported at the end of the progress.
+// The client receives the progress reports.
+func NewIndexer(client Client, index func(ctx context.Context, uri DocumentURI, content []byte) error) *Indexer {
+	return &Indexer{client: client, index: index}
+}
+
+// Initialize records the workspace folders, and whether the client
+// supports the progress reports of the server, from the params of the
+// initialize request. It reports an error, for the folders that are
+// not valid.
+func (x *Indexer) Initialize(params *ParamInitialize) error {
+	folders, err := WorkspaceRoots(params)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.progress = params.Capabilities.Window.WorkDoneProgress
+	x.folders = x.folders[:0]
+	for _, f := range folders {
+		if uri, err := workspaceRoot(f.URI); err == nil && !slices.Contains(x.folders, uri) {
+			x.folders = append(x.folders, uri)
+		}
+	}
+	return err
+}
+
+// Start starts indexing the workspace folders, in the background, with
+// the values of ctx but not its cancellation, as the context of a
+// notification ends when it is handled. Indexing stops on Close.
+func (x *Indexer) Start(ctx context.Context) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.ctx != nil {
+		return
+	}
+	x.ctx, x.stop = context.WithCancel(detach(ctx))
+	for _, folder := range x.folders {
+		x.enqueue(folder)
+	}
+}
+
+// Update applies a change of the workspace folders: it cancels the
+// indexing of the folders that are removed, and queues those that are
+// added, once started.
+func (x *Indexer) Update(change WorkspaceFoldersChangeEvent) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, f := range change.Removed {
+		uri, err := workspaceRoot(f.URI)
+		if err != nil {
+			continue
+		}
+		x.folders = slices.DeleteFunc(x.folders, func(folder DocumentURI) bool { return folder == uri })
+		x.queue = slices.DeleteFunc(x.queue, func(folder DocumentURI) bool { return folder == uri })
+		if x.current == uri {
+			x.cancelCurrent()
+		}
+	}
+	for _, f := range change.Added {
+		uri, err := workspaceRoot(f.URI)
+		if err != nil || slices.Contains(x.folders, uri) {
+			continue
+		}
+		x.folders = append(x.folders, uri)
+		if x.ctx != nil {
+			x.enqueue(uri)
+		}
+	}
+}
+
+// Wait waits until the queued folders are indexed.
+func (x *Indexer) Wait() {
+	x.mu.Lock()
+	done := x.done
+	x.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// Close stops indexing, and waits for the indexing of the current
+// folder to end.
+func (x *Indexer) Close() error {
+	x.mu.Lock()
+	if x.stop != nil {
+		x.stop()
+	}
+	x.queue = nil
+	x.mu.Unlock()
+	x.Wait()
+	return nil
+}
+
+// enqueue queues a folder, and starts the goroutine indexing the queue
+// if it is not running. x.mu must be held.
+func (x *Indexer) enqueue(folder DocumentURI) {
+	if slices.Contains(x.queue, folder) || x.ctx.Err() != nil {
+		return
+	}
+	x.queue = append(x.queue, folder)
+	if x.done == nil {
+		x.done = make(chan struct{})
+		go x.run(x.ctx, x.done)
+	}
+}
+
+// run indexes the queued folders, until the queue is empty or ctx is
+// done, and then closes done.
+func (x *Indexer) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		x.mu.Lock()
+		if len(x.queue) == 0 || ctx.Err() != nil {
+			x.queue = nil
+			x.current, x.cancelCurrent = "", nil
+			x.done = nil
+			x.mu.Unlock()
+			return
+		}
+		folder := x.queue[0]
+		x.queue = x.queue[1:]
+		folderCtx, cancel := context.WithCancel(ctx)
+		x.current, x.cancelCurrent = folder, cancel
+		progress := x.progress
+		x.mu.Unlock()
+
+		x.indexFolder(folderCtx, folder, progress)
+		cancel()
+	}
+}
+
+// indexFolder indexes the files of a folder, reporting its progress if
+// the client supports it.
+func (x *Indexer) indexFolder(ctx context.Context, folder DocumentURI, progress bool) {
+	var token ProgressToken
+	if progress && x.client != nil {
+		token = fmt.Sprintf("lsp-indexer-%d", indexTokens.Add(1))
+		if err := x.client.WorkDoneProgressCreate(ctx, &WorkDoneProgressCreateParams{Token: token}); err != nil {
+			token = nil
+		}
+	}
+	report := func(value any) {
+		if token != nil {
+			_ = x.client.Progress(detach(ctx), &ProgressParams{Token: token, Value: value})
+		}
+	}
+	title := x.Title
+	if title == "" {
+		title = "Indexing"
+	}
+	report(&WorkDoneProgressBegin{Kind: "begin", Title: title + " " + folder.Base()})
+
+	fsys, err := x.folderFS(folder)
+	var paths []string
+	if err == nil {
+		paths, err = x.walk(ctx, fsys)
+	}
+	indexed, failed := 0, 0
+	lastPercentage := uint32(0)
+loop:
+	for i, path := range paths {
+		if ctx.Err() != nil {
+			break
+		}
+		start := time.Now()
+		uri := URIFromPath(filepath.Join(folder.Path(), filepath.FromSlash(path)))
+		content, err := fs.ReadFile(fsys, path)
+		if err == nil {
+			err = x.index(ctx, uri, content)
+		}
+		switch {
+		case ctx.Err() != nil:
+			break loop
+		case err != nil:
+			failed++
+		default:
+			indexed++
+		}
+		if pct := uint32((i + 1) * 100 / len(paths)); pct != lastPercentage {
+			lastPercentage = pct
+			report(&WorkDoneProgressReport{Kind: "report", Message: fmt.Sprintf("%d/%d files", i+1, len(paths)), Percentage: &pct})
+		}
+		x.pause(ctx, time.Since(start))
+	}
+
+	end := &WorkDoneProgressEnd{Kind: "end"}
+	switch {
+	case ctx.Err() != nil:
+		end.Message = "Cancelled"
+	case err != nil:
+		end.Message = err.Error()
+	case failed > 0:
+		end.Message = fmt.Sprintf("Indexed %d files, %d failed", indexed, failed)
+	default:
+		end.Message = fmt.Sprintf("Indexed %d files", indexed)
+	}
+	report(end)
+}
+
+// walk returns the paths of the files of the file system of a folder
+// to index.
+func (x *Indexer) walk(ctx context.Context, fsys fs.FS) ([]string, error) {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err != nil {
+			return nil // skip what cannot be read
+		}
+		if path != "." && x.skip(path, d) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+func (x *Indexer) folderFS(folder DocumentURI) (fs.FS, error) {
+	if x.FS == nil {
+		return os.DirFS(folder.Path()), nil
+	}
+	return x.FS(folder)
+}
+
+func (x *Indexer) skip(path string, d fs.DirEntry) bool {
+	if x.Skip != nil {
+		return x.Skip(path, d)
+	}
+	return d.IsDir() && strings.HasPrefix(d.Name(), ".")
+}
+
+// pause pauses after indexing a file for the given time, as needed to
+// keep the load within MaxLoad, unless ctx is done.
+func (x *Indexer) pause(ctx context.Context, elapsed time.Duration) {
+	if x.MaxLoad <= 0 || x.MaxLoad >= 1 {
+		return
+	}
+	t := time.NewTimer(time.Duration(float64(elapsed) * (1 - x.MaxLoad) / x.MaxLoad))
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// progressRecorder records the progress reported to it.
+type progressRecorder struct {
+	lsp.Client
+	mu      sync.Mutex
+	created int
+	reports []string
+}
+
+func (c *progressRecorder) WorkDoneProgressCreate(context.Context, *lsp.WorkDoneProgressCreateParams) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.created++
+	return nil
+}
+
+func (c *progressRecorder) Progress(_ context.Context, params *lsp.ProgressParams) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch v := params.Value.(type) {
+	case *lsp.WorkDoneProgressBegin:
+		c.reports = append(c.reports, "begin "+v.Title)
+	case *lsp.WorkDoneProgressReport:
+		c.reports = append(c.reports, fmt.Sprintf("report %s %d%%", v.Message, *v.Percentage))
+	case *lsp.WorkDoneProgressEnd:
+		c.reports = append(c.reports, "end "+v.Message)
+	}
+	return nil
+}
+
+func TestIndexer(t *testing.T) {
+	ctx := context.Background()
+	folders := map[lsp.DocumentURI]fstest.MapFS{
+		"file:///a": {
+			"a.go":        {Data: []byte("package a")},
+			"sub/b.go":    {Data: []byte("package sub")},
+			".git/config": {Data: []byte("[core]")},
+		},
+		"file:///b": {
+			"block.go": {Data: []byte("block")},
+		},
+	}
+	client := &progressRecorder{}
+	var (
+		mu      sync.Mutex
+		indexed []lsp.DocumentURI
+	)
+	blocked := make(chan struct{})
+	indexer := lsp.NewIndexer(client, func(ctx context.Context, uri lsp.DocumentURI, content []byte) error {
+		if string(content) == "block" {
+			close(blocked)
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		indexed = append(indexed, uri)
+		return nil
+	})
+	indexer.FS = func(folder lsp.DocumentURI) (fs.FS, error) { return folders[folder], nil }
+	defer indexer.Close()
+
+	params := &lsp.ParamInitialize{}
+	params.Capabilities.Window.WorkDoneProgress = true
+	params.WorkspaceFolders = []lsp.WorkspaceFolder{{URI: "file:///a", Name: "a"}}
+	if err := indexer.Initialize(params); err != nil {
+		t.Fatal(err)
+	}
+	indexer.Start(ctx)
+	indexer.Wait()
+	slices.Sort(indexed)
+	if diff := cmp.Diff([]lsp.DocumentURI{"file:///a/a.go", "file:///a/sub/b.go"}, indexed); diff != "" {
+		t.Errorf("Unexpected indexed files (-want +got):\n%s", diff)
+	}
+	want := []string{"begin Indexing a", "report 1/2 files 50%", "report 2/2 files 100%", "end Indexed 2 files"}
+	if diff := cmp.Diff(want, client.reports); diff != "" || client.created != 1 {
+		t.Errorf("Unexpected progress, created %d times (-want +got):\n%s", client.created, diff)
+	}
+
+	// Removing a folder cancels its indexing.
+	client.reports = nil
+	indexer.Update(lsp.WorkspaceFoldersChangeEvent{Added: []lsp.WorkspaceFolder{{URI: "file:///b"}}})
+	<-blocked
+	indexer.Update(lsp.WorkspaceFoldersChangeEvent{Removed: []lsp.WorkspaceFolder{{URI: "file:///b"}}})
+	indexer.Wait()
+	want = []string{"begin Indexing b", "end Cancelled"}
+	if diff := cmp.Diff(want, client.reports); diff != "" {
+		t.Errorf("Unexpected progress (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// An InlineCompletionProvider provides the inline completions of
+// textDocument/inlineCompletion requests, such as the suggestions of a
+// language model.
+type InlineCompletionProvider interface {
+	// ProvideInlineCompletions passes to yield the completions of req,
+	// as it produces them, until yield returns false. It returns when
+	// it has no more completions, or when ctx is done, as when the
+	// client cancels the request.
+	ProvideInlineCompletions(ctx context.Context, req *InlineCompletionRequest, yield func(InlineCompletionItem) bool) error
+}
+
+// An InlineCompletionRequest is a request for inline completions, with
+// the text around the position to complete.
+type InlineCompletionRequest struct {
+	Params *InlineCompletionParams
+	// Prefix and Suffix are the text of the document before and after
+	// the position, at most ContextSize bytes of each.
+	Prefix, Suffix string
+}
+
+// InlineCompletions answers textDocument/inlineCompletion requests
+// with the completions of a Provider. It collects them as the Provider
+// produces them, up to MaxItems, and answers with those produced before
+// Timeout, so that a slow backend cannot hold up the editor:
+//
+//	ic := &lsp.InlineCompletions{
+//		Provider:  model,
+//		Documents: docs.Mapper,
+//		Timeout:   500 * time.Millisecond,
+//	}
+//	handler := ic.Handler(lsp.ServerHandler(server))
+//
+// A server may instead call InlineCompletion from its own method of the
+// same name.
+type InlineCompletions struct {
+	// Provider provides the completions.
+	Provider InlineCompletionProvider
+	// Documents returns the current content of an open document.
+	Documents func(DocumentURI) (*Mapper, bool)
+	// ContextSize is the maximum length, in bytes, of the prefix and
+	// suffix of a request, or unlimited if zero.
+	ContextSize int
+	// MaxItems is the maximum number of completions, or unlimited if
+	// zero.
+	MaxItems int
+	// Timeout is the time after which the completions produced so far
+	// are returned, or unlimited if zero.
+	Timeout time.Duration
+}
+
+// InlineCompletion returns the inline completions of the provider. A
+// request about a document that is not open has none.
+func (c *InlineCompletions) InlineCompletion(ctx context.Context, params *InlineCompletionParams) (*ResultTextDocumentInlineCompletion, error) {
+	list := &InlineCompletionList{Items: []InlineCompletionItem{}}
+	result := &ResultTextDocumentInlineCompletion{InlineCompletionList: list}
+	m, ok := c.Documents(params.TextDocument.URI)
+	if !ok {
+		return result, nil
+	}
+	offset, err := m.PositionOffset(params.Position)
+	if err != nil {
+		return nil, err
+	}
+	start, end := 0, len(m.Content)
+	if c.ContextSize > 0 {
+		start, end = max(start, offset-c.ContextSize), min(end, offset+c.ContextSize)
+		for start < offset && !utf8.RuneStart(m.Content[start]) {
+			start++
+		}
+		for end > offset && end < len(m.Content) && !utf8.RuneStart(m.Content[end]) {
+			end--
+		}
+	}
+	req := &InlineCompletionRequest{
+		Params: params,
+		Prefix: string(m.Content[start:offset]),
+		Suffix: string(m.Content[offset:end]),
+	}
+
+	pctx, cancel := ctx, context.CancelFunc(func() {})
+	if c.Timeout > 0 {
+		pctx, cancel = context.WithTimeout(ctx, c.Timeout)
+	}
+	defer cancel()
+	var (
+		mu   sync.Mutex
+		done bool // once the provider returned or yield returned false
+	)
+	yield := func(item InlineCompletionItem) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if done || pctx.Err() != nil {
+			return false
+		}
+		list.Items = append(list.Items, item)
+		done = c.MaxItems > 0 && len(list.Items) >= c.MaxItems
+		return !done
+	}
+	err = c.Provider.ProvideInlineCompletions(pctx, req, yield)
+	mu.Lock()
+	defer mu.Unlock()
+	done = true
+	switch {
+	case ctx.Err() != nil:
+		return nil, ErrRequestCancelled
+	case err != nil && !(pctx.Err() != nil && errors.Is(err, pctx.Err())):
+		return nil, err
+	}
+	return result, nil
+}
+
+// Handler returns a handler that answers textDocument/inlineCompletion
+// requests with InlineCompletion, and passes other messages to handler.
+func (c *InlineCompletions) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if req.Method != "textDocument/inlineCompletion" {
+			return handler.Handle(ctx, req)
+		}
+		var params InlineCompletionParams
+		if err := UnmarshalJSON(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return c.InlineCompletion(ctx, &params)
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// A providerFunc is an InlineCompletionProvider that calls itself.
+type providerFunc func(ctx context.Context, req *lsp.InlineCompletionRequest, yield func(lsp.InlineCompletionItem) bool) error
+
+func (f providerFunc) ProvideInlineCompletions(ctx context.Context, req *lsp.InlineCompletionRequest, yield func(lsp.InlineCompletionItem) bool) error {
+	return f(ctx, req, yield)
+}
+
+func inlineItem(text string) lsp.InlineCompletionItem {
+	return lsp.InlineCompletionItem{InsertText: lsp.InlineCompletionItemInsertText{String: &text}}
+}
+
+func TestInlineCompletions(t *testing.T) {
+	const uri = lsp.DocumentURI("file:///a.go")
+	m := lsp.NewMapper(uri, []byte("package a\n\nfunc é() {\n}\n"))
+	documents := func(u lsp.DocumentURI) (*lsp.Mapper, bool) { return m, u == uri }
+	params := &lsp.InlineCompletionParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+			Position:     lsp.Position{Line: 2, Character: 7}, // after "é("
+		},
+	}
+	texts := func(result *lsp.ResultTextDocumentInlineCompletion) []string {
+		texts := []string{}
+		for _, item := range result.InlineCompletionList.Items {
+			texts = append(texts, *item.InsertText.String)
+		}
+		return texts
+	}
+	complete := func(ctx context.Context, c *lsp.InlineCompletions) ([]string, error) {
+		result, err := c.InlineCompletion(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		return texts(result), nil
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		var got *lsp.InlineCompletionRequest
+		c := &lsp.InlineCompletions{
+			Provider: providerFunc(func(_ context.Context, req *lsp.InlineCompletionRequest, _ func(lsp.InlineCompletionItem) bool) error {
+				got = req
+				return nil
+			}),
+			Documents:   documents,
+			ContextSize: 2,
+		}
+		if _, err := complete(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+		// The prefix does not start within "é".
+		if got.Prefix != "(" || got.Suffix != ") " {
+			t.Errorf("Expected prefix %q and suffix %q, got %q and %q", "(", ") ", got.Prefix, got.Suffix)
+		}
+		if got.Params != params {
+			t.Errorf("Expected the params of the request")
+		}
+	})
+
+	t.Run("MaxItems", func(t *testing.T) {
+		c := &lsp.InlineCompletions{
+			Provider: providerFunc(func(_ context.Context, _ *lsp.InlineCompletionRequest, yield func(lsp.InlineCompletionItem) bool) error {
+				for _, text := range []string{"a", "b", "c"} {
+					if !yield(inlineItem(text)) {
+						break
+					}
+				}
+				return nil
+			}),
+			Documents: documents,
+			MaxItems:  2,
+		}
+		got, err := complete(context.Background(), c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"a", "b"}, got); diff != "" {
+			t.Errorf("Unexpected items (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		c := &lsp.InlineCompletions{
+			Provider: providerFunc(func(ctx context.Context, _ *lsp.InlineCompletionRequest, yield func(lsp.InlineCompletionItem) bool) error {
+				yield(inlineItem("fast"))
+				<-ctx.Done()
+				yield(inlineItem("slow"))
+				return ctx.Err()
+			}),
+			Documents: documents,
+			Timeout:   10 * time.Millisecond,
+		}
+		got, err := complete(context.Background(), c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"fast"}, got); diff != "" {
+			t.Errorf("Unexpected items (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		c := &lsp.InlineCompletions{
+			Provider: providerFunc(func(ctx context.Context, _ *lsp.InlineCompletionRequest, yield func(lsp.InlineCompletionItem) bool) error {
+				yield(inlineItem("a"))
+				cancel()
+				return ctx.Err()
+			}),
+			Documents: documents,
+		}
+		if _, err := complete(ctx, c); !errors.Is(err, lsp.ErrRequestCancelled) {
+			t.Errorf("Expected ErrRequestCancelled, got %v", err)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		want := errors.New("backend unavailable")
+		c := &lsp.InlineCompletions{
+			Provider: providerFunc(func(context.Context, *lsp.InlineCompletionRequest, func(lsp.InlineCompletionItem) bool) error {
+				return want
+			}),
+			Documents: documents,
+		}
+		if _, err := complete(context.Background(), c); err != want {
+			t.Errorf("Expected %v, got %v", want, err)
+		}
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		c := &lsp.InlineCompletions{
+			Provider: providerFunc(func(_ context.Context, req *lsp.InlineCompletionRequest, yield func(lsp.InlineCompletionItem) bool) error {
+				yield(inlineItem(req.Prefix[len(req.Prefix)-3:]))
+				return nil
+			}),
+			Documents: documents,
+		}
+		client, _ := connectPair(t, nil, c.Handler(lsp.ServerHandler(hoverServer{})))
+		var result lsp.ResultTextDocumentInlineCompletion
+		if err := client.Call(context.Background(), "textDocument/inlineCompletion", params).Await(context.Background(), &result); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"é("}, texts(&result)); diff != "" {
+			t.Errorf("Unexpected items (-want +got):\n%s", diff)
+		}
+		// Other requests reach the server.
+		var hover lsp.Hover
+		if err := client.Call(context.Background(), "textDocument/hover", params.TextDocumentPositionParams).Await(context.Background(), &hover); err != nil {
+			t.Fatal(err)
+		}
+		if hover.Contents.Value != string(uri) {
+			t.Errorf("Expected hover %q, got %q", uri, hover.Contents.Value)
+		}
+	})
+
+	t.Run("NotOpen", func(t *testing.T) {
+		c := &lsp.InlineCompletions{Documents: documents}
+		p := *params
+		p.TextDocument.URI = "file:///b.go"
+		result, err := c.InlineCompletion(context.Background(), &p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := len(result.InlineCompletionList.Items); n != 0 {
+			t.Errorf("Expected no items, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PackCompletionList factors the properties that the items of list have
+// in common out into the itemDefaults of the list, for the defaults that
+// the client supports according to caps (completionList.itemDefaults),
+// which shrinks large lists considerably. It is the converse of the
+// application of defaults by [Downlevel].
+//
+// A property is factored out only if every item has it, since items
+// without it would otherwise inherit the default. Its most common value
+// becomes the default, and is removed from the items that have it;
+// the other items keep their own. An edit range is factored out of the
+// text edits of the items, whose new text becomes their textEditText
+// unless it is their label. Properties that list.applyKind merges, and
+// defaults the list already has, are left alone.
+func PackCompletionList(list *CompletionList, caps *CompletionClientCapabilities) {
+	if caps.CompletionList == nil || len(list.Items) < 2 {
+		return
+	}
+	supported := func(name string) bool {
+		return slices.Contains(caps.CompletionList.ItemDefaults, name)
+	}
+	merged := func(kind func(*CompletionItemApplyKinds) *ApplyKind) bool {
+		if list.ApplyKind == nil {
+			return false
+		}
+		k := kind(list.ApplyKind)
+		return k != nil && *k == Merge
+	}
+	d := list.ItemDefaults
+	if d == nil {
+		d = new(CompletionItemDefaults)
+	}
+	items := list.Items
+
+	if d.CommitCharacters == nil && supported("commitCharacters") && !merged(func(k *CompletionItemApplyKinds) *ApplyKind { return k.CommitCharacters }) {
+		if i, ok := mostCommon(items, func(item *CompletionItem) (string, bool) {
+			return strings.Join(item.CommitCharacters, "\x00"), item.CommitCharacters != nil
+		}); ok {
+			d.CommitCharacters = items[i].CommitCharacters
+			for j := range items {
+				if slices.Equal(items[j].CommitCharacters, d.CommitCharacters) {
+					items[j].CommitCharacters = nil
+				}
+			}
+		}
+	}
+	if d.EditRange == nil && supported("editRange") {
+		if i, ok := mostCommon(items, editRangeKey); ok {
+			key, _ := editRangeKey(&items[i])
+			switch e := items[i].TextEdit; {
+			case e.TextEdit != nil:
+				d.EditRange = &CompletionItemDefaultsEditRange{Range: &e.TextEdit.Range}
+			case e.InsertReplaceEdit != nil:
+				d.EditRange = &CompletionItemDefaultsEditRange{EditRangeWithInsertReplace: &EditRangeWithInsertReplace{
+					Insert:  e.InsertReplaceEdit.Insert,
+					Replace: e.InsertReplaceEdit.Replace,
+				}}
+			}
+			for j := range items {
+				item := &items[j]
+				if k, _ := editRangeKey(item); k != key {
+					continue
+				}
+				text := item.TextEdit.newText()
+				item.TextEdit = nil
+				if text != item.Label {
+					item.TextEditText = text
+				}
+			}
+		}
+	}
+	if d.InsertTextFormat == nil && supported("insertTextFormat") {
+		if i, ok := mostCommon(items, func(item *CompletionItem) (InsertTextFormat, bool) {
+			return deref(item.InsertTextFormat), item.InsertTextFormat != nil
+		}); ok {
+			d.InsertTextFormat = items[i].InsertTextFormat
+			for j := range items {
+				if *items[j].InsertTextFormat == *d.InsertTextFormat {
+					items[j].InsertTextFormat = nil
+				}
+			}
+		}
+	}
+	if d.InsertTextMode == nil && supported("insertTextMode") {
+		if i, ok := mostCommon(items, func(item *CompletionItem) (InsertTextMode, bool) {
+			return deref(item.InsertTextMode), item.InsertTextMode != nil
+		}); ok {
+			d.InsertTextMode = items[i].InsertTextMode
+			for j := range items {
+				if *items[j].InsertTextMode == *d.InsertTextMode {
+					items[j].InsertTextMode = nil
+				}
+			}
+		}
+	}
+	if d.Data == nil && supported("data") && !merged(func(k *CompletionItemApplyKinds) *ApplyKind { return k.Data }) {
+		if i, ok := mostCommon(items, dataKey); ok {
+			key, _ := dataKey(&items[i])
+			d.Data = items[i].Data
+			for j := range items {
+				if k, _ := dataKey(&items[j]); k == key {
+					items[j].Data = nil
+				}
+			}
+		}
+	}
+
+	if d.CommitCharacters != nil || d.EditRange != nil || d.InsertTextFormat != nil || d.InsertTextMode != nil || d.Data != nil {
+		list.ItemDefaults = d
+	}
+}
+
+// mostCommon returns the index of an item with the most common key, if
+// every item has a key and at least two items have that one.
+func mostCommon[K comparable](items []CompletionItem, key func(*CompletionItem) (K, bool)) (int, bool) {
+	type entry struct{ first, count int }
+	counts := make(map[K]*entry)
+	best := -1
+	bestCount := 0
+	for i := range items {
+		k, ok := key(&items[i])
+		if !ok {
+			return 0, false
+		}
+		e := counts[k]
+		if e == nil {
+			e = &entry{first: i}
+			counts[k] = e
+		}
+		e.count++
+		if e.count > bestCount {
+			best, bestCount = e.first, e.count
+		}
+	}
+	return best, bestCount >= 2
+}
+
+// editRangeKey returns a key identifying the ranges of the text edit of
+// item, if it has one.
+func editRangeKey(item *CompletionItem) (string, bool) {
+	switch e := item.TextEdit; {
+	case e == nil:
+		return "", false
+	case e.TextEdit != nil:
+		return fmt.Sprint(e.TextEdit.Range), true
+	case e.InsertReplaceEdit != nil:
+		return fmt.Sprint(e.InsertReplaceEdit.Insert, e.InsertReplaceEdit.Replace), true
+	}
+	return "", false
+}
+
+// newText returns the new text of the edit.
+func (e *CompletionItemTextEdit) newText() string {
+	if e.InsertReplaceEdit != nil {
+		return e.InsertReplaceEdit.NewText
+	}
+	return e.TextEdit.NewText
+}
+
+// dataKey returns the JSON encoding of the data of item, if it has data.
+func dataKey(item *CompletionItem) (string, bool) {
+	if item.Data == nil {
+		return "", false
+	}
+	data, err := json.Marshal(item.Data)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// deref returns the value p points to, or the zero value if p is nil.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
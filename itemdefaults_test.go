@@ -0,0 +1,89 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// listServer returns a fixed completion list.
+type listServer struct {
+	modernServer
+	list *lsp.CompletionList
+}
+
+func (s listServer) Completion(context.Context, *lsp.CompletionParams) (*lsp.CompletionList, error) {
+	return s.list, nil
+}
+
+func TestPackCompletionList(t *testing.T) {
+	snippet, plain := lsp.SnippetTextFormat, lsp.PlainTextTextFormat
+	other := lsp.Range{Start: lsp.Position{Line: 2}, End: lsp.Position{Line: 2, Character: 1}}
+	edit := func(r lsp.Range, text string) *lsp.CompletionItemTextEdit {
+		return &lsp.CompletionItemTextEdit{TextEdit: &lsp.TextEdit{Range: r, NewText: text}}
+	}
+	items := func() []lsp.CompletionItem {
+		return []lsp.CompletionItem{
+			{Label: "a", TextEdit: edit(editRange, "a"), InsertTextFormat: &snippet, CommitCharacters: []string{"."}, Data: map[string]any{"pkg": "fmt"}},
+			{Label: "b", TextEdit: edit(editRange, "b()"), InsertTextFormat: &snippet, CommitCharacters: []string{"."}, Data: map[string]any{"pkg": "fmt"}},
+			{Label: "c", TextEdit: edit(other, "c"), InsertTextFormat: &plain, CommitCharacters: []string{"("}},
+		}
+	}
+	caps := &lsp.CompletionClientCapabilities{CompletionList: &lsp.CompletionListCapabilities{
+		ItemDefaults: []string{"commitCharacters", "editRange", "insertTextFormat", "data"},
+	}}
+
+	list := &lsp.CompletionList{Items: items()}
+	lsp.PackCompletionList(list, caps)
+	want := &lsp.CompletionList{
+		ItemDefaults: &lsp.CompletionItemDefaults{
+			CommitCharacters: []string{"."},
+			EditRange:        &lsp.CompletionItemDefaultsEditRange{Range: &editRange},
+			InsertTextFormat: &snippet,
+		},
+		Items: []lsp.CompletionItem{
+			{Label: "a", Data: map[string]any{"pkg": "fmt"}},
+			{Label: "b", TextEditText: "b()", Data: map[string]any{"pkg": "fmt"}},
+			{Label: "c", TextEdit: edit(other, "c"), InsertTextFormat: &plain, CommitCharacters: []string{"("}},
+		},
+	}
+	if diff := cmp.Diff(want, list); diff != "" {
+		t.Errorf("Unexpected packed list (-want +got):\n%s", diff)
+	}
+
+	// A client without support for itemDefaults gets the original items.
+	server := lsp.Downlevel(listServer{list: list})
+	if _, err := server.Initialize(context.Background(), &lsp.ParamInitialize{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := server.Completion(context.Background(), &lsp.CompletionParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&lsp.CompletionList{Items: items()}, got); diff != "" {
+		t.Errorf("Unexpected list after Downlevel (-want +got):\n%s", diff)
+	}
+
+	t.Run("Unsupported", func(t *testing.T) {
+		list := &lsp.CompletionList{Items: items()}
+		lsp.PackCompletionList(list, &lsp.CompletionClientCapabilities{})
+		if diff := cmp.Diff(&lsp.CompletionList{Items: items()}, list); diff != "" {
+			t.Errorf("Expected the list to be unchanged (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		merge := lsp.Merge
+		list := &lsp.CompletionList{Items: items(), ApplyKind: &lsp.CompletionItemApplyKinds{CommitCharacters: &merge}}
+		lsp.PackCompletionList(list, caps)
+		if list.ItemDefaults.CommitCharacters != nil {
+			t.Errorf("Expected merged commit characters to be left alone, got %v", list.ItemDefaults.CommitCharacters)
+		}
+	})
+}
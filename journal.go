@@ -0,0 +1,294 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// DefaultJournalSize is the maximum size of the journal of a document
+// of a ChangeJournal whose MaxSize is zero.
+const DefaultJournalSize = 1 << 20
+
+// A ChangeJournal records the content changes of the open documents
+// of a server to files, one per document, so that the content of a
+// document at any recorded version can be reconstructed with
+// [ReplayJournal], or the lsp-journal command. When a server is
+// suspected of having corrupted the buffer of a client, its journal
+// shows what the server made of each change.
+//
+// A journal is installed by wrapping the handler of a connection:
+//
+//	j := &lsp.ChangeJournal{Dir: filepath.Join(os.TempDir(), "journal")}
+//	handler := j.Handler(lsp.ServerHandler(server))
+//
+// The journal of a document is a file of JSON [JournalEntry] values, one
+// per line. When it would exceed MaxSize, it is compacted to a single
+// entry holding the current content, so that earlier versions are lost.
+type ChangeJournal struct {
+	// Dir is the directory of the journals.
+	Dir string
+	// MaxSize is the maximum size of the journal of a document, or
+	// DefaultJournalSize if it is zero.
+	MaxSize int64
+	// Encoding is the position encoding of the changes, UTF-16 if empty.
+	Encoding PositionEncodingKind
+
+	mu   sync.Mutex
+	docs map[DocumentURI]*journalDoc
+}
+
+// A journalDoc is the journal of an open document.
+type journalDoc struct {
+	file   *os.File
+	size   int64
+	mapper *Mapper // of the current content
+}
+
+// A JournalEntry records a version of a document in its journal.
+type JournalEntry struct {
+	URI     DocumentURI `json:"uri"`
+	Version int32       `json:"version"`
+	// Text is the whole content of the document when it was opened, or
+	// when its journal was compacted.
+	Text *string `json:"text,omitempty"`
+	// Encoding is the position encoding of the changes that follow.
+	Encoding PositionEncodingKind `json:"encoding,omitempty"`
+	// Changes are the content changes that produced the version.
+	Changes []TextDocumentContentChangeEvent `json:"changes,omitempty"`
+	// Closed is set when the document was closed.
+	Closed bool `json:"closed,omitempty"`
+	// Error, if not empty, is why the changes could not be applied,
+	// which left the document unchanged.
+	Error string `json:"error,omitempty"`
+}
+
+// Path returns the path of the journal of the document uri.
+func (j *ChangeJournal) Path(uri DocumentURI) string {
+	sum := sha256.Sum256([]byte(uri))
+	base := strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._-", r)) {
+			return r
+		}
+		return '_'
+	}, path.Base(uriPath(uri)))
+	return filepath.Join(j.Dir, hex.EncodeToString(sum[:8])+"-"+base+".jsonl")
+}
+
+// Open records the opening of a document with the given content.
+func (j *ChangeJournal) Open(uri DocumentURI, version int32, text string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if doc := j.docs[uri]; doc != nil {
+		doc.file.Close()
+	}
+	if err := os.MkdirAll(j.Dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.Path(uri), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if j.docs == nil {
+		j.docs = make(map[DocumentURI]*journalDoc)
+	}
+	doc := &journalDoc{file: f, size: info.Size(), mapper: NewMapperEncoding(uri, []byte(text), j.Encoding)}
+	j.docs[uri] = doc
+	return j.write(doc, JournalEntry{URI: uri, Version: version, Text: &text, Encoding: doc.mapper.Encoding()})
+}
+
+// Change records the content changes that produced the given version of
+// an open document.
+func (j *ChangeJournal) Change(uri DocumentURI, version int32, changes []TextDocumentContentChangeEvent) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	doc := j.docs[uri]
+	if doc == nil {
+		return fmt.Errorf("%s is not open", uri)
+	}
+	m, err := doc.mapper.Apply(changes)
+	if err != nil {
+		// Record the changes anyway, as they are the evidence.
+		j.write(doc, JournalEntry{URI: uri, Version: version, Changes: changes, Error: err.Error()})
+		return err
+	}
+	doc.mapper = m
+	return j.write(doc, JournalEntry{URI: uri, Version: version, Changes: changes})
+}
+
+// Close records the closing of a document.
+func (j *ChangeJournal) Close(uri DocumentURI) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	doc := j.docs[uri]
+	if doc == nil {
+		return nil
+	}
+	delete(j.docs, uri)
+	err := j.write(doc, JournalEntry{URI: uri, Closed: true})
+	if cerr := doc.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// write appends an entry to the journal of doc, compacting it first if
+// it would grow too large.
+func (j *ChangeJournal) write(doc *journalDoc, entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	maxSize := j.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultJournalSize
+	}
+	if doc.size+int64(len(data)) > maxSize && entry.Changes != nil && entry.Error == "" {
+		text := string(doc.mapper.Content)
+		entry.Text, entry.Encoding, entry.Changes = &text, doc.mapper.Encoding(), nil
+		if data, err = json.Marshal(entry); err != nil {
+			return err
+		}
+		data = append(data, '\n')
+		if err := doc.file.Truncate(0); err != nil {
+			return err
+		}
+		doc.size = 0
+	}
+	n, err := doc.file.Write(data)
+	doc.size += int64(n)
+	return err
+}
+
+// Handler returns a handler that records the didOpen, didChange and
+// didClose notifications in the journal before invoking handler. The
+// errors of the journal are reported through the event system, as they
+// must not prevent the server from handling the notifications.
+func (j *ChangeJournal) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		var err error
+		switch req.Method {
+		case "textDocument/didOpen":
+			var params DidOpenTextDocumentParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				err = j.Open(params.TextDocument.URI, params.TextDocument.Version, params.TextDocument.Text)
+			}
+		case "textDocument/didChange":
+			var params DidChangeTextDocumentParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				err = j.Change(params.TextDocument.URI, params.TextDocument.Version, params.ContentChanges)
+			}
+		case "textDocument/didClose":
+			var params DidCloseTextDocumentParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				err = j.Close(params.TextDocument.URI)
+			}
+		}
+		if err != nil {
+			event.Error(ctx, "journal failed", err, RequestLabels(ctx)...)
+		}
+		return handler.Handle(ctx, req)
+	})
+}
+
+// ReadJournal reads the entries of a journal.
+func ReadJournal(r io.Reader) ([]JournalEntry, error) {
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<30)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("entry %d: %v", len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// ReplayJournal returns the content of a document at the given version,
+// reconstructed from the entries of its journal. If the version was
+// recorded more than once, as when the document was opened again, the
+// latest is returned.
+//
+// The entries whose changes failed to apply are skipped, as they left
+// the document unchanged. Changes that fail to apply in the replay only
+// make the versions that follow them unknown, until the document is
+// opened again.
+func ReplayJournal(entries []JournalEntry, version int32) ([]byte, error) {
+	var (
+		m       *Mapper
+		content []byte
+		found   bool
+		failed  error // why the requested version is unknown
+		lost    error // why the content is unknown, if m is nil
+	)
+	for i, entry := range entries {
+		switch {
+		case entry.Closed:
+			m, lost = nil, nil
+			continue
+		case entry.Error != "":
+			if entry.Version == version && !found {
+				failed = fmt.Errorf("entry %d (version %d): changes failed to apply: %s", i+1, entry.Version, entry.Error)
+			}
+			continue
+		case entry.Text != nil:
+			m = NewMapperEncoding(entry.URI, []byte(*entry.Text), entry.Encoding)
+		case m == nil:
+			if entry.Version == version && !found {
+				failed = lost
+				if failed == nil {
+					failed = fmt.Errorf("entry %d (version %d): changes of a document not open", i+1, entry.Version)
+				}
+			}
+			continue
+		default:
+			next, err := m.Apply(entry.Changes)
+			if err != nil {
+				// The content is unknown until the document is opened
+				// again.
+				m, lost = nil, fmt.Errorf("entry %d (version %d): %v", i+1, entry.Version, err)
+				if entry.Version == version && !found {
+					failed = lost
+				}
+				continue
+			}
+			m = next
+		}
+		if entry.Version == version {
+			content, found = m.Content, true
+		}
+	}
+	if !found {
+		if failed != nil {
+			return nil, failed
+		}
+		return nil, fmt.Errorf("version %d not in journal", version)
+	}
+	return content, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestChangeJournal(t *testing.T) {
+	const uri = "file:///src/a.go"
+	insert := func(line, char uint32, text string) []lsp.TextDocumentContentChangeEvent {
+		p := lsp.Position{Line: line, Character: char}
+		return []lsp.TextDocumentContentChangeEvent{{Range: &lsp.Range{Start: p, End: p}, Text: text}}
+	}
+	replay := func(j *lsp.ChangeJournal, version int32) (string, error) {
+		t.Helper()
+		f, err := os.Open(j.Path(uri))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		entries, err := lsp.ReadJournal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := lsp.ReplayJournal(entries, version)
+		return string(content), err
+	}
+
+	j := &lsp.ChangeJournal{Dir: t.TempDir()}
+	if err := j.Open(uri, 1, "package a\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Change(uri, 2, insert(1, 0, "\nvar x = 1\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Change(uri, 3, insert(2, 9, "0")); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Close(uri); err != nil {
+		t.Fatal(err)
+	}
+	for version, want := range map[int32]string{
+		1: "package a\n",
+		2: "package a\n\nvar x = 1\n",
+		3: "package a\n\nvar x = 10\n",
+	} {
+		if got, err := replay(j, version); err != nil || got != want {
+			t.Errorf("Expected version %d to be %q, got %q, %v", version, want, got, err)
+		}
+	}
+	if _, err := replay(j, 4); err == nil {
+		t.Error("Expected an error replaying an unknown version")
+	}
+
+	t.Run("FailedChanges", func(t *testing.T) {
+		j := &lsp.ChangeJournal{Dir: t.TempDir()}
+		if err := j.Open(uri, 1, "a\n"); err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Change(uri, 2, insert(0, 1, "b")); err != nil {
+			t.Fatal(err)
+		}
+		// Out of range, which the journal records but does not apply.
+		if err := j.Change(uri, 3, insert(5, 0, "x")); err == nil {
+			t.Fatal("Expected an error applying changes out of range")
+		}
+		if err := j.Change(uri, 4, insert(0, 0, "c")); err != nil {
+			t.Fatal(err)
+		}
+		for version, want := range map[int32]string{
+			1: "a\n",
+			2: "ab\n",
+			4: "cab\n",
+		} {
+			if got, err := replay(j, version); err != nil || got != want {
+				t.Errorf("Expected version %d to be %q, got %q, %v", version, want, got, err)
+			}
+		}
+		if _, err := replay(j, 3); err == nil || !strings.Contains(err.Error(), "failed to apply") {
+			t.Errorf("Expected the failure of version 3 to be reported, got %v", err)
+		}
+
+		// Changes that fail in the replay only lose the versions that
+		// follow them.
+		text := "a\n"
+		entries := []lsp.JournalEntry{
+			{URI: uri, Version: 1, Text: &text},
+			{URI: uri, Version: 2, Changes: insert(5, 0, "x")},
+			{URI: uri, Version: 3, Changes: insert(0, 0, "c")},
+		}
+		if got, err := lsp.ReplayJournal(entries, 1); err != nil || string(got) != text {
+			t.Errorf("Expected version 1 to be %q, got %q, %v", text, got, err)
+		}
+		if _, err := lsp.ReplayJournal(entries, 3); err == nil || !strings.Contains(err.Error(), "version 2") {
+			t.Errorf("Expected version 3 to be lost to the failure of version 2, got %v", err)
+		}
+	})
+
+	t.Run("Compaction", func(t *testing.T) {
+		j := &lsp.ChangeJournal{Dir: t.TempDir(), MaxSize: 200}
+		if err := j.Open(uri, 1, ""); err != nil {
+			t.Fatal(err)
+		}
+		for v := range int32(20) {
+			if err := j.Change(uri, v+2, insert(0, uint32(v), "x")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		info, err := os.Stat(j.Path(uri))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 200 {
+			t.Errorf("Expected a journal of at most 200 bytes, got %d", info.Size())
+		}
+		if got, err := replay(j, 21); err != nil || got != "xxxxxxxxxxxxxxxxxxxx" {
+			t.Errorf("Expected the last version to be replayed, got %q, %v", got, err)
+		}
+		if _, err := replay(j, 1); err == nil {
+			t.Error("Expected the first version to be compacted")
+		}
+	})
+}
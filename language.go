@@ -0,0 +1,258 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Languages of Go modules, identified as Go editors do, which are not
+// among the predefined language kinds of the specification.
+const (
+	LangGoMod  LanguageKind = "go.mod"
+	LangGoSum  LanguageKind = "go.sum"
+	LangGoWork LanguageKind = "go.work"
+)
+
+// Predefined reports whether k is one of the language kinds predefined
+// by the specification, such as [LangGo]. Clients may identify other
+// languages with other kinds.
+func (k LanguageKind) Predefined() bool {
+	return slices.Contains(predefinedLanguageKinds, k)
+}
+
+// predefinedLanguageKinds are the language kinds of the specification.
+// LangDelphi is LangPascal.
+var predefinedLanguageKinds = []LanguageKind{
+	LangABAP,
+	LangWindowsBat,
+	LangBibTeX,
+	LangClojure,
+	LangCoffeescript,
+	LangC,
+	LangCPP,
+	LangCSharp,
+	LangCSS,
+	LangD,
+	LangDiff,
+	LangDart,
+	LangDockerfile,
+	LangElixir,
+	LangErlang,
+	LangFSharp,
+	LangGitCommit,
+	LangGitRebase,
+	LangGo,
+	LangGroovy,
+	LangHandlebars,
+	LangHaskell,
+	LangHTML,
+	LangIni,
+	LangJava,
+	LangJavaScript,
+	LangJavaScriptReact,
+	LangJSON,
+	LangLaTeX,
+	LangLess,
+	LangLua,
+	LangMakefile,
+	LangMarkdown,
+	LangObjectiveC,
+	LangObjectiveCPP,
+	LangPascal,
+	LangPerl,
+	LangPerl6,
+	LangPHP,
+	LangPlaintext,
+	LangPowershell,
+	LangPug,
+	LangPython,
+	LangR,
+	LangRazor,
+	LangRuby,
+	LangRust,
+	LangSCSS,
+	LangSASS,
+	LangScala,
+	LangShaderLab,
+	LangShellScript,
+	LangSQL,
+	LangSwift,
+	LangTypeScript,
+	LangTypeScriptReact,
+	LangTeX,
+	LangVisualBasic,
+	LangXML,
+	LangXSL,
+	LangYAML,
+}
+
+// LanguageSelector returns a document selector of the documents in the
+// given languages, with the given URI scheme if it is not empty.
+//
+//	selector := lsp.LanguageSelector("file", lsp.LangGo, lsp.LangGoMod)
+func LanguageSelector(scheme string, languages ...LanguageKind) DocumentSelector {
+	selector := make(DocumentSelector, 0, len(languages))
+	for _, lang := range languages {
+		selector = append(selector, DocumentFilter{TextDocumentFilter: &TextDocumentFilter{
+			TextDocumentFilterLanguage: &TextDocumentFilterLanguage{Language: string(lang), Scheme: scheme},
+		}})
+	}
+	return selector
+}
+
+// DetectLanguageID returns the language of a file from its name, as
+// clients identify it in the languageId of a TextDocumentItem, or ""
+// if it is not known. The name may be a path, in the syntax of any
+// operating system, or a URI.
+//
+// Some files are known by their names, such as Makefile or go.mod;
+// others by their extensions, such as ".go", matched regardless of case.
+func DetectLanguageID(name string) LanguageKind {
+	if scheme, _, ok := strings.Cut(name, ":"); ok && len(scheme) > 1 && !strings.ContainsAny(scheme, `/\`) {
+		if u, err := url.Parse(name); err == nil {
+			name = u.Path
+			if u.Opaque != "" {
+				name = u.Opaque
+			}
+		}
+	}
+	base := name[strings.LastIndexAny(name, `/\`)+1:]
+	if lang, ok := languageFileNames[base]; ok {
+		return lang
+	}
+	if strings.HasPrefix(base, "Dockerfile.") || strings.HasSuffix(base, ".Dockerfile") {
+		return LangDockerfile
+	}
+	return languageExtensions[strings.ToLower(path.Ext(base))]
+}
+
+// languageFileNames maps the names of files to their languages.
+var languageFileNames = map[string]LanguageKind{
+	"Dockerfile":      LangDockerfile,
+	"Containerfile":   LangDockerfile,
+	"Makefile":        LangMakefile,
+	"makefile":        LangMakefile,
+	"GNUmakefile":     LangMakefile,
+	"go.mod":          LangGoMod,
+	"go.sum":          LangGoSum,
+	"go.work":         LangGoWork,
+	"Gemfile":         LangRuby,
+	"Rakefile":        LangRuby,
+	"COMMIT_EDITMSG":  LangGitCommit,
+	"MERGE_MSG":       LangGitCommit,
+	"git-rebase-todo": LangGitRebase,
+	".bashrc":         LangShellScript,
+	".bash_profile":   LangShellScript,
+	".profile":        LangShellScript,
+	".zshrc":          LangShellScript,
+	".gitconfig":      LangIni,
+	".editorconfig":   LangIni,
+}
+
+// languageExtensions maps lower-case file extensions to languages.
+var languageExtensions = map[string]LanguageKind{
+	".abap":       LangABAP,
+	".bat":        LangWindowsBat,
+	".cmd":        LangWindowsBat,
+	".bib":        LangBibTeX,
+	".clj":        LangClojure,
+	".cljs":       LangClojure,
+	".cljc":       LangClojure,
+	".edn":        LangClojure,
+	".coffee":     LangCoffeescript,
+	".c":          LangC,
+	".h":          LangC,
+	".cc":         LangCPP,
+	".cpp":        LangCPP,
+	".cxx":        LangCPP,
+	".c++":        LangCPP,
+	".hh":         LangCPP,
+	".hpp":        LangCPP,
+	".hxx":        LangCPP,
+	".cs":         LangCSharp,
+	".css":        LangCSS,
+	".d":          LangD,
+	".pas":        LangPascal,
+	".dpr":        LangPascal,
+	".diff":       LangDiff,
+	".patch":      LangDiff,
+	".dart":       LangDart,
+	".ex":         LangElixir,
+	".exs":        LangElixir,
+	".erl":        LangErlang,
+	".hrl":        LangErlang,
+	".fs":         LangFSharp,
+	".fsi":        LangFSharp,
+	".fsx":        LangFSharp,
+	".go":         LangGo,
+	".groovy":     LangGroovy,
+	".gradle":     LangGroovy,
+	".hbs":        LangHandlebars,
+	".handlebars": LangHandlebars,
+	".hs":         LangHaskell,
+	".lhs":        LangHaskell,
+	".html":       LangHTML,
+	".htm":        LangHTML,
+	".ini":        LangIni,
+	".cfg":        LangIni,
+	".java":       LangJava,
+	".js":         LangJavaScript,
+	".mjs":        LangJavaScript,
+	".cjs":        LangJavaScript,
+	".jsx":        LangJavaScriptReact,
+	".json":       LangJSON,
+	".tex":        LangLaTeX,
+	".ltx":        LangLaTeX,
+	".less":       LangLess,
+	".lua":        LangLua,
+	".mk":         LangMakefile,
+	".md":         LangMarkdown,
+	".markdown":   LangMarkdown,
+	".m":          LangObjectiveC,
+	".mm":         LangObjectiveCPP,
+	".pl":         LangPerl,
+	".pm":         LangPerl,
+	".raku":       LangPerl6,
+	".p6":         LangPerl6,
+	".php":        LangPHP,
+	".txt":        LangPlaintext,
+	".ps1":        LangPowershell,
+	".psm1":       LangPowershell,
+	".pug":        LangPug,
+	".jade":       LangPug,
+	".py":         LangPython,
+	".pyi":        LangPython,
+	".r":          LangR,
+	".cshtml":     LangRazor,
+	".razor":      LangRazor,
+	".rb":         LangRuby,
+	".rs":         LangRust,
+	".scss":       LangSCSS,
+	".sass":       LangSASS,
+	".scala":      LangScala,
+	".sc":         LangScala,
+	".shader":     LangShaderLab,
+	".sh":         LangShellScript,
+	".bash":       LangShellScript,
+	".zsh":        LangShellScript,
+	".sql":        LangSQL,
+	".swift":      LangSwift,
+	".ts":         LangTypeScript,
+	".mts":        LangTypeScript,
+	".cts":        LangTypeScript,
+	".tsx":        LangTypeScriptReact,
+	".vb":         LangVisualBasic,
+	".xml":        LangXML,
+	".xsd":        LangXML,
+	".svg":        LangXML,
+	".xsl":        LangXSL,
+	".xslt":       LangXSL,
+	".yaml":       LangYAML,
+	".yml":        LangYAML,
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestDetectLanguageID(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want lsp.LanguageKind
+	}{
+		{"main.go", lsp.LangGo},
+		{"/src/pkg/MAIN.GO", lsp.LangGo},
+		{`C:\src\app.tsx`, lsp.LangTypeScriptReact},
+		{"file:///home/user/app.py", lsp.LangPython},
+		{"file:///c%3A/My%20Docs/notes.md", lsp.LangMarkdown},
+		{"untitled:Untitled-1", ""},
+		{"/repo/Dockerfile", lsp.LangDockerfile},
+		{"/repo/Dockerfile.dev", lsp.LangDockerfile},
+		{"/repo/Makefile", lsp.LangMakefile},
+		{"/repo/go.mod", lsp.LangGoMod},
+		{"/repo/.git/COMMIT_EDITMSG", lsp.LangGitCommit},
+		{"/home/user/.bashrc", lsp.LangShellScript},
+		{"/repo/LICENSE", ""},
+		{"", ""},
+	} {
+		if got := lsp.DetectLanguageID(test.name); got != test.want {
+			t.Errorf("DetectLanguageID(%q): expected %q, got %q", test.name, test.want, got)
+		}
+	}
+}
+
+func TestLanguageKinds(t *testing.T) {
+	if !lsp.LangGo.Predefined() || !lsp.LangDelphi.Predefined() {
+		t.Error("Expected go and pascal to be predefined")
+	}
+	if lsp.LangGoMod.Predefined() || lsp.LanguageKind("Go").Predefined() {
+		t.Error("Expected go.mod and Go not to be predefined")
+	}
+
+	selector := lsp.LanguageSelector("file", lsp.LangGo, lsp.LangGoMod)
+	for _, test := range []struct {
+		uri  lsp.DocumentURI
+		want bool
+	}{
+		{"file:///a/main.go", true},
+		{"file:///a/go.mod", true},
+		{"file:///a/go.sum", false},
+		{"untitled:main.go", false},
+	} {
+		language := lsp.DetectLanguageID(string(test.uri))
+		if got := lsp.MatchDocumentSelector(selector, test.uri, language); got != test.want {
+			t.Errorf("MatchDocumentSelector(%s, %q): expected %v, got %v", test.uri, language, test.want, got)
+		}
+	}
+}
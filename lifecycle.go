@@ -0,0 +1,204 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sync"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A LifecycleKind is the kind of a [LifecycleEvent].
+type LifecycleKind int
+
+const (
+	// LifecycleConnected: the dispatcher was created on the connection.
+	LifecycleConnected LifecycleKind = iota
+	// LifecycleInitialized: the initialized notification was sent or
+	// received.
+	LifecycleInitialized
+	// LifecycleShutdownRequested: the shutdown request was sent or
+	// received.
+	LifecycleShutdownRequested
+	// LifecycleError: the connection failed, or ended before shutdown
+	// was requested, as when the peer crashed.
+	LifecycleError
+	// LifecycleClosed: the connection ended. It is the last event.
+	LifecycleClosed
+)
+
+func (k LifecycleKind) String() string {
+	switch k {
+	case LifecycleConnected:
+		return "connected"
+	case LifecycleInitialized:
+		return "initialized"
+	case LifecycleShutdownRequested:
+		return "shutdown requested"
+	case LifecycleError:
+		return "error"
+	case LifecycleClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("LifecycleKind(%d)", int(k))
+}
+
+// A LifecycleEvent marks a step in the lifecycle of a connection.
+type LifecycleEvent struct {
+	Kind LifecycleKind
+	Err  error // for LifecycleError
+}
+
+// A Lifecycle publishes the lifecycle events of a connection to its
+// subscribers, so that an application may reflect the state of the
+// connection, for example in a status bar, without wrapping every call
+// site:
+//
+//	lifecycle := &lsp.Lifecycle{}
+//	lifecycle.Subscribe(func(e lsp.LifecycleEvent) {
+//		if e.Kind == lsp.LifecycleError {
+//			status.Set("server crashed, restarting")
+//		}
+//	})
+//	server := lsp.ServerDispatcher(conn, lsp.WithLifecycle(lifecycle))
+//
+// The dispatcher publishes the events of the messages it sends, and of
+// the end of the connection. The messages it receives are observed by
+// [Lifecycle.Handler], which servers need to learn of the shutdown
+// requested by the client. A Lifecycle observes a single connection.
+// Its zero value is ready to use.
+type Lifecycle struct {
+	deliverMu sync.Mutex // held while delivering an event
+
+	mu       sync.Mutex
+	subs     []*lifecycleSub // in order of subscription
+	attached bool
+	shutdown bool // shutdown was requested
+	closing  bool // the dispatcher was closed
+}
+
+type lifecycleSub struct {
+	f func(LifecycleEvent)
+}
+
+// WithLifecycle makes the dispatcher publish the lifecycle events of its
+// connection to l.
+func WithLifecycle(l *Lifecycle) DispatcherOption {
+	return func(c *clientConn) { c.lifecycle = l }
+}
+
+// Subscribe registers f to be called with each subsequent event, until
+// unsubscribe is called. Subscribers are called in the order in which
+// they subscribed. Events are delivered one at a time, in order,
+// on the goroutine that observed them, so f must not block.
+func (l *Lifecycle) Subscribe(f func(LifecycleEvent)) (unsubscribe func()) {
+	sub := &lifecycleSub{f}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.subs = append(l.subs, sub)
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.subs = slices.DeleteFunc(l.subs, func(s *lifecycleSub) bool { return s == sub })
+	}
+}
+
+// publish delivers an event to the subscribers. It does nothing if l is
+// nil.
+func (l *Lifecycle) publish(e LifecycleEvent) {
+	if l == nil {
+		return
+	}
+	l.deliverMu.Lock()
+	defer l.deliverMu.Unlock()
+	l.mu.Lock()
+	subs := slices.Clone(l.subs)
+	l.mu.Unlock()
+	for _, sub := range subs {
+		sub.f(e)
+	}
+}
+
+// attach publishes LifecycleConnected, and the end of conn once it
+// ends. It does nothing if l is nil or already attached.
+func (l *Lifecycle) attach(conn *jsonrpc2.Connection) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	attached := l.attached
+	l.attached = true
+	l.mu.Unlock()
+	if attached {
+		return
+	}
+	l.publish(LifecycleEvent{Kind: LifecycleConnected})
+	go func() {
+		err := conn.Wait()
+		l.mu.Lock()
+		expected := l.shutdown || l.closing
+		l.mu.Unlock()
+		switch {
+		case !isClosing(err):
+			l.publish(LifecycleEvent{Kind: LifecycleError, Err: err})
+		case !expected:
+			if err == nil {
+				err = io.EOF
+			}
+			l.publish(LifecycleEvent{Kind: LifecycleError, Err: fmt.Errorf("connection ended before shutdown: %w", err)})
+		}
+		l.publish(LifecycleEvent{Kind: LifecycleClosed})
+	}()
+}
+
+// isClosing reports whether err is nil, or an error of reading from or
+// writing to a closed stream.
+func isClosing(err error) bool {
+	return err == nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, net.ErrClosed)
+}
+
+// observe publishes the event of a message of the given method, sent or
+// received. It does nothing if l is nil.
+func (l *Lifecycle) observe(method string) {
+	if l == nil {
+		return
+	}
+	switch method {
+	case "initialized":
+		l.publish(LifecycleEvent{Kind: LifecycleInitialized})
+	case "shutdown":
+		l.mu.Lock()
+		l.shutdown = true
+		l.mu.Unlock()
+		l.publish(LifecycleEvent{Kind: LifecycleShutdownRequested})
+	}
+}
+
+// close records that the dispatcher closed the connection. It does
+// nothing if l is nil.
+func (l *Lifecycle) close() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closing = true
+}
+
+// Handler returns a handler that invokes handler, after publishing the
+// lifecycle events of the initialized notification and the shutdown
+// request.
+func (l *Lifecycle) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		l.observe(req.Method)
+		return handler.Handle(ctx, req)
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// recordLifecycle returns a function that waits for the closed event of
+// l, and returns the kinds of the events published until then.
+func recordLifecycle(t *testing.T, l *lsp.Lifecycle) (wait func() []string) {
+	var kinds []string
+	closed := make(chan struct{})
+	l.Subscribe(func(e lsp.LifecycleEvent) {
+		kinds = append(kinds, e.Kind.String())
+		if e.Kind == lsp.LifecycleClosed {
+			close(closed)
+		}
+	})
+	return func() []string {
+		select {
+		case <-closed:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Expected the connection to close, got %v", kinds)
+		}
+		return kinds
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	nop := jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) { return json.RawMessage("null"), nil })
+
+	t.Run("Client", func(t *testing.T) {
+		lifecycle := &lsp.Lifecycle{}
+		wait := recordLifecycle(t, lifecycle)
+		conn, _ := connectPair(t, nil, nop)
+		server := lsp.ServerDispatcher(conn, lsp.WithLifecycle(lifecycle))
+		if err := server.Initialized(ctx, &lsp.InitializedParams{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := server.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		conn.Close()
+		want := []string{"connected", "initialized", "shutdown requested", "closed"}
+		if diff := cmp.Diff(want, wait()); diff != "" {
+			t.Errorf("Unexpected events (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Crash", func(t *testing.T) {
+		lifecycle := &lsp.Lifecycle{}
+		var errs []error
+		lifecycle.Subscribe(func(e lsp.LifecycleEvent) {
+			if e.Kind == lsp.LifecycleError {
+				errs = append(errs, e.Err)
+			}
+		})
+		wait := recordLifecycle(t, lifecycle)
+		conn, serverConn := connectPair(t, nil, nop)
+		server := lsp.ServerDispatcher(conn, lsp.WithLifecycle(lifecycle))
+		if err := server.Initialized(ctx, &lsp.InitializedParams{}); err != nil {
+			t.Fatal(err)
+		}
+		serverConn.Close()
+		want := []string{"connected", "initialized", "error", "closed"}
+		if diff := cmp.Diff(want, wait()); diff != "" {
+			t.Errorf("Unexpected events (-want +got):\n%s", diff)
+		}
+		if len(errs) != 1 || errs[0] == nil {
+			t.Errorf("Expected an error event with an error, got %v", errs)
+		}
+	})
+
+	t.Run("Server", func(t *testing.T) {
+		lifecycle := &lsp.Lifecycle{}
+		wait := recordLifecycle(t, lifecycle)
+		conn, serverConn := connectPair(t, nil, lifecycle.Handler(nop))
+		lsp.ClientDispatcher(serverConn, lsp.WithLifecycle(lifecycle))
+		server := lsp.ServerDispatcher(conn)
+		if err := server.Initialized(ctx, &lsp.InitializedParams{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := server.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		conn.Close()
+		want := []string{"connected", "initialized", "shutdown requested", "closed"}
+		if diff := cmp.Diff(want, wait()); diff != "" {
+			t.Errorf("Unexpected events (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		lifecycle := &lsp.Lifecycle{}
+		called := false
+		unsubscribe := lifecycle.Subscribe(func(lsp.LifecycleEvent) { called = true })
+		unsubscribe()
+		wait := recordLifecycle(t, lifecycle)
+		conn, _ := connectPair(t, nil, nop)
+		lsp.ServerDispatcher(conn, lsp.WithLifecycle(lifecycle))
+		conn.Close()
+		wait()
+		if called {
+			t.Errorf("Expected no event after unsubscribing")
+		}
+	})
+}
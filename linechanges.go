@@ -0,0 +1,80 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// A LineRange is a half-open interval [Start, End) of 0-based line numbers.
+type LineRange struct {
+	Start, End uint32
+}
+
+func (r LineRange) String() string { return fmt.Sprintf("[%d, %d)", r.Start, r.End) }
+
+// ChangedLines reports the lines of a document affected by changes, the
+// content changes of a textDocument/didChange notification, which are
+// applied in order to one version of the document to produce the next.
+//
+// The result is a sorted list of disjoint, non-adjacent line ranges of the
+// new version of the document, outside of which the new version consists
+// of the same lines as the old one (although possibly at different line
+// numbers). A line is affected if any part of it was replaced, so a range
+// covers at least one line even for a deletion.
+//
+// If any change replaces the whole document, ChangedLines reports
+// whole=true and no ranges: every line must be considered changed.
+func ChangedLines(changes []TextDocumentContentChangeEvent) (ranges []LineRange, whole bool) {
+	for _, change := range changes {
+		if change.Range == nil {
+			return nil, true
+		}
+		start, end := change.Range.Start.Line, change.Range.End.Line
+		if end < start {
+			start, end = end, start
+		}
+		added := uint32(strings.Count(change.Text, "\n"))
+		removed := end - start
+		changed := LineRange{Start: start, End: start + added + 1}
+
+		// Merge the previous ranges that overlap or abut the replaced
+		// lines, and shift those that follow them.
+		var result []LineRange
+		for _, r := range ranges {
+			switch {
+			case r.End < start:
+				result = append(result, r)
+			case r.Start > end+1:
+				result = append(result, LineRange{Start: r.Start + added - removed, End: r.End + added - removed})
+			default:
+				changed.Start = min(changed.Start, r.Start)
+				if r.End > end+1 {
+					changed.End = max(changed.End, r.End+added-removed)
+				}
+			}
+		}
+		result = append(result, changed)
+		ranges = mergeLineRanges(result)
+	}
+	return ranges, false
+}
+
+// mergeLineRanges sorts ranges and merges those that overlap or abut.
+func mergeLineRanges(ranges []LineRange) []LineRange {
+	slices.SortFunc(ranges, func(x, y LineRange) int { return cmp.Compare(x.Start, y.Start) })
+	var merged []LineRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
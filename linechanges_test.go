@@ -0,0 +1,61 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestChangedLines(t *testing.T) {
+	edit := func(startLine, endLine uint32, text string) lsp.TextDocumentContentChangeEvent {
+		return lsp.TextDocumentContentChangeEvent{
+			Range: &lsp.Range{Start: lsp.Position{Line: startLine, Character: 1}, End: lsp.Position{Line: endLine, Character: 2}},
+			Text:  text,
+		}
+	}
+	tests := []struct {
+		name    string
+		changes []lsp.TextDocumentContentChangeEvent
+		want    []lsp.LineRange
+		whole   bool
+	}{
+		{"None", nil, nil, false},
+		{"WithinLine", []lsp.TextDocumentContentChangeEvent{edit(3, 3, "x")}, []lsp.LineRange{{3, 4}}, false},
+		{"InsertLines", []lsp.TextDocumentContentChangeEvent{edit(3, 3, "a\nb\n")}, []lsp.LineRange{{3, 6}}, false},
+		{"DeleteLines", []lsp.TextDocumentContentChangeEvent{edit(3, 6, "")}, []lsp.LineRange{{3, 4}}, false},
+		{"Disjoint", []lsp.TextDocumentContentChangeEvent{edit(1, 1, "x"), edit(5, 5, "y")}, []lsp.LineRange{{1, 2}, {5, 6}}, false},
+		{
+			// The second edit is before the first, whose lines move down.
+			"ShiftLater",
+			[]lsp.TextDocumentContentChangeEvent{edit(8, 8, "x"), edit(1, 1, "\n\n")},
+			[]lsp.LineRange{{1, 4}, {10, 11}},
+			false,
+		},
+		{
+			// Joining lines 2-6 brings line 8 to line 4.
+			"ShiftEarlier",
+			[]lsp.TextDocumentContentChangeEvent{edit(8, 8, "x"), edit(2, 6, "")},
+			[]lsp.LineRange{{2, 3}, {4, 5}},
+			false,
+		},
+		{"Overlapping", []lsp.TextDocumentContentChangeEvent{edit(2, 4, "a\nb\nc\nd\n"), edit(5, 7, "")}, []lsp.LineRange{{2, 6}}, false},
+		{"Adjacent", []lsp.TextDocumentContentChangeEvent{edit(2, 2, "x"), edit(3, 3, "y")}, []lsp.LineRange{{2, 4}}, false},
+		{"Whole", []lsp.TextDocumentContentChangeEvent{edit(2, 2, "x"), {Text: "new"}}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, whole := lsp.ChangedLines(test.changes)
+			if whole != test.whole {
+				t.Errorf("Expected whole=%v, got %v", test.whole, whole)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ChangedLines mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+// DiffWorkspaceEdits returns a report of the differences between two
+// workspace edits, one line per difference, or "" if they are
+// equivalent. For example:
+//
+//	a.go: missing edit at 3:1-4: replace with "y"
+//	a.go: extra edit at 5:2: insert "x"
+//	missing change: create b.go
+//
+// Edits are compared semantically, by document and range: it does not
+// matter whether they are expressed as Changes or DocumentChanges, in
+// which order, or as plain, annotated or snippet edits. Document
+// versions and change annotations are ignored. Resource operations are
+// compared in order. Positions are reported as 1-based line and column
+// numbers, and documents by their names in the workspace if they belong
+// to it.
+func DiffWorkspaceEdits(want, got *lsp.WorkspaceEdit) string {
+	wantEdits, wantOps := flattenEdit(want)
+	gotEdits, gotOps := flattenEdit(got)
+
+	var report []string
+	uris := make(map[lsp.DocumentURI]bool)
+	for uri := range wantEdits {
+		uris[uri] = true
+	}
+	for uri := range gotEdits {
+		uris[uri] = true
+	}
+	for _, uri := range slices.Sorted(maps.Keys(uris)) {
+		report = append(report, diffTextEdits(name(uri), wantEdits[uri], gotEdits[uri])...)
+	}
+
+	// Report resource operations missing from or added to an otherwise
+	// common sequence.
+	i, j := 0, 0
+	for i < len(wantOps) || j < len(gotOps) {
+		switch {
+		case i < len(wantOps) && j < len(gotOps) && wantOps[i] == gotOps[j]:
+			i++
+			j++
+		case j < len(gotOps) && !slices.Contains(wantOps[i:], gotOps[j]):
+			report = append(report, "extra change: "+gotOps[j])
+			j++
+		default:
+			report = append(report, "missing change: "+wantOps[i])
+			i++
+		}
+	}
+	return strings.Join(report, "\n")
+}
+
+// CheckWorkspaceEdit reports a test error, with the report of
+// [DiffWorkspaceEdits], if the edits differ.
+func CheckWorkspaceEdit(t testing.TB, want, got *lsp.WorkspaceEdit) {
+	t.Helper()
+	if diff := DiffWorkspaceEdits(want, got); diff != "" {
+		t.Errorf("workspace edit mismatch:\n%s", diff)
+	}
+}
+
+// flattenEdit returns the text edits of a workspace edit by document,
+// and a description of each of its resource operations.
+func flattenEdit(edit *lsp.WorkspaceEdit) (map[lsp.DocumentURI][]lsp.TextEdit, []string) {
+	edits := make(map[lsp.DocumentURI][]lsp.TextEdit)
+	var ops []string
+	if edit == nil {
+		return edits, nil
+	}
+	for uri, es := range edit.Changes {
+		edits[uri] = append(edits[uri], es...)
+	}
+	for _, change := range edit.DocumentChanges {
+		switch {
+		case change.TextDocumentEdit != nil:
+			uri := change.TextDocumentEdit.TextDocument.URI
+			for _, e := range change.TextDocumentEdit.Edits {
+				switch {
+				case e.TextEdit != nil:
+					edits[uri] = append(edits[uri], *e.TextEdit)
+				case e.AnnotatedTextEdit != nil:
+					edits[uri] = append(edits[uri], e.AnnotatedTextEdit.TextEdit)
+				case e.SnippetTextEdit != nil:
+					edits[uri] = append(edits[uri], lsp.TextEdit{Range: e.SnippetTextEdit.Range, NewText: e.SnippetTextEdit.Snippet.Value})
+				}
+			}
+		case change.CreateFile != nil:
+			ops = append(ops, "create "+name(change.CreateFile.URI))
+		case change.RenameFile != nil:
+			ops = append(ops, fmt.Sprintf("rename %s to %s", name(change.RenameFile.OldURI), name(change.RenameFile.NewURI)))
+		case change.DeleteFile != nil:
+			ops = append(ops, "delete "+name(change.DeleteFile.URI))
+		}
+	}
+	return edits, ops
+}
+
+// diffTextEdits reports the differences between two sets of text edits
+// of the named document.
+func diffTextEdits(doc string, want, got []lsp.TextEdit) []string {
+	compare := func(x, y lsp.TextEdit) int {
+		if c := lsp.CompareRange(x.Range, y.Range); c != 0 {
+			return c
+		}
+		return strings.Compare(x.NewText, y.NewText)
+	}
+	want = slices.SortedStableFunc(slices.Values(want), compare)
+	got = slices.SortedStableFunc(slices.Values(got), compare)
+
+	var report []string
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		var c int
+		switch {
+		case i == len(want):
+			c = 1
+		case j == len(got):
+			c = -1
+		default:
+			c = lsp.CompareRange(want[i].Range, got[j].Range)
+		}
+		switch {
+		case c == 0 && want[i].NewText == got[j].NewText:
+		case c == 0:
+			report = append(report, fmt.Sprintf("%s: edit at %s: want %s, got %s",
+				doc, formatRange(want[i].Range), describeEdit(want[i]), describeEdit(got[j])))
+		case c < 0:
+			report = append(report, fmt.Sprintf("%s: missing edit at %s: %s", doc, formatRange(want[i].Range), describeEdit(want[i])))
+			i++
+			continue
+		default:
+			report = append(report, fmt.Sprintf("%s: extra edit at %s: %s", doc, formatRange(got[j].Range), describeEdit(got[j])))
+			j++
+			continue
+		}
+		i++
+		j++
+	}
+	return report
+}
+
+// formatRange formats a range with 1-based line and column numbers.
+func formatRange(r lsp.Range) string {
+	start := fmt.Sprintf("%d:%d", r.Start.Line+1, r.Start.Character+1)
+	switch {
+	case r.Start == r.End:
+		return start
+	case r.Start.Line == r.End.Line:
+		return fmt.Sprintf("%s-%d", start, r.End.Character+1)
+	default:
+		return fmt.Sprintf("%s-%d:%d", start, r.End.Line+1, r.End.Character+1)
+	}
+}
+
+func describeEdit(e lsp.TextEdit) string {
+	switch {
+	case e.Range.Empty():
+		return fmt.Sprintf("insert %q", e.NewText)
+	case e.NewText == "":
+		return "delete"
+	default:
+		return fmt.Sprintf("replace with %q", e.NewText)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+func rng(line, start, end uint32) lsp.Range {
+	return lsp.Range{Start: lsp.Position{Line: line, Character: start}, End: lsp.Position{Line: line, Character: end}}
+}
+
+func TestDiffWorkspaceEdits(t *testing.T) {
+	a, b := lsptest.URI("a.go"), lsptest.URI("b.go")
+	annotation := lsp.ChangeAnnotationIdentifier("x")
+	changes := &lsp.WorkspaceEdit{
+		Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+			a: {
+				{Range: rng(4, 0, 0), NewText: "x"},
+				{Range: rng(0, 0, 3), NewText: "y"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		want, got *lsp.WorkspaceEdit
+		report    string
+	}{
+		{"Equal", changes, changes, ""},
+		{"DocumentChanges", changes, &lsp.WorkspaceEdit{
+			DocumentChanges: []lsp.DocumentChange{{TextDocumentEdit: &lsp.TextDocumentEdit{
+				TextDocument: lsp.OptionalVersionedTextDocumentIdentifier{
+					Version:                1,
+					TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: a},
+				},
+				Edits: []lsp.TextDocumentEditEditsElem{
+					{AnnotatedTextEdit: &lsp.AnnotatedTextEdit{
+						AnnotationID: &annotation,
+						TextEdit:     lsp.TextEdit{Range: rng(0, 0, 3), NewText: "y"},
+					}},
+					{TextEdit: &lsp.TextEdit{Range: rng(4, 0, 0), NewText: "x"}},
+				},
+			}}},
+		}, ""},
+		{"Missing", changes, &lsp.WorkspaceEdit{
+			Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+				a: {{Range: rng(4, 0, 0), NewText: "x"}},
+			},
+		}, `a.go: missing edit at 1:1-4: replace with "y"`},
+		{"Extra", changes, &lsp.WorkspaceEdit{
+			Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+				a: {
+					{Range: rng(0, 0, 3), NewText: "y"},
+					{Range: rng(2, 1, 2)},
+					{Range: rng(4, 0, 0), NewText: "x"},
+				},
+				b: {{Range: rng(0, 0, 0), NewText: "z"}},
+			},
+		}, "a.go: extra edit at 3:2-3: delete\n" +
+			`b.go: extra edit at 1:1: insert "z"`},
+		{"Different", changes, &lsp.WorkspaceEdit{
+			Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+				a: {
+					{Range: rng(0, 0, 3), NewText: "z"},
+					{Range: rng(4, 0, 0), NewText: "x"},
+				},
+			},
+		}, `a.go: edit at 1:1-4: want replace with "y", got replace with "z"`},
+		{"ResourceOperations", &lsp.WorkspaceEdit{
+			DocumentChanges: []lsp.DocumentChange{
+				{CreateFile: &lsp.CreateFile{Kind: "create", URI: b}},
+				{RenameFile: &lsp.RenameFile{Kind: "rename", OldURI: a, NewURI: lsptest.URI("c.go")}},
+			},
+		}, &lsp.WorkspaceEdit{
+			DocumentChanges: []lsp.DocumentChange{
+				{RenameFile: &lsp.RenameFile{Kind: "rename", OldURI: a, NewURI: lsptest.URI("c.go")}},
+				{DeleteFile: &lsp.DeleteFile{Kind: "delete", URI: b}},
+			},
+		}, "missing change: create b.go\nextra change: delete b.go"},
+		{"Nil", nil, changes, `a.go: extra edit at 1:1-4: replace with "y"` + "\n" +
+			`a.go: extra edit at 5:1: insert "x"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := lsptest.DiffWorkspaceEdits(test.want, test.got); got != test.report {
+				t.Errorf("Expected report:\n%s\ngot:\n%s", test.report, got)
+			}
+		})
+	}
+}
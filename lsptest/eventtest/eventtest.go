@@ -0,0 +1,132 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package eventtest directs the events of the [event] package, through
+// which this module and the servers built on it report errors, logs and
+// metrics, to tests: to their logs, and to a Capture that tests may
+// assert on.
+package eventtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/event/adapter/logfmt"
+)
+
+// NewContext returns a context, derived from ctx, whose events are
+// logged by tb, in logfmt format, until the end of the test:
+//
+//	ctx := eventtest.NewContext(context.Background(), t)
+//
+// Events emitted after the end of the test, such as by goroutines that
+// outlive it, are dropped.
+func NewContext(ctx context.Context, tb testing.TB) context.Context {
+	h := &logHandler{tb: tb}
+	tb.Cleanup(func() {
+		h.mu.Lock()
+		h.done = true
+		h.mu.Unlock()
+	})
+	return event.WithExporter(ctx, event.NewExporter(h, nil))
+}
+
+type logHandler struct {
+	tb testing.TB
+
+	mu      sync.Mutex
+	done    bool
+	printer logfmt.Printer
+	buf     bytes.Buffer
+}
+
+func (h *logHandler) Event(ctx context.Context, ev *event.Event) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.done {
+		return ctx
+	}
+	h.buf.Reset()
+	h.printer.Event(&h.buf, ev)
+	h.tb.Log(string(bytes.TrimSuffix(h.buf.Bytes(), []byte("\n"))))
+	return ctx
+}
+
+// A Capture records the events of a context, for tests to assert on.
+// Its methods are safe for concurrent use.
+type Capture struct {
+	mu     sync.Mutex
+	events []event.Event
+}
+
+// NewCapture returns a context, derived from ctx, whose events are
+// recorded by the returned Capture:
+//
+//	ctx, capture := eventtest.NewCapture(context.Background())
+//	...
+//	if diff := cmp.Diff(want, capture.Logs()); diff != "" {
+//		t.Errorf("Unexpected logs (-want +got):\n%s", diff)
+//	}
+func NewCapture(ctx context.Context) (context.Context, *Capture) {
+	c := new(Capture)
+	return event.WithExporter(ctx, event.NewExporter(c, nil)), c
+}
+
+// Event implements [event.Handler].
+func (c *Capture) Event(ctx context.Context, ev *event.Event) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := *ev
+	e.Labels = slices.Clone(ev.Labels)
+	c.events = append(c.events, e)
+	return ctx
+}
+
+// Events returns the events recorded so far, in order.
+func (c *Capture) Events() []event.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.events)
+}
+
+// Logs returns the messages of the log events recorded so far, in
+// order, followed by their errors for those of [event.Error], as in
+// "journal failed: permission denied".
+func (c *Capture) Logs() []string {
+	var logs []string
+	for _, ev := range c.Events() {
+		if ev.Kind != event.LogKind {
+			continue
+		}
+		msg, _ := Find(ev, "msg")
+		log := msg.String()
+		if err, ok := Find(ev, "error"); ok {
+			log = fmt.Sprintf("%s: %v", log, err.Interface())
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// Reset forgets the events recorded so far.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = nil
+}
+
+// Find returns the last label of ev with the given name.
+func Find(ev event.Event, name string) (event.Label, bool) {
+	for _, l := range slices.Backward(ev.Labels) {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return event.Label{}, false
+}
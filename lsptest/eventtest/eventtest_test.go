@@ -0,0 +1,80 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eventtest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest/eventtest"
+)
+
+// logger is a testing.TB that records its logs.
+type logger struct {
+	testing.TB
+	cleanups []func()
+	logs     []string
+}
+
+func (l *logger) Cleanup(f func()) { l.cleanups = append(l.cleanups, f) }
+func (l *logger) Log(args ...any)  { l.logs = append(l.logs, fmt.Sprint(args...)) }
+func (l *logger) Helper()          {}
+func (l *logger) end()             { l.cleanups[0]() }
+func (l *logger) lastLog() string  { return l.logs[len(l.logs)-1] }
+
+func TestNewContext(t *testing.T) {
+	l := &logger{TB: t}
+	ctx := eventtest.NewContext(context.Background(), l)
+	event.Log(ctx, "hello", event.String("method", "initialize"))
+	if len(l.logs) != 1 || !strings.HasSuffix(l.lastLog(), "method=initialize msg=hello") {
+		t.Errorf("Expected a log of the event, got %q", l.logs)
+	}
+	l.end()
+	event.Log(ctx, "too late")
+	if len(l.logs) != 1 {
+		t.Errorf("Expected no log after the end of the test, got %q", l.logs)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	ctx, capture := eventtest.NewCapture(context.Background())
+
+	handler := lsp.Recover(jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) {
+		panic("boom")
+	}))
+	req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "textDocument/hover", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handler.Handle(ctx, req); err == nil {
+		t.Fatal("Expected the panic to fail the request")
+	}
+	event.Log(ctx, "done")
+
+	if diff := cmp.Diff([]string{"panic in LSP handler: boom", "done"}, capture.Logs()); diff != "" {
+		t.Errorf("Unexpected logs (-want +got):\n%s", diff)
+	}
+	events := capture.Events()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if l, ok := eventtest.Find(events[0], "correlation.id"); !ok || l.String() == "" {
+		t.Errorf("Expected a correlation ID, got %v", l)
+	}
+	if _, ok := eventtest.Find(events[1], "error"); ok {
+		t.Errorf("Expected no error label in a plain log")
+	}
+
+	capture.Reset()
+	if n := len(capture.Events()); n != 0 {
+		t.Errorf("Expected no events after Reset, got %d", n)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// A Framing is the framing of messages by a transport, to be checked by
+// [FuzzFraming] and [CheckFraming].
+type Framing struct {
+	// Write writes a message with the given JSON content to w.
+	Write func(w io.Writer, content []byte) error
+	// NewReader returns a function that reads the content of the
+	// successive messages in r. It must return io.EOF at the end of
+	// the input if it ends between messages, and some other error if
+	// the input is malformed or truncated; it is not called again after
+	// an error.
+	NewReader func(r io.Reader) func() ([]byte, error)
+}
+
+// FramingSeeds returns inputs for fuzzing readers of messages framed by
+// the base protocol: well-formed streams, and streams with truncated,
+// missing, malformed or huge Content-Lengths.
+func FramingSeeds() [][]byte {
+	return [][]byte{
+		[]byte(""),
+		[]byte("Content-Length: 2\r\n\r\n{}"),
+		[]byte("Content-Length: 2\r\n\r\n{}Content-Length: 4\r\n\r\nnull"),
+		[]byte("Content-Length: 2\r\nContent-Type: application/vscode-jsonrpc; charset=utf-8\r\n\r\n{}"),
+		[]byte("content-length: 2\n\n{}"),
+		[]byte("Content-Length: 2\r\nX-Unknown: 1\r\n\r\n[]"),
+		[]byte("Content-Length: 0\r\n\r\n"),
+		[]byte("Content-Length: 10\r\n\r\n{}"),
+		[]byte("Content-Length: 2\r\n"),
+		[]byte("Content-Len"),
+		[]byte("Content-Length: -1\r\n\r\n{}"),
+		[]byte("Content-Length: 1048577\r\n\r\n{}"),
+		[]byte("Content-Length: 9223372036854775807\r\n\r\n{}"),
+		[]byte("Content-Length: 99999999999999999999\r\n\r\n{}"),
+		[]byte("Content-Length: 2\r\nContent-Length: 3\r\n\r\n{}"),
+		[]byte("\r\n\r\n"),
+	}
+}
+
+// FuzzFraming fuzzes the framing f, starting from [FramingSeeds], with
+// inputs read in chunks of arbitrary sizes, as by [CheckFraming].
+func FuzzFraming(f *testing.F, framing Framing) {
+	for _, seed := range FramingSeeds() {
+		f.Add(seed, uint8(0))
+		f.Add(seed, uint8(1))
+	}
+	f.Fuzz(func(t *testing.T, input []byte, chunk uint8) {
+		CheckFraming(t, framing, input, int(chunk))
+	})
+}
+
+// CheckFraming reports test errors if the framing misbehaves on input:
+//
+//   - if its reader does not terminate, returning more messages than
+//     the input could hold;
+//   - if the messages or the kind of error (none, io.EOF or other) it
+//     returns depend on whether the input is read at once or in chunks
+//     of at most chunk bytes, if chunk is positive;
+//   - if input is JSON, if its writer does not produce a stream from
+//     which its reader reads the same JSON value, twice.
+func CheckFraming(t *testing.T, framing Framing, input []byte, chunk int) {
+	t.Helper()
+	want := readAll(t, framing, bytes.NewReader(input), len(input))
+	if chunk > 0 {
+		got := readAll(t, framing, &chunkReader{input, chunk}, len(input))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("reading in chunks of %d bytes: expected %v, got %v", chunk, want, got)
+		}
+	}
+
+	if !json.Valid(input) {
+		return
+	}
+	var stream bytes.Buffer
+	for range 2 {
+		if err := framing.Write(&stream, input); err != nil {
+			t.Fatalf("writing %q: %v", input, err)
+		}
+	}
+	got := readAll(t, framing, bytes.NewReader(stream.Bytes()), stream.Len())
+	if len(got) != 3 || got[2] != readEOF {
+		t.Fatalf("reading %q written twice: expected two messages, got %v", input, got)
+	}
+	in, _ := decodeJSON(input)
+	for _, result := range got[:2] {
+		if out, err := decodeJSON([]byte(result)); err != nil || !reflect.DeepEqual(out, in) {
+			t.Errorf("reading %q written: got %s", input, result)
+		}
+	}
+}
+
+// decodeJSON decodes data, keeping numbers as written so that values
+// out of the range of float64 compare equal.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// Results of readAll besides the content of messages.
+const (
+	readEOF   = "<EOF>"
+	readError = "<error>"
+)
+
+// readAll reads all messages of r, of the given size, and returns their
+// contents followed by readEOF or readError.
+func readAll(t *testing.T, framing Framing, r io.Reader, size int) []string {
+	t.Helper()
+	read := framing.NewReader(r)
+	var results []string
+	for {
+		content, err := read()
+		switch {
+		case err == io.EOF:
+			return append(results, readEOF)
+		case err != nil:
+			return append(results, readError)
+		}
+		// Each message has a non-empty header.
+		if len(results) >= size {
+			t.Fatalf("read %d messages from %d bytes", len(results)+1, size)
+		}
+		results = append(results, string(content))
+	}
+}
+
+// A chunkReader reads data at most n bytes at a time.
+type chunkReader struct {
+	data []byte
+	n    int
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p[:min(len(p), r.n)], r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a
+// non-empty value, makes [CheckGolden] update the golden files rather
+// than compare against them:
+//
+//	LSPTEST_UPDATE_GOLDEN=1 go test ./...
+//
+// An environment variable, unlike a flag, does not conflict with the
+// flags of the test binaries that import this package.
+const UpdateGoldenEnv = "LSPTEST_UPDATE_GOLDEN"
+
+// MarshalGolden returns the golden file form of v: its canonical JSON
+// encoding (see [lsp.MarshalCanonical]) indented with tabs, with a final
+// newline.
+func MarshalGolden(v any) ([]byte, error) {
+	data, err := lsp.MarshalCanonical(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "\t"); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// CheckGolden reports a test error unless the JSON encoding of v, as by
+// [MarshalGolden], matches the contents of the golden file filename,
+// which is typically in the testdata directory. When the test is run
+// with the environment variable UpdateGoldenEnv set, it instead writes
+// the encoding to the file.
+//
+// CheckGolden also checks that the value round-trips: that the contents
+// of the file decode into a value of the same type as v, which encodes
+// back to the same JSON. So a golden file locks both the wire format of
+// a value and the ability to read it back.
+func CheckGolden[T any](t testing.TB, filename string, v T) {
+	t.Helper()
+	got, err := MarshalGolden(v)
+	if err != nil {
+		t.Fatalf("marshaling %T: %v", v, err)
+	}
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(filename), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, got, 0o666); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("%v (run with %s=1 to create it)", err, UpdateGoldenEnv)
+	}
+	want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n")) // git checkouts on Windows
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch (-want +got):\n%s", filename, cmp.Diff(string(want), string(got)))
+		return
+	}
+
+	// Round trip.
+	var decoded T
+	if err := json.Unmarshal(want, &decoded); err != nil {
+		t.Errorf("%s: decoding into %T: %v", filename, v, err)
+		return
+	}
+	again, err := MarshalGolden(decoded)
+	if err != nil {
+		t.Fatalf("marshaling decoded %T: %v", v, err)
+	}
+	if !bytes.Equal(again, want) {
+		t.Errorf("%s: %T does not round-trip (-want +got):\n%s", filename, v, cmp.Diff(string(want), string(again)))
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+func TestGolden(t *testing.T) {
+	loc := lsp.Location{URI: "file:///a.go", Range: lsp.Range{End: lsp.Position{Character: 1}}}
+
+	t.Run("Format", func(t *testing.T) {
+		got, err := lsptest.MarshalGolden(loc.Range.End)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "{\n\t\"character\": 1,\n\t\"line\": 0\n}\n"
+		if string(got) != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "location.json")
+		data, err := lsptest.MarshalGolden(loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, data, 0o666); err != nil {
+			t.Fatal(err)
+		}
+		lsptest.CheckGolden(t, filename, loc)
+		lsptest.CheckGolden(t, filename, &loc)
+	})
+}
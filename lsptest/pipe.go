@@ -0,0 +1,69 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// Pipe returns the two ends of an in-memory connection, whose incoming
+// messages are handled by the given handlers. A nil handler handles no
+// message. Both ends are closed when the test finishes.
+func Pipe(t testing.TB, clientHandler, serverHandler jsonrpc2.Handler) (client, server *jsonrpc2.Connection) {
+	t.Helper()
+	ctx := context.Background()
+	cc, sc := net.Pipe()
+	client, err := jsonrpc2.Dial(ctx, pipeDialer{cc}, jsonrpc2.ConnectionOptions{Handler: clientHandler})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err = jsonrpc2.Dial(ctx, pipeDialer{sc}, jsonrpc2.ConnectionOptions{Handler: serverHandler})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+type pipeDialer struct{ rwc io.ReadWriteCloser }
+
+func (d pipeDialer) Dial(context.Context) (io.ReadWriteCloser, error) { return d.rwc, nil }
+
+// Connect connects a client to the server returned by newServer, in
+// memory, and returns the Server through which the test sends the
+// requests of the client. The server sends its requests through the
+// Client passed to newServer, and they are handled by client, which may
+// be nil if the server sends none:
+//
+//	server := lsptest.Connect(t, client, func(client lsp.ClientCloser) lsp.Server {
+//		return newServer(client)
+//	})
+//
+// Messages are encoded and decoded as over a real connection, so the
+// test also exercises the JSON encoding of the messages.
+func Connect(t testing.TB, client lsp.Client, newServer func(lsp.ClientCloser) lsp.Server) lsp.Server {
+	t.Helper()
+	var clientHandler jsonrpc2.Handler
+	if client != nil {
+		clientHandler = lsp.ClientHandler(client)
+	}
+	// The server receives no message before it is created, as the test
+	// sends none before Connect returns.
+	var server lsp.Server
+	clientConn, serverConn := Pipe(t, clientHandler, jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		return lsp.ServerHandler(server)(ctx, req)
+	}))
+	server = newServer(lsp.ClientDispatcher(serverConn))
+	return lsp.ServerDispatcher(clientConn)
+}
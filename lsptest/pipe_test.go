@@ -0,0 +1,57 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest_test
+
+import (
+	"context"
+	"testing"
+
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+// logClient is a Client that records the messages it is shown.
+type logClient struct {
+	lsp.Client
+	messages chan string
+}
+
+func (c *logClient) ShowMessage(_ context.Context, params *lsp.ShowMessageParams) error {
+	c.messages <- params.Message
+	return nil
+}
+
+// greetServer is a Server that greets the client on hover.
+type greetServer struct {
+	lsp.Server
+	client lsp.Client
+}
+
+func (s *greetServer) Hover(ctx context.Context, params *lsp.HoverParams) (*lsp.Hover, error) {
+	if err := s.client.ShowMessage(ctx, &lsp.ShowMessageParams{Type: lsp.Info, Message: "hello"}); err != nil {
+		return nil, err
+	}
+	return &lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.PlainText, Value: string(params.TextDocument.URI)}}, nil
+}
+
+func TestConnect(t *testing.T) {
+	ctx := context.Background()
+	client := &logClient{messages: make(chan string, 1)}
+	server := lsptest.Connect(t, client, func(c lsp.ClientCloser) lsp.Server {
+		return &greetServer{client: c}
+	})
+	hover, err := server.Hover(ctx, &lsp.HoverParams{TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: lsptest.URI("a.go")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := string(lsptest.URI("a.go")); hover.Contents.Value != want {
+		t.Errorf("Expected hover %q, got %q", want, hover.Contents.Value)
+	}
+	if got := <-client.messages; got != "hello" {
+		t.Errorf("Expected message %q, got %q", "hello", got)
+	}
+}
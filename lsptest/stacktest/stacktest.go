@@ -0,0 +1,220 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stacktest checks that tests do not leak goroutines, such as
+// those of connections that were not closed, or of handlers still
+// running when their server shut down.
+package stacktest
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// DefaultMaxWait is the time NoLeak waits for goroutines to finish, by
+// default.
+const DefaultMaxWait = time.Second
+
+// An Option configures NoLeak.
+type Option func(*config)
+
+type config struct {
+	maxWait time.Duration
+	ignore  []func(Goroutine) bool
+}
+
+// MaxWait sets the time NoLeak waits for goroutines to finish before it
+// reports them.
+func MaxWait(d time.Duration) Option {
+	return func(c *config) { c.maxWait = d }
+}
+
+// IgnoreFunction ignores the goroutines with the named function in
+// their stack, such as "net/http.(*persistConn).readLoop".
+func IgnoreFunction(name string) Option {
+	return Ignore(func(g Goroutine) bool {
+		return slices.ContainsFunc(g.Stack, func(f Frame) bool { return f.Function == name })
+	})
+}
+
+// IgnoreTopFunction ignores the goroutines running the named function,
+// that is, whose innermost frame is in that function.
+func IgnoreTopFunction(name string) Option {
+	return Ignore(func(g Goroutine) bool {
+		return len(g.Stack) > 0 && g.Stack[0].Function == name
+	})
+}
+
+// IgnoreCreatedBy ignores the goroutines started by the named function.
+func IgnoreCreatedBy(name string) Option {
+	return Ignore(func(g Goroutine) bool { return g.CreatedBy == name })
+}
+
+// Ignore ignores the goroutines for which ignore returns true.
+func Ignore(ignore func(Goroutine) bool) Option {
+	return func(c *config) { c.ignore = append(c.ignore, ignore) }
+}
+
+// A Goroutine is a goroutine, as shown by a stack dump.
+type Goroutine struct {
+	ID        int64
+	State     string  // such as "chan receive" or "select"
+	Stack     []Frame // innermost first
+	CreatedBy string  // the function that started the goroutine
+}
+
+// A Frame is a frame of the stack of a goroutine.
+type Frame struct {
+	Function string // qualified by its package path, as in "net.(*conn).Read"
+	File     string
+	Line     int
+}
+
+// NoLeak checks that a test or benchmark does not leak goroutines: when
+// it completes, NoLeak reports an error listing the goroutines started
+// since it was called that are still running after a delay, those that
+// the options do not ignore. It is typically called first:
+//
+//	func TestServer(t *testing.T) {
+//		stacktest.NoLeak(t)
+//		...
+//	}
+//
+// As NoLeak considers all the goroutines of the process, it must not be
+// used in parallel tests.
+func NoLeak(t testing.TB, opts ...Option) {
+	c := config{maxWait: DefaultMaxWait}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	before := make(map[int64]bool)
+	for _, g := range Capture() {
+		before[g.ID] = true
+	}
+	t.Cleanup(func() {
+		start := time.Now()
+		delay := time.Millisecond
+		for {
+			var leaked []Goroutine
+			for _, g := range Capture() {
+				if !before[g.ID] && !slices.ContainsFunc(c.ignore, func(ignore func(Goroutine) bool) bool { return ignore(g) }) {
+					leaked = append(leaked, g)
+				}
+			}
+			if len(leaked) == 0 {
+				return
+			}
+			if time.Since(start) > c.maxWait {
+				t.Errorf("goroutine leak detected:\n%s", Summarize(leaked))
+				return
+			}
+			time.Sleep(delay)
+			delay = min(2*delay, 100*time.Millisecond)
+		}
+	})
+}
+
+// Capture returns the goroutines of the process, except the calling one.
+func Capture() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	gs := Parse(buf)
+	if len(gs) > 0 {
+		gs = gs[1:] // the first is the current goroutine
+	}
+	return gs
+}
+
+// Parse parses a stack dump, in the format of [runtime.Stack] or of an
+// unrecovered panic. It ignores what it does not recognize.
+func Parse(dump []byte) []Goroutine {
+	var gs []Goroutine
+	for block := range bytes.SplitSeq(dump, []byte("\n\n")) {
+		lines := strings.Split(strings.TrimSpace(string(block)), "\n")
+		header, ok := strings.CutPrefix(lines[0], "goroutine ")
+		if !ok {
+			continue
+		}
+		id, state, ok := strings.Cut(header, " [")
+		if !ok {
+			continue
+		}
+		g := Goroutine{State: strings.TrimSuffix(state, "]:")}
+		g.ID, _ = strconv.ParseInt(id, 10, 64)
+		g.State, _, _ = strings.Cut(g.State, ",") // drop ", 2 minutes"
+		for i := 1; i < len(lines); i++ {
+			line := lines[i]
+			if created, ok := strings.CutPrefix(line, "created by "); ok {
+				g.CreatedBy, _, _ = strings.Cut(created, " in goroutine ")
+				i++ // its position
+				continue
+			}
+			f := Frame{Function: line}
+			if strings.HasSuffix(line, ")") {
+				if j := strings.LastIndexByte(line, '('); j > 0 {
+					f.Function = line[:j]
+				}
+			}
+			if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+				i++
+				pos, _, _ := strings.Cut(strings.TrimSpace(lines[i]), " ")
+				if j := strings.LastIndexByte(pos, ':'); j > 0 {
+					f.File = pos[:j]
+					f.Line, _ = strconv.Atoi(pos[j+1:])
+				}
+			}
+			g.Stack = append(g.Stack, f)
+		}
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// Summarize returns a description of goroutines, in which those with
+// the same stack are listed once, with their number.
+func Summarize(gs []Goroutine) string {
+	type group struct {
+		count int
+		g     Goroutine
+	}
+	var groups []*group
+	index := make(map[string]*group)
+	for _, g := range gs {
+		var key strings.Builder
+		fmt.Fprintf(&key, "%s\x00%s", g.State, g.CreatedBy)
+		for _, f := range g.Stack {
+			fmt.Fprintf(&key, "\x00%s:%d", f.File, f.Line)
+		}
+		k := key.String()
+		if index[k] == nil {
+			index[k] = &group{g: g}
+			groups = append(groups, index[k])
+		}
+		index[k].count++
+	}
+	var b strings.Builder
+	for _, grp := range groups {
+		fmt.Fprintf(&b, "%d goroutine(s) [%s]:\n", grp.count, grp.g.State)
+		for _, f := range grp.g.Stack {
+			fmt.Fprintf(&b, "\t%s\n\t\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if grp.g.CreatedBy != "" {
+			fmt.Fprintf(&b, "\tcreated by %s\n", grp.g.CreatedBy)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stacktest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp/lsptest/stacktest"
+)
+
+// recorder is a testing.TB that records the errors reported at its
+// cleanup.
+type recorder struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (r *recorder) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) cleanup() {
+	for _, f := range r.cleanups {
+		f()
+	}
+}
+
+//go:noinline
+func leak(done chan struct{}) { <-done }
+
+func TestNoLeak(t *testing.T) {
+	const leakFunc = "typefox.dev/lsp/lsptest/stacktest_test.leak"
+
+	t.Run("Leak", func(t *testing.T) {
+		r := &recorder{TB: t}
+		stacktest.NoLeak(r, stacktest.MaxWait(10*time.Millisecond))
+		done := make(chan struct{})
+		defer close(done)
+		go leak(done)
+		r.cleanup()
+		if len(r.errors) != 1 || !strings.Contains(r.errors[0], leakFunc) || !strings.Contains(r.errors[0], "[chan receive]") {
+			t.Errorf("Expected a leak in %s, got %q", leakFunc, r.errors)
+		}
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		r := &recorder{TB: t}
+		stacktest.NoLeak(r)
+		done := make(chan struct{})
+		go leak(done)
+		time.AfterFunc(10*time.Millisecond, func() { close(done) })
+		r.cleanup()
+		if len(r.errors) != 0 {
+			t.Errorf("Expected no leak, got %q", r.errors)
+		}
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		for _, opt := range []stacktest.Option{
+			stacktest.IgnoreFunction(leakFunc),
+			stacktest.IgnoreTopFunction(leakFunc),
+			stacktest.IgnoreCreatedBy("typefox.dev/lsp/lsptest/stacktest_test.TestNoLeak.func3"),
+		} {
+			r := &recorder{TB: t}
+			stacktest.NoLeak(r, stacktest.MaxWait(10*time.Millisecond), opt)
+			done := make(chan struct{})
+			go leak(done)
+			r.cleanup()
+			close(done)
+			if len(r.errors) != 0 {
+				t.Errorf("Expected the leak to be ignored, got %q", r.errors)
+			}
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	const dump = `goroutine 1 [running]:
+main.main()
+	/home/user/main.go:12 +0x1d
+
+goroutine 7 [chan receive, 2 minutes]:
+example.com/server.(*Conn).read(0xc000010000, {0x0, 0x0})
+	/home/user/server/conn.go:42 +0x25
+example.com/server.Serve.func1()
+	/home/user/server/serve.go:10 +0x3a
+created by example.com/server.Serve in goroutine 1
+	/home/user/server/serve.go:9 +0x1f
+`
+	want := []stacktest.Goroutine{
+		{ID: 1, State: "running", Stack: []stacktest.Frame{{"main.main", "/home/user/main.go", 12}}},
+		{ID: 7, State: "chan receive", Stack: []stacktest.Frame{
+			{"example.com/server.(*Conn).read", "/home/user/server/conn.go", 42},
+			{"example.com/server.Serve.func1", "/home/user/server/serve.go", 10},
+		}, CreatedBy: "example.com/server.Serve"},
+	}
+	gs := stacktest.Parse([]byte(dump))
+	if diff := cmp.Diff(want, gs); diff != "" {
+		t.Errorf("Unexpected goroutines (-want +got):\n%s", diff)
+	}
+	got := stacktest.Summarize(append(gs[1:], gs[1:]...))
+	wantSummary := `2 goroutine(s) [chan receive]:
+	example.com/server.(*Conn).read
+		/home/user/server/conn.go:42
+	example.com/server.Serve.func1
+		/home/user/server/serve.go:10
+	created by example.com/server.Serve
+`
+	if got != wantSummary {
+		t.Errorf("Expected summary:\n%s\ngot:\n%s", wantSummary, got)
+	}
+}
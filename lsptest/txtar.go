@@ -0,0 +1,98 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest
+
+import (
+	"bytes"
+	"strings"
+)
+
+// This file implements the txtar archive format of
+// golang.org/x/tools/txtar, to avoid a dependency on that module:
+//
+//	optional comment
+//	-- name1 --
+//	content of name1
+//	-- name2 --
+//	content of name2
+//
+// Each file's content ends with a newline unless it is empty.
+
+// A txtarFile is a file in a txtar archive.
+type txtarFile struct {
+	name string
+	data []byte
+}
+
+// parseTxtar parses data as a txtar archive, returning its comment and files.
+func parseTxtar(data []byte) (comment []byte, files []txtarFile) {
+	comment, name, data := findFileMarker(data)
+	for name != "" {
+		f := txtarFile{name: name}
+		f.data, name, data = findFileMarker(data)
+		files = append(files, f)
+	}
+	return comment, files
+}
+
+var (
+	newlineMarker = []byte("\n-- ")
+	marker        = []byte("-- ")
+	markerEnd     = []byte(" --")
+)
+
+// findFileMarker finds the next file marker in data, returning the data
+// before the marker, the name of the file it introduces, and the data
+// after it. If there is no marker, it returns (fixNL(data), "", nil).
+func findFileMarker(data []byte) (before []byte, name string, after []byte) {
+	var i int
+	for {
+		if name, after = isMarker(data[i:]); name != "" {
+			return data[:i], name, after
+		}
+		j := bytes.Index(data[i:], newlineMarker)
+		if j < 0 {
+			return fixNL(data), "", nil
+		}
+		i += j + 1 // positioned at start of new possible marker
+	}
+}
+
+// isMarker checks whether data begins with a file marker line.
+// If so, it returns the name from the line and the data after the line.
+func isMarker(data []byte) (name string, after []byte) {
+	if !bytes.HasPrefix(data, marker) {
+		return "", nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data, after = data[:i], data[i+1:]
+	}
+	if !(bytes.HasSuffix(data, markerEnd) && len(data) >= len(marker)+len(markerEnd)) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(data[len(marker) : len(data)-len(markerEnd)])), after
+}
+
+// fixNL returns data with a final newline added, if it is non-empty and
+// does not already end in one.
+func fixNL(data []byte) []byte {
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return data
+	}
+	d := make([]byte, len(data)+1)
+	copy(d, data)
+	d[len(data)] = '\n'
+	return d
+}
+
+// formatTxtar returns the txtar archive of the given files.
+func formatTxtar(files []txtarFile) []byte {
+	var buf bytes.Buffer
+	for _, f := range files {
+		buf.WriteString("-- " + f.name + " --\n")
+		buf.Write(fixNL(f.data))
+	}
+	return buf.Bytes()
+}
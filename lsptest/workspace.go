@@ -0,0 +1,326 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lsptest provides utilities for testing language servers and
+// clients built with package lsp.
+package lsptest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+// RootURI is the URI of the root folder of a Workspace.
+const RootURI lsp.DocumentURI = "file:///workspace"
+
+// A Workspace is an in-memory set of documents, each with a version,
+// identified by their URIs within [RootURI]. It is typically loaded from
+// a txtar archive:
+//
+//	ws := lsptest.NewWorkspace(t, `
+//	-- go.mod --
+//	module example.com
+//	-- a.go --
+//	package a
+//	`)
+//
+// A Workspace applies the workspace edits it receives, either directly
+// or as the ApplyEdit method of a client, so that tests may assert the
+// resulting file contents with [Workspace.Check].
+//
+// A Workspace is safe for concurrent use.
+type Workspace struct {
+	mu   sync.Mutex
+	docs map[lsp.DocumentURI]*document
+}
+
+type document struct {
+	version int32
+	content string
+}
+
+// NewWorkspace returns a Workspace holding the files of the txtar archive.
+// Each document has version 1.
+func NewWorkspace(t testing.TB, archive string) *Workspace {
+	t.Helper()
+	ws := &Workspace{docs: make(map[lsp.DocumentURI]*document)}
+	_, files := parseTxtar([]byte(archive))
+	for _, f := range files {
+		uri := URI(f.name)
+		if _, dup := ws.docs[uri]; dup {
+			t.Fatalf("duplicate file %s in archive", f.name)
+		}
+		ws.docs[uri] = &document{version: 1, content: string(f.data)}
+	}
+	return ws
+}
+
+// LoadWorkspace returns a Workspace holding the files of the named txtar
+// archive file.
+func LoadWorkspace(t testing.TB, filename string) *Workspace {
+	t.Helper()
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewWorkspace(t, string(data))
+}
+
+// URI returns the URI of the file with the given slash-separated name,
+// relative to the root of the workspace.
+func URI(name string) lsp.DocumentURI {
+	u := url.URL{Scheme: "file", Path: path.Join("/workspace", name)}
+	return lsp.DocumentURI(u.String())
+}
+
+// name returns the name of the file with the given URI,
+// relative to the root of the workspace.
+func name(uri lsp.DocumentURI) string {
+	u, err := url.Parse(string(uri))
+	if err != nil {
+		return string(uri)
+	}
+	return strings.TrimPrefix(u.Path, "/workspace/")
+}
+
+// URIs returns the URIs of the documents of the workspace, in order.
+func (ws *Workspace) URIs() []lsp.DocumentURI {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return slices.Sorted(maps.Keys(ws.docs))
+}
+
+// Content returns the content of a document, and whether it exists.
+func (ws *Workspace) Content(uri lsp.DocumentURI) (string, bool) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	doc, ok := ws.docs[uri]
+	if !ok {
+		return "", false
+	}
+	return doc.content, true
+}
+
+// Version returns the version of a document, and whether it exists.
+func (ws *Workspace) Version(uri lsp.DocumentURI) (int32, bool) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	doc, ok := ws.docs[uri]
+	if !ok {
+		return 0, false
+	}
+	return doc.version, true
+}
+
+// Mapper returns a Mapper for the current content of a document,
+// or nil if it does not exist.
+func (ws *Workspace) Mapper(uri lsp.DocumentURI) *lsp.Mapper {
+	content, ok := ws.Content(uri)
+	if !ok {
+		return nil
+	}
+	return lsp.NewMapper(uri, []byte(content))
+}
+
+// DidOpen returns the params of a textDocument/didOpen notification
+// for a document, whose language is given by its file extension.
+func (ws *Workspace) DidOpen(uri lsp.DocumentURI) (*lsp.DidOpenTextDocumentParams, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	doc, ok := ws.docs[uri]
+	if !ok {
+		return nil, fmt.Errorf("no document %s", uri)
+	}
+	return &lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        uri,
+			LanguageID: lsp.LanguageKind(strings.TrimPrefix(path.Ext(name(uri)), ".")),
+			Version:    doc.version,
+			Text:       doc.content,
+		},
+	}, nil
+}
+
+// ApplyEdit implements the workspace/applyEdit method of [lsp.Client],
+// so that a Workspace may be embedded in a test client.
+func (ws *Workspace) ApplyEdit(_ context.Context, params *lsp.ApplyWorkspaceEditParams) (*lsp.ApplyWorkspaceEditResult, error) {
+	if err := ws.Apply(&params.Edit); err != nil {
+		return &lsp.ApplyWorkspaceEditResult{FailureReason: err.Error()}, nil
+	}
+	return &lsp.ApplyWorkspaceEditResult{Applied: true}, nil
+}
+
+// Apply applies a workspace edit, incrementing the version of each
+// edited document. It either applies the whole edit or, if any of its
+// changes is invalid, none of it.
+func (ws *Workspace) Apply(edit *lsp.WorkspaceEdit) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	// Apply the changes to a copy, and commit it if all succeed.
+	docs := make(map[lsp.DocumentURI]*document, len(ws.docs))
+	for uri, doc := range ws.docs {
+		docs[uri] = &document{version: doc.version, content: doc.content}
+	}
+	edited := make(map[lsp.DocumentURI]bool)
+	editText := func(uri lsp.DocumentURI, edits []lsp.TextEdit) error {
+		doc, ok := docs[uri]
+		if !ok {
+			return fmt.Errorf("no document %s", uri)
+		}
+		content, err := applyEdits(lsp.NewMapper(uri, []byte(doc.content)), edits)
+		if err != nil {
+			return fmt.Errorf("%s: %v", uri, err)
+		}
+		doc.content = content
+		if !edited[uri] {
+			doc.version++
+			edited[uri] = true
+		}
+		return nil
+	}
+
+	for _, uri := range slices.Sorted(maps.Keys(edit.Changes)) {
+		if err := editText(uri, edit.Changes[uri]); err != nil {
+			return err
+		}
+	}
+	for _, change := range edit.DocumentChanges {
+		switch {
+		case change.TextDocumentEdit != nil:
+			tde := change.TextDocumentEdit
+			uri := tde.TextDocument.URI
+			if doc, ok := docs[uri]; ok && tde.TextDocument.Version != 0 && tde.TextDocument.Version != doc.version {
+				return fmt.Errorf("%s: edit of version %d, have version %d", uri, tde.TextDocument.Version, doc.version)
+			}
+			var edits []lsp.TextEdit
+			for _, e := range tde.Edits {
+				switch {
+				case e.TextEdit != nil:
+					edits = append(edits, *e.TextEdit)
+				case e.AnnotatedTextEdit != nil:
+					edits = append(edits, e.AnnotatedTextEdit.TextEdit)
+				case e.SnippetTextEdit != nil:
+					edits = append(edits, lsp.TextEdit{Range: e.SnippetTextEdit.Range, NewText: e.SnippetTextEdit.Snippet.Value})
+				}
+			}
+			if err := editText(uri, edits); err != nil {
+				return err
+			}
+
+		case change.CreateFile != nil:
+			c := change.CreateFile
+			if _, exists := docs[c.URI]; exists {
+				opts := c.Options
+				if opts != nil && opts.IgnoreIfExists {
+					continue
+				}
+				if opts == nil || !opts.Overwrite {
+					return fmt.Errorf("create %s: file exists", c.URI)
+				}
+			}
+			docs[c.URI] = &document{version: 1}
+
+		case change.RenameFile != nil:
+			r := change.RenameFile
+			doc, ok := docs[r.OldURI]
+			if !ok {
+				return fmt.Errorf("rename %s: no such file", r.OldURI)
+			}
+			if _, exists := docs[r.NewURI]; exists {
+				opts := r.Options
+				if opts != nil && opts.IgnoreIfExists {
+					continue
+				}
+				if opts == nil || !opts.Overwrite {
+					return fmt.Errorf("rename %s: %s exists", r.OldURI, r.NewURI)
+				}
+			}
+			delete(docs, r.OldURI)
+			docs[r.NewURI] = doc
+
+		case change.DeleteFile != nil:
+			d := change.DeleteFile
+			if _, ok := docs[d.URI]; !ok {
+				if d.Options != nil && d.Options.IgnoreIfNotExists {
+					continue
+				}
+				return fmt.Errorf("delete %s: no such file", d.URI)
+			}
+			delete(docs, d.URI)
+		}
+	}
+	ws.docs = docs
+	return nil
+}
+
+// applyEdits returns the content of m after applying the edits, which
+// must not overlap.
+func applyEdits(m *lsp.Mapper, edits []lsp.TextEdit) (string, error) {
+	content, _, err := lsp.ApplyEdits(m, edits, lsp.EditStrict)
+	return string(content), err
+}
+
+// Archive returns the current files of the workspace as a txtar archive.
+func (ws *Workspace) Archive() string {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var files []txtarFile
+	for uri, doc := range ws.docs {
+		files = append(files, txtarFile{name: name(uri), data: []byte(doc.content)})
+	}
+	slices.SortFunc(files, func(x, y txtarFile) int { return strings.Compare(x.name, y.name) })
+	return string(formatTxtar(files))
+}
+
+// Check reports a test error if the files of the workspace differ from
+// those of the txtar archive want, in name or (after normalizing the
+// final newline, as in the archive) content.
+func (ws *Workspace) Check(t testing.TB, want string) {
+	t.Helper()
+	_, files := parseTxtar([]byte(want))
+	wantFiles := make(map[string]string)
+	for _, f := range files {
+		wantFiles[f.name] = string(f.data)
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for uri, doc := range ws.docs {
+		name := name(uri)
+		want, ok := wantFiles[name]
+		if !ok {
+			t.Errorf("unexpected file %s", name)
+			continue
+		}
+		if got := string(fixNL([]byte(doc.content))); got != want {
+			t.Errorf("file %s: got content:\n%s\nwant:\n%s", name, got, want)
+		}
+		delete(wantFiles, name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(wantFiles)) {
+		t.Errorf("missing file %s", name)
+	}
+}
+
+// CheckFile reports a test error if the content of a document differs
+// from want.
+func (ws *Workspace) CheckFile(t testing.TB, uri lsp.DocumentURI, want string) {
+	t.Helper()
+	got, ok := ws.Content(uri)
+	if !ok {
+		t.Errorf("missing file %s", name(uri))
+	} else if got != want {
+		t.Errorf("file %s: got content:\n%s\nwant:\n%s", name(uri), got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsptest_test
+
+import (
+	"context"
+	"testing"
+
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+const archive = `
+A comment, which is ignored.
+-- a.go --
+package a
+
+func F() {}
+-- sub/b.go --
+package b
+-- empty.txt --
+`
+
+func TestWorkspace(t *testing.T) {
+	ws := lsptest.NewWorkspace(t, archive)
+
+	t.Run("Load", func(t *testing.T) {
+		want := []lsp.DocumentURI{"file:///workspace/a.go", "file:///workspace/empty.txt", "file:///workspace/sub/b.go"}
+		got := ws.URIs()
+		if len(got) != len(want) {
+			t.Fatalf("Expected URIs %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Expected URIs %v, got %v", want, got)
+			}
+		}
+		ws.CheckFile(t, lsptest.URI("a.go"), "package a\n\nfunc F() {}\n")
+		params, err := ws.DidOpen(lsptest.URI("sub/b.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params.TextDocument.LanguageID != "go" || params.TextDocument.Version != 1 {
+			t.Errorf("Expected version 1 of a go document, got %+v", params.TextDocument)
+		}
+	})
+
+	t.Run("ApplyEdit", func(t *testing.T) {
+		a := lsptest.URI("a.go")
+		edit := lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{
+			{TextDocumentEdit: &lsp.TextDocumentEdit{
+				TextDocument: lsp.OptionalVersionedTextDocumentIdentifier{Version: 1, TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: a}},
+				Edits: lsp.AsAnnotatedTextEdits([]lsp.TextEdit{{
+					Range:   lsp.Range{Start: lsp.Position{Line: 2, Character: 5}, End: lsp.Position{Line: 2, Character: 6}},
+					NewText: "G",
+				}}),
+			}},
+			{RenameFile: &lsp.RenameFile{Kind: "rename", OldURI: lsptest.URI("sub/b.go"), NewURI: lsptest.URI("b.go")}},
+			{DeleteFile: &lsp.DeleteFile{Kind: "delete", URI: lsptest.URI("empty.txt")}},
+		}}
+		res, err := ws.ApplyEdit(context.Background(), &lsp.ApplyWorkspaceEditParams{Edit: edit})
+		if err != nil || !res.Applied {
+			t.Fatalf("ApplyEdit failed: %+v, %v", res, err)
+		}
+		ws.Check(t, `
+-- a.go --
+package a
+
+func G() {}
+-- b.go --
+package b
+`)
+		if v, _ := ws.Version(a); v != 2 {
+			t.Errorf("Expected version 2, got %d", v)
+		}
+
+		// The same edit is now of an old version, and fails as a whole.
+		res, err = ws.ApplyEdit(context.Background(), &lsp.ApplyWorkspaceEditParams{Edit: edit})
+		if err != nil || res.Applied || res.FailureReason == "" {
+			t.Errorf("Expected ApplyEdit to fail, got %+v, %v", res, err)
+		}
+		if _, ok := ws.Content(lsptest.URI("b.go")); !ok {
+			t.Errorf("Expected a failed edit to leave the workspace unchanged")
+		}
+	})
+}
@@ -0,0 +1,469 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+// This file defines Mapper, which wraps a file content buffer
+// ([]byte) and provides efficient conversion between every kind of
+// position representation.
+//
+// Here's a handy guide for your tour of the location zoo:
+//
+// Imports: lsp  -->  go/token
+//
+// lsp: for the LSP protocol.
+// lsp.DocumentURI = string
+// lsp.Position = (Line, Character uint32) // (line, UTF-16 column)
+// lsp.Range = (start, end Position)
+// lsp.Location = (URI DocumentURI, Range)
+// lsp.Mapper = (URI DocumentURI, Content []byte)
+//
+// go/token:
+// token.Pos = int  // byte offset within token.FileSet
+// token.File = (Name string, Base int, lines []int)
+//
+// The Mapper converts between byte offsets (the representation used
+// internally by most servers) and the Positions of the protocol, in
+// which Character counts UTF-16 codes.
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"typefox.dev/lsp/internal/util/safetoken"
+)
+
+// A Mapper wraps the content of a file and provides mapping
+// between byte offsets and notations of position such as:
+//
+//   - (line, col8) pairs, where col8 is a 1-based UTF-8 column number
+//     (bytes), as used by the go/token package.
+//
+//   - (line, col16) pairs, where col16 is a 0-based UTF-16 column number,
+//     as used by the LSP protocol.
+//
+// All conversion methods are named "FromTo", where From and To are the two types.
+// For example, the PositionOffset method converts from a Position to a byte offset.
+//
+// By default the Character of a Position counts UTF-16 codes, as the
+// protocol requires unless the client and server negotiate another
+// position encoding. A Mapper created by [NewMapperEncoding] for the UTF-8
+// encoding instead counts bytes, which makes conversions as cheap as for
+// ASCII text; for UTF-32 it counts runes. The documentation of the
+// methods refers to UTF-16 for brevity.
+//
+// Mappers are safe for concurrent use; the content must not be modified.
+type Mapper struct {
+	URI     DocumentURI
+	Content []byte
+
+	encoding PositionEncodingKind // UTF8, UTF16 or UTF32
+
+	// Line-number information is requested only for a tiny
+	// fraction of Mappers, so we compute it lazily.
+	// Call initLines() before accessing fields below.
+	linesOnce sync.Once
+	lineStart []int // byte offset of start of ith line (0-based); last=EOF iff \n-terminated
+	nonASCII  bool
+
+	// Optional cache of the columns of lines; see EnableColumnCache.
+	cacheColumns atomic.Bool
+	colMu        sync.Mutex
+	colCache     map[int]*lineColumns // by 0-based line
+}
+
+// NewMapper creates a new mapper for the given URI and content.
+func NewMapper(uri DocumentURI, content []byte) *Mapper {
+	return &Mapper{URI: uri, Content: content, encoding: UTF16}
+}
+
+// NewMapperEncoding creates a new mapper for the given URI and content,
+// whose positions use the given encoding, which is typically the
+// PositionEncoding negotiated by the initialize request.
+// Unknown encodings are treated as UTF-16.
+func NewMapperEncoding(uri DocumentURI, content []byte, encoding PositionEncodingKind) *Mapper {
+	switch encoding {
+	case UTF8, UTF32:
+	default:
+		encoding = UTF16
+	}
+	return &Mapper{URI: uri, Content: content, encoding: encoding}
+}
+
+// Encoding returns the position encoding of the mapper.
+func (m *Mapper) Encoding() PositionEncodingKind {
+	if m.encoding == "" {
+		return UTF16 // zero Mapper
+	}
+	return m.encoding
+}
+
+// initLines populates the lineStart table.
+func (m *Mapper) initLines() {
+	m.linesOnce.Do(func() {
+		nlines := bytes.Count(m.Content, []byte("\n"))
+		m.lineStart = make([]int, 1, nlines+1) // initially []int{0}
+		for offset, b := range m.Content {
+			if b == '\n' {
+				m.lineStart = append(m.lineStart, offset+1)
+			}
+			if b >= utf8.RuneSelf {
+				m.nonASCII = true
+			}
+		}
+	})
+}
+
+// -- conversions from byte offsets --
+
+// OffsetLocation converts a byte-offset interval to a protocol (UTF-16) location.
+func (m *Mapper) OffsetLocation(start, end int) (Location, error) {
+	rng, err := m.OffsetRange(start, end)
+	if err != nil {
+		return Location{}, err
+	}
+	return Location{URI: m.URI, Range: rng}, nil
+}
+
+// OffsetRange converts a byte-offset interval to a protocol (UTF-16) range.
+func (m *Mapper) OffsetRange(start, end int) (Range, error) {
+	if start > end {
+		return Range{}, fmt.Errorf("start offset (%d) > end (%d)", start, end)
+	}
+	startPosition, err := m.OffsetPosition(start)
+	if err != nil {
+		return Range{}, fmt.Errorf("start: %v", err)
+	}
+	endPosition, err := m.OffsetPosition(end)
+	if err != nil {
+		return Range{}, fmt.Errorf("end: %v", err)
+	}
+	return Range{Start: startPosition, End: endPosition}, nil
+}
+
+// OffsetPosition converts a byte offset to a protocol (UTF-16) position.
+func (m *Mapper) OffsetPosition(offset int) (Position, error) {
+	if !(0 <= offset && offset <= len(m.Content)) {
+		return Position{}, fmt.Errorf("invalid offset %d (want 0-%d)", offset, len(m.Content))
+	}
+	// No error may be returned after this point,
+	// even if the offset does not fall at a rune boundary.
+	line, col := m.lineCol(offset)
+	return Position{Line: uint32(line), Character: uint32(col)}, nil
+}
+
+// lineCol converts a valid byte offset to line and column numbers,
+// both 0-based, in the encoding of the mapper.
+func (m *Mapper) lineCol(offset int) (int, int) {
+	line, start, cr := m.line(offset)
+	var col int
+	if lc := m.columnsOf(line); lc != nil {
+		col = int(lc.cols[offset-start])
+	} else {
+		col = m.columns(m.Content[start:offset])
+	}
+	if cr {
+		col-- // retreat from \r at line end
+	}
+	return line, col
+}
+
+// columns returns the number of columns, in the encoding of the mapper,
+// spanned by the partial line text.
+func (m *Mapper) columns(text []byte) int {
+	switch {
+	case !m.nonASCII, m.encoding == UTF8:
+		return len(text)
+	case m.encoding == UTF32:
+		return utf8.RuneCount(text)
+	default:
+		return UTF16Len(text)
+	}
+}
+
+// OffsetLineCol8 converts a valid byte offset to line and UTF-8 column numbers, both 1-based.
+func (m *Mapper) OffsetLineCol8(offset int) (int, int) {
+	line, start, cr := m.line(offset)
+	col8 := offset - start
+	if cr {
+		col8-- // retreat from \r at line end
+	}
+	return line + 1, col8 + 1
+}
+
+// line returns:
+// - the 0-based index of the line that encloses the (valid) byte offset;
+// - the start offset of that line; and
+// - whether the offset denotes a carriage return (\r) at line end.
+func (m *Mapper) line(offset int) (int, int, bool) {
+	m.initLines()
+	// In effect, binary search returns a 1-based result.
+	line := sort.Search(len(m.lineStart), func(i int) bool {
+		return offset < m.lineStart[i]
+	})
+
+	// Adjustment for line-endings: \r|\n is the same as |\r\n.
+	var eol int
+	if line == len(m.lineStart) {
+		eol = len(m.Content) // EOF
+	} else {
+		eol = m.lineStart[line] - 1
+	}
+	cr := offset == eol && offset > 0 && m.Content[offset-1] == '\r'
+
+	line-- // 0-based
+
+	return line, m.lineStart[line], cr
+}
+
+// -- conversions to byte offsets --
+
+// LocationOffsets converts a protocol location to start/end byte offsets.
+func (m *Mapper) LocationOffsets(loc Location) (int, int, error) {
+	if loc.URI != m.URI {
+		return 0, 0, fmt.Errorf("LocationOffsets: location %s does not belong to file %s", loc.URI, m.URI)
+	}
+	return m.RangeOffsets(loc.Range)
+}
+
+// RangeOffsets converts a protocol (UTF-16) range to start/end byte offsets.
+func (m *Mapper) RangeOffsets(r Range) (int, int, error) {
+	start, err := m.PositionOffset(r.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := m.PositionOffset(r.End)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("start (%v) > end (%v)", r.Start, r.End)
+	}
+	return start, end, nil
+}
+
+// PositionOffset converts a protocol (UTF-16) position to a byte offset.
+func (m *Mapper) PositionOffset(p Position) (int, error) {
+	m.initLines()
+
+	// Validate line number.
+	if p.Line > uint32(len(m.lineStart)) {
+		return 0, fmt.Errorf("line number %d out of range 0-%d", p.Line, len(m.lineStart))
+	} else if p.Line == uint32(len(m.lineStart)) {
+		if p.Character == 0 {
+			return len(m.Content), nil // EOF
+		}
+		return 0, fmt.Errorf("column is beyond end of file")
+	}
+
+	offset := m.lineStart[p.Line]
+	content := m.Content[offset:] // rest of file from start of enclosing line
+
+	if m.encoding == UTF8 || !m.nonASCII {
+		// Columns are bytes.
+		if n := bytes.IndexByte(content, '\n'); n >= 0 && int(p.Character) > n {
+			return 0, fmt.Errorf("column is beyond end of line")
+		} else if int(p.Character) > len(content) {
+			return 0, fmt.Errorf("column is beyond end of file")
+		}
+		return offset + int(p.Character), nil
+	}
+
+	if lc := m.columnsOf(int(p.Line)); lc != nil {
+		col8, err := lc.offset(int(p.Character))
+		if err != nil {
+			return 0, err
+		}
+		return offset + col8, nil
+	}
+
+	// Advance bytes up to the required number of UTF-16 codes.
+	col8 := 0
+	for col16 := 0; col16 < int(p.Character); col16++ {
+		r, sz := utf8.DecodeRune(content)
+		if sz == 0 {
+			return 0, fmt.Errorf("column is beyond end of file")
+		}
+		if r == '\n' {
+			return 0, fmt.Errorf("column is beyond end of line")
+		}
+		if sz == 1 && r == utf8.RuneError {
+			return 0, fmt.Errorf("buffer contains invalid UTF-8 text")
+		}
+		content = content[sz:]
+
+		if r >= 0x10000 && m.encoding != UTF32 {
+			col16++ // rune was encoded by a pair of surrogate UTF-16 codes
+
+			if col16 == int(p.Character) {
+				break // requested position is in the middle of a rune
+			}
+		}
+		col8 += sz
+	}
+	return offset + col8, nil
+}
+
+// ClampedRangeOffsets converts a protocol (UTF-16) range to start/end
+// byte offsets, as RangeOffsets does, except that positions out of the
+// bounds of the content are clamped to them, as by
+// ClampedPositionOffset, and a range whose start follows its end is
+// reversed. Servers that tolerate the positions some clients send past
+// the end of a line or file use it in place of RangeOffsets.
+func (m *Mapper) ClampedRangeOffsets(r Range) (int, int) {
+	start, end := m.ClampedPositionOffset(r.Start), m.ClampedPositionOffset(r.End)
+	if start > end {
+		start, end = end, start
+	}
+	return start, end
+}
+
+// ClampedPositionOffset converts a protocol (UTF-16) position to a byte
+// offset, as PositionOffset does, except that a position past the end
+// of its line is clamped to the end of the line, before its line
+// terminator, as the specification prescribes, and one past the last
+// line to the end of the file. A position that cannot be converted,
+// within invalid UTF-8 text, is also clamped to the end of its line.
+func (m *Mapper) ClampedPositionOffset(p Position) int {
+	m.initLines()
+	if p.Line >= uint32(len(m.lineStart)) {
+		return len(m.Content)
+	}
+	eol := len(m.Content)
+	if int(p.Line)+1 < len(m.lineStart) {
+		eol = m.lineStart[p.Line+1] - 1 // the \n
+		if eol > m.lineStart[p.Line] && m.Content[eol-1] == '\r' {
+			eol--
+		}
+	}
+	if offset, err := m.PositionOffset(p); err == nil && offset <= eol {
+		return offset
+	}
+	return eol
+}
+
+// LineCol8Position converts a valid line and UTF-8 column number,
+// both 1-based, to a protocol (UTF-16) position.
+func (m *Mapper) LineCol8Position(line, col8 int) (Position, error) {
+	m.initLines()
+	line0 := line - 1 // 0-based
+	if !(0 <= line0 && line0 < len(m.lineStart)) {
+		return Position{}, fmt.Errorf("line number %d out of range (max %d)", line, len(m.lineStart))
+	}
+
+	// content[start:end] is the preceding partial line.
+	start := m.lineStart[line0]
+	end := start + col8 - 1
+	if !(start <= end && end <= len(m.Content)) {
+		return Position{}, fmt.Errorf("column number %d out of range", col8)
+	}
+
+	if lc := m.columnsOf(line0); lc != nil && end-start < len(lc.cols) {
+		return Position{Line: uint32(line0), Character: uint32(lc.cols[end-start])}, nil
+	}
+	return Position{Line: uint32(line0), Character: uint32(m.columns(m.Content[start:end]))}, nil
+}
+
+// -- go/token domain convenience methods --
+
+// PosPosition converts a token pos to a protocol (UTF-16) position.
+func (m *Mapper) PosPosition(tf *token.File, pos token.Pos) (Position, error) {
+	offset, err := safetoken.Offset(tf, pos)
+	if err != nil {
+		return Position{}, err
+	}
+	return m.OffsetPosition(offset)
+}
+
+// PosLocation converts a token range to a protocol (UTF-16) location.
+func (m *Mapper) PosLocation(tf *token.File, start, end token.Pos) (Location, error) {
+	startOffset, endOffset, err := safetoken.Offsets(tf, start, end)
+	if err != nil {
+		return Location{}, err
+	}
+	rng, err := m.OffsetRange(startOffset, endOffset)
+	if err != nil {
+		return Location{}, err
+	}
+	return Location{URI: m.URI, Range: rng}, nil
+}
+
+// PosRange converts a token range to a protocol (UTF-16) range.
+func (m *Mapper) PosRange(tf *token.File, start, end token.Pos) (Range, error) {
+	startOffset, endOffset, err := safetoken.Offsets(tf, start, end)
+	if err != nil {
+		return Range{}, err
+	}
+	return m.OffsetRange(startOffset, endOffset)
+}
+
+// -- incremental changes --
+
+// Apply returns a Mapper, with the same URI and encoding as m, for the
+// content that results from applying in order the content changes of a
+// textDocument/didChange notification to the content of m.
+//
+// For incremental changes, the line table of the new Mapper is derived
+// from that of m by rescanning only the inserted text, rather than the
+// whole document, which matters for large files edited a keystroke at
+// a time.
+func (m *Mapper) Apply(changes []TextDocumentContentChangeEvent) (*Mapper, error) {
+	cur := m
+	for i, change := range changes {
+		if change.Range == nil {
+			cur = NewMapperEncoding(m.URI, []byte(change.Text), m.Encoding())
+			cur.cacheColumns.Store(m.cacheColumns.Load())
+			continue
+		}
+		start, end, err := cur.RangeOffsets(*change.Range)
+		if err != nil {
+			return nil, fmt.Errorf("change %d: %v", i, err)
+		}
+		cur.initLines()
+		content := make([]byte, 0, len(cur.Content)-(end-start)+len(change.Text))
+		content = append(content, cur.Content[:start]...)
+		content = append(content, change.Text...)
+		content = append(content, cur.Content[end:]...)
+
+		// Line starts up to start are unchanged; those within the
+		// replaced text are dropped; those after it are shifted.
+		lo := sort.SearchInts(cur.lineStart, start+1)
+		hi := sort.SearchInts(cur.lineStart, end+1)
+		delta := len(change.Text) - (end - start)
+		lineStart := make([]int, lo, len(cur.lineStart)-(hi-lo)+strings.Count(change.Text, "\n"))
+		copy(lineStart, cur.lineStart[:lo])
+		nonASCII := cur.nonASCII
+		for j := 0; j < len(change.Text); j++ {
+			switch b := change.Text[j]; {
+			case b == '\n':
+				lineStart = append(lineStart, start+j+1)
+			case b >= utf8.RuneSelf:
+				nonASCII = true
+			}
+		}
+		for _, offset := range cur.lineStart[hi:] {
+			lineStart = append(lineStart, offset+delta)
+		}
+
+		next := NewMapperEncoding(m.URI, content, m.Encoding())
+		next.cacheColumns.Store(m.cacheColumns.Load())
+		next.linesOnce.Do(func() {
+			// Removing the only non-ASCII text leaves nonASCII set,
+			// which only makes conversions slower.
+			next.lineStart, next.nonASCII = lineStart, nonASCII
+		})
+		cur = next
+	}
+	if cur == m {
+		cur = NewMapperEncoding(m.URI, m.Content, m.Encoding())
+		cur.cacheColumns.Store(m.cacheColumns.Load())
+	}
+	return cur, nil
+}
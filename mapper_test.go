@@ -0,0 +1,295 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+// This file tests Mapper's logic for converting between offsets and
+// UTF-16 positions. Line endings may be \n or \r\n.
+
+const (
+	// a string containing runes of 1, 2, 3 and 4 UTF-8 bytes;
+	// the last is encoded by a UTF-16 surrogate pair.
+	mixed = "aé世\U0001F600"
+)
+
+func TestMapperPositionOffset(t *testing.T) {
+	for _, test := range []struct {
+		content string
+		pos     lsp.Position
+		want    int // byte offset, or -1 for an error
+	}{
+		{"", lsp.Position{Line: 0, Character: 0}, 0},
+		{"abc", lsp.Position{Line: 0, Character: 3}, 3},
+		{"abc", lsp.Position{Line: 0, Character: 4}, -1},
+		{"abc\ndef", lsp.Position{Line: 1, Character: 1}, 5},
+		{"abc\n", lsp.Position{Line: 1, Character: 0}, 4}, // EOF
+		{"abc\n", lsp.Position{Line: 2, Character: 0}, 4}, // also EOF
+		{"abc\n", lsp.Position{Line: 3, Character: 0}, -1},
+		{mixed, lsp.Position{Line: 0, Character: 1}, 1},
+		{mixed, lsp.Position{Line: 0, Character: 2}, 3},
+		{mixed, lsp.Position{Line: 0, Character: 3}, 6},
+		{mixed, lsp.Position{Line: 0, Character: 5}, 10},
+		{mixed, lsp.Position{Line: 0, Character: 4}, 6}, // middle of a surrogate pair
+		{"ab\r\ncd", lsp.Position{Line: 1, Character: 0}, 4},
+	} {
+		m := lsp.NewMapper("file:///test.txt", []byte(test.content))
+		got, err := m.PositionOffset(test.pos)
+		if test.want < 0 {
+			if err == nil {
+				t.Errorf("PositionOffset(%q, %v) = %d, want error", test.content, test.pos, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("PositionOffset(%q, %v) = (%d, %v), want %d", test.content, test.pos, got, err, test.want)
+		}
+	}
+}
+
+func TestMapperClampedOffsets(t *testing.T) {
+	m := lsp.NewMapper("file:///test.txt", []byte("ab\r\n"+mixed+"\nlast"))
+	for _, test := range []struct {
+		pos  lsp.Position
+		want int
+	}{
+		{lsp.Position{Line: 0, Character: 1}, 1},
+		{lsp.Position{Line: 0, Character: 2}, 2},
+		{lsp.Position{Line: 0, Character: 3}, 2}, // the \r
+		{lsp.Position{Line: 0, Character: 99}, 2},
+		{lsp.Position{Line: 1, Character: 5}, 14},
+		{lsp.Position{Line: 1, Character: 6}, 14},
+		{lsp.Position{Line: 2, Character: 99}, 19},
+		{lsp.Position{Line: 9, Character: 0}, 19},
+	} {
+		if got := m.ClampedPositionOffset(test.pos); got != test.want {
+			t.Errorf("ClampedPositionOffset(%v) = %d, want %d", test.pos, got, test.want)
+		}
+	}
+	start, end := m.ClampedRangeOffsets(lsp.Range{Start: lsp.Position{Line: 9}, End: lsp.Position{Line: 0, Character: 99}})
+	if start != 2 || end != 19 {
+		t.Errorf("ClampedRangeOffsets = %d, %d, want 2, 19", start, end)
+	}
+}
+
+func TestMapperOffsetPosition(t *testing.T) {
+	content := "x\r\n" + mixed + "\nlast"
+	m := lsp.NewMapper("file:///test.txt", []byte(content))
+	for _, test := range []struct {
+		offset int
+		want   lsp.Position
+	}{
+		{0, lsp.Position{Line: 0, Character: 0}},
+		{1, lsp.Position{Line: 0, Character: 1}},
+		{2, lsp.Position{Line: 0, Character: 1}}, // \r at end of line is treated as the \n
+		{3, lsp.Position{Line: 1, Character: 0}},
+		{3 + 6, lsp.Position{Line: 1, Character: 3}},
+		{3 + 10, lsp.Position{Line: 1, Character: 5}},
+		{len(content), lsp.Position{Line: 2, Character: 4}},
+	} {
+		got, err := m.OffsetPosition(test.offset)
+		if err != nil || got != test.want {
+			t.Errorf("OffsetPosition(%d) = (%v, %v), want %v", test.offset, got, err, test.want)
+		}
+		// Round trip.
+		if off, err := m.PositionOffset(got); err != nil || test.offset != 2 && off != test.offset {
+			t.Errorf("PositionOffset(%v) = (%d, %v), want %d", got, off, err, test.offset)
+		}
+	}
+	if _, err := m.OffsetPosition(len(content) + 1); err == nil {
+		t.Errorf("Expected an error for an offset beyond EOF")
+	}
+}
+
+func TestMapperLineCol8Position(t *testing.T) {
+	m := lsp.NewMapper("file:///test.txt", []byte(strings.Repeat(mixed+"\n", 2)))
+	got, err := m.LineCol8Position(2, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (lsp.Position{Line: 1, Character: 5}); got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if _, err := m.LineCol8Position(4, 1); err == nil {
+		t.Errorf("Expected an error for a line beyond EOF")
+	}
+}
+
+func TestMapperEncoding(t *testing.T) {
+	content := "x\n" + mixed + "y\n"
+	for _, test := range []struct {
+		encoding lsp.PositionEncodingKind
+		want     uint32 // column of "y"
+	}{
+		{lsp.UTF8, 10},
+		{lsp.UTF16, 5},
+		{lsp.UTF32, 4},
+		{"utf-7", 5}, // unknown encodings are UTF-16
+	} {
+		t.Run(string(test.encoding), func(t *testing.T) {
+			m := lsp.NewMapperEncoding("file:///test.txt", []byte(content), test.encoding)
+			offset := strings.Index(content, "y")
+			pos, err := m.OffsetPosition(offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := (lsp.Position{Line: 1, Character: test.want}); pos != want {
+				t.Errorf("Expected position %v, got %v", want, pos)
+			}
+			if got, err := m.PositionOffset(pos); err != nil || got != offset {
+				t.Errorf("PositionOffset(%v) = (%d, %v), want %d", pos, got, err, offset)
+			}
+			if pos, err := m.LineCol8Position(2, offset-2+1); err != nil || pos.Character != test.want {
+				t.Errorf("LineCol8Position = (%v, %v), want character %d", pos, err, test.want)
+			}
+			if _, err := m.PositionOffset(lsp.Position{Line: 1, Character: test.want + 2}); err == nil {
+				t.Errorf("Expected an error for a column beyond the end of the line")
+			}
+		})
+	}
+}
+
+func TestMapperApply(t *testing.T) {
+	edit := func(line, start, end uint32, text string) lsp.TextDocumentContentChangeEvent {
+		r := lsp.Range{Start: lsp.Position{Line: line, Character: start}, End: lsp.Position{Line: line, Character: end}}
+		return lsp.TextDocumentContentChangeEvent{Range: &r, Text: text}
+	}
+	for _, test := range []struct {
+		name    string
+		content string
+		changes []lsp.TextDocumentContentChangeEvent
+		want    string
+	}{
+		{"Insert", "abc\ndef\n", []lsp.TextDocumentContentChangeEvent{edit(1, 1, 1, "X")}, "abc\ndXef\n"},
+		{"InsertLines", "abc\ndef\n", []lsp.TextDocumentContentChangeEvent{edit(0, 3, 3, "\n1\n2")}, "abc\n1\n2\ndef\n"},
+		{"JoinLines", "abc\ndef\nghi", []lsp.TextDocumentContentChangeEvent{{
+			Range: &lsp.Range{Start: lsp.Position{Line: 0, Character: 1}, End: lsp.Position{Line: 2, Character: 1}},
+		}}, "ahi"},
+		{"NonASCII", "abc\ndef\n", []lsp.TextDocumentContentChangeEvent{edit(0, 1, 2, mixed)}, "a" + mixed + "c\ndef\n"},
+		{"Sequence", "abc\r\ndef\r\n", []lsp.TextDocumentContentChangeEvent{
+			edit(0, 3, 3, "\r\n"),
+			edit(2, 0, 3, mixed),
+			edit(1, 0, 0, "x"),
+		}, "abc\r\nx\r\n" + mixed + "\r\n"},
+		{"Whole", "abc", []lsp.TextDocumentContentChangeEvent{edit(0, 0, 1, "x"), {Text: "new\ntext"}, edit(1, 0, 0, "!")}, "new\n!text"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m := lsp.NewMapper("file:///a.go", []byte(test.content))
+			m.OffsetPosition(0) // compute the line table before the changes
+			got, err := m.Apply(test.changes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got.Content) != test.want {
+				t.Fatalf("Expected content %q, got %q", test.want, got.Content)
+			}
+			if string(m.Content) != test.content {
+				t.Errorf("Apply modified the original content: %q", m.Content)
+			}
+			// The incrementally maintained line table must agree with a
+			// fresh one at every offset.
+			fresh := lsp.NewMapper(m.URI, got.Content)
+			for offset := 0; offset <= len(got.Content); offset++ {
+				want, err := fresh.OffsetPosition(offset)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if pos, err := got.OffsetPosition(offset); err != nil || pos != want {
+					t.Errorf("OffsetPosition(%d) = %v, %v; want %v", offset, pos, err, want)
+				}
+				if back, err := got.PositionOffset(want); err != nil {
+					t.Errorf("PositionOffset(%v) failed: %v", want, err)
+				} else if wantBack, _ := fresh.PositionOffset(want); back != wantBack {
+					t.Errorf("PositionOffset(%v) = %d, want %d", want, back, wantBack)
+				}
+			}
+		})
+	}
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		m := lsp.NewMapper("file:///a.go", []byte("abc"))
+		if _, err := m.Apply([]lsp.TextDocumentContentChangeEvent{edit(3, 0, 0, "x")}); err == nil {
+			t.Errorf("Expected an error for a change beyond the end of the document")
+		}
+	})
+}
+
+func BenchmarkMapperApply(b *testing.B) {
+	m := lsp.NewMapper("file:///a.go", []byte(strings.Repeat("\tfmt.Println(\"hello, world\")\n", 100000)))
+	r := lsp.Range{Start: lsp.Position{Line: 50000, Character: 1}, End: lsp.Position{Line: 50000, Character: 1}}
+	changes := []lsp.TextDocumentContentChangeEvent{{Range: &r, Text: "x"}}
+	b.ReportAllocs()
+	for b.Loop() {
+		next, err := m.Apply(changes)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := next.PositionOffset(r.End); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMapperColumnCache(t *testing.T) {
+	content := "package a\r\n// " + mixed + mixed + "\n\xffbad" + mixed + "\nlast " + mixed
+	for _, enc := range []lsp.PositionEncodingKind{lsp.UTF16, lsp.UTF32, lsp.UTF8} {
+		t.Run(string(enc), func(t *testing.T) {
+			plain := lsp.NewMapperEncoding("file:///a.go", []byte(content), enc)
+			cached := lsp.NewMapperEncoding("file:///a.go", []byte(content), enc)
+			cached.EnableColumnCache()
+			// Query twice, to use the cache once it is filled.
+			for range 2 {
+				for offset := 0; offset <= len(content); offset++ {
+					want, _ := plain.OffsetPosition(offset)
+					if got, _ := cached.OffsetPosition(offset); got != want {
+						t.Errorf("OffsetPosition(%d) = %v, want %v", offset, got, want)
+					}
+					line, col8 := plain.OffsetLineCol8(offset)
+					want, _ = plain.LineCol8Position(line, col8)
+					if got, _ := cached.LineCol8Position(line, col8); got != want {
+						t.Errorf("LineCol8Position(%d, %d) = %v, want %v", line, col8, got, want)
+					}
+				}
+				for line := uint32(0); line <= 4; line++ {
+					for char := uint32(0); char <= 24; char++ {
+						pos := lsp.Position{Line: line, Character: char}
+						want, wantErr := plain.PositionOffset(pos)
+						got, err := cached.PositionOffset(pos)
+						if got != want || (err == nil) != (wantErr == nil) || (err != nil && err.Error() != wantErr.Error()) {
+							t.Errorf("PositionOffset(%v) = %d, %v; want %d, %v", pos, got, err, want, wantErr)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMapperColumnCache(b *testing.B) {
+	line := strings.Repeat(mixed+" ", 200)
+	for _, cache := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cache=%t", cache), func(b *testing.B) {
+			m := lsp.NewMapper("file:///a.go", []byte(line+"\n"+line))
+			if cache {
+				m.EnableColumnCache()
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := m.OffsetPosition(len(line) - 1); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := m.PositionOffset(lsp.Position{Line: 1, Character: 1000}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+// This file defines the optional cache of the columns of the lines of a
+// Mapper, which makes repeated conversions within the same lines of
+// non-ASCII text take constant time.
+
+// maxCachedLines bounds the number of lines whose columns a Mapper
+// caches; the cache is emptied when it is full.
+const maxCachedLines = 1024
+
+// EnableColumnCache makes m cache, for each non-ASCII line in which it
+// converts a position, a table of the columns of every byte of the line,
+// so that further conversions within that line take constant time
+// instead of time proportional to the length of the line. This pays off
+// for documents that are queried repeatedly, for instance for semantic
+// tokens, inlay hints and highlights after every keystroke.
+//
+// The cache of a line takes about eight bytes per byte of the line, and
+// at most 1024 lines are cached. Mappers derived from m by [Mapper.Apply]
+// also cache their columns. EnableColumnCache has no effect for the
+// UTF-8 encoding, in which columns are bytes.
+func (m *Mapper) EnableColumnCache() {
+	m.cacheColumns.Store(true)
+}
+
+// lineColumns caches the conversions between byte offsets and columns
+// within one line of a Mapper.
+type lineColumns struct {
+	cols    []int32 // cols[i] is the column of the byte at offset i within the line
+	offsets []int32 // offsets[c] is the offset within the line of column c
+	invalid int     // column of the first invalid UTF-8 byte, or -1
+	newline bool    // whether the line ends with \n
+}
+
+// columnsOf returns the column table of the given line, which must
+// exist, or nil if columns are not cached.
+func (m *Mapper) columnsOf(line int) *lineColumns {
+	if !m.cacheColumns.Load() || !m.nonASCII || m.encoding == UTF8 {
+		return nil
+	}
+	m.colMu.Lock()
+	defer m.colMu.Unlock()
+	if lc, ok := m.colCache[line]; ok {
+		return lc
+	}
+	if m.colCache == nil || len(m.colCache) >= maxCachedLines {
+		m.colCache = make(map[int]*lineColumns)
+	}
+	lc := m.newLineColumns(line)
+	m.colCache[line] = lc
+	return lc
+}
+
+// newLineColumns computes the column table of a line.
+func (m *Mapper) newLineColumns(line int) *lineColumns {
+	text := m.Content[m.lineStart[line]:]
+	lc := &lineColumns{invalid: -1}
+	if n := bytes.IndexByte(text, '\n'); n >= 0 {
+		text, lc.newline = text[:n], true
+	}
+	// Columns are counted as by Mapper.columns, in which each byte of an
+	// invalid or partial rune counts as one column, and offsets as by
+	// Mapper.PositionOffset, in which a column in the middle of a
+	// surrogate pair denotes the start of the rune.
+	lc.cols = make([]int32, len(text)+1)
+	lc.offsets = make([]int32, 0, len(text)+1)
+	col := 0
+	for i := 0; i < len(text); {
+		r, sz := utf8.DecodeRune(text[i:])
+		if r == utf8.RuneError && sz == 1 && lc.invalid < 0 {
+			lc.invalid = col
+		}
+		width := 1
+		if r >= 0x10000 && m.encoding != UTF32 {
+			width = 2
+		}
+		for range width {
+			lc.offsets = append(lc.offsets, int32(i))
+		}
+		for k := range sz {
+			lc.cols[i+k] = int32(col + k)
+		}
+		i += sz
+		col += width
+	}
+	lc.cols[len(text)] = int32(col)
+	lc.offsets = append(lc.offsets, int32(len(text)))
+	return lc
+}
+
+// offset returns the offset within the line of a column, or an error
+// as reported by Mapper.PositionOffset.
+func (lc *lineColumns) offset(col int) (int, error) {
+	switch {
+	case lc.invalid >= 0 && col > lc.invalid:
+		return 0, fmt.Errorf("buffer contains invalid UTF-8 text")
+	case col >= len(lc.offsets) && lc.newline:
+		return 0, fmt.Errorf("column is beyond end of line")
+	case col >= len(lc.offsets):
+		return 0, fmt.Errorf("column is beyond end of file")
+	}
+	return int(lc.offsets[col]), nil
+}
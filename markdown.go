@@ -0,0 +1,466 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultAllowedSchemes are the schemes of the URLs that links and images
+// may have in sanitized Markdown by default.
+var DefaultAllowedSchemes = []string{"http", "https", "mailto", "file"}
+
+// A MarkdownSanitizer sanitizes the Markdown of hovers and documentation
+// that a server relays from third parties, such as the documentation of
+// dependencies, before sending it to the client, which may render it as
+// HTML:
+//
+//	var sanitizer lsp.MarkdownSanitizer
+//	sanitizer.SanitizeContent(&hover.Contents)
+//
+// It removes raw HTML, and the links and images whose URLs have a scheme
+// that is not allowed, such as javascript: or command:, keeping their
+// text. Code spans and code blocks are left alone, as their content is
+// not rendered as HTML.
+//
+// A server that adds command links of its own, as to view the full
+// documentation, should add them after sanitizing. Its zero value is
+// ready to use.
+type MarkdownSanitizer struct {
+	// AllowedSchemes lists the schemes, in lower case, of the URLs that
+	// links and images may have. If nil, it is DefaultAllowedSchemes.
+	// URLs without a scheme, which are relative, are allowed.
+	AllowedSchemes []string
+	// EscapeHTML makes raw HTML show as text, rather than removing it.
+	EscapeHTML bool
+}
+
+// SanitizeMarkdown sanitizes Markdown with the default settings of a
+// [MarkdownSanitizer].
+func SanitizeMarkdown(markdown string) string {
+	var s MarkdownSanitizer
+	return s.Sanitize(markdown)
+}
+
+// SanitizeContent sanitizes markup content if it is Markdown. Plain text
+// is left alone.
+func (s *MarkdownSanitizer) SanitizeContent(c *MarkupContent) {
+	if c != nil && c.Kind == Markdown {
+		c.Value = s.Sanitize(c.Value)
+	}
+}
+
+var (
+	// fenceRE matches the opening line of a fenced code block.
+	fenceRE = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	// containerRE matches the block quote markers and list markers that
+	// open a line, inside which link reference definitions may be.
+	containerRE = regexp.MustCompile(`^(?:[ \t]*(?:>|[-+*][ \t]|[0-9]{1,9}[.)][ \t]))*`)
+	// linkDefRE matches a link reference definition, after the markers
+	// of its containers, whose destination may be on the next line.
+	linkDefRE = regexp.MustCompile(`^[ \t]*\[((?:[^\[\]\\]|\\.)+)\]:[ \t]*(<[^>\n]*>|[^ \t\r\n]+)?`)
+	// anyLinkDefRE matches anything that may be the start of a link
+	// reference definition, wherever it is.
+	anyLinkDefRE = regexp.MustCompile(`\[((?:[^\[\]\\]|\\.)+)\]:`)
+	// autolinkRE matches an autolink to a URL.
+	autolinkRE = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.\-]{1,31}:[^\s<>]*)>`)
+	// htmlRE matches a raw HTML tag, comment, processing instruction,
+	// declaration or CDATA section, as defined by CommonMark.
+	htmlRE = regexp.MustCompile(`^(?:` +
+		`<[A-Za-z][A-Za-z0-9\-]*(?:\s+[A-Za-z_:][A-Za-z0-9_.:\-]*(?:\s*=\s*(?:[^\s"'=<>` + "`" + `]+|'[^']*'|"[^"]*"))?)*\s*/?>` +
+		`|</[A-Za-z][A-Za-z0-9\-]*\s*>` +
+		`|(?s:<!--.*?-->)` +
+		`|(?s:<\?.*?\?>)` +
+		`|<![A-Za-z][^>]*>` +
+		`|(?s:<!\[CDATA\[.*?\]\]>))`)
+	// rawTextRE matches the opening tags of the elements whose content
+	// is removed along with them.
+	rawTextRE = regexp.MustCompile(`(?i)^<(script|style|iframe|textarea|title)[\s/>]`)
+)
+
+// Sanitize returns the sanitized Markdown.
+//
+// The link reference definitions whose destination is not allowed are
+// removed, including those in block quotes and list items, or whose
+// destination is on the next line. As the definitions that it does not
+// recognize might still be taken as such by a renderer, the reference
+// links to their labels are escaped so as not to be links.
+func (s *MarkdownSanitizer) Sanitize(markdown string) string {
+	lines := slices.Collect(strings.Lines(markdown))
+	// The lines of code blocks, and those of the link reference
+	// definitions that are removed.
+	code := make([]bool, len(lines))
+	remove := make([]bool, len(lines))
+	checked := make(map[int]bool) // the offsets of the definitions found
+
+	var fence string // the fence of the current code block, if any
+	indented := false
+	blank := true // the previous line is blank, or the first
+	skip := false // the line holds the destination of a definition
+	offset := 0
+	for i, line := range lines {
+		start := offset
+		offset += len(line)
+		if skip {
+			skip = false
+			blank = false
+			continue
+		}
+		switch {
+		case fence != "":
+			code[i] = true
+			if f := fenceRE.FindStringSubmatch(line); f != nil && f[1][0] == fence[0] && len(f[1]) >= len(fence) && strings.TrimSpace(line[len(f[0]):]) == "" {
+				fence = ""
+			}
+			continue
+		case fenceRE.MatchString(line):
+			fence = fenceRE.FindStringSubmatch(line)[1]
+			code[i] = true
+			continue
+		case (blank || indented) && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")) && strings.TrimSpace(line) != "":
+			// An indented code block.
+			indented = true
+			code[i] = true
+			continue
+		}
+		isBlank := strings.TrimSpace(line) == ""
+		if indented && isBlank {
+			code[i] = true
+			continue
+		}
+		indented = false
+		blank = isBlank
+		prefix := len(containerRE.FindString(line))
+		m := linkDefRE.FindStringSubmatchIndex(line[prefix:])
+		if m == nil {
+			continue
+		}
+		var dest string
+		if m[4] >= 0 {
+			dest = line[prefix+m[4] : prefix+m[5]]
+		}
+		if dest == "" && i+1 < len(lines) {
+			// The destination is on the next line.
+			next := lines[i+1]
+			dest, _, _ = strings.Cut(strings.TrimSpace(next[len(containerRE.FindString(next)):]), " ")
+			skip = dest != ""
+		}
+		if dest == "" {
+			continue
+		}
+		checked[start+prefix+m[2]-1] = true
+		if !s.allowedURL(strings.Trim(dest, "<>")) {
+			remove[i] = true
+			if skip {
+				remove[i+1] = true
+			}
+		}
+	}
+
+	// The labels of what may be definitions that were not checked.
+	unchecked := make(map[string]bool)
+	for _, m := range anyLinkDefRE.FindAllStringSubmatchIndex(markdown, -1) {
+		if !checked[m[0]] {
+			unchecked[normalizeLabel(markdown[m[2]:m[3]])] = true
+		}
+	}
+
+	var out, text strings.Builder
+	flush := func() {
+		out.WriteString(s.sanitizeInline(text.String(), unchecked))
+		text.Reset()
+	}
+	for i, line := range lines {
+		switch {
+		case code[i]:
+			flush()
+			out.WriteString(line)
+		case !remove[i]:
+			text.WriteString(line)
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// normalizeLabel returns the normalized form of a link label, by which
+// references match definitions.
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// sanitizeInline sanitizes the inline content of Markdown, outside code
+// blocks, escaping the reference links to the labels of unchecked.
+func (s *MarkdownSanitizer) sanitizeInline(text string, unchecked map[string]bool) string {
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text):
+			out.WriteString(text[i : i+2])
+			i += 2
+		case c == '`':
+			n := backtickRun(text[i:])
+			end := closingBackticks(text[i+n:], n)
+			if end < 0 {
+				out.WriteString(text[i : i+n])
+				i += n
+			} else {
+				out.WriteString(text[i : i+n+end+n])
+				i += n + end + n
+			}
+		case c == '<':
+			if m := autolinkRE.FindStringSubmatch(text[i:]); m != nil {
+				if s.allowedURL(m[1]) {
+					out.WriteString(m[0])
+				} else {
+					out.WriteString(`\<` + m[1] + `\>`)
+				}
+				i += len(m[0])
+				break
+			}
+			m := htmlRE.FindString(text[i:])
+			if m == "" {
+				out.WriteByte(c)
+				i++
+				break
+			}
+			if s.EscapeHTML {
+				out.WriteString("&lt;")
+				i++
+				break
+			}
+			i += len(m)
+			if tag := rawTextRE.FindStringSubmatch(m + " "); tag != nil && !strings.HasSuffix(m, "/>") {
+				// Remove the content of the element too.
+				closing := strings.Index(strings.ToLower(text[i:]), "</"+strings.ToLower(tag[1]))
+				if closing < 0 {
+					i = len(text)
+				} else if gt := strings.IndexByte(text[i+closing:], '>'); gt < 0 {
+					i = len(text)
+				} else {
+					i += closing + gt + 1
+				}
+			}
+		case c == '[' || c == '!' && i+1 < len(text) && text[i+1] == '[':
+			open := i
+			if c == '!' {
+				open++
+			}
+			label, dest, end, ok := parseInlineLink(text, open)
+			if !ok {
+				if label, ref, end, ok := parseReferenceLink(text, open); ok && unchecked[normalizeLabel(ref)] {
+					// A reference to what may be a definition that
+					// was not checked.
+					out.WriteString(text[i:open])
+					out.WriteString(`\[` + s.sanitizeInline(label, unchecked) + `]`)
+					if rest := text[open+1+len(label)+1 : end]; rest != "" {
+						out.WriteString(`\` + rest)
+					}
+					i = end
+					break
+				}
+				out.WriteString(text[i : open+1])
+				i = open + 1
+				break
+			}
+			if s.allowedURL(dest) {
+				out.WriteString(text[i : open+1])
+				out.WriteString(s.sanitizeInline(label, unchecked))
+				out.WriteString(text[open+1+len(label) : end])
+			} else {
+				out.WriteString(s.sanitizeInline(label, unchecked))
+			}
+			i = end
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+// backtickRun returns the length of the run of backticks at the start of
+// text.
+func backtickRun(text string) int {
+	n := 0
+	for n < len(text) && text[n] == '`' {
+		n++
+	}
+	return n
+}
+
+// closingBackticks returns the offset in text of the first run of exactly
+// n backticks, or -1 if there is none.
+func closingBackticks(text string, n int) int {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := backtickRun(text[i:])
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
+
+// labelEnd returns the offset of the bracket that closes the label
+// opened by the bracket at text[open], or -1 if there is none.
+func labelEnd(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseReferenceLink parses the reference link whose label opens with
+// the bracket at text[open], as in [label][ref], [ref][] or [ref]. It
+// returns the label, the label of the reference, and the offset of the
+// end of the link.
+func parseReferenceLink(text string, open int) (label, ref string, end int, ok bool) {
+	close := labelEnd(text, open)
+	if close < 0 {
+		return "", "", 0, false
+	}
+	label, ref, end = text[open+1:close], text[open+1:close], close+1
+	if end < len(text) && text[end] == '[' {
+		if close := labelEnd(text, end); close >= 0 && !strings.Contains(text[end+1:close], "[") {
+			if close > end+1 {
+				ref = text[end+1 : close]
+			}
+			end = close + 1
+		}
+	}
+	return label, ref, end, true
+}
+
+// parseInlineLink parses the inline link whose label opens with the
+// bracket at text[open], as in [label](dest "title"). It returns the
+// label, the destination, and the offset of the end of the link.
+func parseInlineLink(text string, open int) (label, dest string, end int, ok bool) {
+	// The label, with balanced brackets.
+	depth := 0
+	i := open
+	for ; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if i+1 >= len(text) || text[i] != ']' || text[i+1] != '(' {
+		return "", "", 0, false
+	}
+	label = text[open+1 : i]
+	i += 2
+	i = skipLinkSpace(text, i)
+
+	// The destination, in angle brackets or with balanced parentheses.
+	start := i
+	if i < len(text) && text[i] == '<' {
+		gt := strings.IndexAny(text[i:], ">\n")
+		if gt < 0 || text[i+gt] != '>' {
+			return "", "", 0, false
+		}
+		dest = text[i+1 : i+gt]
+		i += gt + 1
+	} else {
+		depth := 0
+	loop:
+		for ; i < len(text); i++ {
+			switch c := text[i]; {
+			case c == '\\' && i+1 < len(text):
+				i++
+			case c == '(':
+				depth++
+			case c == ')':
+				if depth == 0 {
+					break loop
+				}
+				depth--
+			case c <= ' ':
+				break loop
+			}
+		}
+		dest = text[start:i]
+	}
+	i = skipLinkSpace(text, i)
+
+	// The optional title.
+	if i < len(text) && (text[i] == '"' || text[i] == '\'' || text[i] == '(') {
+		closing := text[i]
+		if closing == '(' {
+			closing = ')'
+		}
+		for i++; i < len(text) && text[i] != closing; i++ {
+			if text[i] == '\\' {
+				i++
+			}
+		}
+		if i >= len(text) {
+			return "", "", 0, false
+		}
+		i = skipLinkSpace(text, i+1)
+	}
+	if i >= len(text) || text[i] != ')' {
+		return "", "", 0, false
+	}
+	return label, dest, i + 1, true
+}
+
+// skipLinkSpace returns the offset of the first character of text at or
+// after i that is not a space, tab or line ending.
+func skipLinkSpace(text string, i int) int {
+	for i < len(text) && (text[i] == ' ' || text[i] == '\t' || text[i] == '\n' || text[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// allowedURL reports whether a link may have the URL, as it appears in
+// Markdown, which is allowed unless it has a scheme that is not. Like
+// renderers, it decodes the entities and escapes of the URL, and ignores
+// the whitespace and control characters that browsers ignore.
+func (s *MarkdownSanitizer) allowedURL(url string) bool {
+	url = html.UnescapeString(url)
+	url = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '\\' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, url)
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return true // relative
+	}
+	schemes := s.AllowedSchemes
+	if schemes == nil {
+		schemes = DefaultAllowedSchemes
+	}
+	return slices.Contains(schemes, strings.ToLower(url[:colon]))
+}
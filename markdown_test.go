@@ -0,0 +1,61 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	for _, test := range []struct {
+		name, in, want string
+	}{
+		{"Plain", "Returns *T* if `x < y`.", "Returns *T* if `x < y`."},
+		{"Tags", "a <b>bold</b> <img src=x onerror=\"alert(1)\"> c", "a bold  c"},
+		{"Script", "a<script>alert('<b>')</script>b", "ab"},
+		{"Comment", "a<!-- <b> -->b", "ab"},
+		{"LessThan", "if a < b && c<d then", "if a < b && c<d then"},
+		{"Link", "see [docs](https://go.dev/doc \"Go\")", "see [docs](https://go.dev/doc \"Go\")"},
+		{"RelativeLink", "see [x](./x.md)", "see [x](./x.md)"},
+		{"JavaScriptLink", "see [docs](javascript:alert(1)) now", "see docs now"},
+		{"EncodedScheme", "[x](jav&#x61;script:alert(1))", "x"},
+		{"WhitespaceInScheme", "[x](<java\tscript:alert(1)>)", "x"},
+		{"CommandLink", "[run](command:rm?%5B%5D)", "run"},
+		{"Image", "![logo](data:image/png;base64,AAAA)", "logo"},
+		{"NestedLabel", "[**<i>a</i>**](https://x)", "[**a**](https://x)"},
+		{"Autolink", "<https://go.dev> <javascript:alert(1)>", `<https://go.dev> \<javascript:alert(1)\>`},
+		{"Definition", "[a]: javascript:alert(1)\n[b]: https://go.dev\n", "[b]: https://go.dev\n"},
+		{"DefinitionOnNextLine", "[r]:\n  javascript:alert(1)\n[x][r]", "[x][r]"},
+		{"DefinitionInQuote", "> [r]: javascript:alert(1)\n\n[x][r]", "\n[x][r]"},
+		{"DefinitionInList", "- [r]: javascript:alert(1)\n\n[r][]", "\n[r][]"},
+		{"AllowedDefinitionInQuote", "> [r]:\n> https://go.dev\n\n[x][r]", "> [r]:\n> https://go.dev\n\n[x][r]"},
+		{"UncheckedDefinition", "[x][r] [r]\n\n```\n[r]: javascript:alert(1)\n```", "\\[x]\\[r] \\[r]\n\n```\n[r]: javascript:alert(1)\n```"},
+		{"Escaped", `\<b> \[x](javascript:y)`, `\<b> \[x](javascript:y)`},
+		{"Fenced", "```html\n<b>x</b>\n```\n<b>y</b>", "```html\n<b>x</b>\n```\ny"},
+		{"Indented", "text\n\n    List<String> l;\n<b>y</b>", "text\n\n    List<String> l;\ny"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := lsp.SanitizeMarkdown(test.in); got != test.want {
+				t.Errorf("SanitizeMarkdown(%q) = %q, want %q", test.in, got, test.want)
+			}
+		})
+	}
+
+	t.Run("Options", func(t *testing.T) {
+		s := lsp.MarkdownSanitizer{AllowedSchemes: []string{"https", "command"}, EscapeHTML: true}
+		c := lsp.MarkupContent{Kind: lsp.Markdown, Value: "<b>[run](command:x) [mail](mailto:a@b)"}
+		s.SanitizeContent(&c)
+		if want := "&lt;b>[run](command:x) mail"; c.Value != want {
+			t.Errorf("Expected %q, got %q", want, c.Value)
+		}
+		plain := lsp.MarkupContent{Kind: lsp.PlainText, Value: "<b>"}
+		s.SanitizeContent(&plain)
+		if plain.Value != "<b>" {
+			t.Errorf("Expected plain text to be left alone, got %q", plain.Value)
+		}
+	})
+}
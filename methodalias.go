@@ -0,0 +1,48 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// MethodAliases maps the nonstandard names of methods that some peers
+// send, such as legacy or vendor-prefixed ones, to the methods they
+// stand for, so that a server interoperates with them without a
+// dispatcher of its own:
+//
+//	aliases := lsp.MethodAliases{
+//		"textDocument/fullSemanticTokens": lsp.MethodTextDocumentSemanticTokensFull,
+//	}
+//	handler := aliases.Handler(lsp.ServerHandler(server))
+//
+// An alias is resolved once: the method it maps to is not itself looked
+// up in the table.
+type MethodAliases map[string]string
+
+// Resolve returns the method that method stands for: the method it is
+// an alias of, or method itself.
+func (a MethodAliases) Resolve(method string) string {
+	if m, ok := a[method]; ok {
+		return m
+	}
+	return method
+}
+
+// Handler returns a handler that invokes handler with the requests and
+// notifications whose method is an alias renamed to the method it
+// stands for. The replies to calls are unaffected.
+func (a MethodAliases) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if m, ok := a[req.Method]; ok {
+			aliased := *req
+			aliased.Method = m
+			req = &aliased
+		}
+		return handler.Handle(ctx, req)
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestMethodAliases(t *testing.T) {
+	ctx := context.Background()
+	aliases := lsp.MethodAliases{
+		"textDocument/fullSemanticTokens": lsp.MethodTextDocumentSemanticTokensFull,
+	}
+	if got := aliases.Resolve("textDocument/fullSemanticTokens"); got != "textDocument/semanticTokens/full" {
+		t.Errorf("Expected textDocument/semanticTokens/full, got %s", got)
+	}
+	if got := aliases.Resolve("textDocument/hover"); got != "textDocument/hover" {
+		t.Errorf("Expected textDocument/hover, got %s", got)
+	}
+
+	handler := aliases.Handler(lsp.NewServer("test", "v1").
+		OnSemanticTokensFull(func(_ context.Context, params *lsp.SemanticTokensParams) (*lsp.SemanticTokens, error) {
+			return &lsp.SemanticTokens{Data: []uint32{0, 0, 3, 1, 0}}, nil
+		}).
+		Handler())
+
+	call, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "textDocument/fullSemanticTokens", &lsp.SemanticTokensParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := handler.Handle(ctx, call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens lsp.SemanticTokens
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]uint32{0, 0, 3, 1, 0}, tokens.Data); diff != "" {
+		t.Errorf("Unexpected tokens (-want +got):\n%s", diff)
+	}
+	if call.Method != "textDocument/fullSemanticTokens" {
+		t.Errorf("Expected the request to be unchanged, got method %s", call.Method)
+	}
+
+	if _, err := handler.Handle(ctx, newHoverCall(t)); errorCode(err) != int64(lsp.MethodNotFound) {
+		t.Errorf("Expected a MethodNotFound error for an unhandled method, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A MethodFilter restricts the methods that the peer of a connection may
+// invoke, for example to keep an untrusted client from running the
+// commands of workspace/executeCommand, or the custom extensions of a
+// server:
+//
+//	filter := &lsp.MethodFilter{Deny: []string{"workspace/executeCommand", "gopls/*"}}
+//	handler := filter.Handler(lsp.ServerHandler(server))
+//
+// The entries of Allow and Deny are methods, or prefixes of methods
+// followed by "*", as in "$/*". Requests for methods that are not
+// allowed fail with RequestFailed, and notifications are dropped; both
+// are logged through the event system.
+type MethodFilter struct {
+	// Allow lists the only methods that may be invoked, in addition to
+	// those of the lifecycle of a connection (initialize, initialized,
+	// shutdown, exit and $/cancelRequest). If empty, all methods may be
+	// invoked, except those of Deny.
+	Allow []string
+	// Deny lists the methods that may not be invoked, even if allowed
+	// by Allow.
+	Deny []string
+}
+
+// lifecycleMethods are the methods that Allow need not list.
+var lifecycleMethods = []string{"initialize", "initialized", "shutdown", "exit", "$/cancelRequest"}
+
+// Allowed reports whether the filter allows method.
+func (f *MethodFilter) Allowed(method string) bool {
+	if matchMethod(f.Deny, method) {
+		return false
+	}
+	return len(f.Allow) == 0 || matchMethod(f.Allow, method) || slices.Contains(lifecycleMethods, method)
+}
+
+// matchMethod reports whether method matches one of the patterns.
+func matchMethod(patterns []string, method string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			return strings.HasPrefix(method, prefix)
+		}
+		return pattern == method
+	})
+}
+
+// Handler returns a handler that passes the requests and notifications
+// the filter allows to handler, and rejects the others.
+func (f *MethodFilter) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if f.Allowed(req.Method) {
+			return handler.Handle(ctx, req)
+		}
+		ctx = withRequest(ctx, req)
+		event.Log(ctx, "method not allowed", RequestLabels(ctx)...)
+		if !req.IsCall() {
+			return nil, nil
+		}
+		return nil, Errorf(RequestFailed, "method %q is not allowed", req.Method)
+	})
+}
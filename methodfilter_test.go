@@ -0,0 +1,90 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest/eventtest"
+)
+
+func TestMethodFilter(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		filter  lsp.MethodFilter
+		allowed []string
+		denied  []string
+	}{
+		{
+			name:    "Empty",
+			allowed: []string{"textDocument/hover", "workspace/executeCommand", "gopls/doc"},
+		},
+		{
+			name:    "Deny",
+			filter:  lsp.MethodFilter{Deny: []string{"workspace/executeCommand", "gopls/*"}},
+			allowed: []string{"textDocument/hover", "initialize"},
+			denied:  []string{"workspace/executeCommand", "gopls/doc", "gopls/"},
+		},
+		{
+			name:    "Allow",
+			filter:  lsp.MethodFilter{Allow: []string{"textDocument/*"}, Deny: []string{"textDocument/formatting"}},
+			allowed: []string{"textDocument/hover", "initialize", "initialized", "shutdown", "exit", "$/cancelRequest"},
+			denied:  []string{"textDocument/formatting", "workspace/symbol", "$/setTrace"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, method := range test.allowed {
+				if !test.filter.Allowed(method) {
+					t.Errorf("Expected %s to be allowed", method)
+				}
+			}
+			for _, method := range test.denied {
+				if test.filter.Allowed(method) {
+					t.Errorf("Expected %s to be denied", method)
+				}
+			}
+		})
+	}
+
+	t.Run("Handler", func(t *testing.T) {
+		ctx, capture := eventtest.NewCapture(context.Background())
+		var handled []string
+		filter := &lsp.MethodFilter{Deny: []string{"workspace/executeCommand", "custom/*"}}
+		handler := filter.Handler(jsonrpc2.HandlerFunc(func(_ context.Context, req *jsonrpc2.Request) (any, error) {
+			handled = append(handled, req.Method)
+			return "ok", nil
+		}))
+
+		call, _ := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "workspace/executeCommand", &lsp.ExecuteCommandParams{Command: "rm"})
+		if _, err := handler.Handle(ctx, call); !errors.Is(err, lsp.NewError(lsp.RequestFailed, "")) {
+			t.Errorf("Expected a RequestFailed error, got %v", err)
+		}
+		notification, _ := jsonrpc2.NewNotification("custom/event", nil)
+		if result, err := handler.Handle(ctx, notification); result != nil || err != nil {
+			t.Errorf("Expected the notification to be dropped, got %v, %v", result, err)
+		}
+		if result, err := handler.Handle(ctx, newHoverCall(t)); result != "ok" || err != nil {
+			t.Errorf("Expected the hover to be handled, got %v, %v", result, err)
+		}
+
+		if diff := cmp.Diff([]string{"textDocument/hover"}, handled); diff != "" {
+			t.Errorf("Unexpected handled methods (-want +got):\n%s", diff)
+		}
+		var logged []string
+		for _, ev := range capture.Events() {
+			if method, ok := eventtest.Find(ev, "method"); ok {
+				logged = append(logged, method.String())
+			}
+		}
+		if diff := cmp.Diff([]string{"workspace/executeCommand", "custom/event"}, logged); diff != "" {
+			t.Errorf("Unexpected logged methods (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A MethodSpec describes a method of the protocol, as proxies and tests
+// need to know how to route and decode its messages.
+type MethodSpec struct {
+	Method       string       // the name of the method, such as MethodTextDocumentHover
+	ToServer     bool         // sent by the client, handled by a Server
+	ToClient     bool         // sent by the server, handled by a Client
+	Notification bool         // a notification, not a request
+	Params       reflect.Type // the type of the params, or nil
+	Result       reflect.Type // the type of the result of a request, or nil
+}
+
+// LookupMethod returns the description of a method of the protocol, and
+// whether it is one.
+func LookupMethod(method string) (MethodSpec, bool) {
+	info, ok := methods[method]
+	if !ok {
+		return MethodSpec{}, false
+	}
+	return info.spec(method), true
+}
+
+// Methods returns the descriptions of the methods of the protocol,
+// sorted by name.
+func Methods() []MethodSpec {
+	specs := make([]MethodSpec, 0, len(methods))
+	for method, info := range methods {
+		specs = append(specs, info.spec(method))
+	}
+	slices.SortFunc(specs, func(a, b MethodSpec) int { return strings.Compare(a.Method, b.Method) })
+	return specs
+}
+
+func (info methodInfo) spec(method string) MethodSpec {
+	return MethodSpec{
+		Method:       method,
+		ToServer:     info.toServer,
+		ToClient:     info.toClient,
+		Notification: info.notification,
+		Params:       info.params,
+		Result:       info.result,
+	}
+}
+
+// A RequestType describes a request whose params are of type P and
+// whose result is of type R, so that calls are type-checked:
+//
+//	hover, err := lsp.CallMethod(ctx, conn, lsp.HoverMethod, &lsp.HoverParams{...})
+//
+// Requests without params have P struct{}, and those without a result,
+// R struct{}. The requests of the protocol are described by variables
+// such as HoverMethod; other requests may be described likewise:
+//
+//	var docMethod = lsp.RequestType[*DocParams, *DocResult]{Name: "gopls/doc"}
+type RequestType[P, R any] struct {
+	Name string // the name of the method, such as MethodTextDocumentHover
+}
+
+// CallMethod calls the request m with params over conn, and returns its
+// result, as [Call] does.
+func CallMethod[P, R any](ctx context.Context, conn *jsonrpc2.Connection, m RequestType[P, R], params P) (R, error) {
+	var p any = params
+	if _, ok := p.(struct{}); ok {
+		p = nil // no params
+	}
+	var result R
+	err := Call(ctx, conn, m.Name, p, &result)
+	return result, err
+}
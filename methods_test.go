@@ -0,0 +1,106 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestLookupMethod(t *testing.T) {
+	got, ok := lsp.LookupMethod(lsp.MethodTextDocumentHover)
+	want := lsp.MethodSpec{
+		Method:   "textDocument/hover",
+		ToServer: true,
+		Params:   reflect.TypeFor[lsp.HoverParams](),
+		Result:   reflect.TypeFor[*lsp.Hover](),
+	}
+	if !ok {
+		t.Fatalf("Expected %s to be a method", lsp.MethodTextDocumentHover)
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b reflect.Type) bool { return a == b })); diff != "" {
+		t.Errorf("Unexpected method (-want +got):\n%s", diff)
+	}
+
+	got, ok = lsp.LookupMethod(lsp.MethodProgress)
+	if !ok || !got.ToServer || !got.ToClient || !got.Notification || got.Result != nil {
+		t.Errorf("Expected $/progress to be a notification in both directions, got %+v", got)
+	}
+
+	if _, ok := lsp.LookupMethod("gopls/doc"); ok {
+		t.Errorf("Expected gopls/doc not to be a method of the protocol")
+	}
+}
+
+func TestMethods(t *testing.T) {
+	specs := lsp.Methods()
+	if len(specs) == 0 {
+		t.Fatal("Expected methods")
+	}
+	if !slices.IsSortedFunc(specs, func(a, b lsp.MethodSpec) int { return strings.Compare(a.Method, b.Method) }) {
+		t.Errorf("Expected the methods to be sorted by name")
+	}
+	for _, spec := range specs {
+		if !spec.ToServer && !spec.ToClient {
+			t.Errorf("Expected %s to be sent in some direction", spec.Method)
+		}
+		if spec.Notification && spec.Result != nil {
+			t.Errorf("Expected the notification %s to have no result, got %v", spec.Method, spec.Result)
+		}
+		if got, ok := lsp.LookupMethod(spec.Method); !ok || got.Params != spec.Params || got.Result != spec.Result {
+			t.Errorf("Expected LookupMethod(%q) to return %+v, got %+v", spec.Method, spec, got)
+		}
+	}
+}
+
+func TestCallMethod(t *testing.T) {
+	ctx := context.Background()
+	var shutdownParams json.RawMessage
+	handler := jsonrpc2.HandlerFunc(func(_ context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case lsp.MethodTextDocumentHover:
+			var params lsp.HoverParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return nil, err
+			}
+			return &lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.PlainText, Value: string(params.TextDocument.URI)}}, nil
+		case lsp.MethodShutdown:
+			shutdownParams = req.Params
+			return json.RawMessage("null"), nil
+		}
+		return nil, jsonrpc2.ErrMethodNotFound
+	})
+	client, _ := connectPair(t, nil, handler)
+
+	hover, err := lsp.CallMethod(ctx, client, lsp.HoverMethod, &lsp.HoverParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hover == nil || hover.Contents.Value != "file:///a.go" {
+		t.Errorf("Expected the hover of file:///a.go, got %+v", hover)
+	}
+
+	if _, err := lsp.CallMethod(ctx, client, lsp.ShutdownMethod, struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if shutdownParams != nil {
+		t.Errorf("Expected shutdown without params, got %s", shutdownParams)
+	}
+
+	custom := lsp.RequestType[*lsp.HoverParams, *lsp.Hover]{Name: "custom/hover"}
+	if _, err := lsp.CallMethod(ctx, client, custom, &lsp.HoverParams{}); errorCode(err) != int64(lsp.MethodNotFound) {
+		t.Errorf("Expected a MethodNotFound error, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A MetricsSink receives the metrics of the messages handled through
+// the [Metrics] middleware, to aggregate them or export them to a
+// monitoring system. Its methods must be safe for concurrent use.
+//
+// The sinks of package typefox.dev/lsp/metrics export per-method
+// counters, error counts and latency histograms to Prometheus and
+// expvar; other monitoring systems, or the Prometheus client library,
+// are served by sinks of their own.
+type MetricsSink interface {
+	// Observe records that a message of the given method was handled in
+	// the given time, and whether its handler failed.
+	Observe(method string, latency time.Duration, failed bool)
+}
+
+// UnknownMethod is the method under which [Metrics] reports the
+// messages whose method is not of the protocol and not handled, so that
+// a peer cannot make up a metric per method it sends.
+const UnknownMethod = "unknown"
+
+// Metrics returns a middleware that reports every message to sink, with
+// the time its handler took and whether it failed:
+//
+//	sink := &metrics.Prometheus{}
+//	http.Handle("/metrics", sink)
+//	handler := lsp.ServerHandler(server, lsp.Metrics(sink), lsp.Recover)
+//
+// The methods outside the protocol are reported as UnknownMethod if the
+// handler reports them as not found, as the handlers of ServerHandler,
+// ClientHandler and ServerBuilder do, notifications included.
+//
+// Panics are reported as failures if the middleware recovering from
+// them follows Metrics, as above. A handler that defers its reply,
+// returning jsonrpc2.ErrAsyncResponse, is reported as succeeding when
+// it returns.
+func Metrics(sink MetricsSink) Middleware {
+	return func(handler jsonrpc2.Handler) jsonrpc2.Handler {
+		return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+			start := time.Now()
+			result, err := handler.Handle(ctx, req)
+			method := req.Method
+			if _, ok := methods[method]; !ok && isMethodNotFound(err) {
+				method = UnknownMethod
+			}
+			failed := err != nil && !errors.Is(err, jsonrpc2.ErrAsyncResponse)
+			sink.Observe(method, time.Since(start), failed)
+			return result, err
+		})
+	}
+}
+
+// isMethodNotFound reports whether err reports that a method is not
+// handled.
+func isMethodNotFound(err error) bool {
+	if errors.Is(err, jsonrpc2.ErrNotHandled) {
+		return true
+	}
+	rerr, ok := ErrorFrom(err)
+	return ok && rerr.Code == int64(MethodNotFound)
+}
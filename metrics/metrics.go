@@ -0,0 +1,213 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package metrics provides sinks for the metrics of the messages
+// handled through the [typefox.dev/lsp.Metrics] middleware, which export
+// per-method counters, error counts and latency histograms to Prometheus
+// and expvar. The sinks are safe for concurrent use.
+package metrics
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the buckets
+// of the latency histograms of an Expvar sink, and of a Prometheus sink
+// whose Buckets are nil.
+var DefaultLatencyBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// A Prometheus sink serves the metrics it aggregates over HTTP in the
+// text format of Prometheus, without depending on its client library:
+//
+//	lsp_messages_total{method="textDocument/hover"} 42
+//	lsp_message_errors_total{method="textDocument/hover"} 1
+//	lsp_message_duration_seconds_bucket{method="textDocument/hover",le="0.005"} 40
+//	...
+//
+// The zero value is a sink ready to use. Its settings must not be
+// changed once it observes messages.
+type Prometheus struct {
+	// Namespace is the prefix of the names of the metrics, "lsp" if
+	// empty.
+	Namespace string
+	// Buckets are the upper bounds of the buckets of the latency
+	// histograms, in seconds and in increasing order, or
+	// DefaultLatencyBuckets if nil.
+	Buckets []float64
+
+	table metricsTable
+}
+
+// Observe records a message, as a [typefox.dev/lsp.MetricsSink].
+func (s *Prometheus) Observe(method string, latency time.Duration, failed bool) {
+	s.table.observe(s.Buckets, method, latency, failed)
+}
+
+// ServeHTTP serves the metrics.
+func (s *Prometheus) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	s.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics in the text format of Prometheus to
+// out.
+func (s *Prometheus) WriteMetrics(out io.Writer) error {
+	w := bufio.NewWriter(out)
+	ns := s.Namespace
+	if ns == "" {
+		ns = "lsp"
+	}
+	stats := s.table.snapshot()
+	label := func(method string) string {
+		return `method="` + promEscaper.Replace(method) + `"`
+	}
+	fmt.Fprintf(w, "# HELP %s_messages_total Messages handled, by method.\n", ns)
+	fmt.Fprintf(w, "# TYPE %s_messages_total counter\n", ns)
+	for _, st := range stats {
+		fmt.Fprintf(w, "%s_messages_total{%s} %d\n", ns, label(st.method), st.count)
+	}
+	fmt.Fprintf(w, "# HELP %s_message_errors_total Messages whose handler failed, by method.\n", ns)
+	fmt.Fprintf(w, "# TYPE %s_message_errors_total counter\n", ns)
+	for _, st := range stats {
+		fmt.Fprintf(w, "%s_message_errors_total{%s} %d\n", ns, label(st.method), st.errors)
+	}
+	fmt.Fprintf(w, "# HELP %s_message_duration_seconds Time taken to handle messages, by method.\n", ns)
+	fmt.Fprintf(w, "# TYPE %s_message_duration_seconds histogram\n", ns)
+	for _, st := range stats {
+		for i, bound := range st.bounds {
+			fmt.Fprintf(w, "%s_message_duration_seconds_bucket{%s,le=%q} %d\n", ns, label(st.method), formatFloat(bound), st.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_message_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", ns, label(st.method), st.count)
+		fmt.Fprintf(w, "%s_message_duration_seconds_sum{%s} %s\n", ns, label(st.method), formatFloat(st.sum.Seconds()))
+		fmt.Fprintf(w, "%s_message_duration_seconds_count{%s} %d\n", ns, label(st.method), st.count)
+	}
+	return w.Flush()
+}
+
+// promEscaper escapes the values of labels.
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// An Expvar sink publishes the metrics it aggregates as an expvar
+// variable, which the /debug/vars endpoint of package expvar serves as
+// JSON:
+//
+//	"lsp": {"textDocument/hover": {"count": 42, "errors": 1, "latency": {"sum": 0.21, "buckets": {"0.005": 40, ...}}}}
+//
+// The buckets of the latency histograms count the messages handled
+// within their upper bound, in seconds, as in Prometheus.
+type Expvar struct {
+	table metricsTable
+}
+
+// NewExpvar returns an Expvar published under the given name.
+// As with [expvar.Publish], it panics if the name is already in use.
+func NewExpvar(name string) *Expvar {
+	s := new(Expvar)
+	expvar.Publish(name, expvar.Func(s.value))
+	return s
+}
+
+// Observe records a message, as a [typefox.dev/lsp.MetricsSink].
+func (s *Expvar) Observe(method string, latency time.Duration, failed bool) {
+	s.table.observe(nil, method, latency, failed)
+}
+
+type expvarLatency struct {
+	Sum     float64          `json:"sum"`
+	Buckets map[string]int64 `json:"buckets"`
+}
+
+type expvarMethod struct {
+	Count   int64         `json:"count"`
+	Errors  int64         `json:"errors"`
+	Latency expvarLatency `json:"latency"`
+}
+
+// value returns the value of the expvar variable.
+func (s *Expvar) value() any {
+	v := make(map[string]expvarMethod)
+	for _, st := range s.table.snapshot() {
+		m := expvarMethod{
+			Count:   st.count,
+			Errors:  st.errors,
+			Latency: expvarLatency{Sum: st.sum.Seconds(), Buckets: make(map[string]int64)},
+		}
+		for i, bound := range st.bounds {
+			m.Latency.Buckets[formatFloat(bound)] = st.buckets[i]
+		}
+		v[st.method] = m
+	}
+	return v
+}
+
+// A metricsTable aggregates the metrics of messages by method.
+type metricsTable struct {
+	mu      sync.Mutex
+	methods map[string]*methodStats
+}
+
+// methodStats are the metrics of the messages of a method.
+type methodStats struct {
+	method        string
+	count, errors int64
+	sum           time.Duration
+	bounds        []float64 // of the buckets, in seconds
+	buckets       []int64   // cumulative counts of the buckets
+}
+
+// observe adds a message to the metrics of its method, whose latency
+// histogram has the given bounds, or DefaultLatencyBuckets if nil.
+func (t *metricsTable) observe(bounds []float64, method string, latency time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st, ok := t.methods[method]
+	if !ok {
+		if t.methods == nil {
+			t.methods = make(map[string]*methodStats)
+		}
+		if bounds == nil {
+			bounds = DefaultLatencyBuckets
+		}
+		st = &methodStats{method: method, bounds: bounds, buckets: make([]int64, len(bounds))}
+		t.methods[method] = st
+	}
+	st.count++
+	if failed {
+		st.errors++
+	}
+	st.sum += latency
+	seconds := latency.Seconds()
+	for i, bound := range st.bounds {
+		if seconds <= bound {
+			st.buckets[i]++
+		}
+	}
+}
+
+// snapshot returns a copy of the metrics, sorted by method.
+func (t *metricsTable) snapshot() []methodStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := make([]methodStats, 0, len(t.methods))
+	for _, st := range t.methods {
+		c := *st
+		c.buckets = slices.Clone(st.buckets)
+		stats = append(stats, c)
+	}
+	slices.SortFunc(stats, func(a, b methodStats) int { return strings.Compare(a.method, b.method) })
+	return stats
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp/metrics"
+)
+
+func TestPrometheus(t *testing.T) {
+	sink := &metrics.Prometheus{Namespace: "test", Buckets: []float64{0.01, 1}}
+	sink.Observe("textDocument/hover", 5*time.Millisecond, false)
+	sink.Observe("textDocument/hover", 500*time.Millisecond, true)
+	sink.Observe(`odd"method`, 2*time.Second, false)
+
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected a text/plain content type, got %q", ct)
+	}
+	want := `# HELP test_messages_total Messages handled, by method.
+# TYPE test_messages_total counter
+test_messages_total{method="odd\"method"} 1
+test_messages_total{method="textDocument/hover"} 2
+# HELP test_message_errors_total Messages whose handler failed, by method.
+# TYPE test_message_errors_total counter
+test_message_errors_total{method="odd\"method"} 0
+test_message_errors_total{method="textDocument/hover"} 1
+# HELP test_message_duration_seconds Time taken to handle messages, by method.
+# TYPE test_message_duration_seconds histogram
+test_message_duration_seconds_bucket{method="odd\"method",le="0.01"} 0
+test_message_duration_seconds_bucket{method="odd\"method",le="1"} 0
+test_message_duration_seconds_bucket{method="odd\"method",le="+Inf"} 1
+test_message_duration_seconds_sum{method="odd\"method"} 2
+test_message_duration_seconds_count{method="odd\"method"} 1
+test_message_duration_seconds_bucket{method="textDocument/hover",le="0.01"} 1
+test_message_duration_seconds_bucket{method="textDocument/hover",le="1"} 2
+test_message_duration_seconds_bucket{method="textDocument/hover",le="+Inf"} 2
+test_message_duration_seconds_sum{method="textDocument/hover"} 0.505
+test_message_duration_seconds_count{method="textDocument/hover"} 2
+`
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("Unexpected metrics (-want +got):\n%s", diff)
+	}
+}
+
+func TestExpvar(t *testing.T) {
+	sink := metrics.NewExpvar("lsp_test")
+	sink.Observe("textDocument/hover", 5*time.Millisecond, false)
+	sink.Observe("textDocument/hover", 20*time.Millisecond, true)
+
+	var got map[string]struct {
+		Count   int64
+		Errors  int64
+		Latency struct {
+			Sum     float64
+			Buckets map[string]int64
+		}
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("lsp_test").String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	hover := got["textDocument/hover"]
+	if hover.Count != 2 || hover.Errors != 1 || hover.Latency.Sum != 0.025 {
+		t.Errorf("Expected 2 messages, 1 error and 0.025s, got %+v", hover)
+	}
+	if n := hover.Latency.Buckets["0.005"]; n != 1 {
+		t.Errorf("Expected 1 message within 0.005s, got %d", n)
+	}
+	if n := hover.Latency.Buckets["0.025"]; n != 2 {
+		t.Errorf("Expected 2 messages within 0.025s, got %d", n)
+	}
+}
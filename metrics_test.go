@@ -0,0 +1,73 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// sinkRecorder is a MetricsSink that records the methods it observes,
+// and whether they failed.
+type sinkRecorder struct {
+	mu       sync.Mutex
+	observed []string
+}
+
+func (r *sinkRecorder) Observe(method string, latency time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if failed {
+		method += " failed"
+	}
+	r.observed = append(r.observed, method)
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	sink := &sinkRecorder{}
+	handler := lsp.ServerHandler(hoverServer{}, lsp.Metrics(sink), lsp.Recover)
+
+	if _, err := handler.Handle(ctx, newHoverCall(t)); err != nil {
+		t.Fatal(err)
+	}
+	// hoverServer does not implement Definition, which panics.
+	call, _ := jsonrpc2.NewCall(jsonrpc2.Int64ID(2), lsp.MethodTextDocumentDefinition, &lsp.DefinitionParams{})
+	if _, err := handler.Handle(ctx, call); err == nil {
+		t.Errorf("Expected the definition to fail")
+	}
+	for _, method := range []string{"vendor/unknown", "vendor/other"} {
+		call, _ := jsonrpc2.NewCall(jsonrpc2.Int64ID(3), method, nil)
+		if _, err := handler.Handle(ctx, call); errorCode(err) != int64(lsp.MethodNotFound) {
+			t.Errorf("Expected a MethodNotFound error for %s, got %v", method, err)
+		}
+	}
+
+	want := []string{"textDocument/hover", "textDocument/definition failed", "unknown failed", "unknown failed"}
+	if diff := cmp.Diff(want, sink.observed); diff != "" {
+		t.Errorf("Unexpected observations (-want +got):\n%s", diff)
+	}
+}
+
+func TestMetricsServerBuilder(t *testing.T) {
+	ctx := context.Background()
+	sink := &sinkRecorder{}
+	handler := lsp.Metrics(sink)(lsp.NewServer("test", "1").Handler())
+	for _, method := range []string{"$/a1", "$/a2", lsp.MethodTextDocumentDidSave} {
+		req, _ := jsonrpc2.NewNotification(method, nil)
+		handler.Handle(ctx, req)
+	}
+	// The notifications outside the protocol share a metric.
+	want := []string{"unknown failed", "unknown failed", lsp.MethodTextDocumentDidSave}
+	if diff := cmp.Diff(want, sink.observed); diff != "" {
+		t.Errorf("Unexpected observations (-want +got):\n%s", diff)
+	}
+}
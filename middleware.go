@@ -0,0 +1,37 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import "golang.org/x/exp/jsonrpc2"
+
+// A Middleware wraps a handler with behavior of its own, such as
+// logging, panic recovery, metrics or authorization, which it applies
+// to the messages before or after the handler sees them. [Recover] is a
+// Middleware, and so are the Handler methods of wrappers such as
+// [MethodFilter] and [MethodAliases].
+type Middleware func(jsonrpc2.Handler) jsonrpc2.Handler
+
+// Chain returns handler wrapped by the middleware, the first one
+// outermost, so that it sees the messages first:
+//
+//	handler := lsp.Chain(lsp.ServerHandler(server), lsp.Recover, filter.Handler, logRequests)
+//
+// Here, the panics of logRequests and the filter are recovered from,
+// and the requests that the filter rejects are not logged.
+func Chain(handler jsonrpc2.Handler, middleware ...Middleware) jsonrpc2.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// chainFunc returns the function of Chain(f, middleware...), or f if
+// there is no middleware.
+func chainFunc(f jsonrpc2.HandlerFunc, middleware []Middleware) jsonrpc2.HandlerFunc {
+	if len(middleware) == 0 {
+		return f
+	}
+	return Chain(f, middleware...).Handle
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	var trace []string
+	record := func(name string) lsp.Middleware {
+		return func(handler jsonrpc2.Handler) jsonrpc2.Handler {
+			return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+				trace = append(trace, name+" "+req.Method)
+				return handler.Handle(ctx, req)
+			})
+		}
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		trace = nil
+		handler := lsp.Chain(lsp.ServerHandler(hoverServer{}), record("outer"), record("inner"))
+		if _, err := handler.Handle(ctx, newHoverCall(t)); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"outer textDocument/hover", "inner textDocument/hover"}
+		if diff := cmp.Diff(want, trace); diff != "" {
+			t.Errorf("Unexpected trace (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ServerHandler", func(t *testing.T) {
+		trace = nil
+		handler := lsp.ServerHandler(hoverServer{}, lsp.Recover, record("logged"))
+		resp, err := handler.Handle(ctx, newHoverCall(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := resp.(*lsp.Hover); !ok {
+			t.Errorf("Expected *lsp.Hover, got %T", resp)
+		}
+
+		// hoverServer does not implement Definition, which panics.
+		call, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(2), lsp.MethodTextDocumentDefinition, &lsp.DefinitionParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler.Handle(ctx, call); !errors.Is(err, jsonrpc2.ErrInternal) {
+			t.Errorf("Expected ErrInternal, got %v", err)
+		}
+		want := []string{"logged textDocument/hover", "logged textDocument/definition"}
+		if diff := cmp.Diff(want, trace); diff != "" {
+			t.Errorf("Unexpected trace (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"os"
+)
+
+// MapFile returns a Mapper for the content of the named file, with the
+// given position encoding as for [NewMapperEncoding]. Where the
+// operating system allows, the content is memory-mapped rather than
+// read into the heap, which reduces the resident memory of servers that
+// keep the content of many large files that are not open in the editor.
+//
+// The caller must call close once the Mapper, and any slice of its
+// Content, is no longer in use. The file must not be modified or
+// truncated in the meantime: the Mapper would see the change, or the
+// program would crash.
+func MapFile(uri DocumentURI, filename string, encoding PositionEncodingKind) (m *Mapper, close func() error, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, nil, fmt.Errorf("%s is not a regular file", filename)
+	}
+	content, close, err := mmapFile(f, info.Size())
+	if err != nil {
+		return nil, nil, fmt.Errorf("mapping %s: %v", filename, err)
+	}
+	return NewMapperEncoding(uri, content, encoding), close, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestMapFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return filename
+	}
+
+	t.Run("Content", func(t *testing.T) {
+		filename := write("a.go", "package a\n\nvar 𐐀x = 1\n")
+		m, close, err := lsp.MapFile(lsp.URIFromPath(filename), filename, lsp.UTF16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := close(); err != nil {
+				t.Error(err)
+			}
+		}()
+		if got := string(m.Content); got != "package a\n\nvar 𐐀x = 1\n" {
+			t.Errorf("Expected the file content, got %q", got)
+		}
+		// x follows a rune encoded by two UTF-16 codes.
+		offset, err := m.PositionOffset(lsp.Position{Line: 2, Character: 6})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.Content[offset]; got != 'x' {
+			t.Errorf("Expected x at 2:6, got %q", got)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		filename := write("empty.go", "")
+		m, close, err := lsp.MapFile(lsp.URIFromPath(filename), filename, lsp.UTF8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer close()
+		if len(m.Content) != 0 || m.Encoding() != lsp.UTF8 {
+			t.Errorf("Expected an empty UTF-8 mapper, got %q in %s", m.Content, m.Encoding())
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, _, err := lsp.MapFile("", filepath.Join(dir, "missing.go"), lsp.UTF16); err == nil {
+			t.Errorf("Expected an error for a missing file")
+		}
+		if _, _, err := lsp.MapFile("", dir, lsp.UTF16); err == nil {
+			t.Errorf("Expected an error for a directory")
+		}
+	})
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// WithMessageLimits returns a binder that binds connections as b does,
+// and bounds the size of the messages read from them, so that a peer
+// cannot exhaust the memory of the process by announcing a huge
+// message, by accident or malice:
+//
+//	srv, err := jsonrpc2.Serve(ctx, l, lsp.WithMessageLimits(binder, 0, 16<<20))
+//
+// A message whose content exceeds maxContentLength, or
+// DefaultMaxContentLength if zero, is discarded as it is read, without
+// being held in memory, and a call is answered with an InvalidRequest
+// error; a notification is dropped. The connection goes on. A header
+// that exceeds maxHeaderSize, or DefaultMaxHeaderSize if zero, cannot be
+// skipped, and ends the connection, as malformed headers do.
+//
+// The response to an outgoing call that exceeds the limits fails the
+// call with the same error.
+//
+// The messages are read as a [Framer] reads them, leniently unless the
+// framer of b is a Framer, whose other settings are kept. The framers of
+// this package that wrap another, such as those of [Hooks] and
+// [TraceRecorder], keep wrapping it with its limits; a connection whose
+// framer is of another kind, such as [NDJSONFramer], fails to bind.
+func WithMessageLimits(b jsonrpc2.Binder, maxHeaderSize int, maxContentLength int64) jsonrpc2.Binder {
+	return limitBinder{b, maxHeaderSize, maxContentLength}
+}
+
+type limitBinder struct {
+	binder           jsonrpc2.Binder
+	maxHeaderSize    int
+	maxContentLength int64
+}
+
+func (b limitBinder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	opts, err := b.binder.Bind(ctx, conn)
+	if err != nil {
+		return opts, err
+	}
+	opts.Framer, err = b.limit(opts.Framer)
+	if err != nil {
+		return opts, err
+	}
+	handler := opts.Handler
+	if handler == nil {
+		handler = jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) {
+			return nil, jsonrpc2.ErrNotHandled
+		})
+	}
+	opts.Handler = jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if req.Method != tooLargeMethod {
+			return handler.Handle(ctx, req)
+		}
+		var params tooLargeParams
+		json.Unmarshal(req.Params, &params)
+		method := params.Method
+		if method == "" {
+			method = "unknown method"
+		}
+		return nil, Errorf(InvalidRequest, "%s: message of %d bytes exceeds the limit of %d bytes", method, params.Length, params.Limit)
+	})
+	return opts, nil
+}
+
+// limit returns a framer that reads messages as f does, within the
+// limits of b.
+func (b limitBinder) limit(f jsonrpc2.Framer) (jsonrpc2.Framer, error) {
+	var lf Framer
+	switch g := f.(type) {
+	case nil:
+		lf = Framer{Lenient: true} // as tolerant as jsonrpc2.HeaderFramer
+	case Framer:
+		lf = g
+	case limitFramer:
+		lf = g.Framer
+	case wrappingFramer:
+		inner, err := b.limit(g.wrapped())
+		if err != nil {
+			return nil, err
+		}
+		return g.rewrap(inner), nil
+	default:
+		if f != jsonrpc2.HeaderFramer() {
+			return nil, fmt.Errorf("cannot limit the size of the messages read by a framer of type %T", f)
+		}
+		lf = Framer{Lenient: true}
+	}
+	lf.MaxHeaderSize = b.maxHeaderSize
+	lf.MaxContentLength = b.maxContentLength
+	return limitFramer{lf}, nil
+}
+
+// A wrappingFramer is a framer of this package that reads and writes
+// messages with another framer, which it wraps.
+type wrappingFramer interface {
+	jsonrpc2.Framer
+	// wrapped returns the wrapped framer.
+	wrapped() jsonrpc2.Framer
+	// rewrap returns a copy of the framer that wraps f instead.
+	rewrap(f jsonrpc2.Framer) jsonrpc2.Framer
+}
+
+// tooLargeMethod is the method of the requests that stand for the
+// messages that a limitFramer discarded, and which fail.
+const tooLargeMethod = "$/messageTooLarge"
+
+// tooLargeParams are the params of a tooLargeMethod request.
+type tooLargeParams struct {
+	Method string `json:"method,omitempty"`
+	Length int64  `json:"length"`
+	Limit  int64  `json:"limit"`
+}
+
+// A limitFramer is a Framer that discards the messages exceeding its
+// limit, and reads a tooLargeMethod request in their stead, with the ID
+// of the message if it is a call, or a failed response if the message is
+// a response.
+type limitFramer struct {
+	Framer
+}
+
+func (f limitFramer) Reader(r io.Reader) jsonrpc2.Reader {
+	return limitReader{f.Framer.Reader(r).(framerReader)}
+}
+
+type limitReader struct {
+	framerReader
+}
+
+func (r limitReader) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	mr := r.r
+	length, err := mr.readHeader()
+	if err != nil {
+		return nil, 0, err
+	}
+	limit := mr.maxContentLength()
+	if length <= limit {
+		data, err := mr.readContent(length)
+		if err != nil {
+			return nil, 0, err
+		}
+		msg, err := jsonrpc2.DecodeMessage(data)
+		return msg, mr.size, err
+	}
+	id, method, hasMethod, err := scanEnvelope(mr.in, length)
+	if err != nil {
+		return nil, 0, contentError(err)
+	}
+	if !hasMethod && id.IsValid() {
+		err := Errorf(InvalidRequest, "response: message of %d bytes exceeds the limit of %d bytes", length, limit)
+		return &jsonrpc2.Response{ID: id, Error: err}, mr.size, nil
+	}
+	params := tooLargeParams{Length: length, Limit: limit}
+	json.Unmarshal(method, &params.Method)
+	var msg *jsonrpc2.Request
+	if id.IsValid() {
+		msg, err = jsonrpc2.NewCall(id, tooLargeMethod, params)
+	} else {
+		msg, err = jsonrpc2.NewNotification(tooLargeMethod, params)
+	}
+	return msg, mr.size, err
+}
+
+// maxEnvelopeValue bounds the length of the id and method of a message,
+// and of its keys, scanned by scanEnvelope.
+const maxEnvelopeValue = 256
+
+// scanEnvelope reads the n bytes of the content of a message from r,
+// without retaining them, and returns the ID of the message and its
+// method as JSON, if any, provided they are short, and whether it has a
+// method member at all, short or not. It does not validate the content.
+func scanEnvelope(r io.ByteReader, n int64) (id jsonrpc2.ID, method []byte, hasMethod bool, err error) {
+	var (
+		depth     int
+		inString  bool
+		escaped   bool
+		inKey     bool // reading a key of the message object
+		expectKey bool // the next string at depth 1 is a key
+		key       []byte
+		capture   []byte // the value of an id or method member
+		capturing bool
+		rawID     []byte
+	)
+	finish := func() {
+		if capturing && string(key) == "method" {
+			hasMethod = true
+		}
+		if capturing && len(capture) < maxEnvelopeValue {
+			switch string(key) {
+			case "id":
+				rawID = bytes.Clone(capture)
+			case "method":
+				method = bytes.Clone(capture)
+			}
+		}
+		capturing = false
+	}
+	for range n {
+		c, err := r.ReadByte()
+		if err != nil {
+			return jsonrpc2.ID{}, nil, false, err
+		}
+		if capturing && len(capture) <= maxEnvelopeValue {
+			capture = append(capture, c)
+		}
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+				inKey = false
+				continue
+			}
+			if inKey && len(key) <= maxEnvelopeValue {
+				key = append(key, c)
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+			if depth == 1 && expectKey {
+				inKey = true
+				key = key[:0]
+			}
+		case '{', '[':
+			depth++
+			if depth == 1 {
+				expectKey = c == '{'
+			}
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				capture = capture[:max(len(capture)-1, 0)] // the closing brace
+				finish()
+			}
+		case ':':
+			if depth == 1 && expectKey {
+				expectKey = false
+				capturing = true
+				capture = capture[:0]
+			}
+		case ',':
+			if depth == 1 {
+				capture = capture[:max(len(capture)-1, 0)] // the comma
+				finish()
+				expectKey = true
+			}
+		}
+	}
+	rawID = bytes.TrimSpace(rawID)
+	if len(rawID) > 0 && rawID[0] == '"' {
+		var s string
+		if json.Unmarshal(rawID, &s) == nil {
+			id = jsonrpc2.StringID(s)
+		}
+	} else if i, err := strconv.ParseInt(string(rawID), 10, 64); err == nil {
+		id = jsonrpc2.Int64ID(i)
+	}
+	return id, method, hasMethod, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestWithMessageLimits(t *testing.T) {
+	ctx := context.Background()
+	cc, sc := net.Pipe()
+	recorder := &methodRecorder{}
+	server, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, lsp.WithMessageLimits(jsonrpc2.ConnectionOptions{Handler: recorder}, 0, 1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	r := lsp.NewMessageReader(cc)
+	call := func(msg string) string {
+		t.Helper()
+		if err := lsp.WriteMessage(cc, json.RawMessage(msg)); err != nil {
+			t.Fatal(err)
+		}
+		data, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	text := strings.Repeat("x", 2048)
+	// The ID and method of a call are found wherever they are.
+	for _, test := range []struct{ msg, want string }{
+		{
+			`{"jsonrpc":"2.0","id":1,"method":"large","params":{"text":"` + text + `"}}`,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"large: message of %d bytes exceeds the limit of 1024 bytes"}}`,
+		},
+		{
+			`{"params":{"id":2,"text":"` + text + `, \"id\": 3"},"method":"large","id":"a","jsonrpc":"2.0"}`,
+			`{"jsonrpc":"2.0","id":"a","error":{"code":-32600,"message":"large: message of %d bytes exceeds the limit of 1024 bytes"}}`,
+		},
+	} {
+		want := fmt.Sprintf(test.want, len(test.msg))
+		if got := call(test.msg); got != want {
+			t.Errorf("Unexpected response:\nwant %s\ngot  %s", want, got)
+		}
+	}
+	if err := lsp.WriteMessage(cc, json.RawMessage(`{"jsonrpc":"2.0","method":"large","params":"`+text+`"}`)); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"jsonrpc":"2.0","id":4,"result":null}`
+	if got := call(`{"jsonrpc":"2.0","id":4,"method":"small"}`); got != want {
+		t.Errorf("Expected the connection to go on, with %s, got %s", want, got)
+	}
+	if diff := cmp.Diff([]string{"small"}, recorder.Methods()); diff != "" {
+		t.Errorf("Unexpected methods handled (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithMessageLimitsResponse(t *testing.T) {
+	ctx := context.Background()
+	cc, sc := net.Pipe()
+	hooks := lsp.NewHooks(10)
+	t.Cleanup(hooks.Close)
+	responses := make(chan *jsonrpc2.Response, 1)
+	hooks.OnResponse(func(e lsp.HookEvent) {
+		if e.Incoming {
+			responses <- e.Response
+		}
+	})
+	opts := jsonrpc2.ConnectionOptions{Framer: hooks.Framer(nil)}
+	server, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, lsp.WithMessageLimits(opts, 0, 1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	read := make(chan error, 1)
+	go func() {
+		_, err := lsp.NewMessageReader(cc).Read()
+		read <- err
+	}()
+	call := server.Call(ctx, "workspace/configuration", nil)
+	if err := <-read; err != nil {
+		t.Fatal(err)
+	}
+	// The oversized response fails the pending call, rather than being
+	// taken for a call of the client.
+	msg := `{"jsonrpc":"2.0","id":1,"result":"` + strings.Repeat("x", 2048) + `"}`
+	if err := lsp.WriteMessage(cc, json.RawMessage(msg)); err != nil {
+		t.Fatal(err)
+	}
+	err = call.Await(ctx, nil)
+	var lspErr *lsp.ResponseError
+	if !errors.As(err, &lspErr) || lspErr.Code != int64(lsp.InvalidRequest) {
+		t.Errorf("Await() = %v, want an InvalidRequest error", err)
+	}
+	// The framer of the hooks still observes the messages read.
+	if resp := <-responses; resp.ID != call.ID() {
+		t.Errorf("Hooks observed a response to %v, want %v", resp.ID, call.ID())
+	}
+}
+
+func TestWithMessageLimitsUnknownFramer(t *testing.T) {
+	ctx := context.Background()
+	_, sc := net.Pipe()
+	opts := jsonrpc2.ConnectionOptions{Framer: lsp.NDJSONFramer{}}
+	if _, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, lsp.WithMessageLimits(opts, 0, 1024)); err == nil {
+		t.Error("Expected binding a connection with an NDJSONFramer to fail")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A NotificationQueue handles the notifications about text documents
+// asynchronously, on a pool of workers, so that a slow didChange of one
+// document does not hold up the messages about others. The
+// notifications about a document are still handled one at a time, in
+// the order in which they arrived, and a request about a document is
+// handled once all earlier notifications about it have been.
+//
+// A NotificationQueue belongs to a single connection, whose handler it
+// wraps with [NotificationQueue.Handler].
+type NotificationQueue struct {
+	workers chan struct{} // a token for each idle worker
+
+	mu   sync.Mutex
+	docs map[DocumentURI]*documentQueue // with notifications to handle
+}
+
+// A documentQueue holds the notifications about a document that have
+// not been handled, and counts those queued and handled.
+type documentQueue struct {
+	tasks           []func()
+	queued, handled uint64
+	changed         chan struct{} // closed when handled changes
+}
+
+// NewNotificationQueue returns a queue that handles notifications on the
+// given number of workers, at least one.
+func NewNotificationQueue(workers int) *NotificationQueue {
+	q := &NotificationQueue{
+		workers: make(chan struct{}, max(workers, 1)),
+		docs:    make(map[DocumentURI]*documentQueue),
+	}
+	for range cap(q.workers) {
+		q.workers <- struct{}{}
+	}
+	return q
+}
+
+// Handler returns a handler that invokes handler, queuing the
+// notifications whose parameters have a text document, such as
+// didChange. Other notifications, such as didChangeConfiguration, are
+// handled once all queued notifications have been, and so are requests
+// without a text document; requests with one await the notifications
+// about their document only.
+//
+// The errors of queued notifications are reported through the event
+// system, as the connection no longer awaits them.
+func (q *NotificationQueue) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"` // not validated as a file URI
+			} `json:"textDocument"`
+		}
+		UnmarshalJSON(req.Params, &params) // params may be of another shape
+		uri := DocumentURI(params.TextDocument.URI)
+		if !req.IsCall() && uri != "" {
+			ctx := detach(ctx) // the connection cancels ctx on return
+			q.enqueue(uri, func() {
+				if _, err := handler.Handle(ctx, req); err != nil {
+					event.Error(ctx, "queued notification failed", fmt.Errorf("%q: %w", req.Method, err), RequestLabels(ctx)...)
+				}
+			})
+			return nil, nil
+		}
+		if err := q.Wait(ctx, uri); err != nil {
+			return nil, ErrRequestCancelled
+		}
+		return handler.Handle(ctx, req)
+	})
+}
+
+// Wait waits until the notifications about the document uri, or all
+// notifications if uri is empty, that were queued before the call have
+// been handled, or until ctx is done.
+func (q *NotificationQueue) Wait(ctx context.Context, uri DocumentURI) error {
+	type barrier struct {
+		doc    *documentQueue
+		target uint64
+	}
+	var barriers []barrier
+	q.mu.Lock()
+	for u, doc := range q.docs {
+		if uri == "" || u == uri {
+			barriers = append(barriers, barrier{doc, doc.queued})
+		}
+	}
+	defer q.mu.Unlock()
+	for _, b := range barriers {
+		for b.doc.handled < b.target {
+			changed := b.doc.changed
+			q.mu.Unlock()
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				q.mu.Lock()
+				return ctx.Err()
+			}
+			q.mu.Lock()
+		}
+	}
+	return nil
+}
+
+// enqueue queues task after the other tasks for the document uri.
+func (q *NotificationQueue) enqueue(uri DocumentURI, task func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	doc, ok := q.docs[uri]
+	if !ok {
+		doc = &documentQueue{changed: make(chan struct{})}
+		q.docs[uri] = doc
+	}
+	doc.queued++
+	doc.tasks = append(doc.tasks, task)
+	if len(doc.tasks) == 1 {
+		go q.run(uri, doc)
+	}
+}
+
+// run handles the tasks of doc, one at a time, until there are none.
+func (q *NotificationQueue) run(uri DocumentURI, doc *documentQueue) {
+	for {
+		<-q.workers
+		q.mu.Lock()
+		task := doc.tasks[0]
+		q.mu.Unlock()
+
+		task()
+
+		q.workers <- struct{}{}
+		q.mu.Lock()
+		doc.tasks = doc.tasks[1:]
+		doc.handled++
+		close(doc.changed)
+		doc.changed = make(chan struct{})
+		if len(doc.tasks) == 0 {
+			delete(q.docs, uri)
+			q.mu.Unlock()
+			return
+		}
+		q.mu.Unlock()
+	}
+}
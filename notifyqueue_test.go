@@ -0,0 +1,108 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// versionServer records the versions of the documents it is told about,
+// holding up the changes of a document until they are released.
+type versionServer struct {
+	lsp.Server
+	slow    lsp.DocumentURI
+	release chan struct{}
+
+	mu       sync.Mutex
+	versions map[lsp.DocumentURI][]int32
+}
+
+func (s *versionServer) DidChange(_ context.Context, params *lsp.DidChangeTextDocumentParams) error {
+	if params.TextDocument.URI == s.slow {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.versions[params.TextDocument.URI] = append(s.versions[params.TextDocument.URI], params.TextDocument.Version)
+	return nil
+}
+
+// Hover reports the versions of the document seen so far.
+func (s *versionServer) Hover(_ context.Context, params *lsp.HoverParams) (*lsp.Hover, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &lsp.Hover{Contents: lsp.MarkupContent{Value: fmt.Sprint(s.versions[params.TextDocument.URI])}}, nil
+}
+
+func TestNotificationQueue(t *testing.T) {
+	ctx := context.Background()
+	const a, b = "file:///a.go", "file:///b.go"
+	server := &versionServer{slow: a, release: make(chan struct{}), versions: make(map[lsp.DocumentURI][]int32)}
+	q := lsp.NewNotificationQueue(2)
+	handler := q.Handler(lsp.ServerHandler(server))
+
+	change := func(uri lsp.DocumentURI, version int32) {
+		t.Helper()
+		req, err := jsonrpc2.NewNotification("textDocument/didChange", &lsp.DidChangeTextDocumentParams{
+			TextDocument: lsp.VersionedTextDocumentIdentifier{TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: uri}, Version: version},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler.Handle(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hover := func(uri lsp.DocumentURI) string {
+		req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "textDocument/hover", &lsp.HoverParams{
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}},
+		})
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		result, err := handler.Handle(ctx, req)
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		return result.(*lsp.Hover).Contents.Value
+	}
+
+	for v := range int32(3) {
+		change(a, v+1)
+		change(b, v+1)
+	}
+	// The changes of b are not held up by those of a.
+	if got := hover(b); got != "[1 2 3]" {
+		t.Errorf("Expected the hover of b to see versions [1 2 3], got %s", got)
+	}
+	hoverA := make(chan string)
+	go func() { hoverA <- hover(a) }()
+	select {
+	case got := <-hoverA:
+		t.Fatalf("Expected the hover of a to await its changes, got %s", got)
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(server.release)
+	if got := <-hoverA; got != "[1 2 3]" {
+		t.Errorf("Expected the hover of a to see versions [1 2 3] in order, got %s", got)
+	}
+
+	t.Run("Wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if err := q.Wait(ctx, a); err != nil {
+			t.Errorf("Expected no wait for handled notifications, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// DefaultOpenDelay is the time after the last didOpen notification of a
+// burst after which an OpenBatcher whose Delay is zero ends the burst.
+const DefaultOpenDelay = 50 * time.Millisecond
+
+// OpenDocuments opens many documents in the server in one pass, as an
+// editor does when it restores a session, by sending their didOpen
+// notifications back to back, without awaiting anything in between, so
+// that a server with an [OpenBatcher] sees them as a single burst. It
+// stops at the first error.
+func OpenDocuments(ctx context.Context, server Server, docs []TextDocumentItem) error {
+	for _, doc := range docs {
+		if err := server.DidOpen(ctx, &DidOpenTextDocumentParams{TextDocument: doc}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An OpenBatcher defers the expensive work that a server does for the
+// documents that a client opens, such as their analysis, while the
+// client opens many of them in a burst, as when an editor restores a
+// session, so that the server does it once for the burst instead of
+// once per document:
+//
+//	batcher := &lsp.OpenBatcher{Opened: func(ctx context.Context, uris []lsp.DocumentURI) {
+//		analyze(uris)
+//	}}
+//	handler := batcher.Handler(lsp.ServerHandler(server))
+//
+// The didOpen notifications are still handled as they arrive, so that
+// the server records the content of the documents; it does the rest of
+// the work in Opened. A burst ends when a message other than didOpen
+// arrives, such as a request about one of the documents, or when no
+// didOpen arrives for Delay.
+//
+// An OpenBatcher belongs to a single connection. Its settings must not
+// be changed after Handler is called.
+type OpenBatcher struct {
+	// Opened is called with the URIs of the documents opened in a burst,
+	// in the order in which they were opened, except those closed since.
+	// At the end of a burst caused by a message, it is called before the
+	// message is handled, and holds it up until it returns, so it should
+	// schedule long work rather than do it.
+	Opened func(ctx context.Context, uris []DocumentURI)
+	// Delay is the time after the last didOpen notification of a burst
+	// after which the burst ends, or DefaultOpenDelay if zero.
+	Delay time.Duration
+
+	mu      sync.Mutex
+	ctx     context.Context // of the last didOpen, detached
+	pending []DocumentURI   // opened in the current burst
+	timer   *time.Timer     // ends the current burst, if any
+}
+
+// Handler returns a handler that invokes handler, and batches the
+// documents opened by the didOpen notifications it handles.
+func (b *OpenBatcher) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case MethodTextDocumentDidOpen:
+			var params DidOpenTextDocumentParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				b.add(ctx, params.TextDocument.URI)
+			}
+		case MethodTextDocumentDidClose:
+			var params DidCloseTextDocumentParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				b.remove(params.TextDocument.URI)
+			}
+			fallthrough
+		default:
+			b.Flush(ctx)
+		}
+		return handler.Handle(ctx, req)
+	})
+}
+
+// Flush ends the current burst, if any, calling Opened with its
+// documents.
+func (b *OpenBatcher) Flush(ctx context.Context) {
+	b.mu.Lock()
+	uris := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+	if len(uris) > 0 && b.Opened != nil {
+		b.Opened(ctx, uris)
+	}
+}
+
+// add adds a document to the current burst, starting one if needed, and
+// postpones its end.
+func (b *OpenBatcher) add(ctx context.Context, uri DocumentURI) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !slices.Contains(b.pending, uri) {
+		b.pending = append(b.pending, uri)
+	}
+	b.ctx = detach(ctx) // the burst outlives the notification
+	delay := b.Delay
+	if delay == 0 {
+		delay = DefaultOpenDelay
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		b.mu.Lock()
+		if b.timer != timer {
+			b.mu.Unlock()
+			return // the burst ended, or was postponed
+		}
+		ctx := b.ctx
+		b.mu.Unlock()
+		b.Flush(ctx)
+	})
+	b.timer = timer
+}
+
+// remove removes a document closed before the end of its burst.
+func (b *OpenBatcher) remove(uri DocumentURI) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = slices.DeleteFunc(b.pending, func(u DocumentURI) bool { return u == uri })
+}
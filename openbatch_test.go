@@ -0,0 +1,93 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestOpenBatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Message", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			batches [][]lsp.DocumentURI
+		)
+		recorder := &methodRecorder{}
+		batcher := &lsp.OpenBatcher{
+			Delay: time.Hour,
+			Opened: func(_ context.Context, uris []lsp.DocumentURI) {
+				mu.Lock()
+				defer mu.Unlock()
+				batches = append(batches, uris)
+				if n := len(recorder.Methods()); n != 3 {
+					t.Errorf("Expected the batch to end before the fourth message, got %d messages", n)
+				}
+			},
+		}
+		client, _ := connectPair(t, nil, batcher.Handler(recorder))
+		server := lsp.ServerDispatcher(client)
+
+		docs := []lsp.TextDocumentItem{
+			{URI: "file:///a.go", LanguageID: "go", Version: 1},
+			{URI: "file:///b.go", LanguageID: "go", Version: 1},
+			{URI: "file:///c.go", LanguageID: "go", Version: 1},
+		}
+		if err := lsp.OpenDocuments(ctx, server, docs); err != nil {
+			t.Fatal(err)
+		}
+		if err := server.DidClose(ctx, &lsp.DidCloseTextDocumentParams{TextDocument: lsp.TextDocumentIdentifier{URI: "file:///b.go"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Call(ctx, lsp.MethodTextDocumentHover, &lsp.HoverParams{}).Await(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		want := [][]lsp.DocumentURI{{"file:///a.go", "file:///c.go"}}
+		if diff := cmp.Diff(want, batches); diff != "" {
+			t.Errorf("Unexpected batches (-want +got):\n%s", diff)
+		}
+		wantMethods := []string{"textDocument/didOpen", "textDocument/didOpen", "textDocument/didOpen", "textDocument/didClose", "textDocument/hover"}
+		if diff := cmp.Diff(wantMethods, recorder.Methods()); diff != "" {
+			t.Errorf("Unexpected handled methods (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Delay", func(t *testing.T) {
+		opened := make(chan []lsp.DocumentURI, 1)
+		batcher := &lsp.OpenBatcher{
+			Delay:  10 * time.Millisecond,
+			Opened: func(_ context.Context, uris []lsp.DocumentURI) { opened <- uris },
+		}
+		handler := batcher.Handler(&methodRecorder{})
+		for _, uri := range []lsp.DocumentURI{"file:///a.go", "file:///b.go", "file:///a.go"} {
+			req, err := jsonrpc2.NewNotification(lsp.MethodTextDocumentDidOpen, &lsp.DidOpenTextDocumentParams{TextDocument: lsp.TextDocumentItem{URI: uri}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := handler.Handle(ctx, req); err != nil {
+				t.Fatal(err)
+			}
+		}
+		select {
+		case uris := <-opened:
+			if diff := cmp.Diff([]lsp.DocumentURI{"file:///a.go", "file:///b.go"}, uris); diff != "" {
+				t.Errorf("Unexpected batch (-want +got):\n%s", diff)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the batch to end after the delay")
+		}
+	})
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// ErrQueueFull is the error of writing to a connection whose
+// [OutgoingQueue] is full, with the policy [QueueClose].
+var ErrQueueFull = errors.New("outgoing queue full")
+
+// DefaultQueueLimit is the limit of an OutgoingQueue whose Limit is
+// zero.
+const DefaultQueueLimit = 1024
+
+// A QueuePolicy tells what an [OutgoingQueue] does with a message
+// written while it is full.
+type QueuePolicy int
+
+const (
+	// QueueBlock blocks the writer until the queue has room.
+	QueueBlock QueuePolicy = iota
+	// QueueDrop drops low-priority notifications, such as $/progress:
+	// the message written if it is one, or else the oldest queued one.
+	// The writer blocks if there is none.
+	QueueDrop
+	// QueueClose closes the connection.
+	QueueClose
+)
+
+// An OutgoingQueue bounds the messages written to connections that have
+// yet to be sent, so that an editor that stopped reading cannot make
+// the server grow its memory without bound. Messages are written to
+// the queue of their connection, which sends them in order from its
+// own goroutine; when it is full, its Policy applies. Notifications
+// that supersede queued ones, such as the diagnostics of a document
+// analyzed again, replace them. A queue is installed by wrapping the
+// framer of a connection:
+//
+//	q := &lsp.OutgoingQueue{Limit: 100, Policy: lsp.QueueDrop}
+//	conn, err := jsonrpc2.Dial(ctx, dialer, jsonrpc2.ConnectionOptions{
+//		Framer:  q.Framer(nil),
+//		Handler: handler,
+//	})
+//
+// An OutgoingQueue may be shared by several connections, each with its
+// own queue. Messages still queued when a connection closes are lost.
+type OutgoingQueue struct {
+	// Limit is the maximum number of queued messages of a connection,
+	// or DefaultQueueLimit if it is zero.
+	Limit int
+	// Policy is what happens to a message written to a full queue.
+	Policy QueuePolicy
+	// LowPriority reports whether a notification may be dropped by the
+	// policy QueueDrop. If nil, $/progress, $/logTrace,
+	// window/logMessage and telemetry/event notifications may.
+	LowPriority func(method string) bool
+	// Coalesce maps the methods of notifications to the keys of their
+	// parameters: a notification written while one with the same
+	// method and key is queued replaces it, so that only the latest is
+	// sent. If nil, textDocument/publishDiagnostics notifications are
+	// coalesced by [CoalesceByURI]; an empty map coalesces nothing.
+	Coalesce map[string]CoalesceKey
+
+	dropped atomic.Int64
+}
+
+// A CoalesceKey returns the key of the parameters of a notification,
+// and whether the notification may be coalesced with others of the same
+// method and key.
+type CoalesceKey func(params json.RawMessage) (key string, ok bool)
+
+// CoalesceByURI is the CoalesceKey of notifications about a document,
+// such as publishDiagnostics: their key is the "uri" of their
+// parameters.
+func CoalesceByURI(params json.RawMessage) (string, bool) {
+	var p struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil || p.URI == "" {
+		return "", false
+	}
+	return p.URI, true
+}
+
+// Dropped returns the number of messages dropped by the policy
+// QueueDrop.
+func (q *OutgoingQueue) Dropped() int64 {
+	return q.dropped.Load()
+}
+
+// Framer returns a framer that encodes messages as f does, and queues
+// the messages written. If f is nil, [jsonrpc2.HeaderFramer] is used.
+func (q *OutgoingQueue) Framer(f jsonrpc2.Framer) jsonrpc2.Framer {
+	if f == nil {
+		f = jsonrpc2.HeaderFramer()
+	}
+	return queueFramer{f, q}
+}
+
+func (q *OutgoingQueue) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueueLimit
+	}
+	return q.Limit
+}
+
+// lowPriority reports whether msg is a notification that may be dropped.
+func (q *OutgoingQueue) lowPriority(msg jsonrpc2.Message) bool {
+	req, ok := msg.(*jsonrpc2.Request)
+	if !ok || req.IsCall() {
+		return false
+	}
+	if q.LowPriority != nil {
+		return q.LowPriority(req.Method)
+	}
+	switch req.Method {
+	case "$/progress", "$/logTrace", "window/logMessage", "telemetry/event":
+		return true
+	}
+	return false
+}
+
+// coalesceKey returns the key of msg among the queued messages, or ""
+// if it may not be coalesced.
+func (q *OutgoingQueue) coalesceKey(msg jsonrpc2.Message) string {
+	req, ok := msg.(*jsonrpc2.Request)
+	if !ok || req.IsCall() {
+		return ""
+	}
+	var key CoalesceKey
+	if q.Coalesce == nil {
+		if req.Method == "textDocument/publishDiagnostics" {
+			key = CoalesceByURI
+		}
+	} else {
+		key = q.Coalesce[req.Method]
+	}
+	if key == nil {
+		return ""
+	}
+	k, ok := key(req.Params)
+	if !ok {
+		return ""
+	}
+	return req.Method + "\x00" + k
+}
+
+type queueFramer struct {
+	framer jsonrpc2.Framer
+	queue  *OutgoingQueue
+}
+
+func (f queueFramer) Reader(r io.Reader) jsonrpc2.Reader {
+	return f.framer.Reader(r)
+}
+
+func (f queueFramer) Writer(w io.Writer) jsonrpc2.Writer {
+	qw := &queueWriter{
+		queue:   f.queue,
+		writer:  f.framer.Writer(w),
+		changed: make(chan struct{}),
+	}
+	qw.closer, _ = w.(io.Closer)
+	return qw
+}
+
+func (f queueFramer) wrapped() jsonrpc2.Framer { return f.framer }
+
+func (f queueFramer) rewrap(framer jsonrpc2.Framer) jsonrpc2.Framer {
+	f.framer = framer
+	return f
+}
+
+// A queueWriter holds the messages written to a connection until they
+// are sent.
+type queueWriter struct {
+	queue  *OutgoingQueue
+	writer jsonrpc2.Writer
+	closer io.Closer // of the connection, if any
+
+	mu      sync.Mutex
+	msgs    []queuedMessage
+	sending bool          // whether a goroutine sends msgs
+	err     error         // of the last send, after which msgs are discarded
+	changed chan struct{} // closed when msgs shrinks or err is set
+}
+
+type queuedMessage struct {
+	ctx context.Context
+	msg jsonrpc2.Message
+	key string // for coalescing, if any
+}
+
+// Write queues msg, unless it replaces a queued message it coalesces
+// with, applying the policy of the queue if it is full. It returns the
+// error of sending an earlier message, if any.
+func (w *queueWriter) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	key := w.queue.coalesceKey(msg)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if key != "" && w.err == nil {
+		for i, m := range w.msgs {
+			if m.key == key {
+				w.msgs[i] = queuedMessage{context.WithoutCancel(ctx), msg, key}
+				return 0, nil
+			}
+		}
+	}
+	for w.err == nil && len(w.msgs) >= w.queue.limit() {
+		switch w.queue.Policy {
+		case QueueDrop:
+			if w.queue.lowPriority(msg) {
+				w.queue.dropped.Add(1)
+				return 0, nil
+			}
+			if w.dropQueued() {
+				continue
+			}
+		case QueueClose:
+			w.fail(fmt.Errorf("%w: %d messages", ErrQueueFull, len(w.msgs)))
+			if w.closer != nil {
+				w.closer.Close()
+			}
+			return 0, w.err
+		}
+		changed := w.changed
+		w.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			w.mu.Lock()
+			return 0, ctx.Err()
+		}
+		w.mu.Lock()
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.msgs = append(w.msgs, queuedMessage{context.WithoutCancel(ctx), msg, key})
+	if !w.sending {
+		w.sending = true
+		go w.send()
+	}
+	return 0, nil
+}
+
+// dropQueued drops the oldest queued low-priority notification, and
+// reports whether there was one.
+func (w *queueWriter) dropQueued() bool {
+	for i, m := range w.msgs {
+		if w.queue.lowPriority(m.msg) {
+			w.msgs = append(w.msgs[:i], w.msgs[i+1:]...)
+			w.queue.dropped.Add(1)
+			return true
+		}
+	}
+	return false
+}
+
+// send sends the queued messages, in order, until there are none.
+func (w *queueWriter) send() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(w.msgs) > 0 && w.err == nil {
+		m := w.msgs[0]
+		w.msgs[0] = queuedMessage{}
+		w.msgs = w.msgs[1:]
+		w.broadcast()
+		w.mu.Unlock()
+		_, err := w.writer.Write(m.ctx, m.msg)
+		w.mu.Lock()
+		if err != nil {
+			w.fail(err)
+		}
+	}
+	w.sending = false
+}
+
+// fail records the error that ends the sending of messages.
+func (w *queueWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+		w.msgs = nil
+		w.broadcast()
+	}
+}
+
+// broadcast wakes up the writers awaiting a change.
+func (w *queueWriter) broadcast() {
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
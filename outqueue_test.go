@@ -0,0 +1,198 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// stalledConn is a connection whose peer does not read until released.
+type stalledConn struct {
+	entered chan struct{} // receives when a write blocks
+	release chan struct{}
+
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+func newStalledConn() *stalledConn {
+	return &stalledConn{entered: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (c *stalledConn) Write(p []byte) (int, error) {
+	select {
+	case c.entered <- struct{}{}:
+	default:
+	}
+	<-c.release
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Write(p)
+}
+
+func (c *stalledConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// methods returns the methods of the messages written to c, or the URI
+// and version of diagnostics.
+func (c *stalledConn) methods(t *testing.T) []string {
+	t.Helper()
+	c.mu.Lock()
+	r := jsonrpc2.HeaderFramer().Reader(bytes.NewReader(c.buf.Bytes()))
+	c.mu.Unlock()
+	var methods []string
+	for {
+		msg, _, err := r.Read(context.Background())
+		if err != nil {
+			return methods
+		}
+		req := msg.(*jsonrpc2.Request)
+		if req.Method == "textDocument/publishDiagnostics" {
+			var params lsp.PublishDiagnosticsParams
+			json.Unmarshal(req.Params, &params)
+			methods = append(methods, fmt.Sprintf("%s@%d", params.URI, params.Version))
+			continue
+		}
+		methods = append(methods, req.Method)
+	}
+}
+
+func TestOutgoingQueue(t *testing.T) {
+	ctx := context.Background()
+	call := func(method string) jsonrpc2.Message {
+		msg, err := jsonrpc2.NewCall(jsonrpc2.StringID(method), method, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+	notification := func(method string) jsonrpc2.Message {
+		msg, err := jsonrpc2.NewNotification(method, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+	diagnostics := func(uri lsp.DocumentURI, version int32) jsonrpc2.Message {
+		msg, err := jsonrpc2.NewNotification("textDocument/publishDiagnostics", &lsp.PublishDiagnosticsParams{URI: uri, Version: version})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+	// start returns a writer of a queue whose first message, m0, is
+	// being sent to a stalled connection.
+	start := func(q *lsp.OutgoingQueue) (jsonrpc2.Writer, *stalledConn) {
+		conn := newStalledConn()
+		w := q.Framer(nil).Writer(conn)
+		if _, err := w.Write(ctx, call("m0")); err != nil {
+			t.Fatal(err)
+		}
+		<-conn.entered
+		return w, conn
+	}
+	write := func(w jsonrpc2.Writer, msgs ...jsonrpc2.Message) {
+		t.Helper()
+		for _, msg := range msgs {
+			if _, err := w.Write(ctx, msg); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// await returns the methods written to conn once it has n of them.
+	await := func(conn *stalledConn, n int) []string {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			methods := conn.methods(t)
+			if len(methods) >= n || time.Now().After(deadline) {
+				return methods
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	t.Run("Block", func(t *testing.T) {
+		w, conn := start(&lsp.OutgoingQueue{Limit: 2})
+		write(w, call("m1"), notification("$/progress"))
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := w.Write(timeout, call("m2")); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected the write to a full queue to block, got %v", err)
+		}
+		close(conn.release)
+		write(w, call("m3"))
+		want := []string{"m0", "m1", "$/progress", "m3"}
+		if diff := cmp.Diff(want, await(conn, len(want))); diff != "" {
+			t.Errorf("Unexpected messages (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		q := &lsp.OutgoingQueue{Limit: 2, Policy: lsp.QueueDrop}
+		w, conn := start(q)
+		write(w,
+			notification("$/progress"),
+			call("m1"),
+			call("m2"),                        // drops the queued progress
+			notification("window/logMessage"), // dropped
+		)
+		if got := q.Dropped(); got != 2 {
+			t.Errorf("Expected 2 dropped messages, got %d", got)
+		}
+		close(conn.release)
+		want := []string{"m0", "m1", "m2"}
+		if diff := cmp.Diff(want, await(conn, len(want))); diff != "" {
+			t.Errorf("Unexpected messages (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		w, conn := start(&lsp.OutgoingQueue{Limit: 1, Policy: lsp.QueueClose})
+		write(w, call("m1"))
+		if _, err := w.Write(ctx, call("m2")); !errors.Is(err, lsp.ErrQueueFull) {
+			t.Errorf("Expected ErrQueueFull, got %v", err)
+		}
+		conn.mu.Lock()
+		closed := conn.closed
+		conn.mu.Unlock()
+		if !closed {
+			t.Error("Expected the connection to be closed")
+		}
+		close(conn.release)
+	})
+
+	t.Run("Coalesce", func(t *testing.T) {
+		w, conn := start(&lsp.OutgoingQueue{})
+		write(w,
+			diagnostics("file:///a.go", 1),
+			diagnostics("file:///b.go", 1),
+			call("m1"),
+			diagnostics("file:///a.go", 2), // replaces a.go@1
+			diagnostics("file:///a.go", 3), // replaces a.go@2
+		)
+		close(conn.release)
+		want := []string{"m0", "file:///a.go@3", "file:///b.go@1", "m1"}
+		if diff := cmp.Diff(want, await(conn, len(want))); diff != "" {
+			t.Errorf("Unexpected messages (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// PendingCalls tracks the outgoing calls of the dispatchers it is given
+// to with [TrackPendingCalls] that have not yet received a response, so
+// that an editor can show that it is waiting for the server, or a
+// debugging tool can spot stuck requests. Its zero value is ready to use.
+type PendingCalls struct {
+	mu    sync.Mutex
+	calls map[*pendingEntry]bool // not keyed by ID, which may repeat across connections
+}
+
+// A PendingCall describes an outgoing call awaiting its response.
+type PendingCall struct {
+	Method  string
+	ID      jsonrpc2.ID
+	Start   time.Time     // when the call was sent
+	Elapsed time.Duration // time since Start
+}
+
+type pendingEntry struct {
+	method string
+	id     jsonrpc2.ID
+	start  time.Time
+}
+
+// TrackPendingCalls makes the dispatcher record its outgoing calls in p
+// while they await a response.
+func TrackPendingCalls(p *PendingCalls) DispatcherOption {
+	return func(c *clientConn) { c.pending = p }
+}
+
+// List returns the calls awaiting a response, oldest first.
+func (p *PendingCalls) List() []PendingCall {
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	calls := make([]PendingCall, 0, len(p.calls))
+	for e := range p.calls {
+		calls = append(calls, PendingCall{Method: e.method, ID: e.id, Start: e.start, Elapsed: now.Sub(e.start)})
+	}
+	slices.SortFunc(calls, func(a, b PendingCall) int { return a.Start.Compare(b.Start) })
+	return calls
+}
+
+// add records a call sent at start, and returns a function that removes
+// it. It does nothing if p is nil.
+func (p *PendingCalls) add(method string, id jsonrpc2.ID, start time.Time) (remove func()) {
+	if p == nil {
+		return func() {}
+	}
+	e := &pendingEntry{method, id, start}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.calls == nil {
+		p.calls = make(map[*pendingEntry]bool)
+	}
+	p.calls[e] = true
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.calls, e)
+	}
+}
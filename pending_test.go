@@ -0,0 +1,60 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"typefox.dev/lsp"
+)
+
+// blockingServer answers hovers once unblocked.
+type blockingServer struct {
+	lsp.Server
+	unblock chan struct{}
+}
+
+func (s blockingServer) Hover(ctx context.Context, _ *lsp.HoverParams) (*lsp.Hover, error) {
+	<-s.unblock
+	return &lsp.Hover{}, nil
+}
+
+func TestPendingCalls(t *testing.T) {
+	srv := blockingServer{unblock: make(chan struct{})}
+	client, _ := connectPair(t, nil, lsp.ServerHandler(srv))
+	var pending lsp.PendingCalls
+	server := lsp.ServerDispatcher(client, lsp.TrackPendingCalls(&pending))
+
+	if calls := pending.List(); len(calls) != 0 {
+		t.Fatalf("Expected no pending calls, got %v", calls)
+	}
+	errc := make(chan error)
+	go func() {
+		_, err := server.Hover(context.Background(), &lsp.HoverParams{})
+		errc <- err
+	}()
+
+	var calls []lsp.PendingCall
+	for len(calls) == 0 {
+		time.Sleep(time.Millisecond)
+		calls = pending.List()
+	}
+	if len(calls) != 1 || calls[0].Method != "textDocument/hover" || !calls[0].ID.IsValid() {
+		t.Errorf("Expected a pending hover, got %+v", calls)
+	}
+	if c := calls[0]; c.Elapsed < 0 || c.Start.IsZero() {
+		t.Errorf("Expected the start and elapsed time of the call, got %+v", c)
+	}
+
+	close(srv.unblock)
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	if calls := pending.List(); len(calls) != 0 {
+		t.Errorf("Expected no pending calls after the response, got %+v", calls)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// EncodePositions returns a handler that invokes handler, a server
+// handler whose results express positions in UTF-8: the Character of each
+// Position is a 0-based byte offset within its line. The positions in
+// the results are converted to the encoding negotiated by the initialize
+// request (the PositionEncoding of the server capabilities it returned,
+// UTF-16 by default), so that handler code can work in byte offsets
+// throughout, whatever the client.
+//
+// The content of each document is obtained from mapper, which may return
+// nil for a document it does not know; positions in such documents are
+// left unchanged. A position is taken to belong to the document of the
+// nearest enclosing value that names one (a Location, a TextDocumentEdit,
+// the key of the changes of a WorkspaceEdit, ...), or else to the
+// textDocument of the request.
+//
+// Only results are converted: params, and requests sent to the client,
+// still carry positions in the negotiated encoding. Nor are the positions
+// encoded in the Data of SemanticTokens and SemanticTokensDelta results
+// converted, as they are not Positions: semantic token handlers must
+// compute them in the negotiated encoding.
+//
+// The positions are converted in a deep copy of each result, so that
+// handlers may return results they keep, such as cached ones. Results
+// that are already encoded, as json.RawMessage, are sent unchanged:
+// a [ResponseCache], which returns such results, must wrap the handler
+// returned by EncodePositions, rather than be wrapped by it, so that it
+// caches converted results:
+//
+//	handler := cache.Handler(lsp.EncodePositions(lsp.ServerHandler(server), mapper))
+func EncodePositions(handler jsonrpc2.Handler, mapper func(DocumentURI) *Mapper) jsonrpc2.Handler {
+	var encoding atomic.Pointer[PositionEncodingKind]
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		result, err := handler.Handle(ctx, req)
+		if err != nil || result == nil {
+			return result, err
+		}
+		if res, ok := result.(*InitializeResult); ok && req.Method == "initialize" {
+			enc := UTF16
+			if res.Capabilities.PositionEncoding != nil {
+				enc = *res.Capabilities.PositionEncoding
+			}
+			encoding.Store(&enc)
+			return result, nil
+		}
+		enc := UTF16
+		if e := encoding.Load(); e != nil {
+			enc = *e
+		}
+		if enc == UTF8 {
+			return result, nil
+		}
+		var params struct {
+			TextDocument struct {
+				URI DocumentURI `json:"uri"`
+			} `json:"textDocument"`
+		}
+		_ = json.Unmarshal(req.Params, &params) // the document is optional
+		if _, ok := result.(json.RawMessage); ok {
+			return result, nil // already encoded
+		}
+		pe := &positionEncoder{mapper: mapper, encoding: enc}
+		// Convert a copy, which the handler does not own.
+		v := deepCopy(reflect.ValueOf(result), make(map[uintptr]reflect.Value))
+		if err := pe.walk(v, params.TextDocument.URI); err != nil {
+			return nil, fmt.Errorf("%w: converting positions of %s result: %v", jsonrpc2.ErrInternal, req.Method, err)
+		}
+		return v.Interface(), nil
+	})
+}
+
+// A positionEncoder converts positions in UTF-8 to another encoding.
+type positionEncoder struct {
+	mapper   func(DocumentURI) *Mapper
+	encoding PositionEncodingKind
+	mappers  map[DocumentURI]*Mapper
+	seen     map[uintptr]bool // pointers already walked
+}
+
+var (
+	positionType    = reflect.TypeFor[Position]()
+	documentURIType = reflect.TypeFor[DocumentURI]()
+)
+
+// walk converts in place the positions in v, which belong to the
+// document uri unless v names another.
+func (e *positionEncoder) walk(v reflect.Value, uri DocumentURI) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || e.seen[v.Pointer()] {
+			return nil // don't convert shared values twice
+		}
+		if e.seen == nil {
+			e.seen = make(map[uintptr]bool)
+		}
+		e.seen[v.Pointer()] = true
+		return e.walk(v.Elem(), uri)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Pointer || !v.CanSet() {
+			return e.walk(v.Elem(), uri)
+		}
+		// The value of an interface is not addressable: convert a copy.
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := e.walk(elem, uri); err != nil {
+			return err
+		}
+		v.Set(elem)
+
+	case reflect.Struct:
+		if v.Type() == positionType {
+			if !v.CanSet() {
+				return nil
+			}
+			p, err := e.convert(uri, v.Interface().(Position))
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(p))
+			return nil
+		}
+		uri = structURI(v, uri)
+		for i := range v.NumField() {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := e.walk(v.Field(i), uri); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := e.walk(v.Index(i), uri); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elemURI := uri
+			if iter.Key().Type() == documentURIType {
+				elemURI = iter.Key().Interface().(DocumentURI)
+			}
+			// Map elements are not addressable: convert a copy.
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			if err := e.walk(elem, elemURI); err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	}
+	return nil
+}
+
+// deepCopy returns an addressable deep copy of v, which copies the
+// values v refers to through pointers, slices, maps and interfaces,
+// preserving their sharing. The unexported fields of structs are copied
+// shallowly.
+func deepCopy(v reflect.Value, copies map[uintptr]reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			break
+		}
+		if p, ok := copies[v.Pointer()]; ok {
+			c.Set(p)
+			break
+		}
+		p := reflect.New(v.Type().Elem())
+		copies[v.Pointer()] = p
+		p.Elem().Set(deepCopy(v.Elem(), copies))
+		c.Set(p)
+
+	case reflect.Interface:
+		if !v.IsNil() {
+			c.Set(deepCopy(v.Elem(), copies))
+		}
+
+	case reflect.Struct:
+		c.Set(v)
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(deepCopy(v.Field(i), copies))
+			}
+		}
+
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+
+	case reflect.Array:
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copies))
+		}
+
+	default:
+		c.Set(v)
+	}
+	return c
+}
+
+// structURI returns the document to which the positions within the
+// struct v belong: the one it names, if any, or else uri.
+func structURI(v reflect.Value, uri DocumentURI) DocumentURI {
+	for _, name := range []string{"URI", "TargetURI"} {
+		if f := v.FieldByName(name); f.IsValid() && f.Type() == documentURIType && !f.IsZero() {
+			return f.Interface().(DocumentURI)
+		}
+	}
+	// e.g. TextDocumentEdit
+	if td := v.FieldByName("TextDocument"); td.IsValid() && td.Kind() == reflect.Struct {
+		if f := td.FieldByName("URI"); f.IsValid() && f.Type() == documentURIType && !f.IsZero() {
+			return f.Interface().(DocumentURI)
+		}
+	}
+	return uri
+}
+
+// convert converts a position in uri from UTF-8 to the encoding of e.
+func (e *positionEncoder) convert(uri DocumentURI, p Position) (Position, error) {
+	m, ok := e.mappers[uri]
+	if !ok {
+		m = e.mapper(uri)
+		if e.mappers == nil {
+			e.mappers = make(map[DocumentURI]*Mapper)
+		}
+		e.mappers[uri] = m
+	}
+	if m == nil {
+		return p, nil
+	}
+	m.initLines()
+	line := int(p.Line)
+	if line == len(m.lineStart) && p.Character == 0 {
+		return p, nil // EOF
+	}
+	if line >= len(m.lineStart) {
+		return Position{}, fmt.Errorf("%s: line number %d out of range (max %d)", uri, line, len(m.lineStart)-1)
+	}
+	start := m.lineStart[line]
+	end := start + int(p.Character)
+	if end > len(m.Content) {
+		return Position{}, fmt.Errorf("%s: column %d of line %d out of range", uri, p.Character, line)
+	}
+	switch e.encoding {
+	case UTF32:
+		p.Character = uint32(utf8.RuneCount(m.Content[start:end]))
+	default:
+		p.Character = uint32(UTF16Len(m.Content[start:end]))
+	}
+	return p, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// byteServer reports positions as byte offsets within their lines.
+type byteServer struct {
+	lsp.Server
+	encoding lsp.PositionEncodingKind
+	hover    *lsp.Hover // returned by Hover, as if cached
+}
+
+func (s byteServer) Initialize(context.Context, *lsp.ParamInitialize) (*lsp.InitializeResult, error) {
+	res := &lsp.InitializeResult{}
+	if s.encoding != "" {
+		res.Capabilities.PositionEncoding = &s.encoding
+	}
+	return res, nil
+}
+
+// References returns the location of "x" in both documents.
+func (byteServer) References(context.Context, *lsp.ReferenceParams) ([]lsp.Location, error) {
+	return []lsp.Location{
+		{URI: "file:///a.go", Range: lsp.Range{Start: lsp.Position{Line: 1, Character: 7}, End: lsp.Position{Line: 1, Character: 8}}},
+		{URI: "file:///b.go", Range: lsp.Range{Start: lsp.Position{Line: 0, Character: 6}, End: lsp.Position{Line: 0, Character: 7}}},
+	}, nil
+}
+
+// DocumentHighlight returns a range in the document of the request.
+func (byteServer) DocumentHighlight(context.Context, *lsp.DocumentHighlightParams) ([]lsp.DocumentHighlight, error) {
+	return []lsp.DocumentHighlight{{Range: lsp.Range{Start: lsp.Position{Line: 1, Character: 7}, End: lsp.Position{Line: 1, Character: 8}}}}, nil
+}
+
+// DocumentSymbol returns a symbol in the document of the request, as a
+// value rather than a pointer.
+func (byteServer) DocumentSymbol(context.Context, *lsp.DocumentSymbolParams) ([]any, error) {
+	r := lsp.Range{Start: lsp.Position{Line: 1, Character: 7}, End: lsp.Position{Line: 1, Character: 8}}
+	return []any{lsp.DocumentSymbol{Name: "x", Range: r, SelectionRange: r}}, nil
+}
+
+func (s byteServer) Hover(context.Context, *lsp.HoverParams) (*lsp.Hover, error) {
+	return s.hover, nil
+}
+
+func TestEncodePositions(t *testing.T) {
+	ctx := context.Background()
+	docs := map[lsp.DocumentURI]*lsp.Mapper{
+		"file:///a.go": lsp.NewMapper("file:///a.go", []byte("package a\n// 世: x\n")),
+		"file:///b.go": lsp.NewMapper("file:///b.go", []byte("\U0001F600: x\n")),
+	}
+	mapper := func(uri lsp.DocumentURI) *lsp.Mapper { return docs[uri] }
+
+	call := func(t *testing.T, handler jsonrpc2.Handler, method string, params any) any {
+		t.Helper()
+		req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), method, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := handler.Handle(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	textDocument := json.RawMessage(`{"textDocument":{"uri":"file:///a.go"},"position":{"line":0,"character":0},"context":{"includeDeclaration":true}}`)
+
+	for _, test := range []struct {
+		encoding       lsp.PositionEncodingKind
+		wantA, wantB   uint32 // start character of the references in a.go and b.go
+		wantHighlights uint32
+	}{
+		{"", 5, 4, 5},
+		{lsp.UTF16, 5, 4, 5},
+		{lsp.UTF32, 5, 3, 5},
+		{lsp.UTF8, 7, 6, 7},
+	} {
+		name := string(test.encoding)
+		if name == "" {
+			name = "default"
+		}
+		t.Run(name, func(t *testing.T) {
+			handler := lsp.EncodePositions(lsp.ServerHandler(byteServer{encoding: test.encoding}), mapper)
+			call(t, handler, "initialize", &lsp.ParamInitialize{})
+
+			locs := call(t, handler, "textDocument/references", textDocument).([]lsp.Location)
+			if got := locs[0].Range.Start.Character; got != test.wantA {
+				t.Errorf("Expected reference in a.go at character %d, got %d", test.wantA, got)
+			}
+			if got := locs[1].Range.Start.Character; got != test.wantB {
+				t.Errorf("Expected reference in b.go at character %d, got %d", test.wantB, got)
+			}
+			highlights := call(t, handler, "textDocument/documentHighlight", textDocument).([]lsp.DocumentHighlight)
+			if got := highlights[0].Range.Start.Character; got != test.wantHighlights {
+				t.Errorf("Expected highlight at character %d, got %d", test.wantHighlights, got)
+			}
+			symbols := call(t, handler, "textDocument/documentSymbol", textDocument).([]any)
+			if got := symbols[0].(lsp.DocumentSymbol).SelectionRange.Start.Character; got != test.wantHighlights {
+				t.Errorf("Expected symbol at character %d, got %d", test.wantHighlights, got)
+			}
+		})
+	}
+
+	t.Run("Cached", func(t *testing.T) {
+		// The hover of the server is converted anew for each call, and
+		// left unchanged.
+		r := lsp.Range{Start: lsp.Position{Line: 1, Character: 7}, End: lsp.Position{Line: 1, Character: 8}}
+		hover := &lsp.Hover{Range: r}
+		handler := lsp.EncodePositions(lsp.ServerHandler(byteServer{hover: hover}), mapper)
+		call(t, handler, "initialize", &lsp.ParamInitialize{})
+		for range 2 {
+			got := call(t, handler, "textDocument/hover", textDocument).(*lsp.Hover)
+			if got.Range.Start.Character != 5 || got.Range.End.Character != 6 {
+				t.Errorf("Expected the hover at 1:5-1:6, got %v", got.Range)
+			}
+		}
+		if hover.Range.Start.Character != 7 {
+			t.Errorf("Expected the result of the server to be left unchanged, got %v", hover.Range)
+		}
+	})
+}
@@ -8,18 +8,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"time"
 
 	"golang.org/x/exp/jsonrpc2"
 )
 
-var (
-	// RequestCancelledError should be used when a request is cancelled early.
-	RequestCancelledError = jsonrpc2.NewError(-32800, "JSON RPC cancelled")
-)
-
 // detach returns a context that keeps all the values of its parent context
 // but detaches from the cancellation and error handling.
 func detach(ctx context.Context) context.Context { return detachedContext{ctx} }
@@ -53,25 +48,66 @@ func (c *clientDispatcher) Close() error {
 
 // ClientDispatcher returns a Client that dispatches LSP requests across the
 // given jsonrpc2 connection.
-func ClientDispatcher(conn *jsonrpc2.Connection) ClientCloser {
-	return &clientDispatcher{sender: clientConn{conn}}
+func ClientDispatcher(conn *jsonrpc2.Connection, opts ...DispatcherOption) ClientCloser {
+	return &clientDispatcher{sender: newClientConn(conn, opts)}
+}
+
+// A DispatcherOption configures the dispatcher returned by
+// [ServerDispatcher] or [ClientDispatcher].
+type DispatcherOption func(*clientConn)
+
+// RetryServerCancelled makes the dispatcher transparently resend a request,
+// up to n times, when the peer fails it with ServerCancelled and the error's
+// data does not say that the request should not be retriggered.
+func RetryServerCancelled(n int) DispatcherOption {
+	return func(c *clientConn) { c.serverCancelledRetries = n }
 }
 
 type clientConn struct {
-	conn *jsonrpc2.Connection
+	conn                   *jsonrpc2.Connection
+	serverCancelledRetries int
+	pending                *PendingCalls
+	lifecycle              *Lifecycle
+}
+
+func newClientConn(conn *jsonrpc2.Connection, opts []DispatcherOption) clientConn {
+	c := clientConn{conn: conn}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	c.lifecycle.attach(conn)
+	return c
 }
 
 func (c clientConn) Close() error {
+	c.lifecycle.close()
 	return c.conn.Close()
 }
 
 func (c clientConn) Notify(ctx context.Context, method string, params any) error {
-	return c.conn.Notify(ctx, method, params)
+	if err := c.conn.Notify(ctx, method, params); err != nil {
+		return err
+	}
+	c.lifecycle.observe(method)
+	return nil
 }
 
 func (c clientConn) Call(ctx context.Context, method string, params any, result any) error {
+	c.lifecycle.observe(method)
+	for retries := 0; ; retries++ {
+		err := c.call(ctx, method, params, result)
+		if retries >= c.serverCancelledRetries || ctx.Err() != nil || !shouldRetrigger(err) {
+			return err
+		}
+	}
+}
+
+func (c clientConn) call(ctx context.Context, method string, params any, result any) error {
+	start := time.Now()
 	call := c.conn.Call(ctx, method, params)
+	done := c.pending.add(method, call.ID(), start)
 	err := call.Await(ctx, result)
+	done()
 	if ctx.Err() != nil {
 		detached := detach(ctx)
 		_ = c.conn.Notify(detached, "$/cancelRequest", &CancelParams{ID: cancelParamsFromID(call.ID())})
@@ -81,30 +117,74 @@ func (c clientConn) Call(ctx context.Context, method string, params any, result
 
 // ServerDispatcher returns a Server that dispatches LSP requests across the
 // given jsonrpc2 connection.
-func ServerDispatcher(conn *jsonrpc2.Connection) Server {
-	return &serverDispatcher{sender: clientConn{conn}}
+func ServerDispatcher(conn *jsonrpc2.Connection, opts ...DispatcherOption) Server {
+	return &serverDispatcher{sender: newClientConn(conn, opts)}
 }
 
 type serverDispatcher struct {
 	sender connSender
 }
 
-func ClientHandler(client Client) jsonrpc2.HandlerFunc {
-	return func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+// ClientHandler returns a handler that dispatches incoming requests and
+// notifications to client, through the given middleware, as [Chain]
+// applies it. Requests without a result, such as
+// window/workDoneProgress/create, are answered with the result null.
+func ClientHandler(client Client, middleware ...Middleware) jsonrpc2.HandlerFunc {
+	return chainFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
 		if ctx.Err() != nil {
-			return nil, RequestCancelledError
+			return nil, ErrRequestCancelled
 		}
-		return clientDispatch(ctx, client, req)
-	}
+		resp, err := clientDispatch(withRequest(ctx, req), client, req)
+		return replyResult(req, resp), replyError(err)
+	}, middleware)
 }
 
-func ServerHandler(server Server) jsonrpc2.HandlerFunc {
-	return func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+// ServerHandler returns a handler that dispatches incoming requests and
+// notifications to server, through the given middleware, as [Chain]
+// applies it:
+//
+//	handler := lsp.ServerHandler(server, lsp.Recover, filter.Handler)
+//
+// Handlers may simply return ctx.Err() when their context is done:
+// context.Canceled is reported to the client as RequestCancelled and
+// context.DeadlineExceeded as RequestFailed. Requests without a result,
+// such as shutdown, are answered with the result null.
+func ServerHandler(server Server, middleware ...Middleware) jsonrpc2.HandlerFunc {
+	return chainFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
 		if ctx.Err() != nil {
-			return nil, RequestCancelledError
+			return nil, ErrRequestCancelled
 		}
-		return serverDispatch(ctx, server, req)
+		resp, err := serverDispatch(withRequest(ctx, req), server, req)
+		return replyResult(req, resp), replyError(err)
+	}, middleware)
+}
+
+// replyResult returns the result of req, a call whose handler returned
+// resp: a request without a result, such as shutdown, has the result
+// null, which jsonrpc2 would otherwise report as an internal error.
+func replyResult(req *jsonrpc2.Request, resp any) any {
+	if resp == nil && req.IsCall() {
+		return json.RawMessage("null")
 	}
+	return resp
+}
+
+// replyError maps context errors returned by a handler to the
+// corresponding LSP error codes, and ResponseErrors to the jsonrpc2
+// errors that carry their data. Other errors are returned unchanged.
+func replyError(err error) error {
+	var rerr *ResponseError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &rerr):
+		return rerr.wireError(err.Error())
+	case errors.Is(err, context.Canceled):
+		return Errorf(RequestCancelled, "%v", err).wireError(err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return Errorf(RequestFailed, "%v", err).wireError(err.Error())
+	}
+	return err
 }
 
 func Call(ctx context.Context, conn *jsonrpc2.Connection, method string, params any, result any) error {
@@ -137,19 +217,6 @@ func NonNilSlice[T comparable](x []T) []T {
 	return x
 }
 
-// EncodeMessage is a utility to encode LSP protocol messages to JSON.
-func EncodeMessage(msg any) ([]byte, error) {
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal message: %w", err)
-	}
-
-	var buf bytes.Buffer
-	buf.WriteString(fmt.Sprintf("Content-Length: %d\r\n\r\n", len(data)))
-	buf.Write(data)
-	return buf.Bytes(), nil
-}
-
 func cancelParamsFromID(id jsonrpc2.ID) CancelParamsId {
 	switch v := id.Raw().(type) {
 	case int32:
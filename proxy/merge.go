@@ -0,0 +1,279 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"typefox.dev/lsp"
+)
+
+// A Result is the successful result of a request from one backend.
+type Result struct {
+	Backend string          // name of the backend
+	Value   json.RawMessage // result, possibly null
+}
+
+// A MergeFunc merges the results of a request from the backends that
+// succeeded, in the order in which the backends were added. It is
+// called with at least one result.
+type MergeFunc func(results []Result) (any, error)
+
+// DefaultMerges returns the merge strategies of a new Proxy: the
+// results of completion, code actions, code lenses, references, links,
+// inlay hints, workspace symbols and pull diagnostics are combined, and
+// the capabilities of initialize are united.
+func DefaultMerges() map[string]MergeFunc {
+	return map[string]MergeFunc{
+		lsp.MethodInitialize:                    MergeInitialize,
+		lsp.MethodShutdown:                      MergeFirst,
+		lsp.MethodTextDocumentCompletion:        MergeCompletion,
+		lsp.MethodTextDocumentCodeAction:        MergeConcat,
+		lsp.MethodTextDocumentCodeLens:          MergeConcat,
+		lsp.MethodTextDocumentReferences:        MergeConcat,
+		lsp.MethodTextDocumentDocumentLink:      MergeConcat,
+		lsp.MethodTextDocumentInlayHint:         MergeConcat,
+		lsp.MethodTextDocumentDiagnostic:        MergeDiagnosticReports,
+		lsp.MethodWorkspaceSymbol:               MergeConcat,
+		lsp.MethodWorkspaceWillCreateFiles:      MergeFirst,
+		lsp.MethodWorkspaceWillRenameFiles:      MergeFirst,
+		lsp.MethodWorkspaceWillDeleteFiles:      MergeFirst,
+		lsp.MethodTextDocumentWillSaveWaitUntil: MergeFirst,
+	}
+}
+
+var null = json.RawMessage("null")
+
+func isNull(v json.RawMessage) bool {
+	return len(v) == 0 || bytes.Equal(v, null)
+}
+
+// MergeFirst returns the first result that is not null.
+func MergeFirst(results []Result) (any, error) {
+	for _, r := range results {
+		if !isNull(r.Value) {
+			return r.Value, nil
+		}
+	}
+	return null, nil
+}
+
+// MergeConcat concatenates results that are arrays, in order. The
+// result is null if all results are.
+func MergeConcat(results []Result) (any, error) {
+	var all []json.RawMessage
+	for _, r := range results {
+		if isNull(r.Value) {
+			continue
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(r.Value, &elems); err != nil {
+			return nil, err
+		}
+		all = append(all, elems...)
+	}
+	if all == nil {
+		return null, nil
+	}
+	return all, nil
+}
+
+// MergeCompletion merges the results of textDocument/completion, which
+// are CompletionLists or arrays of CompletionItems, into a single list
+// that is incomplete if any of them is. The item defaults of each list
+// are applied to its items, as they may differ between backends.
+func MergeCompletion(results []Result) (any, error) {
+	merged := &lsp.CompletionList{Items: []lsp.CompletionItem{}}
+	for _, r := range results {
+		if isNull(r.Value) {
+			continue
+		}
+		var list lsp.CompletionList
+		if bytes.HasPrefix(bytes.TrimSpace(r.Value), []byte("[")) {
+			if err := json.Unmarshal(r.Value, &list.Items); err != nil {
+				return nil, err
+			}
+		} else if err := json.Unmarshal(r.Value, &list); err != nil {
+			return nil, err
+		} else {
+			fixEditRange(&list, r.Value)
+		}
+		merged.IsIncomplete = merged.IsIncomplete || list.IsIncomplete
+		for _, item := range list.Items {
+			applyItemDefaults(&item, list.ItemDefaults, list.ApplyKind)
+			merged.Items = append(merged.Items, item)
+		}
+	}
+	return merged, nil
+}
+
+// fixEditRange corrects the default edit range of list, decoded from
+// data: a plain Range also decodes as an EditRangeWithInsertReplace
+// with empty ranges, so the two are told apart by their fields.
+func fixEditRange(list *lsp.CompletionList, data json.RawMessage) {
+	if list.ItemDefaults == nil || list.ItemDefaults.EditRange == nil {
+		return
+	}
+	var raw struct {
+		ItemDefaults struct {
+			EditRange struct {
+				lsp.Range
+				Insert *lsp.Range `json:"insert"`
+			} `json:"editRange"`
+		} `json:"itemDefaults"`
+	}
+	if json.Unmarshal(data, &raw) == nil && raw.ItemDefaults.EditRange.Insert == nil {
+		list.ItemDefaults.EditRange = &lsp.CompletionItemDefaultsEditRange{Range: &raw.ItemDefaults.EditRange.Range}
+	}
+}
+
+// applyItemDefaults sets the fields of item that defaults provide,
+// combined according to kinds.
+func applyItemDefaults(item *lsp.CompletionItem, defaults *lsp.CompletionItemDefaults, kinds *lsp.CompletionItemApplyKinds) {
+	if defaults == nil {
+		return
+	}
+	if defaults.CommitCharacters != nil {
+		if item.CommitCharacters == nil {
+			item.CommitCharacters = slices.Clone(defaults.CommitCharacters)
+		} else if kinds != nil && kinds.CommitCharacters != nil && *kinds.CommitCharacters == lsp.Merge {
+			for _, c := range defaults.CommitCharacters {
+				if !slices.Contains(item.CommitCharacters, c) {
+					item.CommitCharacters = append(item.CommitCharacters, c)
+				}
+			}
+		}
+	}
+	if r := defaults.EditRange; r != nil && item.TextEdit == nil {
+		text := item.Label
+		if item.TextEditText != "" {
+			text = item.TextEditText
+		}
+		if r.Range != nil {
+			item.TextEdit = &lsp.CompletionItemTextEdit{TextEdit: &lsp.TextEdit{Range: *r.Range, NewText: text}}
+		} else if r.EditRangeWithInsertReplace != nil {
+			item.TextEdit = &lsp.CompletionItemTextEdit{InsertReplaceEdit: &lsp.InsertReplaceEdit{
+				NewText: text,
+				Insert:  r.EditRangeWithInsertReplace.Insert,
+				Replace: r.EditRangeWithInsertReplace.Replace,
+			}}
+		}
+	}
+	if item.InsertTextFormat == nil {
+		item.InsertTextFormat = defaults.InsertTextFormat
+	}
+	if item.InsertTextMode == nil {
+		item.InsertTextMode = defaults.InsertTextMode
+	}
+	if item.Data == nil {
+		item.Data = defaults.Data
+	}
+}
+
+// MergeDiagnosticReports merges the full reports of
+// textDocument/diagnostic into one full report, without a result ID so
+// that clients do not ask for unchanged reports. Unchanged reports and
+// related documents are dropped.
+func MergeDiagnosticReports(results []Result) (any, error) {
+	merged := &lsp.FullDocumentDiagnosticReport{Kind: string(lsp.DiagnosticFull), Items: []lsp.Diagnostic{}}
+	for _, r := range results {
+		if isNull(r.Value) {
+			continue
+		}
+		var report lsp.FullDocumentDiagnosticReport
+		if err := json.Unmarshal(r.Value, &report); err != nil {
+			return nil, err
+		}
+		if report.Kind == string(lsp.DiagnosticFull) {
+			merged.Items = append(merged.Items, report.Items...)
+		}
+	}
+	return merged, nil
+}
+
+// MergeInitialize merges the results of initialize: each capability is
+// that of the first backend that declares it, except for the commands
+// of executeCommandProvider and the trigger characters of
+// completionProvider and signatureHelpProvider, which are united. The
+// server information is that of the first backend.
+//
+// The position encoding of the backends must agree, as the proxy does
+// not translate positions.
+func MergeInitialize(results []Result) (any, error) {
+	var (
+		merged struct {
+			Capabilities map[string]json.RawMessage `json:"capabilities"`
+			ServerInfo   json.RawMessage            `json:"serverInfo,omitempty"`
+		}
+		commands []string
+		unions   = map[string][]string{"completionProvider": nil, "signatureHelpProvider": nil}
+	)
+	merged.Capabilities = make(map[string]json.RawMessage)
+	for _, r := range results {
+		var result struct {
+			Capabilities map[string]json.RawMessage `json:"capabilities"`
+			ServerInfo   json.RawMessage            `json:"serverInfo"`
+		}
+		if err := lsp.UnmarshalJSON(r.Value, &result); err != nil {
+			return nil, err
+		}
+		if merged.ServerInfo == nil && !isNull(result.ServerInfo) {
+			merged.ServerInfo = result.ServerInfo
+		}
+		for name, value := range result.Capabilities {
+			if _, ok := merged.Capabilities[name]; !ok && !isNull(value) {
+				merged.Capabilities[name] = value
+			}
+		}
+		commands = appendNew(commands, executeCommands(result.Capabilities)...)
+		for name := range unions {
+			var provider struct {
+				TriggerCharacters []string `json:"triggerCharacters"`
+			}
+			json.Unmarshal(result.Capabilities[name], &provider) // may be true or absent
+			unions[name] = appendNew(unions[name], provider.TriggerCharacters...)
+		}
+	}
+	if commands != nil {
+		merged.Capabilities["executeCommandProvider"] = setField(merged.Capabilities["executeCommandProvider"], "commands", commands)
+	}
+	for name, chars := range unions {
+		if chars != nil {
+			merged.Capabilities[name] = setField(merged.Capabilities[name], "triggerCharacters", chars)
+		}
+	}
+	return merged, nil
+}
+
+// executeCommands returns the commands of the executeCommandProvider
+// among capabilities.
+func executeCommands(capabilities map[string]json.RawMessage) []string {
+	var provider struct {
+		Commands []string `json:"commands"`
+	}
+	json.Unmarshal(capabilities["executeCommandProvider"], &provider)
+	return provider.Commands
+}
+
+// setField returns the JSON object obj with its field name set to value.
+func setField(obj json.RawMessage, name string, value any) json.RawMessage {
+	fields := make(map[string]any)
+	json.Unmarshal(obj, &fields) // obj may be a boolean
+	fields[name] = value
+	data, _ := json.Marshal(fields)
+	return data
+}
+
+// appendNew appends to slice the elements of elems it does not contain.
+func appendNew[T comparable](slice []T, elems ...T) []T {
+	for _, e := range elems {
+		if !slices.Contains(slice, e) {
+			slice = append(slice, e)
+		}
+	}
+	return slice
+}
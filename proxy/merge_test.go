@@ -0,0 +1,72 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proxy_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"typefox.dev/lsp/proxy"
+)
+
+func results(values ...string) []proxy.Result {
+	var rs []proxy.Result
+	for i, v := range values {
+		rs = append(rs, proxy.Result{Backend: string(rune('a' + i)), Value: json.RawMessage(v)})
+	}
+	return rs
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		merge   proxy.MergeFunc
+		results []proxy.Result
+		want    string
+	}{
+		{"FirstNull", proxy.MergeFirst, results(`null`, `null`), `null`},
+		{"First", proxy.MergeFirst, results(`null`, `{"x":1}`, `{"x":2}`), `{"x":1}`},
+		{"ConcatNull", proxy.MergeConcat, results(`null`, `null`), `null`},
+		{"Concat", proxy.MergeConcat, results(`[1,2]`, `null`, `[3]`), `[1,2,3]`},
+		{"CompletionArrays", proxy.MergeCompletion, results(`[{"label":"x"}]`, `null`, `[{"label":"y"}]`),
+			`{"isIncomplete":false,"items":[{"label":"x"},{"label":"y"}]}`},
+		{"CompletionDefaults", proxy.MergeCompletion, results(
+			`{"isIncomplete":false,"itemDefaults":{"commitCharacters":["."],"insertTextFormat":2,
+			  "editRange":{"start":{"line":0,"character":1},"end":{"line":0,"character":2}}},
+			  "applyKind":{"commitCharacters":2},
+			  "items":[{"label":"x","commitCharacters":["("]},{"label":"y","textEditText":"y()","insertTextFormat":1}]}`,
+			`{"isIncomplete":true,"items":[{"label":"z"}]}`),
+			`{"isIncomplete":true,"items":[` +
+				`{"label":"x","insertTextFormat":2,"textEdit":{"range":{"start":{"line":0,"character":1},"end":{"line":0,"character":2}},"newText":"x"},"commitCharacters":["(","."]},` +
+				`{"label":"y","insertTextFormat":1,"textEdit":{"range":{"start":{"line":0,"character":1},"end":{"line":0,"character":2}},"newText":"y()"},"textEditText":"y()","commitCharacters":["."]},` +
+				`{"label":"z"}]}`},
+		{"DiagnosticReports", proxy.MergeDiagnosticReports, results(
+			`{"kind":"full","resultId":"1","items":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"message":"a"}]}`,
+			`{"kind":"unchanged","resultId":"2"}`,
+			`{"kind":"full","items":[{"range":{"start":{"line":1,"character":0},"end":{"line":1,"character":0}},"message":"c"}]}`),
+			`{"kind":"full","items":[` +
+				`{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"code":null,"message":"a"},` +
+				`{"range":{"start":{"line":1,"character":0},"end":{"line":1,"character":0}},"code":null,"message":"c"}]}`},
+		{"Initialize", proxy.MergeInitialize, results(
+			`{"capabilities":{"hoverProvider":true,"completionProvider":{"triggerCharacters":["."],"resolveProvider":true}},"serverInfo":{"name":"a"}}`,
+			`{"capabilities":{"hoverProvider":{"workDoneProgress":true},"definitionProvider":true,"completionProvider":{"triggerCharacters":[".",":"]},"executeCommandProvider":{"commands":["b.run"]}}}`),
+			`{"capabilities":{"completionProvider":{"resolveProvider":true,"triggerCharacters":[".",":"]},"definitionProvider":true,"executeCommandProvider":{"commands":["b.run"]},"hoverProvider":true},"serverInfo":{"name":"a"}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := test.merge(test.results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("Expected\n%s\ngot\n%s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,511 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package proxy implements a language server that serves a single
+// client by fanning its messages out to several backend language
+// servers, so that an editor may use many servers for one file.
+//
+//	p := proxy.New()
+//	if err := p.AddBackend(ctx, "gopls", goplsDialer); err != nil { ... }
+//	if err := p.AddBackend(ctx, "spell", spellDialer); err != nil { ... }
+//	_, err := jsonrpc2.Dial(ctx, editorDialer, p)
+//
+// Notifications from the client, such as document changes, go to all
+// backends. Requests go to the first backend, the primary one, unless
+// a [MergeFunc] is set for their method, in which case they go to all
+// backends and their results are merged; see [DefaultMerges]. Items
+// that may be resolved later, such as completion items, remember their
+// backend, to which the resolve requests are sent, and commands are
+// executed by the backend that declared them.
+//
+// Messages about a text document go only to the backends that select
+// the document: those that registered the method dynamically with a
+// matching document selector, or else those whose selector, set by
+// [WithDocumentSelector], matches. The dynamic registrations of the
+// backends are presented to the client as a single set, with their IDs
+// prefixed by the name of their backend, and with the selector of their
+// backend in place of a null document selector.
+//
+// Messages from the backends are forwarded to the client, except for
+// diagnostics, which are merged: the client sees, for each document,
+// the diagnostics last published by each backend.
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// A Proxy is a language server that forwards the messages of a single
+// client to several backends. It is a [jsonrpc2.Binder] for the
+// connection to the client.
+type Proxy struct {
+	mu          sync.Mutex
+	merges      map[string]MergeFunc
+	client      *jsonrpc2.Connection
+	backends    []*backend
+	commands    map[string]int // backend that declared each command
+	diagnostics map[lsp.DocumentURI]*documentDiagnostics
+	languages   map[lsp.DocumentURI]lsp.LanguageKind // language of each open document
+}
+
+type backend struct {
+	name     string
+	conn     *jsonrpc2.Connection
+	selector lsp.DocumentSelector // nil for all documents
+
+	// registrations are the dynamic registrations of the backend, by
+	// ID; it is guarded by the mutex of the proxy.
+	registrations map[string]registration
+}
+
+// A registration is a dynamic registration of a backend.
+type registration struct {
+	method   string
+	selector lsp.DocumentSelector // nil for all documents
+}
+
+// selects reports whether the backend handles method for the document
+// with the given URI and language.
+func (b *backend) selects(method string, uri lsp.DocumentURI, language lsp.LanguageKind) bool {
+	registered := false
+	for _, r := range b.registrations {
+		if r.method != method {
+			continue
+		}
+		if r.selector == nil || lsp.MatchDocumentSelector(r.selector, uri, language) {
+			return true
+		}
+		registered = true
+	}
+	if registered {
+		return false
+	}
+	return b.selector == nil || lsp.MatchDocumentSelector(b.selector, uri, language)
+}
+
+// A BackendOption configures a backend added by [Proxy.AddBackend].
+type BackendOption func(*backend)
+
+// WithDocumentSelector restricts the messages about text documents that
+// go to a backend to those about the documents that selector selects,
+// for the methods that the backend does not register dynamically.
+func WithDocumentSelector(selector lsp.DocumentSelector) BackendOption {
+	return func(b *backend) {
+		b.selector = selector
+	}
+}
+
+// documentDiagnostics records the diagnostics of a document.
+type documentDiagnostics struct {
+	version int32
+	backend map[int][]lsp.Diagnostic
+}
+
+// New returns a Proxy without backends, using the [DefaultMerges].
+func New() *Proxy {
+	return &Proxy{
+		merges:      DefaultMerges(),
+		commands:    make(map[string]int),
+		diagnostics: make(map[lsp.DocumentURI]*documentDiagnostics),
+		languages:   make(map[lsp.DocumentURI]lsp.LanguageKind),
+	}
+}
+
+// SetMerge sets the strategy for merging the results of requests for
+// method. If merge is nil, the requests go to the primary backend only.
+func (p *Proxy) SetMerge(method string, merge MergeFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if merge == nil {
+		delete(p.merges, method)
+	} else {
+		p.merges[method] = merge
+	}
+}
+
+// AddBackend connects to a backend server. Backends must be added
+// before the client connects. The first backend is the primary one.
+func (p *Proxy) AddBackend(ctx context.Context, name string, d jsonrpc2.Dialer, opts ...BackendOption) error {
+	b := &backend{name: name, registrations: make(map[string]registration)}
+	for _, opt := range opts {
+		opt(b)
+	}
+	p.mu.Lock()
+	index := len(p.backends)
+	p.backends = append(p.backends, b)
+	p.mu.Unlock()
+
+	_, err := jsonrpc2.Dial(ctx, d, binderFunc(func(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+		b.conn = conn
+		return jsonrpc2.ConnectionOptions{
+			Handler: jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+				return p.handleBackend(ctx, index, b, req)
+			}),
+		}, nil
+	}))
+	if err != nil {
+		p.mu.Lock()
+		p.backends = p.backends[:index]
+		p.mu.Unlock()
+		return fmt.Errorf("connecting to backend %s: %w", name, err)
+	}
+	return nil
+}
+
+// Close closes the connections to the backends. It is called when the
+// connection to the client ends.
+func (p *Proxy) Close() error {
+	p.mu.Lock()
+	backends := p.backends
+	p.mu.Unlock()
+	var errs []error
+	for _, b := range backends {
+		errs = append(errs, b.conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Bind binds the connection to the client. A proxy serves a single
+// client.
+func (p *Proxy) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return jsonrpc2.ConnectionOptions{}, errors.New("proxy already has a client")
+	}
+	if len(p.backends) == 0 {
+		return jsonrpc2.ConnectionOptions{}, errors.New("proxy has no backends")
+	}
+	p.client = conn
+	go func() {
+		conn.Wait()
+		p.Close()
+	}()
+	return jsonrpc2.ConnectionOptions{
+		Preempter: preempterFunc(p.preemptClient),
+		Handler:   jsonrpc2.HandlerFunc(p.handleClient),
+	}, nil
+}
+
+type binderFunc func(context.Context, *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error)
+
+func (f binderFunc) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	return f(ctx, conn)
+}
+
+type preempterFunc func(context.Context, *jsonrpc2.Request) (any, error)
+
+func (f preempterFunc) Preempt(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+	return f(ctx, req)
+}
+
+// preemptClient cancels the requests that the client cancels, which in
+// turn cancels the requests to the backends.
+func (p *Proxy) preemptClient(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+	if req.Method != "$/cancelRequest" {
+		return nil, jsonrpc2.ErrNotHandled
+	}
+	var params lsp.CancelParams
+	if err := lsp.UnmarshalJSON(req.Params, &params); err != nil {
+		return nil, nil
+	}
+	switch {
+	case params.ID.Int32 != nil:
+		p.client.Cancel(jsonrpc2.Int64ID(int64(*params.ID.Int32)))
+	case params.ID.String != nil:
+		p.client.Cancel(jsonrpc2.StringID(*params.ID.String))
+	}
+	return nil, nil
+}
+
+// handleClient handles a message from the client.
+func (p *Proxy) handleClient(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+	p.mu.Lock()
+	backends := p.backends
+	merge := p.merges[req.Method]
+	selected := p.selectBackends(req.Method, req.Params)
+	p.mu.Unlock()
+
+	if !req.IsCall() {
+		var errs []error
+		for _, i := range selected {
+			errs = append(errs, backends[i].conn.Notify(ctx, req.Method, req.Params))
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	// Send the requests before returning, to preserve the order of
+	// messages, and await the responses asynchronously.
+	params := req.Params
+	targets := selected[:min(len(selected), 1)]
+	retag := -1
+	switch {
+	case resolveMethods[req.Method]:
+		if backend, untagged, ok := untag(params, len(backends)); ok {
+			targets, params, retag = []int{backend}, untagged, backend
+		}
+		merge = nil
+	case req.Method == lsp.MethodWorkspaceExecuteCommand:
+		var cmd lsp.ExecuteCommandParams
+		if lsp.UnmarshalJSON(params, &cmd) == nil {
+			p.mu.Lock()
+			targets = []int{p.commands[cmd.Command]}
+			p.mu.Unlock()
+		}
+		merge = nil
+	case merge != nil:
+		targets = selected
+	}
+	if len(targets) == 0 {
+		return null, nil // no backend selects the document
+	}
+	calls := make([]*jsonrpc2.AsyncCall, len(targets))
+	for i, target := range targets {
+		calls[i] = backends[target].conn.Call(ctx, req.Method, params)
+	}
+	go func() {
+		result, err := p.await(ctx, req.Method, backends, targets, calls, merge, retag)
+		p.client.Respond(req.ID, result, err)
+	}()
+	return nil, jsonrpc2.ErrAsyncResponse
+}
+
+// selectBackends returns the indices of the backends that may handle a
+// message from the client with the given method and parameters: those
+// that select its text document if it has one, or else all of them. It
+// records the languages of the documents opened and closed by the
+// message. p.mu must be held.
+func (p *Proxy) selectBackends(method string, params json.RawMessage) []int {
+	var doc struct {
+		TextDocument struct {
+			URI        string           `json:"uri"` // not validated as a file URI
+			LanguageID lsp.LanguageKind `json:"languageId"`
+		} `json:"textDocument"`
+	}
+	lsp.UnmarshalJSON(params, &doc) // params may be absent or of another shape
+	uri := lsp.DocumentURI(doc.TextDocument.URI)
+	switch method {
+	case lsp.MethodTextDocumentDidOpen:
+		p.languages[uri] = doc.TextDocument.LanguageID
+	case lsp.MethodTextDocumentDidClose:
+		defer delete(p.languages, uri)
+	}
+	selected := make([]int, 0, len(p.backends))
+	for i, b := range p.backends {
+		if uri == "" || b.selects(method, uri, p.languages[uri]) {
+			selected = append(selected, i)
+		}
+	}
+	return selected
+}
+
+// await awaits the responses of the backends to calls, and combines
+// them into the response to the client.
+func (p *Proxy) await(ctx context.Context, method string, backends []*backend, targets []int, calls []*jsonrpc2.AsyncCall, merge MergeFunc, retag int) (any, error) {
+	results := make([]Result, 0, len(calls))
+	var firstErr error
+	for i, call := range calls {
+		var value json.RawMessage
+		err := call.Await(ctx, &value)
+		if ctx.Err() != nil {
+			for j := i; j < len(calls); j++ {
+				backends[targets[j]].conn.Notify(context.WithoutCancel(ctx), "$/cancelRequest", cancelParams(calls[j].ID()))
+			}
+			return nil, lsp.ErrRequestCancelled
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if value == nil {
+			value = null
+		}
+		if resolvableMethods[method] {
+			value = tagItems(method, value, targets[i])
+		}
+		if retag >= 0 {
+			value = tagItem(value, retag)
+		}
+		if method == lsp.MethodInitialize {
+			p.recordCommands(targets[i], value)
+		}
+		results = append(results, Result{Backend: backends[targets[i]].name, Value: value})
+	}
+	if len(results) == 0 {
+		return nil, firstErr
+	}
+	if merge == nil {
+		return results[0].Value, nil
+	}
+	return merge(results)
+}
+
+// recordCommands records the commands declared by the initialize result
+// of a backend.
+func (p *Proxy) recordCommands(backend int, result json.RawMessage) {
+	var r struct {
+		Capabilities map[string]json.RawMessage `json:"capabilities"`
+	}
+	lsp.UnmarshalJSON(result, &r)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, cmd := range executeCommands(r.Capabilities) {
+		if _, ok := p.commands[cmd]; !ok {
+			p.commands[cmd] = backend
+		}
+	}
+}
+
+func cancelParams(id jsonrpc2.ID) *lsp.CancelParams {
+	var params lsp.CancelParams
+	switch v := id.Raw().(type) {
+	case int64:
+		i := int32(v)
+		params.ID.Int32 = &i
+	case string:
+		params.ID.String = &v
+	}
+	return &params
+}
+
+// handleBackend handles a message from a backend.
+func (p *Proxy) handleBackend(ctx context.Context, index int, b *backend, req *jsonrpc2.Request) (any, error) {
+	p.mu.Lock()
+	client := p.client
+	p.mu.Unlock()
+
+	if req.Method == lsp.MethodTextDocumentPublishDiagnostics {
+		var params lsp.PublishDiagnosticsParams
+		if err := lsp.UnmarshalJSON(req.Params, &params); err != nil {
+			return nil, err
+		}
+		merged := p.mergeDiagnostics(index, &params)
+		if client == nil {
+			return nil, nil
+		}
+		return nil, client.Notify(ctx, req.Method, merged)
+	}
+	params := req.Params
+	switch req.Method {
+	case lsp.MethodClientRegisterCapability:
+		var err error
+		if params, err = p.register(b, params); err != nil {
+			return nil, err
+		}
+	case lsp.MethodClientUnregisterCapability:
+		var err error
+		if params, err = p.unregister(b, params); err != nil {
+			return nil, err
+		}
+	}
+	if !req.IsCall() {
+		if client == nil {
+			return nil, nil
+		}
+		return nil, client.Notify(ctx, req.Method, params)
+	}
+	if client == nil {
+		return nil, lsp.Errorf(lsp.RequestFailed, "%s: no client connected", req.Method)
+	}
+	call := client.Call(ctx, req.Method, params)
+	go func() {
+		var result json.RawMessage
+		err := call.Await(ctx, &result)
+		if ctx.Err() != nil {
+			client.Notify(context.WithoutCancel(ctx), "$/cancelRequest", cancelParams(call.ID()))
+		}
+		b.conn.Respond(req.ID, result, err)
+	}()
+	return nil, jsonrpc2.ErrAsyncResponse
+}
+
+// rawRegistration is a Registration whose options are kept as sent.
+type rawRegistration struct {
+	ID              string          `json:"id"`
+	Method          string          `json:"method"`
+	RegisterOptions json.RawMessage `json:"registerOptions,omitempty"`
+}
+
+// register records the dynamic registrations of a backend, and returns
+// them as they are presented to the client: with IDs prefixed by the
+// name of the backend, and with its selector in place of a null
+// document selector.
+func (p *Proxy) register(b *backend, params json.RawMessage) (json.RawMessage, error) {
+	var r struct {
+		Registrations []rawRegistration `json:"registrations"`
+	}
+	if err := lsp.UnmarshalJSON(params, &r); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, reg := range r.Registrations {
+		var options struct {
+			DocumentSelector json.RawMessage `json:"documentSelector"`
+		}
+		json.Unmarshal(reg.RegisterOptions, &options) // options may be absent
+		rec := registration{method: reg.Method}
+		if options.DocumentSelector != nil && isNull(options.DocumentSelector) && b.selector != nil {
+			reg.RegisterOptions = setField(reg.RegisterOptions, "documentSelector", b.selector)
+			rec.selector = b.selector
+		} else if err := lsp.UnmarshalJSON(options.DocumentSelector, &rec.selector); err != nil {
+			return nil, err
+		}
+		b.registrations[reg.ID] = rec
+		reg.ID = b.name + "/" + reg.ID
+		r.Registrations[i] = reg
+	}
+	return json.Marshal(r)
+}
+
+// unregister forgets the dynamic registrations that a backend cancels,
+// and returns the cancellations as they are presented to the client.
+func (p *Proxy) unregister(b *backend, params json.RawMessage) (json.RawMessage, error) {
+	var r lsp.UnregistrationParams
+	if err := lsp.UnmarshalJSON(params, &r); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, unreg := range r.Unregisterations {
+		delete(b.registrations, unreg.ID)
+		r.Unregisterations[i].ID = b.name + "/" + unreg.ID
+	}
+	return json.Marshal(r)
+}
+
+// mergeDiagnostics records the diagnostics published by a backend, and
+// returns the diagnostics of all backends for the document.
+func (p *Proxy) mergeDiagnostics(backend int, params *lsp.PublishDiagnosticsParams) *lsp.PublishDiagnosticsParams {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	doc := p.diagnostics[params.URI]
+	if doc == nil {
+		doc = &documentDiagnostics{backend: make(map[int][]lsp.Diagnostic)}
+		p.diagnostics[params.URI] = doc
+	}
+	doc.version = params.Version
+	if len(params.Diagnostics) == 0 {
+		delete(doc.backend, backend)
+	} else {
+		doc.backend[backend] = params.Diagnostics
+	}
+	merged := &lsp.PublishDiagnosticsParams{URI: params.URI, Version: doc.version, Diagnostics: []lsp.Diagnostic{}}
+	for i := range p.backends {
+		merged.Diagnostics = append(merged.Diagnostics, doc.backend[i]...)
+	}
+	if len(doc.backend) == 0 {
+		delete(p.diagnostics, params.URI)
+	}
+	return merged
+}
@@ -0,0 +1,392 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proxy_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/proxy"
+	"typefox.dev/lsp/transport"
+)
+
+// fakeBackend is a backend server whose results identify it by name.
+type fakeBackend struct {
+	lsp.Server
+	name   string
+	client lsp.ClientCloser
+}
+
+func (b *fakeBackend) Initialize(context.Context, *lsp.ParamInitialize) (*lsp.InitializeResult, error) {
+	return &lsp.InitializeResult{
+		Capabilities: lsp.ServerCapabilities{
+			CompletionProvider:     &lsp.CompletionOptions{TriggerCharacters: []string{b.name[:1]}, ResolveProvider: true},
+			ExecuteCommandProvider: &lsp.ExecuteCommandOptions{Commands: []string{b.name + ".run"}},
+		},
+		ServerInfo: &lsp.ServerInfo{Name: b.name},
+	}, nil
+}
+
+func (b *fakeBackend) Completion(context.Context, *lsp.CompletionParams) (*lsp.CompletionList, error) {
+	if b.name == "a" {
+		return &lsp.CompletionList{Items: []lsp.CompletionItem{{Label: "a1", Data: "a1"}, {Label: "a2"}}}, nil
+	}
+	// Data provided as a default.
+	return &lsp.CompletionList{
+		IsIncomplete: true,
+		ItemDefaults: &lsp.CompletionItemDefaults{Data: "b"},
+		Items:        []lsp.CompletionItem{{Label: "b1"}},
+	}, nil
+}
+
+func (b *fakeBackend) ResolveCompletionItem(_ context.Context, item *lsp.CompletionItem) (*lsp.CompletionItem, error) {
+	item.Detail = fmt.Sprintf("%s resolved %v", b.name, item.Data)
+	return item, nil
+}
+
+func (b *fakeBackend) ExecuteCommand(_ context.Context, params *lsp.ExecuteCommandParams) (any, error) {
+	return b.name + " ran " + params.Command, nil
+}
+
+func (b *fakeBackend) Hover(context.Context, *lsp.HoverParams) (*lsp.Hover, error) {
+	return &lsp.Hover{Contents: lsp.MarkupContent{Value: b.name}}, nil
+}
+
+func (b *fakeBackend) DidOpen(ctx context.Context, params *lsp.DidOpenTextDocumentParams) error {
+	return b.client.PublishDiagnostics(ctx, &lsp.PublishDiagnosticsParams{
+		URI:         params.TextDocument.URI,
+		Version:     params.TextDocument.Version,
+		Diagnostics: []lsp.Diagnostic{{Message: lsp.DiagnosticMessageFromString(b.name), Source: b.name}},
+	})
+}
+
+// diagnosticsClient is a client that reports the diagnostics it
+// receives.
+type diagnosticsClient struct {
+	lsp.Client
+	diagnostics chan *lsp.PublishDiagnosticsParams
+}
+
+func (c diagnosticsClient) PublishDiagnostics(_ context.Context, params *lsp.PublishDiagnosticsParams) error {
+	c.diagnostics <- params
+	return nil
+}
+
+// listen serves binder on a new stream listener, and returns a dialer
+// of the listener.
+func listen(t *testing.T, binder jsonrpc2.Binder) jsonrpc2.Dialer {
+	t.Helper()
+	l := transport.NewStreamListener()
+	t.Cleanup(func() { l.Close() })
+	if _, err := jsonrpc2.Serve(context.Background(), l, binder); err != nil {
+		t.Fatal(err)
+	}
+	return l.Dialer()
+}
+
+// connectProxy connects client to p, and returns the server.
+func connectProxy(t *testing.T, p *proxy.Proxy, client lsp.Client) lsp.Server {
+	t.Helper()
+	server, conn, err := transport.ConnectServer(context.Background(), listen(t, p), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return server
+}
+
+func TestProxy(t *testing.T) {
+	ctx := context.Background()
+	p := proxy.New()
+	for _, name := range []string{"a", "b"} {
+		d := listen(t, transport.ServerBinder(func(_ context.Context, client lsp.ClientCloser, _ *lsp.Session) (lsp.Server, error) {
+			return &fakeBackend{name: name, client: client}, nil
+		}))
+		if err := p.AddBackend(ctx, name, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := diagnosticsClient{diagnostics: make(chan *lsp.PublishDiagnosticsParams, 10)}
+	server := connectProxy(t, p, client)
+
+	t.Run("Initialize", func(t *testing.T) {
+		result, err := server.Initialize(ctx, &lsp.ParamInitialize{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.Capabilities.CompletionProvider.TriggerCharacters; !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("Expected trigger characters [a b], got %v", got)
+		}
+		if got := result.Capabilities.ExecuteCommandProvider.Commands; !slices.Equal(got, []string{"a.run", "b.run"}) {
+			t.Errorf("Expected commands [a.run b.run], got %v", got)
+		}
+		if result.ServerInfo == nil || result.ServerInfo.Name != "a" {
+			t.Errorf("Expected the server info of the primary backend, got %+v", result.ServerInfo)
+		}
+	})
+
+	t.Run("Completion", func(t *testing.T) {
+		list, err := server.Completion(ctx, &lsp.CompletionParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var labels []string
+		for _, item := range list.Items {
+			labels = append(labels, item.Label)
+		}
+		if !slices.Equal(labels, []string{"a1", "a2", "b1"}) || !list.IsIncomplete {
+			t.Fatalf("Expected incomplete [a1 a2 b1], got %v, %v", list.IsIncomplete, labels)
+		}
+		want := []string{"a resolved a1", "a resolved <nil>", "b resolved b"}
+		for i, item := range list.Items {
+			resolved, err := server.ResolveCompletionItem(ctx, &item)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resolved.Detail != want[i] {
+				t.Errorf("Expected %q, got %q", want[i], resolved.Detail)
+			}
+			// Resolved items may be resolved again.
+			if again, err := server.ResolveCompletionItem(ctx, resolved); err != nil || again.Detail != want[i] {
+				t.Errorf("Expected %q, got %v, %v", want[i], again, err)
+			}
+		}
+	})
+
+	t.Run("Routing", func(t *testing.T) {
+		for _, cmd := range []string{"b.run", "a.run"} {
+			got, err := server.ExecuteCommand(ctx, &lsp.ExecuteCommandParams{Command: cmd})
+			if want := cmd[:1] + " ran " + cmd; err != nil || got != want {
+				t.Errorf("Expected %q, got %v, %v", want, got, err)
+			}
+		}
+		hover, err := server.Hover(ctx, &lsp.HoverParams{})
+		if err != nil || hover.Contents.Value != "a" {
+			t.Errorf("Expected the hover of the primary backend, got %v, %v", hover, err)
+		}
+	})
+
+	t.Run("Diagnostics", func(t *testing.T) {
+		err := server.DidOpen(ctx, &lsp.DidOpenTextDocumentParams{
+			TextDocument: lsp.TextDocumentItem{URI: "file:///a.go", Version: 3},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for range 2 {
+			select {
+			case params := <-client.diagnostics:
+				got = got[:0]
+				for _, d := range params.Diagnostics {
+					got = append(got, d.Source)
+				}
+				if params.Version != 3 {
+					t.Errorf("Expected version 3, got %d", params.Version)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for diagnostics")
+			}
+		}
+		if diff := cmp.Diff([]string{"a", "b"}, got); diff != "" {
+			t.Errorf("Unexpected diagnostics (-want +got):\n%s", diff)
+		}
+	})
+}
+
+// selectorBackend is a backend server that registers capabilities once
+// initialized, and cancels them when running its command.
+type selectorBackend struct {
+	lsp.Server
+	name          string
+	client        lsp.ClientCloser
+	registrations []lsp.Registration
+}
+
+func (b *selectorBackend) Initialize(context.Context, *lsp.ParamInitialize) (*lsp.InitializeResult, error) {
+	return &lsp.InitializeResult{Capabilities: lsp.ServerCapabilities{
+		ExecuteCommandProvider: &lsp.ExecuteCommandOptions{Commands: []string{b.name + ".unregister"}},
+	}}, nil
+}
+
+func (b *selectorBackend) Initialized(ctx context.Context, _ *lsp.InitializedParams) error {
+	if len(b.registrations) == 0 {
+		return nil
+	}
+	return b.client.RegisterCapability(ctx, &lsp.RegistrationParams{Registrations: b.registrations})
+}
+
+func (b *selectorBackend) ExecuteCommand(ctx context.Context, _ *lsp.ExecuteCommandParams) (any, error) {
+	var params lsp.UnregistrationParams
+	for _, r := range b.registrations {
+		params.Unregisterations = append(params.Unregisterations, lsp.Unregistration{ID: r.ID, Method: r.Method})
+	}
+	return nil, b.client.UnregisterCapability(ctx, &params)
+}
+
+func (b *selectorBackend) Hover(context.Context, *lsp.HoverParams) (*lsp.Hover, error) {
+	return &lsp.Hover{Contents: lsp.MarkupContent{Value: b.name}}, nil
+}
+
+func (b *selectorBackend) DidOpen(context.Context, *lsp.DidOpenTextDocumentParams) error {
+	return nil
+}
+
+// registrationClient is a client that reports the registrations it
+// receives.
+type registrationClient struct {
+	lsp.Client
+	registrations chan any
+}
+
+func (c registrationClient) RegisterCapability(_ context.Context, params *lsp.RegistrationParams) error {
+	c.registrations <- params
+	return nil
+}
+
+func (c registrationClient) UnregisterCapability(_ context.Context, params *lsp.UnregistrationParams) error {
+	c.registrations <- params
+	return nil
+}
+
+func TestProxySelectors(t *testing.T) {
+	ctx := context.Background()
+	goFiles := lsp.DocumentSelector{{TextDocumentFilter: &lsp.TextDocumentFilter{
+		TextDocumentFilterPattern: &lsp.TextDocumentFilterPattern{Pattern: lsp.GlobPattern{Pattern: new("**/*.go")}},
+	}}}
+	markdown := lsp.DocumentSelector{{TextDocumentFilter: &lsp.TextDocumentFilter{
+		TextDocumentFilterLanguage: &lsp.TextDocumentFilterLanguage{Language: "markdown"},
+	}}}
+	backends := []struct {
+		backend *selectorBackend
+		opts    []proxy.BackendOption
+	}{
+		{
+			// Registers completion for its own documents.
+			backend: &selectorBackend{name: "go", registrations: []lsp.Registration{{
+				ID:              "1",
+				Method:          "textDocument/completion",
+				RegisterOptions: lsp.TextDocumentRegistrationOptions{},
+			}}},
+			opts: []proxy.BackendOption{proxy.WithDocumentSelector(goFiles)},
+		},
+		{
+			// Registers hover for markdown documents only.
+			backend: &selectorBackend{name: "md", registrations: []lsp.Registration{{
+				ID:              "1",
+				Method:          "textDocument/hover",
+				RegisterOptions: lsp.TextDocumentRegistrationOptions{DocumentSelector: markdown},
+			}}},
+		},
+	}
+	p := proxy.New()
+	for _, b := range backends {
+		d := listen(t, transport.ServerBinder(func(_ context.Context, client lsp.ClientCloser, _ *lsp.Session) (lsp.Server, error) {
+			b.backend.client = client
+			return b.backend, nil
+		}))
+		if err := p.AddBackend(ctx, b.backend.name, d, b.opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := registrationClient{registrations: make(chan any, 10)}
+	server := connectProxy(t, p, client)
+	if _, err := server.Initialize(ctx, &lsp.ParamInitialize{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Initialized(ctx, &lsp.InitializedParams{}); err != nil {
+		t.Fatal(err)
+	}
+	receive := func() any {
+		t.Helper()
+		select {
+		case params := <-client.registrations:
+			return params
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for registrations")
+			return nil
+		}
+	}
+
+	t.Run("Registrations", func(t *testing.T) {
+		selectors := make(map[string]lsp.DocumentSelector)
+		for range backends {
+			params, ok := receive().(*lsp.RegistrationParams)
+			if !ok || len(params.Registrations) != 1 {
+				t.Fatalf("Expected a registration, got %+v", params)
+			}
+			r := params.Registrations[0]
+			data, _ := json.Marshal(r.RegisterOptions)
+			var options lsp.TextDocumentRegistrationOptions
+			if err := json.Unmarshal(data, &options); err != nil {
+				t.Fatal(err)
+			}
+			selectors[r.ID] = options.DocumentSelector
+		}
+		want := map[string]lsp.DocumentSelector{"go/1": goFiles, "md/1": markdown}
+		if diff := cmp.Diff(want, selectors); diff != "" {
+			t.Errorf("Unexpected registrations (-want +got):\n%s", diff)
+		}
+	})
+
+	open := func(uri lsp.DocumentURI, language lsp.LanguageKind) {
+		t.Helper()
+		err := server.DidOpen(ctx, &lsp.DidOpenTextDocumentParams{
+			TextDocument: lsp.TextDocumentItem{URI: uri, LanguageID: language},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	hover := func(uri lsp.DocumentURI) string {
+		t.Helper()
+		h, err := server.Hover(ctx, &lsp.HoverParams{TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h == nil {
+			return "<nil>"
+		}
+		return h.Contents.Value
+	}
+	open("file:///a.go", "go")
+	open("file:///README.md", "markdown")
+	open("file:///a.txt", "plaintext")
+
+	t.Run("Routing", func(t *testing.T) {
+		for uri, want := range map[lsp.DocumentURI]string{
+			"file:///a.go":      "go",
+			"file:///README.md": "md",
+			"file:///a.txt":     "<nil>", // selected by no backend
+		} {
+			if got := hover(uri); got != want {
+				t.Errorf("Expected the hover of %s to come from %s, got %s", uri, want, got)
+			}
+		}
+	})
+
+	t.Run("Unregistration", func(t *testing.T) {
+		if _, err := server.ExecuteCommand(ctx, &lsp.ExecuteCommandParams{Command: "md.unregister"}); err != nil {
+			t.Fatal(err)
+		}
+		params, ok := receive().(*lsp.UnregistrationParams)
+		if !ok || len(params.Unregisterations) != 1 || params.Unregisterations[0].ID != "md/1" {
+			t.Fatalf("Expected the unregistration of md/1, got %+v", params)
+		}
+		// The backend now handles hovers for all documents.
+		if got := hover("file:///a.txt"); got != "md" {
+			t.Errorf("Expected the hover of a.txt to come from md, got %s", got)
+		}
+	})
+}
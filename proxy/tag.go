@@ -0,0 +1,126 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proxy
+
+import (
+	"encoding/json"
+
+	"typefox.dev/lsp"
+)
+
+// Items that may be resolved later are tagged with the index of their
+// backend, by wrapping their data:
+//
+//	"data": {"proxyBackend": 1, "data": <original data>}
+//
+// The original data is restored before the resolve request goes to the
+// backend.
+
+// tagKey is the key of the backend index in the data of tagged items.
+const tagKey = "proxyBackend"
+
+// resolvableMethods are the methods whose results hold items that may be
+// resolved.
+var resolvableMethods = map[string]bool{
+	lsp.MethodTextDocumentCompletion:   true,
+	lsp.MethodTextDocumentCodeAction:   true,
+	lsp.MethodTextDocumentCodeLens:     true,
+	lsp.MethodTextDocumentInlayHint:    true,
+	lsp.MethodTextDocumentDocumentLink: true,
+}
+
+// resolveMethods are the methods that resolve items.
+var resolveMethods = map[string]bool{
+	lsp.MethodCompletionItemResolve: true,
+	lsp.MethodCodeActionResolve:     true,
+	lsp.MethodCodeLensResolve:       true,
+	lsp.MethodInlayHintResolve:      true,
+	lsp.MethodDocumentLinkResolve:   true,
+}
+
+type tag struct {
+	Backend int             `json:"proxyBackend"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// tagItems tags the items in the result of method from a backend.
+func tagItems(method string, result json.RawMessage, backend int) json.RawMessage {
+	if isNull(result) {
+		return result
+	}
+	var list map[string]json.RawMessage
+	if method == lsp.MethodTextDocumentCompletion && json.Unmarshal(result, &list) == nil {
+		// The default data of a CompletionList applies to the items
+		// without data, which must be tagged too.
+		var defaults map[string]json.RawMessage
+		json.Unmarshal(list["itemDefaults"], &defaults)
+		defaultData, ok := defaults["data"]
+		if ok {
+			delete(defaults, "data")
+			list["itemDefaults"], _ = json.Marshal(defaults)
+		}
+		list["items"] = tagArray(list["items"], backend, defaultData)
+		data, _ := json.Marshal(list)
+		return data
+	}
+	return tagArray(result, backend, nil)
+}
+
+// tagArray tags the elements of array, using defaultData as the data of
+// the elements without data. Commands, which cannot be resolved, are
+// not tagged.
+func tagArray(array json.RawMessage, backend int, defaultData json.RawMessage) json.RawMessage {
+	var elems []map[string]json.RawMessage
+	if json.Unmarshal(array, &elems) != nil {
+		return array
+	}
+	for _, elem := range elems {
+		var command string
+		if json.Unmarshal(elem["command"], &command) == nil && command != "" {
+			continue // a Command, not a CodeAction
+		}
+		data, ok := elem["data"]
+		if !ok {
+			data = defaultData
+		}
+		elem["data"], _ = json.Marshal(tag{backend, data})
+	}
+	data, _ := json.Marshal(elems)
+	return data
+}
+
+// tagItem tags a single item.
+func tagItem(item json.RawMessage, backend int) json.RawMessage {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(item, &obj) != nil || obj == nil {
+		return item
+	}
+	obj["data"], _ = json.Marshal(tag{backend, obj["data"]})
+	data, _ := json.Marshal(obj)
+	return data
+}
+
+// untag returns the backend of the item in params, and the item with
+// its original data.
+func untag(params json.RawMessage, backends int) (int, json.RawMessage, bool) {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(params, &obj) != nil {
+		return 0, nil, false
+	}
+	var t struct {
+		Backend *int            `json:"proxyBackend"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(obj["data"], &t) != nil || t.Backend == nil || *t.Backend < 0 || *t.Backend >= backends {
+		return 0, nil, false
+	}
+	if t.Data == nil {
+		delete(obj, "data")
+	} else {
+		obj["data"] = t.Data
+	}
+	data, _ := json.Marshal(obj)
+	return *t.Backend, data, true
+}
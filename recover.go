@@ -0,0 +1,47 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// Recover returns a handler that invokes handler, converting a panic into
+// an ErrInternal reply so that one buggy method does not kill the whole
+// connection.
+//
+// The panic value and stack are reported through the event system together
+// with a correlation ID, which is also included in the error message sent to
+// the peer so that a failure seen in the editor can be found in the logs.
+func Recover(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (result any, err error) {
+		ctx = withRequest(ctx, req)
+		defer func() {
+			if r := recover(); r != nil {
+				id := newCorrelationID()
+				labels := append(RequestLabels(ctx),
+					event.String("correlation.id", id),
+					event.String("stack", string(debug.Stack())))
+				event.Error(ctx, "panic in LSP handler", fmt.Errorf("%v", r), labels...)
+				result, err = nil, fmt.Errorf("%w: panic in %q (correlation ID %s)", jsonrpc2.ErrInternal, req.Method, id)
+			}
+		}()
+		return handler.Handle(ctx, req)
+	})
+}
+
+// newCorrelationID returns a short random identifier for an error report.
+func newCorrelationID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestRecover(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Panic", func(t *testing.T) {
+		handler := lsp.Recover(jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) {
+			panic("boom")
+		}))
+		_, err := handler.Handle(ctx, newHoverCall(t))
+		if !errors.Is(err, jsonrpc2.ErrInternal) {
+			t.Fatalf("Expected ErrInternal, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "correlation ID") {
+			t.Errorf("Expected correlation ID in error message, got %q", err.Error())
+		}
+	})
+
+	t.Run("NoPanic", func(t *testing.T) {
+		handler := lsp.Recover(lsp.ServerHandler(hoverServer{}))
+		resp, err := handler.Handle(ctx, newHoverCall(t))
+		if err != nil {
+			t.Fatalf("Hover failed: %v", err)
+		}
+		if _, ok := resp.(*lsp.Hover); !ok {
+			t.Errorf("Expected *lsp.Hover, got %T", resp)
+		}
+	})
+}
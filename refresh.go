@@ -0,0 +1,358 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/event"
+)
+
+// A Refresher asks a client to refresh the results of requests it
+// issued earlier, such as its semantic tokens, once the server knows
+// them to be stale. It sends a refresh request only if the client
+// supports it, and at most once per interval for each feature:
+// a refresh asked for sooner is deferred to the end of the interval,
+// and merged with those asked for meanwhile, so that a burst of changes
+// on the server does not cause a storm of refreshes.
+//
+//	refresher := lsp.NewRefresher(client, session.ClientCapabilities(), time.Second)
+//	defer refresher.Close()
+//	...
+//	err := refresher.RefreshSemanticTokens(ctx)
+type Refresher struct {
+	client   Client
+	caps     *ClientCapabilities
+	interval time.Duration
+
+	mu      sync.Mutex
+	closed  bool
+	refresh [numRefreshFeatures]refreshState
+}
+
+// refreshState is the state of the refreshes of one feature.
+type refreshState struct {
+	last  time.Time   // when the last refresh was sent
+	timer *time.Timer // to send a deferred refresh, if any
+}
+
+// A RefreshFeature is a feature whose results a client may refresh.
+type RefreshFeature int
+
+const (
+	DiagnosticsRefresh RefreshFeature = iota
+	SemanticTokensRefresh
+	CodeLensRefresh
+	InlayHintRefresh
+	InlineValueRefresh
+	FoldingRangeRefresh
+	numRefreshFeatures
+)
+
+// String returns the method of the refresh request of f.
+func (f RefreshFeature) String() string {
+	if f < 0 || f >= numRefreshFeatures {
+		return fmt.Sprintf("RefreshFeature(%d)", int(f))
+	}
+	return refreshFeatures[f].method
+}
+
+// refreshFeatures describes the refresh requests of each feature.
+var refreshFeatures = [numRefreshFeatures]struct {
+	method    string
+	supported func(*WorkspaceClientCapabilities) bool
+	send      func(Client, context.Context) error
+}{
+	DiagnosticsRefresh: {
+		"workspace/diagnostic/refresh",
+		func(c *WorkspaceClientCapabilities) bool { return c.Diagnostics != nil && c.Diagnostics.RefreshSupport },
+		Client.DiagnosticRefresh,
+	},
+	SemanticTokensRefresh: {
+		"workspace/semanticTokens/refresh",
+		func(c *WorkspaceClientCapabilities) bool {
+			return c.SemanticTokens != nil && c.SemanticTokens.RefreshSupport
+		},
+		Client.SemanticTokensRefresh,
+	},
+	CodeLensRefresh: {
+		"workspace/codeLens/refresh",
+		func(c *WorkspaceClientCapabilities) bool { return c.CodeLens != nil && c.CodeLens.RefreshSupport },
+		Client.CodeLensRefresh,
+	},
+	InlayHintRefresh: {
+		"workspace/inlayHint/refresh",
+		func(c *WorkspaceClientCapabilities) bool { return c.InlayHint != nil && c.InlayHint.RefreshSupport },
+		Client.InlayHintRefresh,
+	},
+	InlineValueRefresh: {
+		"workspace/inlineValue/refresh",
+		func(c *WorkspaceClientCapabilities) bool { return c.InlineValue != nil && c.InlineValue.RefreshSupport },
+		Client.InlineValueRefresh,
+	},
+	FoldingRangeRefresh: {
+		"workspace/foldingRange/refresh",
+		func(c *WorkspaceClientCapabilities) bool {
+			return c.FoldingRange != nil && c.FoldingRange.RefreshSupport
+		},
+		Client.FoldingRangeRefresh,
+	},
+}
+
+// NewRefresher returns a Refresher that sends refresh requests to a
+// client with the given capabilities, at most once per interval for
+// each feature. If caps is nil, it sends none.
+func NewRefresher(client Client, caps *ClientCapabilities, interval time.Duration) *Refresher {
+	return &Refresher{
+		client:   client,
+		caps:     caps,
+		interval: interval,
+	}
+}
+
+// RefreshDiagnostics asks the client to pull diagnostics again, with
+// workspace/diagnostic/refresh.
+func (r *Refresher) RefreshDiagnostics(ctx context.Context) error {
+	return r.Refresh(ctx, DiagnosticsRefresh)
+}
+
+// RefreshSemanticTokens asks the client to request semantic tokens
+// again, with workspace/semanticTokens/refresh.
+func (r *Refresher) RefreshSemanticTokens(ctx context.Context) error {
+	return r.Refresh(ctx, SemanticTokensRefresh)
+}
+
+// RefreshCodeLenses asks the client to request code lenses again, with
+// workspace/codeLens/refresh.
+func (r *Refresher) RefreshCodeLenses(ctx context.Context) error {
+	return r.Refresh(ctx, CodeLensRefresh)
+}
+
+// RefreshInlayHints asks the client to request inlay hints again, with
+// workspace/inlayHint/refresh.
+func (r *Refresher) RefreshInlayHints(ctx context.Context) error {
+	return r.Refresh(ctx, InlayHintRefresh)
+}
+
+// RefreshInlineValues asks the client to request inline values again,
+// with workspace/inlineValue/refresh.
+func (r *Refresher) RefreshInlineValues(ctx context.Context) error {
+	return r.Refresh(ctx, InlineValueRefresh)
+}
+
+// RefreshFoldingRanges asks the client to request folding ranges again,
+// with workspace/foldingRange/refresh.
+func (r *Refresher) RefreshFoldingRanges(ctx context.Context) error {
+	return r.Refresh(ctx, FoldingRangeRefresh)
+}
+
+// Supports reports whether the client supports the refresh of f.
+func (r *Refresher) Supports(f RefreshFeature) bool {
+	return r.caps != nil && f >= 0 && f < numRefreshFeatures && refreshFeatures[f].supported(&r.caps.Workspace)
+}
+
+// Close stops sending the refreshes that were deferred.
+func (r *Refresher) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for i := range r.refresh {
+		if s := &r.refresh[i]; s.timer != nil {
+			s.timer.Stop()
+			s.timer = nil
+		}
+	}
+}
+
+// Refresh asks the client to refresh the results of f now, or at the
+// end of the interval since the last refresh of f. It does nothing if
+// the client does not support the refresh, or if it is already
+// deferred.
+func (r *Refresher) Refresh(ctx context.Context, f RefreshFeature) error {
+	if !r.Supports(f) {
+		return nil
+	}
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	s := &r.refresh[f]
+	if s.timer != nil {
+		r.mu.Unlock()
+		return nil // already deferred
+	}
+	if wait := r.interval - time.Since(s.last); wait > 0 && !s.last.IsZero() {
+		ctx := detach(ctx)
+		s.timer = time.AfterFunc(wait, func() {
+			r.mu.Lock()
+			if r.closed {
+				r.mu.Unlock()
+				return
+			}
+			s.timer = nil
+			r.mu.Unlock()
+			if err := r.send(ctx, f); err != nil {
+				event.Error(ctx, "deferred refresh failed", fmt.Errorf("%v: %w", f, err))
+			}
+		})
+		r.mu.Unlock()
+		return nil
+	}
+	r.mu.Unlock()
+	return r.send(ctx, f)
+}
+
+// send sends the refresh request of f.
+func (r *Refresher) send(ctx context.Context, f RefreshFeature) error {
+	r.mu.Lock()
+	r.refresh[f].last = time.Now()
+	r.mu.Unlock()
+	return refreshFeatures[f].send(r.client, ctx)
+}
+
+// A RefreshController is the single place through which a server asks
+// its client to refresh results. The refreshes asked for within a
+// window are batched: at the end of the window, each feature asked for
+// is refreshed once, however many times it was asked for, so that the
+// parts of a server that each see a change need not coordinate. The
+// controller also counts the refreshes of each feature, to tell how
+// often the client is made to request results again.
+//
+//	rc := lsp.NewRefreshController(client, session.ClientCapabilities(), 100*time.Millisecond)
+//	defer rc.Close()
+//	...
+//	rc.Refresh(ctx, lsp.SemanticTokensRefresh, lsp.InlayHintRefresh)
+type RefreshController struct {
+	refresher *Refresher
+	window    time.Duration
+	created   time.Time
+
+	mu      sync.Mutex
+	closed  bool
+	pending [numRefreshFeatures]bool
+	timer   *time.Timer // to send the pending refreshes, if any
+	stats   [numRefreshFeatures]RefreshStats
+}
+
+// RefreshStats are the statistics of the refreshes of a feature.
+type RefreshStats struct {
+	Requested int64     // the number of refreshes asked for
+	Sent      int64     // the number of refresh requests sent
+	LastSent  time.Time // when the last refresh request was sent
+	PerMinute float64   // the average number sent per minute
+}
+
+// NewRefreshController returns a RefreshController that sends refresh
+// requests to a client with the given capabilities, in batches of
+// those asked for within window. If caps is nil, it sends none.
+func NewRefreshController(client Client, caps *ClientCapabilities, window time.Duration) *RefreshController {
+	return &RefreshController{
+		refresher: NewRefresher(client, caps, 0),
+		window:    window,
+		created:   time.Now(),
+	}
+}
+
+// Supports reports whether the client supports the refresh of f.
+func (c *RefreshController) Supports(f RefreshFeature) bool {
+	return c.refresher.Supports(f)
+}
+
+// Refresh asks for the refresh of the given features at the end of the
+// current window, which starts if there is none. The refreshes the
+// client does not support are counted but never sent. Errors of the
+// requests are reported through the event system.
+func (c *RefreshController) Refresh(ctx context.Context, features ...RefreshFeature) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	batch := false
+	for _, f := range features {
+		if f < 0 || f >= numRefreshFeatures {
+			continue
+		}
+		c.stats[f].Requested++
+		if c.Supports(f) {
+			c.pending[f] = true
+			batch = true
+		}
+	}
+	if batch && c.timer == nil {
+		ctx := detach(ctx)
+		c.timer = time.AfterFunc(c.window, func() {
+			for _, err := range c.flush(ctx) {
+				event.Error(ctx, "refresh failed", err)
+			}
+		})
+	}
+}
+
+// Flush sends the refreshes of the current window at once.
+func (c *RefreshController) Flush(ctx context.Context) error {
+	return errors.Join(c.flush(ctx)...)
+}
+
+// flush sends the pending refreshes, returning their errors.
+func (c *RefreshController) flush(ctx context.Context) []error {
+	c.mu.Lock()
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	pending := c.pending
+	c.pending = [numRefreshFeatures]bool{}
+	c.mu.Unlock()
+
+	var errs []error
+	for f, ok := range pending {
+		if !ok {
+			continue
+		}
+		f := RefreshFeature(f)
+		c.mu.Lock()
+		c.stats[f].Sent++
+		c.stats[f].LastSent = time.Now()
+		c.mu.Unlock()
+		if err := c.refresher.Refresh(ctx, f); err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", f, err))
+		}
+	}
+	return errs
+}
+
+// Stats returns the statistics of the refreshes of f.
+func (c *RefreshController) Stats(f RefreshFeature) RefreshStats {
+	if f < 0 || f >= numRefreshFeatures {
+		return RefreshStats{}
+	}
+	c.mu.Lock()
+	stats := c.stats[f]
+	c.mu.Unlock()
+	if minutes := time.Since(c.created).Minutes(); minutes > 0 {
+		stats.PerMinute = float64(stats.Sent) / minutes
+	}
+	return stats
+}
+
+// Close drops the refreshes of the current window, and stops sending
+// refreshes.
+func (c *RefreshController) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	c.pending = [numRefreshFeatures]bool{}
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	c.refresher.Close()
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// refreshClient reports the refresh requests it receives.
+type refreshClient struct {
+	lsp.Client
+	refreshes chan string
+}
+
+func (c refreshClient) SemanticTokensRefresh(context.Context) error {
+	c.refreshes <- "semanticTokens"
+	return nil
+}
+
+func (c refreshClient) CodeLensRefresh(context.Context) error {
+	c.refreshes <- "codeLens"
+	return nil
+}
+
+func TestRefresher(t *testing.T) {
+	ctx := context.Background()
+	client := refreshClient{refreshes: make(chan string, 10)}
+	caps := &lsp.ClientCapabilities{}
+	caps.Workspace.SemanticTokens = &lsp.SemanticTokensWorkspaceClientCapabilities{RefreshSupport: true}
+	r := lsp.NewRefresher(client, caps, 20*time.Millisecond)
+	defer r.Close()
+
+	// Unsupported refreshes are not sent.
+	if err := r.RefreshCodeLenses(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if err := r.RefreshSemanticTokens(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []string
+	timeout := time.After(5 * time.Second)
+	for len(got) < 2 {
+		select {
+		case refresh := <-client.refreshes:
+			got = append(got, refresh)
+		case <-timeout:
+			t.Fatalf("Timed out waiting for refreshes, got %v", got)
+		}
+	}
+	// The first refresh is sent at once, the others once, later.
+	if got[0] != "semanticTokens" || got[1] != "semanticTokens" {
+		t.Errorf("Expected two semantic tokens refreshes, got %v", got)
+	}
+	select {
+	case refresh := <-client.refreshes:
+		t.Errorf("Unexpected refresh %s", refresh)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	t.Run("Close", func(t *testing.T) {
+		r := lsp.NewRefresher(client, caps, time.Hour)
+		r.RefreshSemanticTokens(ctx)
+		r.RefreshSemanticTokens(ctx) // deferred
+		r.Close()
+		if got := <-client.refreshes; got != "semanticTokens" {
+			t.Errorf("Expected a semantic tokens refresh, got %s", got)
+		}
+		if err := r.RefreshSemanticTokens(ctx); err != nil || len(client.refreshes) != 0 {
+			t.Errorf("Expected no refresh once closed, got %v, %d", err, len(client.refreshes))
+		}
+	})
+}
+
+func TestRefreshController(t *testing.T) {
+	ctx := context.Background()
+	client := refreshClient{refreshes: make(chan string, 10)}
+	caps := &lsp.ClientCapabilities{}
+	caps.Workspace.SemanticTokens = &lsp.SemanticTokensWorkspaceClientCapabilities{RefreshSupport: true}
+	caps.Workspace.CodeLens = &lsp.CodeLensWorkspaceClientCapabilities{RefreshSupport: true}
+	c := lsp.NewRefreshController(client, caps, time.Hour)
+	defer c.Close()
+
+	if !c.Supports(lsp.CodeLensRefresh) || c.Supports(lsp.InlayHintRefresh) {
+		t.Errorf("Expected support for code lens refreshes only")
+	}
+	c.Refresh(ctx, lsp.SemanticTokensRefresh, lsp.InlayHintRefresh)
+	c.Refresh(ctx, lsp.SemanticTokensRefresh)
+	c.Refresh(ctx, lsp.CodeLensRefresh)
+	if len(client.refreshes) != 0 {
+		t.Fatalf("Expected no refresh within the window, got %d", len(client.refreshes))
+	}
+	if err := c.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	close(client.refreshes)
+	var got []string
+	for refresh := range client.refreshes {
+		got = append(got, refresh)
+	}
+	if diff := cmp.Diff([]string{"semanticTokens", "codeLens"}, got); diff != "" {
+		t.Errorf("Unexpected refreshes (-want +got):\n%s", diff)
+	}
+
+	for _, test := range []struct {
+		feature         lsp.RefreshFeature
+		requested, sent int64
+	}{
+		{lsp.SemanticTokensRefresh, 2, 1},
+		{lsp.CodeLensRefresh, 1, 1},
+		{lsp.InlayHintRefresh, 1, 0},
+	} {
+		stats := c.Stats(test.feature)
+		if stats.Requested != test.requested || stats.Sent != test.sent {
+			t.Errorf("Expected %v to be requested %d and sent %d times, got %d and %d", test.feature, test.requested, test.sent, stats.Requested, stats.Sent)
+		}
+		if (stats.PerMinute > 0) != (test.sent > 0) {
+			t.Errorf("Unexpected rate of %v: %v", test.feature, stats.PerMinute)
+		}
+	}
+
+	t.Run("Window", func(t *testing.T) {
+		client := refreshClient{refreshes: make(chan string, 10)}
+		c := lsp.NewRefreshController(client, caps, 10*time.Millisecond)
+		defer c.Close()
+		for range 3 {
+			c.Refresh(ctx, lsp.SemanticTokensRefresh)
+		}
+		select {
+		case got := <-client.refreshes:
+			if got != "semanticTokens" {
+				t.Errorf("Expected a semantic tokens refresh, got %s", got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the refresh")
+		}
+		select {
+		case refresh := <-client.refreshes:
+			t.Errorf("Unexpected refresh %s", refresh)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,323 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// This file builds the registrations of capabilities that a server
+// registers dynamically, with client/registerCapability. The
+// constructors check the options that the specification requires, which
+// a client would otherwise reject, or silently ignore.
+
+// NewCompletionRegistration returns the registration of
+// textDocument/completion for the documents selected. A nil selector
+// stands for the document selector of the client.
+func NewCompletionRegistration(selector DocumentSelector, opts CompletionOptions) (Registration, error) {
+	if err := validateSelector(selector); err != nil {
+		return Registration{}, err
+	}
+	for _, chars := range [][]string{opts.TriggerCharacters, opts.AllCommitCharacters} {
+		for _, c := range chars {
+			if c == "" {
+				return Registration{}, errors.New("empty trigger or commit character")
+			}
+		}
+	}
+	return Registration{
+		Method: "textDocument/completion",
+		RegisterOptions: &CompletionRegistrationOptions{
+			TextDocumentRegistrationOptions: TextDocumentRegistrationOptions{DocumentSelector: selector},
+			CompletionOptions:               opts,
+		},
+	}, nil
+}
+
+// NewSemanticTokensRegistration returns the registration of the
+// semantic tokens requests for the documents selected. A nil selector
+// stands for the document selector of the client. The options must have
+// a legend, and provide full or range tokens.
+func NewSemanticTokensRegistration(selector DocumentSelector, opts SemanticTokensOptions) (Registration, error) {
+	if err := validateSelector(selector); err != nil {
+		return Registration{}, err
+	}
+	if len(opts.Legend.TokenTypes) == 0 {
+		return Registration{}, errors.New("semantic tokens legend without token types")
+	}
+	full := opts.Full != nil && (opts.Full.SemanticTokensFullDelta != nil || opts.Full.Bool != nil && *opts.Full.Bool)
+	ranges := opts.Range != nil && (opts.Range.PRangeESemanticTokensOptions != nil || opts.Range.Bool != nil && *opts.Range.Bool)
+	if !full && !ranges {
+		return Registration{}, errors.New("semantic tokens provided neither in full nor by range")
+	}
+	if opts.Legend.TokenModifiers == nil {
+		opts.Legend.TokenModifiers = []string{} // required, even if empty
+	}
+	return Registration{
+		Method: "textDocument/semanticTokens",
+		RegisterOptions: &SemanticTokensRegistrationOptions{
+			TextDocumentRegistrationOptions: TextDocumentRegistrationOptions{DocumentSelector: selector},
+			SemanticTokensOptions:           opts,
+		},
+	}, nil
+}
+
+// fileOperationMethods are the methods of the file operations a server
+// may register for.
+var fileOperationMethods = []string{
+	"workspace/didCreateFiles",
+	"workspace/willCreateFiles",
+	"workspace/didRenameFiles",
+	"workspace/willRenameFiles",
+	"workspace/didDeleteFiles",
+	"workspace/willDeleteFiles",
+}
+
+// NewFileOperationRegistration returns the registration of a file
+// operation method, such as workspace/willRenameFiles, for the files
+// matched by one of the filters.
+func NewFileOperationRegistration(method string, filters ...FileOperationFilter) (Registration, error) {
+	if !slices.Contains(fileOperationMethods, method) {
+		return Registration{}, fmt.Errorf("%q is not a file operation", method)
+	}
+	if len(filters) == 0 {
+		return Registration{}, fmt.Errorf("%s: no filters", method)
+	}
+	for i, f := range filters {
+		if err := validateGlob(f.Pattern.Glob); err != nil {
+			return Registration{}, fmt.Errorf("%s: filter %d: %v", method, i, err)
+		}
+		if m := f.Pattern.Matches; m != nil && *m != FilePattern && *m != FolderPattern {
+			return Registration{}, fmt.Errorf("%s: filter %d: invalid kind of match %q", method, i, *m)
+		}
+	}
+	return Registration{
+		Method:          method,
+		RegisterOptions: &FileOperationRegistrationOptions{Filters: filters},
+	}, nil
+}
+
+// NewWatchedFilesRegistration returns the registration of
+// workspace/didChangeWatchedFiles for the files matched by the watchers.
+func NewWatchedFilesRegistration(watchers ...FileSystemWatcher) (Registration, error) {
+	if len(watchers) == 0 {
+		return Registration{}, errors.New("no file system watchers")
+	}
+	for i, w := range watchers {
+		var err error
+		switch p := w.GlobPattern; {
+		case p.Pattern != nil:
+			err = validateGlob(*p.Pattern)
+		case p.RelativePattern != nil:
+			if p.RelativePattern.BaseURI == "" {
+				err = errors.New("relative pattern without base URI")
+			} else {
+				err = validateGlob(p.RelativePattern.Pattern)
+			}
+		default:
+			err = errors.New("missing glob pattern")
+		}
+		if err == nil && w.Kind != nil && (*w.Kind == 0 || *w.Kind&^(WatchCreate|WatchChange|WatchDelete) != 0) {
+			err = fmt.Errorf("invalid watch kind %d", *w.Kind)
+		}
+		if err != nil {
+			return Registration{}, fmt.Errorf("watcher %d: %v", i, err)
+		}
+	}
+	return Registration{
+		Method:          "workspace/didChangeWatchedFiles",
+		RegisterOptions: &DidChangeWatchedFilesRegistrationOptions{Watchers: watchers},
+	}, nil
+}
+
+// validateSelector checks that each filter of a document selector sets
+// its required property.
+func validateSelector(selector DocumentSelector) error {
+	for i, f := range selector {
+		var err error
+		switch tf := f.TextDocumentFilter; {
+		case tf != nil && tf.TextDocumentFilterLanguage != nil:
+			if tf.TextDocumentFilterLanguage.Language == "" {
+				err = errors.New("empty language")
+			}
+		case tf != nil && tf.TextDocumentFilterScheme != nil:
+			if tf.TextDocumentFilterScheme.Scheme == "" {
+				err = errors.New("empty scheme")
+			}
+		case tf != nil && tf.TextDocumentFilterPattern != nil:
+			p := tf.TextDocumentFilterPattern.Pattern
+			switch {
+			case p.Pattern != nil:
+				err = validateGlob(*p.Pattern)
+			case p.RelativePattern != nil:
+				err = validateGlob(p.RelativePattern.Pattern)
+			default:
+				err = errors.New("missing pattern")
+			}
+		case f.NotebookCellTextDocumentFilter != nil:
+			nb := f.NotebookCellTextDocumentFilter.Notebook
+			if nb.String == nil && nb.NotebookDocumentFilter == nil {
+				err = errors.New("missing notebook")
+			}
+		default:
+			err = errors.New("empty filter")
+		}
+		if err != nil {
+			return fmt.Errorf("document filter %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// validateGlob checks that pattern is a well-formed glob pattern, as
+// described by [MatchGlobPattern].
+func validateGlob(pattern string) error {
+	if pattern == "" {
+		return errors.New("empty glob pattern")
+	}
+	if strings.Count(pattern, "{") != strings.Count(pattern, "}") {
+		return fmt.Errorf("unbalanced braces in glob pattern %q", pattern)
+	}
+	for _, alt := range expandBraces(pattern) {
+		for _, segment := range strings.Split(strings.ReplaceAll(alt, "[!", "[^"), "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("malformed glob pattern %q", pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// A Registrar registers capabilities with a client dynamically,
+// assigning an ID to each registration, and remembers the capabilities
+// registered until they are unregistered. Unregistering a capability
+// that is not registered, such as one unregistered already, does
+// nothing, rather than make the client report an error; at shutdown, a
+// server may unregister all that remain with UnregisterAll.
+//
+//	reg, err := lsp.NewWatchedFilesRegistration(watchers...)
+//	...
+//	regs, err := registrar.Register(ctx, reg)
+//	...
+//	err = registrar.Unregister(ctx, regs[0].ID)
+type Registrar struct {
+	client Client
+
+	mu         sync.Mutex
+	next       int                     // the number of IDs assigned
+	registered map[string]Registration // by ID
+}
+
+// NewRegistrar returns a Registrar that registers capabilities with
+// client.
+func NewRegistrar(client Client) *Registrar {
+	return &Registrar{client: client, registered: make(map[string]Registration)}
+}
+
+// Register registers regs with the client, in a single request, and
+// returns them with their IDs. A registration without an ID is assigned
+// one, unique to the Registrar; one with the ID of a registered
+// capability is an error.
+func (r *Registrar) Register(ctx context.Context, regs ...Registration) ([]Registration, error) {
+	if len(regs) == 0 {
+		return nil, nil
+	}
+	regs = append([]Registration(nil), regs...)
+	r.mu.Lock()
+	for i := range regs {
+		if regs[i].ID == "" {
+			r.next++
+			regs[i].ID = fmt.Sprintf("%s#%d", regs[i].Method, r.next)
+		}
+	}
+	for i, reg := range regs {
+		_, dup := r.registered[reg.ID]
+		if dup || slices.ContainsFunc(regs[:i], func(prev Registration) bool { return prev.ID == reg.ID }) {
+			r.mu.Unlock()
+			return nil, fmt.Errorf("%s: ID %q already registered", reg.Method, reg.ID)
+		}
+	}
+	for _, reg := range regs {
+		r.registered[reg.ID] = reg
+	}
+	r.mu.Unlock()
+	if err := r.client.RegisterCapability(ctx, &RegistrationParams{Registrations: regs}); err != nil {
+		r.forget(regs)
+		return nil, err
+	}
+	return regs, nil
+}
+
+// Unregister unregisters the capabilities registered with the given
+// IDs, in a single request. IDs of no registered capability are
+// ignored.
+func (r *Registrar) Unregister(ctx context.Context, ids ...string) error {
+	r.mu.Lock()
+	var regs []Registration
+	for _, id := range ids {
+		if reg, ok := r.registered[id]; ok {
+			regs = append(regs, reg)
+			delete(r.registered, id)
+		}
+	}
+	r.mu.Unlock()
+	return r.unregister(ctx, regs)
+}
+
+// UnregisterAll unregisters all registered capabilities, in a single
+// request.
+func (r *Registrar) UnregisterAll(ctx context.Context) error {
+	r.mu.Lock()
+	regs := slices.Collect(maps.Values(r.registered))
+	clear(r.registered)
+	r.mu.Unlock()
+	return r.unregister(ctx, regs)
+}
+
+// Registered returns the registered capabilities, ordered by ID.
+func (r *Registrar) Registered() []Registration {
+	r.mu.Lock()
+	regs := slices.Collect(maps.Values(r.registered))
+	r.mu.Unlock()
+	slices.SortFunc(regs, func(a, b Registration) int { return strings.Compare(a.ID, b.ID) })
+	return regs
+}
+
+// unregister sends the unregistration of regs, which are registered
+// again if it fails.
+func (r *Registrar) unregister(ctx context.Context, regs []Registration) error {
+	if len(regs) == 0 {
+		return nil
+	}
+	params := &UnregistrationParams{}
+	for _, reg := range regs {
+		params.Unregisterations = append(params.Unregisterations, Unregistration{ID: reg.ID, Method: reg.Method})
+	}
+	if err := r.client.UnregisterCapability(ctx, params); err != nil {
+		r.mu.Lock()
+		for _, reg := range regs {
+			r.registered[reg.ID] = reg
+		}
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// forget forgets regs, whose registration failed.
+func (r *Registrar) forget(regs []Registration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, reg := range regs {
+		delete(r.registered, reg.ID)
+	}
+}
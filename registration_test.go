@@ -0,0 +1,214 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestRegistrationOptions(t *testing.T) {
+	pattern := func(p string) lsp.GlobPattern { return lsp.GlobPattern{Pattern: &p} }
+	goFiles := lsp.DocumentSelector{{TextDocumentFilter: &lsp.TextDocumentFilter{
+		TextDocumentFilterLanguage: &lsp.TextDocumentFilterLanguage{Language: "go"},
+	}}}
+	yes := true
+	kind := lsp.WatchKind(8)
+
+	for _, test := range []struct {
+		name string
+		reg  func() (lsp.Registration, error)
+		want string // JSON of the registration, or "" for an error
+	}{
+		{
+			"Completion",
+			func() (lsp.Registration, error) {
+				return lsp.NewCompletionRegistration(goFiles, lsp.CompletionOptions{TriggerCharacters: []string{"."}})
+			},
+			`{"id":"","method":"textDocument/completion","registerOptions":{"documentSelector":[{"language":"go"}],"triggerCharacters":["."]}}`,
+		},
+		{
+			"CompletionClientSelector",
+			func() (lsp.Registration, error) {
+				return lsp.NewCompletionRegistration(nil, lsp.CompletionOptions{})
+			},
+			`{"id":"","method":"textDocument/completion","registerOptions":{"documentSelector":null}}`,
+		},
+		{
+			"CompletionEmptyFilter",
+			func() (lsp.Registration, error) {
+				return lsp.NewCompletionRegistration(lsp.DocumentSelector{{}}, lsp.CompletionOptions{})
+			},
+			"",
+		},
+		{
+			"SemanticTokens",
+			func() (lsp.Registration, error) {
+				return lsp.NewSemanticTokensRegistration(goFiles, lsp.SemanticTokensOptions{
+					Legend: lsp.SemanticTokensLegend{TokenTypes: []string{"keyword"}},
+					Full:   &lsp.SemanticTokensOptionsFull{Bool: &yes},
+				})
+			},
+			`{"id":"","method":"textDocument/semanticTokens","registerOptions":{"documentSelector":[{"language":"go"}],"legend":{"tokenTypes":["keyword"],"tokenModifiers":[]},"full":true}}`,
+		},
+		{
+			"SemanticTokensNoLegend",
+			func() (lsp.Registration, error) {
+				return lsp.NewSemanticTokensRegistration(goFiles, lsp.SemanticTokensOptions{Full: &lsp.SemanticTokensOptionsFull{Bool: &yes}})
+			},
+			"",
+		},
+		{
+			"SemanticTokensNotProvided",
+			func() (lsp.Registration, error) {
+				return lsp.NewSemanticTokensRegistration(goFiles, lsp.SemanticTokensOptions{
+					Legend: lsp.SemanticTokensLegend{TokenTypes: []string{"keyword"}},
+				})
+			},
+			"",
+		},
+		{
+			"FileOperation",
+			func() (lsp.Registration, error) {
+				return lsp.NewFileOperationRegistration("workspace/willRenameFiles", lsp.FileOperationFilter{
+					Pattern: lsp.FileOperationPattern{Glob: "**/*.go"},
+				})
+			},
+			`{"id":"","method":"workspace/willRenameFiles","registerOptions":{"filters":[{"pattern":{"glob":"**/*.go"}}]}}`,
+		},
+		{
+			"FileOperationUnknownMethod",
+			func() (lsp.Registration, error) {
+				return lsp.NewFileOperationRegistration("workspace/didChangeWatchedFiles", lsp.FileOperationFilter{
+					Pattern: lsp.FileOperationPattern{Glob: "**/*.go"},
+				})
+			},
+			"",
+		},
+		{
+			"FileOperationMalformedGlob",
+			func() (lsp.Registration, error) {
+				return lsp.NewFileOperationRegistration("workspace/didCreateFiles", lsp.FileOperationFilter{
+					Pattern: lsp.FileOperationPattern{Glob: "**/*.[go"},
+				})
+			},
+			"",
+		},
+		{
+			"WatchedFiles",
+			func() (lsp.Registration, error) {
+				return lsp.NewWatchedFilesRegistration(lsp.FileSystemWatcher{GlobPattern: pattern("**/go.{mod,sum}")})
+			},
+			`{"id":"","method":"workspace/didChangeWatchedFiles","registerOptions":{"watchers":[{"globPattern":"**/go.{mod,sum}"}]}}`,
+		},
+		{
+			"WatchedFilesNoWatchers",
+			func() (lsp.Registration, error) {
+				return lsp.NewWatchedFilesRegistration()
+			},
+			"",
+		},
+		{
+			"WatchedFilesInvalidKind",
+			func() (lsp.Registration, error) {
+				return lsp.NewWatchedFilesRegistration(lsp.FileSystemWatcher{GlobPattern: pattern("**/*.go"), Kind: &kind})
+			},
+			"",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			reg, err := test.reg()
+			if test.want == "" {
+				if err == nil {
+					t.Fatalf("Expected an error, got %v", reg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(reg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(data); got != test.want {
+				t.Errorf("Expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+// registrationRecorder records the registrations of a client.
+type registrationRecorder struct {
+	lsp.Client
+	registered   []lsp.Registration
+	unregistered []lsp.Unregistration
+}
+
+func (c *registrationRecorder) RegisterCapability(_ context.Context, params *lsp.RegistrationParams) error {
+	c.registered = append(c.registered, params.Registrations...)
+	return nil
+}
+
+func (c *registrationRecorder) UnregisterCapability(_ context.Context, params *lsp.UnregistrationParams) error {
+	c.unregistered = append(c.unregistered, params.Unregisterations...)
+	return nil
+}
+
+func TestRegistrar(t *testing.T) {
+	ctx := context.Background()
+	client := &registrationRecorder{}
+	r := lsp.NewRegistrar(client)
+	regs, err := r.Register(ctx,
+		lsp.Registration{Method: "textDocument/hover"},
+		lsp.Registration{ID: "mine", Method: "textDocument/hover"},
+		lsp.Registration{Method: "textDocument/hover"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []lsp.Registration{
+		{ID: "textDocument/hover#1", Method: "textDocument/hover"},
+		{ID: "mine", Method: "textDocument/hover"},
+		{ID: "textDocument/hover#2", Method: "textDocument/hover"},
+	}
+	if diff := cmp.Diff(want, regs); diff != "" {
+		t.Errorf("Unexpected registrations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, client.registered); diff != "" {
+		t.Errorf("Unexpected registrations of the client (-want +got):\n%s", diff)
+	}
+	if _, err := r.Register(ctx, lsp.Registration{ID: "mine", Method: "textDocument/hover"}); err == nil {
+		t.Error("Expected an error registering an ID twice")
+	}
+
+	if err := r.Unregister(ctx, "mine", "unknown"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Unregister(ctx, "mine"); err != nil { // already unregistered
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]lsp.Unregistration{{ID: "mine", Method: "textDocument/hover"}}, client.unregistered); diff != "" {
+		t.Errorf("Unexpected unregistrations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]lsp.Registration{want[0], want[2]}, r.Registered()); diff != "" {
+		t.Errorf("Unexpected registered capabilities (-want +got):\n%s", diff)
+	}
+
+	client.unregistered = nil
+	if err := r.UnregisterAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UnregisterAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.unregistered) != 2 || len(r.Registered()) != 0 {
+		t.Errorf("Expected the 2 remaining capabilities to be unregistered once, got %v", client.unregistered)
+	}
+}
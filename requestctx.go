@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// This file threads the identity and arrival time of the incoming request
+// being handled through the handler's context, so that a single request can
+// be followed across logs, traces and metrics.
+
+type requestKey struct{}
+
+// requestInfo describes the request being handled.
+type requestInfo struct {
+	id      jsonrpc2.ID // invalid for notifications
+	method  string
+	arrival time.Time
+}
+
+// withRequest returns a context that records req as the request being
+// handled, and the current time as its arrival. It is applied by [ServerHandler], [ClientHandler] and the
+// built-in handler wrappers such as [Recover].
+func withRequest(ctx context.Context, req *jsonrpc2.Request) context.Context {
+	if info, ok := ctx.Value(requestKey{}).(*requestInfo); ok && info.method == req.Method && info.id == req.ID {
+		return ctx // already recorded by an outer wrapper
+	}
+	return context.WithValue(ctx, requestKey{}, &requestInfo{id: req.ID, method: req.Method, arrival: time.Now()})
+}
+
+// RequestID returns the JSON-RPC ID of the request being handled in ctx.
+// It reports false if ctx does not belong to a handler, or if the message
+// being handled is a notification.
+func RequestID(ctx context.Context) (jsonrpc2.ID, bool) {
+	info, ok := ctx.Value(requestKey{}).(*requestInfo)
+	if !ok || !info.id.IsValid() {
+		return jsonrpc2.ID{}, false
+	}
+	return info.id, true
+}
+
+// RequestMethod returns the method of the request or notification being
+// handled in ctx, or "" if ctx does not belong to a handler.
+func RequestMethod(ctx context.Context) string {
+	if info, ok := ctx.Value(requestKey{}).(*requestInfo); ok {
+		return info.method
+	}
+	return ""
+}
+
+// IsNotification reports whether the message being handled in ctx is a
+// notification, to which no reply is sent.
+func IsNotification(ctx context.Context) bool {
+	info, ok := ctx.Value(requestKey{}).(*requestInfo)
+	return ok && !info.id.IsValid()
+}
+
+// RequestArrival returns the time at which the handling of the request
+// being handled in ctx began, that is, when it was first seen by
+// [ServerHandler], [ClientHandler] or a wrapper such as [Recover]. It
+// reports false if ctx does not belong to a handler.
+func RequestArrival(ctx context.Context) (time.Time, bool) {
+	info, ok := ctx.Value(requestKey{}).(*requestInfo)
+	if !ok {
+		return time.Time{}, false
+	}
+	return info.arrival, true
+}
+
+// WithRequestDeadline returns a copy of ctx that is done when timeout has
+// elapsed since the arrival of the request being handled in ctx, so that
+// time spent before reaching the handler counts against its budget:
+//
+//	ctx, cancel := lsp.WithRequestDeadline(ctx, 2*time.Second)
+//	defer cancel()
+//
+// Outside a handler, the timeout starts now.
+func WithRequestDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	start, ok := RequestArrival(ctx)
+	if !ok {
+		start = time.Now()
+	}
+	return context.WithDeadline(ctx, start.Add(timeout))
+}
+
+// RequestLabels returns event labels identifying the request being handled
+// in ctx, for use with the event package:
+//
+//	event.Log(ctx, "computing hover", lsp.RequestLabels(ctx)...)
+//
+// The labels match those the jsonrpc2 package attaches to its own spans.
+func RequestLabels(ctx context.Context) []event.Label {
+	info, ok := ctx.Value(requestKey{}).(*requestInfo)
+	if !ok {
+		return nil
+	}
+	labels := []event.Label{jsonrpc2.Method(info.method)}
+	if info.id.IsValid() {
+		labels = append(labels, jsonrpc2.RPCID(fmt.Sprint(info.id.Raw())))
+	}
+	return labels
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// requestRecorder records the request metadata seen by its handlers.
+type requestRecorder struct {
+	lsp.Server
+	id           jsonrpc2.ID
+	hasID        bool
+	method       string
+	notification bool
+	arrival      time.Time
+}
+
+func (r *requestRecorder) record(ctx context.Context) {
+	r.id, r.hasID = lsp.RequestID(ctx)
+	r.method = lsp.RequestMethod(ctx)
+	r.notification = lsp.IsNotification(ctx)
+	r.arrival, _ = lsp.RequestArrival(ctx)
+}
+
+func (r *requestRecorder) Hover(ctx context.Context, _ *lsp.HoverParams) (*lsp.Hover, error) {
+	r.record(ctx)
+	return &lsp.Hover{}, nil
+}
+
+func (r *requestRecorder) DidSave(ctx context.Context, _ *lsp.DidSaveTextDocumentParams) error {
+	r.record(ctx)
+	return nil
+}
+
+func TestRequestContext(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Call", func(t *testing.T) {
+		rec := new(requestRecorder)
+		before := time.Now()
+		if _, err := lsp.Recover(lsp.ServerHandler(rec)).Handle(ctx, newHoverCall(t)); err != nil {
+			t.Fatal(err)
+		}
+		if rec.notification {
+			t.Errorf("IsNotification reported a call as a notification")
+		}
+		if rec.arrival.Before(before) || rec.arrival.After(time.Now()) {
+			t.Errorf("RequestArrival = %v, want a time since %v", rec.arrival, before)
+		}
+		if !rec.hasID || rec.id != jsonrpc2.Int64ID(1) {
+			t.Errorf("RequestID = %v, %t; want 1, true", rec.id.Raw(), rec.hasID)
+		}
+		if rec.method != "textDocument/hover" {
+			t.Errorf("RequestMethod = %q, want %q", rec.method, "textDocument/hover")
+		}
+	})
+
+	t.Run("Notification", func(t *testing.T) {
+		rec := new(requestRecorder)
+		req, err := jsonrpc2.NewNotification("textDocument/didSave", &lsp.DidSaveTextDocumentParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lsp.ServerHandler(rec)(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+		if rec.hasID {
+			t.Errorf("RequestID reported an ID for a notification")
+		}
+		if !rec.notification {
+			t.Errorf("IsNotification did not report a notification")
+		}
+		if rec.method != "textDocument/didSave" {
+			t.Errorf("RequestMethod = %q, want %q", rec.method, "textDocument/didSave")
+		}
+	})
+
+	t.Run("OutsideHandler", func(t *testing.T) {
+		if _, ok := lsp.RequestID(ctx); ok {
+			t.Error("RequestID reported an ID outside a handler")
+		}
+		if labels := lsp.RequestLabels(ctx); labels != nil {
+			t.Errorf("RequestLabels = %v outside a handler", labels)
+		}
+		if lsp.IsNotification(ctx) {
+			t.Error("IsNotification reported a notification outside a handler")
+		}
+		if _, ok := lsp.RequestArrival(ctx); ok {
+			t.Error("RequestArrival reported a time outside a handler")
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		var deadline, arrival time.Time
+		handler := lsp.ServerHandler(deadlineServer{hover: func(ctx context.Context) {
+			arrival, _ = lsp.RequestArrival(ctx)
+			ctx, cancel := lsp.WithRequestDeadline(ctx, time.Minute)
+			defer cancel()
+			deadline, _ = ctx.Deadline()
+		}})
+		if _, err := handler(ctx, newHoverCall(t)); err != nil {
+			t.Fatal(err)
+		}
+		if want := arrival.Add(time.Minute); !deadline.Equal(want) {
+			t.Errorf("Deadline = %v, want %v", deadline, want)
+		}
+	})
+}
+
+// deadlineServer calls its hover function from Hover.
+type deadlineServer struct {
+	lsp.Server
+	hover func(ctx context.Context)
+}
+
+func (s deadlineServer) Hover(ctx context.Context, _ *lsp.HoverParams) (*lsp.Hover, error) {
+	s.hover(ctx)
+	return &lsp.Hover{}, nil
+}
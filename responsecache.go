@@ -0,0 +1,152 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A ResponseCache caches the results of idempotent requests about open
+// documents, so that clients that repeat identical requests do not
+// force the server to compute identical results again. Results are
+// keyed by method, document URI, document version and position, and
+// those of a document are dropped when it changes or closes.
+//
+// A ResponseCache belongs to a single connection, whose handler it wraps
+// with [ResponseCache.Handler]. The server must invalidate the results
+// that depend on state other than the content of their document, such
+// as the files on disk, with [ResponseCache.Invalidate].
+type ResponseCache struct {
+	methods map[string]bool
+
+	mu       sync.Mutex
+	versions map[DocumentURI]int32 // of open documents
+	entries  map[DocumentURI]map[cacheKey]json.RawMessage
+}
+
+type cacheKey struct {
+	method   string
+	version  int32
+	position Position
+}
+
+// NewResponseCache returns a cache of the results of requests for the
+// given methods, which default to textDocument/hover,
+// textDocument/documentSymbol and textDocument/foldingRange.
+func NewResponseCache(methods ...string) *ResponseCache {
+	if len(methods) == 0 {
+		methods = []string{"textDocument/hover", "textDocument/documentSymbol", "textDocument/foldingRange"}
+	}
+	c := &ResponseCache{
+		methods:  make(map[string]bool),
+		versions: make(map[DocumentURI]int32),
+		entries:  make(map[DocumentURI]map[cacheKey]json.RawMessage),
+	}
+	for _, m := range methods {
+		c.methods[m] = true
+	}
+	return c
+}
+
+// Handler returns a handler that invokes handler, answering the requests
+// for the methods of the cache from the cache when it can. It tracks
+// the versions of the documents opened, changed and closed, and drops
+// all results when the configuration or the watched files change.
+// Errors are not cached.
+func (c *ResponseCache) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case "textDocument/didOpen", "textDocument/didChange":
+			var params versionedParams
+			if UnmarshalJSON(req.Params, &params) == nil && params.TextDocument.Version != nil {
+				c.setVersion(params.TextDocument.URI, *params.TextDocument.Version)
+			}
+		case "textDocument/didClose":
+			var params versionedParams
+			if UnmarshalJSON(req.Params, &params) == nil {
+				c.close(params.TextDocument.URI)
+			}
+		case "workspace/didChangeConfiguration", "workspace/didChangeWatchedFiles":
+			c.Invalidate("")
+		}
+		if !req.IsCall() || !c.methods[req.Method] {
+			return handler.Handle(ctx, req)
+		}
+
+		var params versionedParams
+		if UnmarshalJSON(req.Params, &params) != nil {
+			return handler.Handle(ctx, req)
+		}
+		uri := params.TextDocument.URI
+		c.mu.Lock()
+		version, open := c.versions[uri]
+		key := cacheKey{req.Method, version, params.Position}
+		result, ok := c.entries[uri][key]
+		c.mu.Unlock()
+		if !open {
+			return handler.Handle(ctx, req)
+		}
+		if ok {
+			return result, nil
+		}
+
+		v, err := handler.Handle(ctx, req)
+		if err != nil || ctx.Err() != nil {
+			return v, err
+		}
+		if result, err = json.Marshal(v); err != nil {
+			return v, nil // let the connection report the error
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if current, open := c.versions[uri]; open && current == version {
+			if c.entries[uri] == nil {
+				c.entries[uri] = make(map[cacheKey]json.RawMessage)
+			}
+			c.entries[uri][key] = result
+		}
+		return result, nil
+	})
+}
+
+// versionedParams holds the fields of request parameters that identify
+// a cached result.
+type versionedParams struct {
+	TextDocument struct {
+		URI     DocumentURI `json:"uri"`
+		Version *int32      `json:"version"`
+	} `json:"textDocument"`
+	Position Position `json:"position"`
+}
+
+func (c *ResponseCache) setVersion(uri DocumentURI, version int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versions[uri] = version
+	delete(c.entries, uri)
+}
+
+func (c *ResponseCache) close(uri DocumentURI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.versions, uri)
+	delete(c.entries, uri)
+}
+
+// Invalidate drops the cached results for the document uri, or all
+// cached results if uri is empty.
+func (c *ResponseCache) Invalidate(uri DocumentURI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if uri == "" {
+		clear(c.entries)
+	} else {
+		delete(c.entries, uri)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// countingServer answers hovers with the number of hovers it computed.
+type countingServer struct {
+	lsp.Server
+	hovers int
+}
+
+func (s *countingServer) Hover(context.Context, *lsp.HoverParams) (*lsp.Hover, error) {
+	s.hovers++
+	return &lsp.Hover{Contents: lsp.MarkupContent{Value: fmt.Sprint(s.hovers)}}, nil
+}
+
+func (s *countingServer) DidOpen(context.Context, *lsp.DidOpenTextDocumentParams) error {
+	return nil
+}
+
+func (s *countingServer) DidChange(context.Context, *lsp.DidChangeTextDocumentParams) error {
+	return nil
+}
+
+func (s *countingServer) DidClose(context.Context, *lsp.DidCloseTextDocumentParams) error {
+	return nil
+}
+
+func TestResponseCache(t *testing.T) {
+	ctx := context.Background()
+	cache := lsp.NewResponseCache()
+	handler := cache.Handler(lsp.ServerHandler(&countingServer{}))
+	const uri = "file:///a.go"
+
+	notify := func(method string, params any) {
+		t.Helper()
+		req, err := jsonrpc2.NewNotification(method, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler.Handle(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hover := func(line uint32) string {
+		t.Helper()
+		req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "textDocument/hover", &lsp.HoverParams{
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+				Position:     lsp.Position{Line: line},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := handler.Handle(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var h lsp.Hover
+		if err := json.Unmarshal(data, &h); err != nil {
+			t.Fatal(err)
+		}
+		return h.Contents.Value
+	}
+	check := func(line uint32, want string) {
+		t.Helper()
+		if got := hover(line); got != want {
+			t.Errorf("Expected hover %s at line %d, got %s", want, line, got)
+		}
+	}
+
+	// Closed documents are not cached.
+	check(0, "1")
+	check(0, "2")
+
+	notify("textDocument/didOpen", &lsp.DidOpenTextDocumentParams{TextDocument: lsp.TextDocumentItem{URI: uri, Version: 1}})
+	check(0, "3")
+	check(0, "3")
+	check(1, "4")
+	check(1, "4")
+
+	notify("textDocument/didChange", &lsp.DidChangeTextDocumentParams{
+		TextDocument: lsp.VersionedTextDocumentIdentifier{TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: uri}, Version: 2},
+	})
+	check(0, "5")
+	check(0, "5")
+
+	cache.Invalidate(uri)
+	check(0, "6")
+
+	notify("textDocument/didClose", &lsp.DidCloseTextDocumentParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	check(0, "7")
+	check(0, "8")
+}
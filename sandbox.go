@@ -0,0 +1,393 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/event"
+)
+
+// A SandboxPolicy tells a [Sandbox] what to do with a message that
+// refers to a file outside of it.
+type SandboxPolicy int
+
+const (
+	// SandboxReject fails the request or result that refers to the file,
+	// with RequestFailed.
+	SandboxReject SandboxPolicy = iota
+	// SandboxRewrite drops the references to the file from the request
+	// or result, and logs them through the event system.
+	SandboxRewrite
+)
+
+// A Sandbox confines the file URIs that a server exchanges with its
+// client to the workspace folders negotiated at initialization, so that
+// a server deployed for several tenants, or in a container, cannot lead
+// clients to the files of others:
+//
+//	sandbox := &lsp.Sandbox{Policy: lsp.SandboxRewrite}
+//	client := sandbox.Client(lsp.ClientDispatcher(conn))
+//	handler := lsp.ServerHandler(sandbox.Server(newServer(client)))
+//
+// The Server it returns learns the folders from the initialize request
+// and workspace/didChangeWorkspaceFolders, and checks the targets of the
+// definition, declaration, typeDefinition, implementation and
+// references results. The Client it returns checks the documents of
+// workspace/applyEdit and window/showDocument, and the glob patterns of
+// the file watchers registered by client/registerCapability.
+//
+// URIs of other schemes than file, such as untitled:, are not confined.
+// A Sandbox compares the paths of URIs, once cleaned of "." and ".."
+// elements; it does not resolve symbolic links.
+type Sandbox struct {
+	Policy SandboxPolicy
+	// Roots lists directories outside the workspace folders whose files
+	// are also inside the sandbox, such as a read-only module cache.
+	Roots []DocumentURI
+
+	mu      sync.Mutex
+	folders []DocumentURI // normalized as by workspaceRoot
+}
+
+// SetFolders replaces the workspace folders of the sandbox.
+func (s *Sandbox) SetFolders(folders []WorkspaceFolder) {
+	var uris []DocumentURI
+	for _, f := range folders {
+		if uri, err := workspaceRoot(f.URI); err == nil {
+			uris = append(uris, uri)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.folders = uris
+}
+
+// changeFolders applies a workspace/didChangeWorkspaceFolders event.
+func (s *Sandbox) changeFolders(event WorkspaceFoldersChangeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range event.Removed {
+		if uri, err := workspaceRoot(f.URI); err == nil {
+			s.folders = slices.DeleteFunc(s.folders, func(u DocumentURI) bool { return u == uri })
+		}
+	}
+	for _, f := range event.Added {
+		if uri, err := workspaceRoot(f.URI); err == nil && !slices.Contains(s.folders, uri) {
+			s.folders = append(s.folders, uri)
+		}
+	}
+}
+
+// Contains reports whether uri is inside the sandbox: whether it is not
+// a file URI, or its path is that of a workspace folder or of one of
+// Roots, or is below them. Until the folders are known, no file is
+// inside the sandbox, except those below Roots.
+func (s *Sandbox) Contains(uri DocumentURI) bool {
+	if uriScheme(uri) != fileScheme {
+		return true
+	}
+	norm, err := ParseDocumentURI(string(uri))
+	if err != nil {
+		return false
+	}
+	return s.containsPath(path.Clean(uriPath(norm)))
+}
+
+// containsPath reports whether the clean, slash-separated path of a file
+// is inside the sandbox.
+func (s *Sandbox) containsPath(p string) bool {
+	s.mu.Lock()
+	roots := append(slices.Clip(s.folders), s.Roots...)
+	s.mu.Unlock()
+	for _, root := range roots {
+		norm, err := workspaceRoot(string(root))
+		if err != nil {
+			continue
+		}
+		dir := uriPath(norm)
+		if p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// containsGlob reports whether the files matched by a glob pattern are
+// inside the sandbox, as each alternative of its brace groups is. An
+// alternative that is not absolute matches files relative to the
+// workspace folders, and is inside it unless it has a ".." element. An
+// absolute alternative is inside if the directory of its literal prefix
+// is; those of a relative pattern never are.
+func (s *Sandbox) containsGlob(g GlobPattern) bool {
+	var pattern string
+	switch {
+	case g.RelativePattern != nil:
+		if !s.Contains(g.RelativePattern.BaseURI) {
+			return false
+		}
+		pattern = g.RelativePattern.Pattern
+	case g.Pattern != nil:
+		pattern = *g.Pattern
+	}
+	for _, alt := range expandBraces(pattern) {
+		if !s.containsGlobAlternative(alt, g.RelativePattern != nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsGlobAlternative reports whether the files matched by a glob
+// pattern without brace groups are inside the sandbox, as containsGlob
+// does.
+func (s *Sandbox) containsGlobAlternative(pattern string, relative bool) bool {
+	if slices.Contains(strings.Split(pattern, "/"), "..") {
+		return false
+	}
+	if isWindowsDrivePath(pattern) {
+		pattern = "/" + pattern
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		return true
+	}
+	if relative {
+		return false
+	}
+	if i := strings.IndexAny(pattern, "*?[{"); i >= 0 {
+		pattern = pattern[:i]
+		pattern = pattern[:strings.LastIndex(pattern, "/")+1]
+	}
+	return s.containsPath(path.Clean(pattern))
+}
+
+// confine returns the items whose URIs are inside the sandbox. Under
+// SandboxReject, it fails if any is not; under SandboxRewrite, it drops
+// those items. The items are not modified.
+func confine[T any](ctx context.Context, s *Sandbox, items []T, inside func(T) (DocumentURI, bool)) ([]T, error) {
+	var kept []T
+	for i, item := range items {
+		uri, ok := inside(item)
+		if ok {
+			if kept != nil {
+				kept = append(kept, item)
+			}
+			continue
+		}
+		if s.Policy == SandboxReject {
+			return nil, Errorf(RequestFailed, "%s is outside the workspace folders", uri)
+		}
+		event.Log(ctx, "dropped a reference outside the workspace folders", event.String("uri", string(uri)))
+		if kept == nil {
+			kept = append(make([]T, 0, len(items)), items[:i]...)
+		}
+	}
+	if kept == nil {
+		return items, nil
+	}
+	return kept, nil
+}
+
+// Server returns a Server that invokes server, and confines the folders
+// and results it exchanges with the client to the sandbox.
+func (s *Sandbox) Server(server Server) Server {
+	return &sandboxServer{Server: server, sandbox: s}
+}
+
+type sandboxServer struct {
+	Server
+	sandbox *Sandbox
+}
+
+func (s *sandboxServer) Initialize(ctx context.Context, params *ParamInitialize) (*InitializeResult, error) {
+	folders, _ := WorkspaceRoots(params)
+	s.sandbox.SetFolders(folders)
+	return s.Server.Initialize(ctx, params)
+}
+
+func (s *sandboxServer) DidChangeWorkspaceFolders(ctx context.Context, params *DidChangeWorkspaceFoldersParams) error {
+	s.sandbox.changeFolders(params.Event)
+	return s.Server.DidChangeWorkspaceFolders(ctx, params)
+}
+
+func (s *sandboxServer) Declaration(ctx context.Context, params *DeclarationParams) ([]DefinitionLink, error) {
+	links, err := s.Server.Declaration(ctx, params)
+	return s.checkLinks(ctx, links, err)
+}
+
+func (s *sandboxServer) Definition(ctx context.Context, params *DefinitionParams) ([]DefinitionLink, error) {
+	links, err := s.Server.Definition(ctx, params)
+	return s.checkLinks(ctx, links, err)
+}
+
+func (s *sandboxServer) Implementation(ctx context.Context, params *ImplementationParams) ([]DefinitionLink, error) {
+	links, err := s.Server.Implementation(ctx, params)
+	return s.checkLinks(ctx, links, err)
+}
+
+func (s *sandboxServer) TypeDefinition(ctx context.Context, params *TypeDefinitionParams) ([]DefinitionLink, error) {
+	links, err := s.Server.TypeDefinition(ctx, params)
+	return s.checkLinks(ctx, links, err)
+}
+
+// checkLinks checks the targets of the links of a result.
+func (s *sandboxServer) checkLinks(ctx context.Context, links []DefinitionLink, err error) ([]DefinitionLink, error) {
+	if err != nil {
+		return nil, err
+	}
+	return confine(ctx, s.sandbox, links, func(l DefinitionLink) (DocumentURI, bool) {
+		return l.TargetURI, s.sandbox.Contains(l.TargetURI)
+	})
+}
+
+func (s *sandboxServer) References(ctx context.Context, params *ReferenceParams) ([]Location, error) {
+	locs, err := s.Server.References(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return confine(ctx, s.sandbox, locs, func(l Location) (DocumentURI, bool) {
+		return l.URI, s.sandbox.Contains(l.URI)
+	})
+}
+
+// Client returns a Client that invokes client, and confines the requests
+// the server sends it to the sandbox.
+//
+// Under SandboxRewrite, the edits of a workspace edit that are outside
+// the sandbox are dropped, and if none remains the edit is not applied,
+// without asking the client. File watchers outside the sandbox are
+// dropped from their registration, which remains, and a document
+// outside the sandbox is not shown.
+func (s *Sandbox) Client(client Client) Client {
+	return &sandboxClient{Client: client, sandbox: s}
+}
+
+type sandboxClient struct {
+	Client
+	sandbox *Sandbox
+}
+
+func (c *sandboxClient) ApplyEdit(ctx context.Context, params *ApplyWorkspaceEditParams) (*ApplyWorkspaceEditResult, error) {
+	s := c.sandbox
+	edit := params.Edit
+	if edit.Changes != nil {
+		uris, err := confine(ctx, s, slices.Sorted(maps.Keys(edit.Changes)), func(uri DocumentURI) (DocumentURI, bool) {
+			return uri, s.Contains(uri)
+		})
+		if err != nil {
+			return nil, err
+		}
+		edit.Changes = make(map[DocumentURI][]TextEdit, len(uris))
+		for _, uri := range uris {
+			edit.Changes[uri] = params.Edit.Changes[uri]
+		}
+	}
+	changes, err := confine(ctx, s, edit.DocumentChanges, func(ch DocumentChange) (DocumentURI, bool) {
+		for _, uri := range documentChangeURIs(ch) {
+			if !s.Contains(uri) {
+				return uri, false
+			}
+		}
+		return "", true
+	})
+	if err != nil {
+		return nil, err
+	}
+	edit.DocumentChanges = changes
+	if len(edit.Changes) == 0 && len(edit.DocumentChanges) == 0 && (len(params.Edit.Changes) > 0 || len(params.Edit.DocumentChanges) > 0) {
+		return &ApplyWorkspaceEditResult{FailureReason: "the edit is outside the workspace folders"}, nil
+	}
+	p := *params
+	p.Edit = edit
+	return c.Client.ApplyEdit(ctx, &p)
+}
+
+// documentChangeURIs returns the documents a change affects.
+func documentChangeURIs(ch DocumentChange) []DocumentURI {
+	switch {
+	case ch.TextDocumentEdit != nil:
+		return []DocumentURI{ch.TextDocumentEdit.TextDocument.URI}
+	case ch.CreateFile != nil:
+		return []DocumentURI{ch.CreateFile.URI}
+	case ch.RenameFile != nil:
+		return []DocumentURI{ch.RenameFile.OldURI, ch.RenameFile.NewURI}
+	case ch.DeleteFile != nil:
+		return []DocumentURI{ch.DeleteFile.URI}
+	}
+	return nil
+}
+
+func (c *sandboxClient) RegisterCapability(ctx context.Context, params *RegistrationParams) error {
+	p := *params
+	p.Registrations = slices.Clone(params.Registrations)
+	for i, reg := range p.Registrations {
+		if reg.Method != "workspace/didChangeWatchedFiles" {
+			continue
+		}
+		opts, err := watchedFilesOptions(reg.RegisterOptions)
+		if err != nil {
+			return err
+		}
+		watchers, err := confine(ctx, c.sandbox, opts.Watchers, func(w FileSystemWatcher) (DocumentURI, bool) {
+			return globURI(w.GlobPattern), c.sandbox.containsGlob(w.GlobPattern)
+		})
+		if err != nil {
+			return err
+		}
+		p.Registrations[i].RegisterOptions = &DidChangeWatchedFilesRegistrationOptions{Watchers: watchers}
+	}
+	return c.Client.RegisterCapability(ctx, &p)
+}
+
+// watchedFilesOptions returns the options of a registration of
+// workspace/didChangeWatchedFiles, which may be of any type that encodes
+// to them.
+func watchedFilesOptions(v any) (*DidChangeWatchedFilesRegistrationOptions, error) {
+	switch v := v.(type) {
+	case *DidChangeWatchedFilesRegistrationOptions:
+		return v, nil
+	case DidChangeWatchedFilesRegistrationOptions:
+		return &v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	opts := new(DidChangeWatchedFilesRegistrationOptions)
+	if err := UnmarshalJSON(data, opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// globURI returns a URI describing a glob pattern, for errors and logs.
+func globURI(g GlobPattern) DocumentURI {
+	switch {
+	case g.RelativePattern != nil:
+		return g.RelativePattern.BaseURI + "/" + DocumentURI(g.RelativePattern.Pattern)
+	case g.Pattern != nil:
+		return DocumentURI(*g.Pattern)
+	}
+	return ""
+}
+
+func (c *sandboxClient) ShowDocument(ctx context.Context, params *ShowDocumentParams) (*ShowDocumentResult, error) {
+	uris, err := confine(ctx, c.sandbox, []DocumentURI{DocumentURI(params.URI)}, func(uri DocumentURI) (DocumentURI, bool) {
+		return uri, c.sandbox.Contains(uri)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(uris) == 0 {
+		return &ShowDocumentResult{Success: false}, nil
+	}
+	return c.Client.ShowDocument(ctx, params)
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest/eventtest"
+)
+
+// definitionServer answers definition requests with fixed links.
+type definitionServer struct {
+	lsp.Server
+	links []lsp.DefinitionLink
+}
+
+func (s *definitionServer) Initialize(context.Context, *lsp.ParamInitialize) (*lsp.InitializeResult, error) {
+	return &lsp.InitializeResult{}, nil
+}
+
+func (s *definitionServer) DidChangeWorkspaceFolders(context.Context, *lsp.DidChangeWorkspaceFoldersParams) error {
+	return nil
+}
+
+func (s *definitionServer) Definition(context.Context, *lsp.DefinitionParams) ([]lsp.DefinitionLink, error) {
+	return s.links, nil
+}
+
+// editRecorder records the edits applied by a client.
+type editRecorder struct {
+	registrationRecorder
+	edits []lsp.WorkspaceEdit
+}
+
+func (c *editRecorder) ApplyEdit(_ context.Context, params *lsp.ApplyWorkspaceEditParams) (*lsp.ApplyWorkspaceEditResult, error) {
+	c.edits = append(c.edits, params.Edit)
+	return &lsp.ApplyWorkspaceEditResult{Applied: true}, nil
+}
+
+func TestSandboxContains(t *testing.T) {
+	s := &lsp.Sandbox{Roots: []lsp.DocumentURI{"file:///cache/mod/"}}
+	s.SetFolders([]lsp.WorkspaceFolder{{URI: "file:///home/a/proj"}, {URI: "file:///c%3A/src/"}})
+	for _, test := range []struct {
+		uri  lsp.DocumentURI
+		want bool
+	}{
+		{"file:///home/a/proj", true},
+		{"file:///home/a/proj/main.go", true},
+		{"file:///home/a/proj/./sub/../x.go", true},
+		{"file:///home/a/project/x.go", false},
+		{"file:///home/a/proj/../b/secret", false},
+		{"file:///home/a/proj/%2e%2e/b/secret", false},
+		{"file:///etc/passwd", false},
+		{"file:///C:/src/a.go", true},
+		{"file:///D:/src/a.go", false},
+		{"file:///cache/mod/x@v1/x.go", true},
+		{"untitled:Untitled-1", true},
+		{"file:///home/a/proj/%zz", false},
+	} {
+		if got := s.Contains(test.uri); got != test.want {
+			t.Errorf("Contains(%s): expected %v, got %v", test.uri, test.want, got)
+		}
+	}
+
+	if (&lsp.Sandbox{}).Contains("file:///home/a/proj/main.go") {
+		t.Errorf("Expected no file inside a sandbox without folders")
+	}
+}
+
+func TestSandboxServer(t *testing.T) {
+	ctx := context.Background()
+	links := []lsp.DefinitionLink{
+		{TargetURI: "file:///home/a/proj/a.go"},
+		{TargetURI: "file:///home/b/proj/b.go"},
+		{TargetURI: "file:///home/a/other/c.go"},
+	}
+	targets := func(links []lsp.DefinitionLink) []lsp.DocumentURI {
+		var uris []lsp.DocumentURI
+		for _, l := range links {
+			uris = append(uris, l.TargetURI)
+		}
+		return uris
+	}
+	initialize := func(t *testing.T, server lsp.Server) {
+		params := &lsp.ParamInitialize{}
+		params.RootURI = "file:///home/a/proj"
+		if _, err := server.Initialize(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Rewrite", func(t *testing.T) {
+		ctx, capture := eventtest.NewCapture(ctx)
+		sandbox := &lsp.Sandbox{Policy: lsp.SandboxRewrite}
+		server := sandbox.Server(&definitionServer{links: links})
+		initialize(t, server)
+		got, err := server.Definition(ctx, &lsp.DefinitionParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]lsp.DocumentURI{"file:///home/a/proj/a.go"}, targets(got)); diff != "" {
+			t.Errorf("Unexpected targets (-want +got):\n%s", diff)
+		}
+		if n := len(capture.Events()); n != 2 {
+			t.Errorf("Expected 2 events for the dropped targets, got %d", n)
+		}
+
+		// The targets of added folders are inside the sandbox.
+		if err := server.DidChangeWorkspaceFolders(ctx, &lsp.DidChangeWorkspaceFoldersParams{Event: lsp.WorkspaceFoldersChangeEvent{
+			Added:   []lsp.WorkspaceFolder{{URI: "file:///home/a/other/"}},
+			Removed: []lsp.WorkspaceFolder{{URI: "file:///home/a/proj"}},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		got, err = server.Definition(ctx, &lsp.DefinitionParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]lsp.DocumentURI{"file:///home/a/other/c.go"}, targets(got)); diff != "" {
+			t.Errorf("Unexpected targets after changing folders (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		server := (&lsp.Sandbox{}).Server(&definitionServer{links: links})
+		initialize(t, server)
+		if _, err := server.Definition(ctx, &lsp.DefinitionParams{}); !errors.Is(err, lsp.ErrRequestFailed) {
+			t.Errorf("Expected RequestFailed, got %v", err)
+		}
+		if got := targets(links); len(got) != 3 {
+			t.Errorf("Expected the result of the server to be unchanged, got %v", got)
+		}
+	})
+}
+
+func TestSandboxClient(t *testing.T) {
+	ctx := context.Background()
+	newSandbox := func(policy lsp.SandboxPolicy) (*lsp.Sandbox, *editRecorder, lsp.Client) {
+		sandbox := &lsp.Sandbox{Policy: policy}
+		sandbox.SetFolders([]lsp.WorkspaceFolder{{URI: "file:///home/a/proj"}})
+		recorder := &editRecorder{}
+		return sandbox, recorder, sandbox.Client(recorder)
+	}
+	edit := lsp.WorkspaceEdit{
+		Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+			"file:///home/a/proj/a.go": {{NewText: "a"}},
+			"file:///etc/passwd":       {{NewText: "root"}},
+		},
+		DocumentChanges: []lsp.DocumentChange{
+			{CreateFile: &lsp.CreateFile{Kind: "create", URI: "file:///home/a/proj/new.go"}},
+			{RenameFile: &lsp.RenameFile{Kind: "rename", OldURI: "file:///home/a/proj/b.go", NewURI: "file:///tmp/b.go"}},
+			{DeleteFile: &lsp.DeleteFile{Kind: "delete", URI: "file:///home/a/proj/../b/c.go"}},
+		},
+	}
+
+	t.Run("ApplyEdit", func(t *testing.T) {
+		_, recorder, client := newSandbox(lsp.SandboxRewrite)
+		result, err := client.ApplyEdit(ctx, &lsp.ApplyWorkspaceEditParams{Edit: edit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Applied {
+			t.Errorf("Expected the edit to be applied")
+		}
+		want := []lsp.WorkspaceEdit{{
+			Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+				"file:///home/a/proj/a.go": {{NewText: "a"}},
+			},
+			DocumentChanges: []lsp.DocumentChange{
+				{CreateFile: &lsp.CreateFile{Kind: "create", URI: "file:///home/a/proj/new.go"}},
+			},
+		}}
+		if diff := cmp.Diff(want, recorder.edits); diff != "" {
+			t.Errorf("Unexpected edits (-want +got):\n%s", diff)
+		}
+		if len(edit.Changes) != 2 || len(edit.DocumentChanges) != 3 {
+			t.Errorf("Expected the edit of the server to be unchanged")
+		}
+
+		// An edit entirely outside is not sent.
+		outside := lsp.WorkspaceEdit{Changes: map[lsp.DocumentURI][]lsp.TextEdit{"file:///etc/passwd": {}}}
+		result, err = client.ApplyEdit(ctx, &lsp.ApplyWorkspaceEditParams{Edit: outside})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Applied || result.FailureReason == "" {
+			t.Errorf("Expected the edit to fail with a reason, got %+v", result)
+		}
+		if len(recorder.edits) != 1 {
+			t.Errorf("Expected 1 edit sent to the client, got %d", len(recorder.edits))
+		}
+	})
+
+	t.Run("ApplyEditReject", func(t *testing.T) {
+		_, recorder, client := newSandbox(lsp.SandboxReject)
+		if _, err := client.ApplyEdit(ctx, &lsp.ApplyWorkspaceEditParams{Edit: edit}); !errors.Is(err, lsp.ErrRequestFailed) {
+			t.Errorf("Expected RequestFailed, got %v", err)
+		}
+		if len(recorder.edits) != 0 {
+			t.Errorf("Expected no edit sent to the client, got %d", len(recorder.edits))
+		}
+	})
+
+	t.Run("Watchers", func(t *testing.T) {
+		pattern := func(p string) lsp.FileSystemWatcher {
+			return lsp.FileSystemWatcher{GlobPattern: lsp.GlobPattern{Pattern: &p}}
+		}
+		relative := func(base lsp.DocumentURI, p string) lsp.FileSystemWatcher {
+			return lsp.FileSystemWatcher{GlobPattern: lsp.GlobPattern{RelativePattern: &lsp.RelativePattern{BaseURI: base, Pattern: p}}}
+		}
+		inside := []lsp.FileSystemWatcher{
+			pattern("**/*.go"),
+			pattern("/home/a/proj/**/go.mod"),
+			pattern("/home/a/proj/sub*/x"),
+			pattern("{/home/a/proj/x/**,**/*.go}"),
+			relative("file:///home/a/proj/sub", "*.go"),
+		}
+		outside := []lsp.FileSystemWatcher{
+			pattern("../**/*.go"),
+			pattern("/home/a/**"),
+			pattern("/home/a/proj*/x"),
+			pattern("/etc/passwd"),
+			pattern("{/etc/**,x}"),
+			pattern("{x,{y,../z}}/*"),
+			relative("file:///home/b", "**"),
+			relative("file:///home/a/proj", "../b/**"),
+			relative("file:///home/a/proj", "/etc/*"),
+			relative("file:///home/a/proj", "{*.go,/etc/*}"),
+		}
+		reg, err := lsp.NewWatchedFilesRegistration(append(inside, outside...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reg.ID = "watchers"
+
+		_, recorder, client := newSandbox(lsp.SandboxRewrite)
+		if err := client.RegisterCapability(ctx, &lsp.RegistrationParams{Registrations: []lsp.Registration{reg, {ID: "hover", Method: "textDocument/hover"}}}); err != nil {
+			t.Fatal(err)
+		}
+		want := []lsp.Registration{
+			{ID: "watchers", Method: "workspace/didChangeWatchedFiles", RegisterOptions: &lsp.DidChangeWatchedFilesRegistrationOptions{Watchers: inside}},
+			{ID: "hover", Method: "textDocument/hover"},
+		}
+		if diff := cmp.Diff(want, recorder.registered); diff != "" {
+			t.Errorf("Unexpected registrations (-want +got):\n%s", diff)
+		}
+
+		_, recorder, client = newSandbox(lsp.SandboxReject)
+		if err := client.RegisterCapability(ctx, &lsp.RegistrationParams{Registrations: []lsp.Registration{reg}}); !errors.Is(err, lsp.ErrRequestFailed) {
+			t.Errorf("Expected RequestFailed, got %v", err)
+		}
+		if len(recorder.registered) != 0 {
+			t.Errorf("Expected no registration, got %v", recorder.registered)
+		}
+	})
+
+	t.Run("ShowDocument", func(t *testing.T) {
+		_, _, client := newSandbox(lsp.SandboxRewrite)
+		result, err := client.ShowDocument(ctx, &lsp.ShowDocumentParams{URI: "file:///etc/passwd"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Success {
+			t.Errorf("Expected the document not to be shown")
+		}
+	})
+}
@@ -0,0 +1,509 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A ServerBuilder builds the handler of a server from functions that
+// handle individual methods, as an alternative to implementing every
+// method of [Server]:
+//
+//	handler := lsp.NewServer("mylang", "v1.0.0").
+//		OnDidOpen(didOpen).
+//		OnDidChange(didChange).
+//		OnHover(hover).
+//		Handler()
+//
+// The handler answers the initialize request with the capabilities of
+// the features whose methods are handled, as returned by
+// [ServerBuilder.Capabilities]. Requests for other methods fail with
+// MethodNotFound, and other notifications are ignored. Shutdown and
+// exit succeed unless handled otherwise.
+//
+// Each method modifies the builder and returns it. The builder must not
+// be modified once its handler is in use.
+type ServerBuilder struct {
+	name, version string
+	handlers      map[string]func(context.Context, json.RawMessage) (any, error)
+	commands      map[string]func(context.Context, *ExecuteCommandParams) (any, error)
+	initialize    func(context.Context, *ParamInitialize, *InitializeResult) error
+	overrides     []func(*ServerCapabilities)
+	trust         *WorkspaceTrust
+}
+
+// NewServer returns a builder of a server with the given name and
+// version (which may be empty), which initially handles no method.
+func NewServer(name, version string) *ServerBuilder {
+	return &ServerBuilder{
+		name:     name,
+		version:  version,
+		handlers: make(map[string]func(context.Context, json.RawMessage) (any, error)),
+		commands: make(map[string]func(context.Context, *ExecuteCommandParams) (any, error)),
+	}
+}
+
+// HandleRequest registers f to handle the requests of method. If method
+// is a method of the protocol, P and R must be the types of its params
+// and result, as in the corresponding method of [Server]; HandleRequest
+// panics otherwise. Other methods, such as the extensions of a server,
+// may have any params and result.
+func HandleRequest[P, R any](b *ServerBuilder, method string, f func(context.Context, *P) (R, error)) *ServerBuilder {
+	checkHandler(method, false, reflect.TypeFor[P](), reflect.TypeFor[R]())
+	b.handlers[method] = func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var params P
+		if err := UnmarshalJSON(raw, &params); err != nil {
+			return nil, err
+		}
+		resp, err := f(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	return b
+}
+
+// HandleNotification registers f to handle the notifications of method.
+// If method is a method of the protocol, P must be the type of its
+// params; HandleNotification panics otherwise.
+func HandleNotification[P any](b *ServerBuilder, method string, f func(context.Context, *P) error) *ServerBuilder {
+	checkHandler(method, true, reflect.TypeFor[P](), nil)
+	b.handlers[method] = func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var params P
+		if err := UnmarshalJSON(raw, &params); err != nil {
+			return nil, err
+		}
+		return nil, f(ctx, &params)
+	}
+	return b
+}
+
+// checkHandler panics if a method of the protocol is not handled by
+// servers, or not with the given params and result types.
+func checkHandler(method string, notification bool, params, result reflect.Type) {
+	info, ok := methods[method]
+	switch {
+	case method == "initialize" || method == "shutdown":
+		panic(fmt.Sprintf("method %q must be handled with its own method of ServerBuilder", method))
+	case !ok:
+		// Not a method of the protocol.
+	case !info.toServer:
+		panic(fmt.Sprintf("method %q is not sent to servers", method))
+	case info.notification != notification:
+		if notification {
+			panic(fmt.Sprintf("method %q is a request, not a notification", method))
+		}
+		panic(fmt.Sprintf("method %q is a notification, not a request", method))
+	case info.params != params:
+		panic(fmt.Sprintf("method %q has params of type %v, not %v", method, info.params, params))
+	case !notification && info.result != result:
+		panic(fmt.Sprintf("method %q has a result of type %v, not %v", method, info.result, result))
+	}
+}
+
+// OnInitialize registers f to be called with the parameters of the
+// initialize request, and the result about to be returned, which it may
+// modify, for example to adapt the capabilities to those of the client.
+// If f returns an error, initialization fails.
+func (b *ServerBuilder) OnInitialize(f func(ctx context.Context, params *ParamInitialize, result *InitializeResult) error) *ServerBuilder {
+	b.initialize = f
+	return b
+}
+
+// OnInitialized registers f to handle the initialized notification.
+func (b *ServerBuilder) OnInitialized(f func(context.Context, *InitializedParams) error) *ServerBuilder {
+	return HandleNotification(b, "initialized", f)
+}
+
+// OnShutdown registers f to handle the shutdown request.
+func (b *ServerBuilder) OnShutdown(f func(context.Context) error) *ServerBuilder {
+	b.handlers["shutdown"] = func(ctx context.Context, _ json.RawMessage) (any, error) {
+		return nil, f(ctx)
+	}
+	return b
+}
+
+// OnDidOpen registers f to handle textDocument/didOpen.
+func (b *ServerBuilder) OnDidOpen(f func(context.Context, *DidOpenTextDocumentParams) error) *ServerBuilder {
+	return HandleNotification(b, "textDocument/didOpen", f)
+}
+
+// OnDidChange registers f to handle textDocument/didChange. The changes
+// are incremental unless the capabilities are overridden.
+func (b *ServerBuilder) OnDidChange(f func(context.Context, *DidChangeTextDocumentParams) error) *ServerBuilder {
+	return HandleNotification(b, "textDocument/didChange", f)
+}
+
+// OnDidSave registers f to handle textDocument/didSave.
+func (b *ServerBuilder) OnDidSave(f func(context.Context, *DidSaveTextDocumentParams) error) *ServerBuilder {
+	return HandleNotification(b, "textDocument/didSave", f)
+}
+
+// OnDidClose registers f to handle textDocument/didClose.
+func (b *ServerBuilder) OnDidClose(f func(context.Context, *DidCloseTextDocumentParams) error) *ServerBuilder {
+	return HandleNotification(b, "textDocument/didClose", f)
+}
+
+// OnDidChangeConfiguration registers f to handle
+// workspace/didChangeConfiguration.
+func (b *ServerBuilder) OnDidChangeConfiguration(f func(context.Context, *DidChangeConfigurationParams) error) *ServerBuilder {
+	return HandleNotification(b, "workspace/didChangeConfiguration", f)
+}
+
+// OnDidChangeWatchedFiles registers f to handle
+// workspace/didChangeWatchedFiles.
+func (b *ServerBuilder) OnDidChangeWatchedFiles(f func(context.Context, *DidChangeWatchedFilesParams) error) *ServerBuilder {
+	return HandleNotification(b, "workspace/didChangeWatchedFiles", f)
+}
+
+// OnDidChangeWorkspaceFolders registers f to handle
+// workspace/didChangeWorkspaceFolders.
+func (b *ServerBuilder) OnDidChangeWorkspaceFolders(f func(context.Context, *DidChangeWorkspaceFoldersParams) error) *ServerBuilder {
+	return HandleNotification(b, "workspace/didChangeWorkspaceFolders", f)
+}
+
+// OnCompletion registers f to handle textDocument/completion.
+func (b *ServerBuilder) OnCompletion(f func(context.Context, *CompletionParams) (*CompletionList, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/completion", f)
+}
+
+// OnResolveCompletionItem registers f to handle completionItem/resolve.
+func (b *ServerBuilder) OnResolveCompletionItem(f func(context.Context, *CompletionItem) (*CompletionItem, error)) *ServerBuilder {
+	return HandleRequest(b, "completionItem/resolve", f)
+}
+
+// OnHover registers f to handle textDocument/hover.
+func (b *ServerBuilder) OnHover(f func(context.Context, *HoverParams) (*Hover, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/hover", f)
+}
+
+// OnSignatureHelp registers f to handle textDocument/signatureHelp.
+func (b *ServerBuilder) OnSignatureHelp(f func(context.Context, *SignatureHelpParams) (*SignatureHelp, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/signatureHelp", f)
+}
+
+// OnDeclaration registers f to handle textDocument/declaration.
+func (b *ServerBuilder) OnDeclaration(f func(context.Context, *DeclarationParams) ([]DefinitionLink, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/declaration", f)
+}
+
+// OnDefinition registers f to handle textDocument/definition.
+func (b *ServerBuilder) OnDefinition(f func(context.Context, *DefinitionParams) ([]DefinitionLink, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/definition", f)
+}
+
+// OnTypeDefinition registers f to handle textDocument/typeDefinition.
+func (b *ServerBuilder) OnTypeDefinition(f func(context.Context, *TypeDefinitionParams) ([]DefinitionLink, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/typeDefinition", f)
+}
+
+// OnImplementation registers f to handle textDocument/implementation.
+func (b *ServerBuilder) OnImplementation(f func(context.Context, *ImplementationParams) ([]DefinitionLink, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/implementation", f)
+}
+
+// OnReferences registers f to handle textDocument/references.
+func (b *ServerBuilder) OnReferences(f func(context.Context, *ReferenceParams) ([]Location, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/references", f)
+}
+
+// OnDocumentHighlight registers f to handle
+// textDocument/documentHighlight.
+func (b *ServerBuilder) OnDocumentHighlight(f func(context.Context, *DocumentHighlightParams) ([]DocumentHighlight, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/documentHighlight", f)
+}
+
+// OnDocumentSymbol registers f to handle textDocument/documentSymbol.
+// The result holds either DocumentSymbols or SymbolInformations.
+func (b *ServerBuilder) OnDocumentSymbol(f func(context.Context, *DocumentSymbolParams) ([]any, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/documentSymbol", f)
+}
+
+// OnWorkspaceSymbol registers f to handle workspace/symbol.
+func (b *ServerBuilder) OnWorkspaceSymbol(f func(context.Context, *WorkspaceSymbolParams) ([]SymbolInformation, error)) *ServerBuilder {
+	return HandleRequest(b, "workspace/symbol", f)
+}
+
+// OnCodeAction registers f to handle textDocument/codeAction.
+func (b *ServerBuilder) OnCodeAction(f func(context.Context, *CodeActionParams) ([]CodeAction, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/codeAction", f)
+}
+
+// OnResolveCodeAction registers f to handle codeAction/resolve.
+func (b *ServerBuilder) OnResolveCodeAction(f func(context.Context, *CodeAction) (*CodeAction, error)) *ServerBuilder {
+	return HandleRequest(b, "codeAction/resolve", f)
+}
+
+// OnCodeLens registers f to handle textDocument/codeLens.
+func (b *ServerBuilder) OnCodeLens(f func(context.Context, *CodeLensParams) ([]CodeLens, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/codeLens", f)
+}
+
+// OnFormatting registers f to handle textDocument/formatting.
+func (b *ServerBuilder) OnFormatting(f func(context.Context, *DocumentFormattingParams) ([]TextEdit, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/formatting", f)
+}
+
+// OnRangeFormatting registers f to handle textDocument/rangeFormatting.
+func (b *ServerBuilder) OnRangeFormatting(f func(context.Context, *DocumentRangeFormattingParams) ([]TextEdit, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/rangeFormatting", f)
+}
+
+// OnRename registers f to handle textDocument/rename.
+func (b *ServerBuilder) OnRename(f func(context.Context, *RenameParams) (*WorkspaceEdit, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/rename", f)
+}
+
+// OnPrepareRename registers f to handle textDocument/prepareRename.
+func (b *ServerBuilder) OnPrepareRename(f func(context.Context, *PrepareRenameParams) (*PrepareRenameResult, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/prepareRename", f)
+}
+
+// OnFoldingRange registers f to handle textDocument/foldingRange.
+func (b *ServerBuilder) OnFoldingRange(f func(context.Context, *FoldingRangeParams) ([]FoldingRange, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/foldingRange", f)
+}
+
+// OnInlayHint registers f to handle textDocument/inlayHint.
+func (b *ServerBuilder) OnInlayHint(f func(context.Context, *InlayHintParams) ([]InlayHint, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/inlayHint", f)
+}
+
+// OnSemanticTokensFull registers f to handle
+// textDocument/semanticTokens/full.
+func (b *ServerBuilder) OnSemanticTokensFull(f func(context.Context, *SemanticTokensParams) (*SemanticTokens, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/semanticTokens/full", f)
+}
+
+// OnSemanticTokensRange registers f to handle
+// textDocument/semanticTokens/range.
+func (b *ServerBuilder) OnSemanticTokensRange(f func(context.Context, *SemanticTokensRangeParams) (*SemanticTokens, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/semanticTokens/range", f)
+}
+
+// OnDiagnostic registers f to handle textDocument/diagnostic.
+func (b *ServerBuilder) OnDiagnostic(f func(context.Context, *DocumentDiagnosticParams) (*DocumentDiagnosticReport, error)) *ServerBuilder {
+	return HandleRequest(b, "textDocument/diagnostic", f)
+}
+
+// OnCommand registers f to execute the given command, with
+// workspace/executeCommand. Requests to execute other commands fail with
+// InvalidParams.
+func (b *ServerBuilder) OnCommand(command string, f func(context.Context, *ExecuteCommandParams) (any, error)) *ServerBuilder {
+	b.commands[command] = f
+	return HandleRequest(b, "workspace/executeCommand", b.executeCommand)
+}
+
+// executeCommand handles workspace/executeCommand with the function
+// registered for the command.
+func (b *ServerBuilder) executeCommand(ctx context.Context, params *ExecuteCommandParams) (any, error) {
+	f, ok := b.commands[params.Command]
+	if !ok {
+		return nil, Errorf(InvalidParams, "unknown command %q", params.Command)
+	}
+	return f(ctx, params)
+}
+
+// WithInlineCompletions makes the server provide the inline completions
+// of c.
+func (b *ServerBuilder) WithInlineCompletions(c *InlineCompletions) *ServerBuilder {
+	return HandleRequest(b, "textDocument/inlineCompletion", c.InlineCompletion)
+}
+
+// WithWorkspaceTrust makes the server disable the features restricted by
+// trust until the workspace is trusted, and declare so in its
+// capabilities. The server should also ask the client to apply edits
+// through [WorkspaceTrust.Client].
+func (b *ServerBuilder) WithWorkspaceTrust(trust *WorkspaceTrust) *ServerBuilder {
+	b.trust = trust
+	return b
+}
+
+// With registers f to modify the capabilities derived from the handled
+// methods, for example to set trigger characters or the legend of
+// semantic tokens.
+func (b *ServerBuilder) With(f func(*ServerCapabilities)) *ServerBuilder {
+	b.overrides = append(b.overrides, f)
+	return b
+}
+
+// builderProviders lists, for the features of [FullServerCapabilities],
+// the method that provides each, and the removal of the feature from
+// the capabilities of a server that does not handle it.
+var builderProviders = []struct {
+	method string
+	remove func(*ServerCapabilities)
+}{
+	{"textDocument/completion", func(c *ServerCapabilities) { c.CompletionProvider = nil }},
+	{"textDocument/hover", func(c *ServerCapabilities) { c.HoverProvider = nil }},
+	{"textDocument/signatureHelp", func(c *ServerCapabilities) { c.SignatureHelpProvider = nil }},
+	{"textDocument/declaration", func(c *ServerCapabilities) { c.DeclarationProvider = nil }},
+	{"textDocument/definition", func(c *ServerCapabilities) { c.DefinitionProvider = nil }},
+	{"textDocument/typeDefinition", func(c *ServerCapabilities) { c.TypeDefinitionProvider = nil }},
+	{"textDocument/implementation", func(c *ServerCapabilities) { c.ImplementationProvider = nil }},
+	{"textDocument/references", func(c *ServerCapabilities) { c.ReferencesProvider = nil }},
+	{"textDocument/documentHighlight", func(c *ServerCapabilities) { c.DocumentHighlightProvider = nil }},
+	{"textDocument/documentSymbol", func(c *ServerCapabilities) { c.DocumentSymbolProvider = nil }},
+	{"textDocument/codeAction", func(c *ServerCapabilities) { c.CodeActionProvider = nil }},
+	{"textDocument/codeLens", func(c *ServerCapabilities) { c.CodeLensProvider = nil }},
+	{"textDocument/documentLink", func(c *ServerCapabilities) { c.DocumentLinkProvider = nil }},
+	{"textDocument/documentColor", func(c *ServerCapabilities) { c.ColorProvider = nil }},
+	{"workspace/symbol", func(c *ServerCapabilities) { c.WorkspaceSymbolProvider = nil }},
+	{"textDocument/formatting", func(c *ServerCapabilities) { c.DocumentFormattingProvider = nil }},
+	{"textDocument/rangeFormatting", func(c *ServerCapabilities) { c.DocumentRangeFormattingProvider = nil }},
+	{"textDocument/rename", func(c *ServerCapabilities) { c.RenameProvider = nil }},
+	{"textDocument/foldingRange", func(c *ServerCapabilities) { c.FoldingRangeProvider = nil }},
+	{"textDocument/selectionRange", func(c *ServerCapabilities) { c.SelectionRangeProvider = nil }},
+	{"textDocument/prepareCallHierarchy", func(c *ServerCapabilities) { c.CallHierarchyProvider = nil }},
+	{"textDocument/linkedEditingRange", func(c *ServerCapabilities) { c.LinkedEditingRangeProvider = nil }},
+	{"textDocument/moniker", func(c *ServerCapabilities) { c.MonikerProvider = nil }},
+	{"textDocument/prepareTypeHierarchy", func(c *ServerCapabilities) { c.TypeHierarchyProvider = nil }},
+	{"textDocument/inlineValue", func(c *ServerCapabilities) { c.InlineValueProvider = nil }},
+	{"textDocument/inlayHint", func(c *ServerCapabilities) { c.InlayHintProvider = nil }},
+	{"textDocument/diagnostic", func(c *ServerCapabilities) { c.DiagnosticProvider = nil }},
+}
+
+// Capabilities returns the capabilities of the server: those of
+// [FullServerCapabilities] for the features whose methods are handled,
+// with resolve requests and the like if they are handled too, modified
+// by the functions registered with With.
+func (b *ServerBuilder) Capabilities() *ServerCapabilities {
+	has := func(method string) bool {
+		_, ok := b.handlers[method]
+		return ok
+	}
+	caps := FullServerCapabilities()
+
+	sync := caps.TextDocumentSync
+	sync.OpenClose = has("textDocument/didOpen") || has("textDocument/didClose")
+	if !has("textDocument/didChange") {
+		sync.Change = None
+	}
+	if !has("textDocument/didSave") {
+		sync.Save = nil
+	}
+	sync.WillSave = has("textDocument/willSave")
+	sync.WillSaveWaitUntil = has("textDocument/willSaveWaitUntil")
+	if *sync == (TextDocumentSyncOptions{}) {
+		caps.TextDocumentSync = nil
+	}
+
+	for _, p := range builderProviders {
+		if !has(p.method) {
+			p.remove(caps)
+		}
+	}
+	if p := caps.CompletionProvider; p != nil {
+		p.ResolveProvider = has("completionItem/resolve")
+	}
+	if p := caps.CodeActionProvider; p != nil {
+		p.ResolveProvider = has("codeAction/resolve")
+	}
+	if p := caps.CodeLensProvider; p != nil {
+		p.ResolveProvider = has("codeLens/resolve")
+	}
+	if p := caps.DocumentLinkProvider; p != nil {
+		p.ResolveProvider = has("documentLink/resolve")
+	}
+	if p := caps.WorkspaceSymbolProvider; p != nil {
+		p.ResolveProvider = has("workspaceSymbol/resolve")
+	}
+	if p := caps.InlayHintProvider; p != nil {
+		p.ResolveProvider = has("inlayHint/resolve")
+	}
+	if p := caps.RenameProvider; p != nil {
+		p.PrepareProvider = has("textDocument/prepareRename")
+	}
+	if p := caps.DiagnosticProvider; p != nil {
+		p.DiagnosticOptions.WorkspaceDiagnostics = has("workspace/diagnostic")
+	}
+	tokens := &caps.SemanticTokensProvider.SemanticTokensOptions
+	switch {
+	case has("textDocument/semanticTokens/full/delta"):
+		// Full with delta, as in the template.
+	case has("textDocument/semanticTokens/full"):
+		tokens.Full = &SemanticTokensOptionsFull{Bool: ptrTo(true)}
+	default:
+		tokens.Full = nil
+	}
+	if !has("textDocument/semanticTokens/range") {
+		tokens.Range = nil
+	}
+	if tokens.Full == nil && tokens.Range == nil {
+		caps.SemanticTokensProvider = nil
+	}
+	if has("textDocument/inlineCompletion") {
+		caps.InlineCompletionProvider = &InlineCompletionOptions{}
+	}
+	if len(b.commands) > 0 {
+		commands := make([]string, 0, len(b.commands))
+		for command := range b.commands {
+			commands = append(commands, command)
+		}
+		slices.Sort(commands)
+		caps.ExecuteCommandProvider = &ExecuteCommandOptions{Commands: commands}
+	}
+	if has("workspace/didChangeWorkspaceFolders") {
+		caps.Workspace.WorkspaceFolders.ChangeNotifications = "workspace/didChangeWorkspaceFolders"
+	}
+	if b.trust != nil {
+		SetServerWorkspaceTrust(caps, b.trust.Capabilities())
+	}
+
+	for _, override := range b.overrides {
+		override(caps)
+	}
+	return caps
+}
+
+// Handler returns the handler of the server.
+func (b *ServerBuilder) Handler() jsonrpc2.Handler {
+	var handler jsonrpc2.Handler = jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if ctx.Err() != nil {
+			return nil, ErrRequestCancelled
+		}
+		resp, err := b.dispatch(withRequest(ctx, req), req)
+		return replyResult(req, resp), replyError(err)
+	})
+	if b.trust != nil {
+		handler = b.trust.Handler(handler)
+	}
+	return handler
+}
+
+// dispatch handles req with the function registered for its method.
+func (b *ServerBuilder) dispatch(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+	if req.Method == "initialize" {
+		var params ParamInitialize
+		if err := UnmarshalJSON(req.Params, &params); err != nil {
+			return nil, err
+		}
+		result := NewInitializeResult(b.name, b.version, b.Capabilities())
+		if b.initialize != nil {
+			if err := b.initialize(ctx, &params, result); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+	if f, ok := b.handlers[req.Method]; ok {
+		return f(ctx, req.Params)
+	}
+	if _, ok := methods[req.Method]; !ok || req.IsCall() && req.Method != "shutdown" {
+		// As ServerHandler, report the methods outside the protocol as
+		// not found, notifications included, so that middleware such as
+		// Metrics and UnknownMethods tell them apart.
+		return nil, jsonrpc2.ErrMethodNotFound
+	}
+	return nil, nil
+}
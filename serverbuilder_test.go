@@ -0,0 +1,138 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+// errorCode returns the code of the response error of a failed call, or
+// 0 if the call did not fail with one.
+func errorCode(err error) int64 {
+	if rerr, ok := lsp.ErrorFrom(err); ok {
+		return rerr.Code
+	}
+	return 0
+}
+
+func TestServerBuilder(t *testing.T) {
+	ctx := context.Background()
+	var opened []lsp.DocumentURI
+	builder := lsp.NewServer("test", "v1").
+		OnDidOpen(func(_ context.Context, params *lsp.DidOpenTextDocumentParams) error {
+			opened = append(opened, params.TextDocument.URI)
+			return nil
+		}).
+		OnHover(hoverServer{}.Hover).
+		OnCommand("b", func(context.Context, *lsp.ExecuteCommandParams) (any, error) { return "b", nil }).
+		OnCommand("a", func(context.Context, *lsp.ExecuteCommandParams) (any, error) { return "a", nil }).
+		With(func(caps *lsp.ServerCapabilities) {
+			caps.HoverProvider.WorkDoneProgress = true
+		})
+
+	t.Run("Capabilities", func(t *testing.T) {
+		caps := builder.Capabilities()
+		want := &lsp.ServerCapabilities{
+			TextDocumentSync:       &lsp.TextDocumentSyncOptions{OpenClose: true},
+			HoverProvider:          &lsp.HoverOptions{WorkDoneProgressOptions: lsp.WorkDoneProgressOptions{WorkDoneProgress: true}},
+			ExecuteCommandProvider: &lsp.ExecuteCommandOptions{Commands: []string{"a", "b"}},
+			Workspace:              &lsp.WorkspaceOptions{WorkspaceFolders: &lsp.WorkspaceFolders5Gn{Supported: true}},
+		}
+		if diff := cmp.Diff(want, caps); diff != "" {
+			t.Errorf("Unexpected capabilities (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		complete := func(context.Context, *lsp.CompletionParams) (*lsp.CompletionList, error) { return nil, nil }
+		caps := lsp.NewServer("", "").OnCompletion(complete).Capabilities()
+		if caps.CompletionProvider == nil || caps.CompletionProvider.ResolveProvider {
+			t.Errorf("Expected completion without resolve, got %+v", caps.CompletionProvider)
+		}
+		resolve := func(_ context.Context, item *lsp.CompletionItem) (*lsp.CompletionItem, error) { return item, nil }
+		caps = lsp.NewServer("", "").OnCompletion(complete).OnResolveCompletionItem(resolve).Capabilities()
+		if caps.CompletionProvider == nil || !caps.CompletionProvider.ResolveProvider {
+			t.Errorf("Expected completion with resolve, got %+v", caps.CompletionProvider)
+		}
+	})
+
+	t.Run("Dispatch", func(t *testing.T) {
+		conn, _ := connectPair(t, nil, builder.Handler())
+		server := lsp.ServerDispatcher(conn)
+		result, err := server.Initialize(ctx, &lsp.ParamInitialize{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ServerInfo == nil || result.ServerInfo.Name != "test" || result.Capabilities.HoverProvider == nil {
+			t.Errorf("Unexpected initialize result %+v", result)
+		}
+		if err := server.DidOpen(ctx, &lsp.DidOpenTextDocumentParams{TextDocument: lsp.TextDocumentItem{URI: "file:///a.go"}}); err != nil {
+			t.Fatal(err)
+		}
+		hover, err := server.Hover(ctx, &lsp.HoverParams{TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hover.Contents.Value != "file:///a.go" {
+			t.Errorf("Expected hover for file:///a.go, got %q", hover.Contents.Value)
+		}
+		got, err := server.ExecuteCommand(ctx, &lsp.ExecuteCommandParams{Command: "a"})
+		if err != nil || got != "a" {
+			t.Errorf("Expected command a to return a, got %v, %v", got, err)
+		}
+		if _, err := server.ExecuteCommand(ctx, &lsp.ExecuteCommandParams{Command: "c"}); errorCode(err) != int64(lsp.InvalidParams) {
+			t.Errorf("Expected InvalidParams for an unknown command, got %v", err)
+		}
+		if _, err := server.Definition(ctx, &lsp.DefinitionParams{}); errorCode(err) != int64(lsp.MethodNotFound) {
+			t.Errorf("Expected MethodNotFound, got %v", err)
+		}
+		if err := server.DidClose(ctx, &lsp.DidCloseTextDocumentParams{}); err != nil {
+			t.Errorf("Expected unhandled notifications to be ignored, got %v", err)
+		}
+		if err := server.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown failed: %v", err)
+		}
+		if diff := cmp.Diff([]lsp.DocumentURI{"file:///a.go"}, opened); diff != "" {
+			t.Errorf("Unexpected opened documents (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("WrongTypes", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic for the wrong params type")
+			}
+		}()
+		lsp.HandleRequest(lsp.NewServer("", ""), "textDocument/hover", func(context.Context, *lsp.DefinitionParams) (*lsp.Hover, error) {
+			return nil, nil
+		})
+	})
+
+	t.Run("WorkspaceTrust", func(t *testing.T) {
+		trust := &lsp.WorkspaceTrust{}
+		b := lsp.NewServer("", "").
+			OnCommand("run", func(context.Context, *lsp.ExecuteCommandParams) (any, error) { return nil, nil }).
+			WithWorkspaceTrust(trust)
+		if _, ok := lsp.ClientWorkspaceTrust(&lsp.ClientCapabilities{Experimental: b.Capabilities().Experimental}); !ok {
+			t.Errorf("Expected the workspace trust capability to be declared")
+		}
+		conn, _ := connectPair(t, nil, b.Handler())
+		server := lsp.ServerDispatcher(conn)
+		params := &lsp.ParamInitialize{}
+		params.Capabilities.Experimental = map[string]any{lsp.WorkspaceTrustCapability: map[string]any{"trusted": false}}
+		if _, err := server.Initialize(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := server.ExecuteCommand(ctx, &lsp.ExecuteCommandParams{Command: "run"}); errorCode(err) != int64(lsp.RequestFailed) {
+			t.Errorf("Expected RequestFailed in an untrusted workspace, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A Session holds the state of one connection that handlers may need
+// deep inside their call graph, such as the capabilities negotiated by
+// the initialize request, without threading it through every call:
+//
+//	caps := lsp.SessionFromContext(ctx).ClientCapabilities()
+//	if caps.GetTextDocument().GetCompletion().GetCompletionItem().GetSnippetSupport() {
+//		...
+//	}
+//
+// The methods of a Session are safe for concurrent use, and may be
+// called on a nil *Session, which holds nothing.
+type Session struct {
+	id string
+
+	mu         sync.Mutex
+	clientInfo *ClientInfo
+	clientCaps *ClientCapabilities
+	serverCaps *ServerCapabilities
+	values     map[any]any
+}
+
+// NewSession returns a new Session with a random ID.
+func NewSession() *Session {
+	return &Session{id: newCorrelationID()}
+}
+
+// ID returns the identifier of the session, suitable for logs.
+func (s *Session) ID() string {
+	if s == nil {
+		return ""
+	}
+	return s.id
+}
+
+// ClientInfo returns the information the client gave about itself in
+// the initialize request, or nil if it gave none.
+func (s *Session) ClientInfo() *ClientInfo {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clientInfo
+}
+
+// ClientCapabilities returns the capabilities of the client, or nil
+// before the initialize request.
+func (s *Session) ClientCapabilities() *ClientCapabilities {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clientCaps
+}
+
+// ServerCapabilities returns the capabilities the server declared in its
+// reply to the initialize request, or nil before that reply.
+func (s *Session) ServerCapabilities() *ServerCapabilities {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.serverCaps
+}
+
+// Value returns the value associated with key by Set, or nil.
+func (s *Session) Value(key any) any {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key]
+}
+
+// Set associates value with key for the rest of the session. As with
+// context values, key should be of an unexported type to avoid
+// collisions. Set panics if s is nil.
+func (s *Session) Set(key, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values == nil {
+		s.values = make(map[any]any)
+	}
+	s.values[key] = value
+}
+
+type sessionKey struct{}
+
+// ContextWithSession returns a context that carries s.
+func ContextWithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// SessionFromContext returns the session carried by ctx, or nil.
+func SessionFromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionKey{}).(*Session)
+	return s
+}
+
+// WithSession returns a handler that invokes handler with s in its
+// context. It records in s the client information and capabilities of
+// the initialize request before handler sees it, and the server
+// capabilities of a successful reply.
+//
+// A Session belongs to a single connection, so WithSession is typically
+// called by the [jsonrpc2.Binder] of each connection:
+//
+//	handler := lsp.WithSession(lsp.ServerHandler(server), lsp.NewSession())
+func WithSession(handler jsonrpc2.Handler, s *Session) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		ctx = ContextWithSession(ctx, s)
+		if req.Method != "initialize" {
+			return handler.Handle(ctx, req)
+		}
+		var params ParamInitialize
+		if err := UnmarshalJSON(req.Params, &params); err == nil {
+			s.mu.Lock()
+			s.clientInfo = params.ClientInfo
+			s.clientCaps = &params.Capabilities
+			s.mu.Unlock()
+		}
+		result, err := handler.Handle(ctx, req)
+		if r, ok := result.(*InitializeResult); ok && err == nil && r != nil {
+			s.mu.Lock()
+			s.serverCaps = &r.Capabilities
+			s.mu.Unlock()
+		}
+		return result, err
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+type sessionKey struct{}
+
+// sessionServer records the session seen by its handlers.
+type sessionServer struct {
+	lsp.Server
+	snippets bool
+}
+
+func (s *sessionServer) Initialize(ctx context.Context, params *lsp.ParamInitialize) (*lsp.InitializeResult, error) {
+	lsp.SessionFromContext(ctx).Set(sessionKey{}, "initialized")
+	return lsp.NewInitializeResult("test-server", "1.0.0", lsp.SyncOnlyServerCapabilities()), nil
+}
+
+func (s *sessionServer) Hover(ctx context.Context, _ *lsp.HoverParams) (*lsp.Hover, error) {
+	caps := lsp.SessionFromContext(ctx).ClientCapabilities()
+	s.snippets = caps.GetTextDocument().GetCompletion().GetCompletionItem().GetSnippetSupport()
+	return &lsp.Hover{}, nil
+}
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Connection", func(t *testing.T) {
+		session := lsp.NewSession()
+		srv := new(sessionServer)
+		client, _ := connectPair(t, nil, lsp.WithSession(lsp.ServerHandler(srv), session))
+		server := lsp.ServerDispatcher(client)
+
+		if _, err := server.Hover(ctx, &lsp.HoverParams{}); err != nil {
+			t.Fatal(err)
+		}
+		if srv.snippets {
+			t.Errorf("Expected no client capabilities before initialize")
+		}
+		if session.ClientCapabilities() != nil || session.ServerCapabilities() != nil {
+			t.Errorf("Expected no capabilities before initialize")
+		}
+
+		params := &lsp.ParamInitialize{XInitializeParams: lsp.XInitializeParams{
+			ClientInfo:   &lsp.ClientInfo{Name: "test-client", Version: "2.0"},
+			Capabilities: *lsp.NewClientCapabilities().WithSnippetSupport().Build(),
+		}}
+		if _, err := server.Initialize(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := server.Hover(ctx, &lsp.HoverParams{}); err != nil {
+			t.Fatal(err)
+		}
+		if !srv.snippets {
+			t.Errorf("Expected the handler to see snippet support")
+		}
+		if info := session.ClientInfo(); info == nil || info.Name != "test-client" {
+			t.Errorf("Expected client info for test-client, got %+v", info)
+		}
+		if caps := session.ServerCapabilities(); caps == nil || !caps.GetTextDocumentSync().GetOpenClose() {
+			t.Errorf("Expected the server capabilities to be recorded, got %+v", caps)
+		}
+		if got := session.Value(sessionKey{}); got != "initialized" {
+			t.Errorf("Expected value %q, got %v", "initialized", got)
+		}
+	})
+
+	t.Run("Distinct", func(t *testing.T) {
+		a, b := lsp.NewSession(), lsp.NewSession()
+		if a.ID() == "" || a.ID() == b.ID() {
+			t.Errorf("Expected distinct session IDs, got %q and %q", a.ID(), b.ID())
+		}
+		a.Set(sessionKey{}, 1)
+		if got := b.Value(sessionKey{}); got != nil {
+			t.Errorf("Expected no value in another session, got %v", got)
+		}
+	})
+
+	t.Run("OutsideHandler", func(t *testing.T) {
+		s := lsp.SessionFromContext(ctx)
+		if s != nil {
+			t.Fatalf("Expected no session, got %v", s.ID())
+		}
+		if s.ClientCapabilities().GetGeneral().GetPositionEncodings() != nil || s.Value(sessionKey{}) != nil || s.ID() != "" {
+			t.Errorf("Expected a nil session to hold nothing")
+		}
+		var handled bool
+		handler := lsp.WithSession(jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+			handled = lsp.SessionFromContext(ctx) != nil
+			return nil, nil
+		}), lsp.NewSession())
+		if _, err := handler.Handle(ctx, newHoverCall(t)); err != nil {
+			t.Fatal(err)
+		}
+		if !handled {
+			t.Errorf("Expected the handler to see the session")
+		}
+	})
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+// This file relates the typed settings of a server to the JSON Schema
+// of its configuration in an editor, and to the values that the editor
+// returns to workspace/configuration requests. Both derive from the
+// same struct tags:
+//
+//	type Settings struct {
+//		Gofumpt bool   `json:"gofumpt" description:"Format with gofumpt." default:"false"`
+//		Level   string `json:"level" enum:"off,info,debug" default:"info" scope:"window"`
+//	}
+//
+// The json tag names a setting, as encoding/json does; fields named "-"
+// or unexported are not settings. The description tag describes it; the
+// default tag holds its default value, as JSON, or as a bare string for
+// string settings; the enum tag lists its allowed values, separated by
+// commas; and the scope tag is its scope in VS Code, such as "resource".
+// The settings of a nested struct are named with a dot, as in
+// "ui.completion.matcher".
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A ConfigurationContribution is the configuration an extension of VS
+// Code contributes to the settings of the editor, in the
+// contributes.configuration section of its package.json file.
+type ConfigurationContribution struct {
+	Title      string                     `json:"title,omitempty"`
+	Properties map[string]*SettingsSchema `json:"properties"`
+}
+
+// A SettingsSchema is the JSON Schema of the value of a setting.
+type SettingsSchema struct {
+	Type                 string                     `json:"type,omitempty"`
+	Description          string                     `json:"description,omitempty"`
+	Default              any                        `json:"default,omitempty"`
+	Enum                 []any                      `json:"enum,omitempty"`
+	Items                *SettingsSchema            `json:"items,omitempty"`
+	Properties           map[string]*SettingsSchema `json:"properties,omitempty"`
+	AdditionalProperties *SettingsSchema            `json:"additionalProperties,omitempty"`
+	Scope                string                     `json:"scope,omitempty"`
+}
+
+// SettingsContribution returns the configuration contribution of the
+// settings of type T under the given section, such as "gopls", so that
+// the setting of a field named "gofumpt" is "gopls.gofumpt". Fields of
+// struct type are named with a dot, as described above; struct elements
+// of slices and maps have object schemas.
+func SettingsContribution[T any](title, section string) (*ConfigurationContribution, error) {
+	c := &ConfigurationContribution{Title: title, Properties: make(map[string]*SettingsSchema)}
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("settings of type %v are not a struct", t)
+	}
+	if err := settingsProperties(c.Properties, section, t); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// settingsProperties adds the schemas of the settings of the struct
+// type t, prefixed by prefix, to props.
+func settingsProperties(props map[string]*SettingsSchema, prefix string, t reflect.Type) error {
+	for _, f := range settingsFields(t) {
+		name := f.name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		ft := derefType(f.Type)
+		if ft.Kind() == reflect.Struct && f.Tag.Get("default") == "" {
+			if err := settingsProperties(props, name, ft); err != nil {
+				return err
+			}
+			continue
+		}
+		schema, err := settingsSchema(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		schema.Description = f.Tag.Get("description")
+		schema.Scope = f.Tag.Get("scope")
+		if def, ok := f.Tag.Lookup("default"); ok {
+			if schema.Default, err = settingsDefault(def, f.Type); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+		if enum, ok := f.Tag.Lookup("enum"); ok {
+			for _, v := range strings.Split(enum, ",") {
+				value, err := settingsDefault(v, f.Type)
+				if err != nil {
+					return fmt.Errorf("%s: enum: %v", name, err)
+				}
+				schema.Enum = append(schema.Enum, value)
+			}
+		}
+		props[name] = schema
+	}
+	return nil
+}
+
+// settingsSchema returns the schema of values of type t.
+func settingsSchema(t reflect.Type) (*SettingsSchema, error) {
+	t = derefType(t)
+	switch t.Kind() {
+	case reflect.Bool:
+		return &SettingsSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &SettingsSchema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &SettingsSchema{Type: "number"}, nil
+	case reflect.String:
+		return &SettingsSchema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := settingsSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &SettingsSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map key of type %v is not a string", t.Key())
+		}
+		values, err := settingsSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &SettingsSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		s := &SettingsSchema{Type: "object", Properties: make(map[string]*SettingsSchema)}
+		for _, f := range settingsFields(t) {
+			prop, err := settingsSchema(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", f.name, err)
+			}
+			prop.Description = f.Tag.Get("description")
+			s.Properties[f.name] = prop
+		}
+		return s, nil
+	case reflect.Interface:
+		return &SettingsSchema{}, nil // any value
+	}
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+// settingsDefault returns the value of the default (or enum) tag def of
+// a setting of type t.
+func settingsDefault(def string, t reflect.Type) (any, error) {
+	v := reflect.New(t)
+	err := json.Unmarshal([]byte(def), v.Interface())
+	if err != nil && derefType(t).Kind() == reflect.String {
+		data, _ := json.Marshal(def) // a bare string
+		err = json.Unmarshal(data, v.Interface())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %v", def, err)
+	}
+	return v.Elem().Interface(), nil
+}
+
+type settingsField struct {
+	reflect.StructField
+	name string
+}
+
+// settingsFields returns the fields of the struct type t that are
+// settings, with their names.
+func settingsFields(t reflect.Type) []settingsField {
+	var fields []settingsField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, settingsField{f, name})
+	}
+	return fields
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// DecodeSettings decodes into settings, a pointer to a struct with the
+// tags described above, a value of the configuration section of the
+// settings, as returned by a workspace/configuration request. The
+// settings absent from the value are set to their defaults. Settings
+// named with a dot, as in {"ui.completion.matcher": "fuzzy"}, are
+// decoded as nested objects.
+func DecodeSettings(value LSPAny, settings any) error {
+	v := reflect.ValueOf(settings)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("settings of type %T are not a pointer to a struct", settings)
+	}
+	if err := setDefaults(v.Elem()); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	if obj, ok := value.(map[string]any); ok {
+		value = expandDottedKeys(obj)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, settings)
+}
+
+// setDefaults sets the fields of the struct v to their defaults.
+func setDefaults(v reflect.Value) error {
+	for _, f := range settingsFields(v.Type()) {
+		fv := v.FieldByIndex(f.Index)
+		def, ok := f.Tag.Lookup("default")
+		if !ok {
+			if derefType(f.Type).Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						continue // no defaults for optional groups
+					}
+					fv = fv.Elem()
+				}
+				if err := setDefaults(fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		value, err := settingsDefault(def, f.Type)
+		if err != nil {
+			return fmt.Errorf("%s: %v", f.name, err)
+		}
+		fv.Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// expandDottedKeys returns obj with its keys containing dots, such as
+// "a.b", expanded to nested objects.
+func expandDottedKeys(obj map[string]any) map[string]any {
+	result := make(map[string]any, len(obj))
+	for k, v := range obj {
+		if nested, ok := v.(map[string]any); ok {
+			v = expandDottedKeys(nested)
+		}
+		m := result
+		parts := strings.Split(k, ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := m[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				m[part] = next
+			}
+			m = next
+		}
+		last := parts[len(parts)-1]
+		if existing, ok := m[last].(map[string]any); ok {
+			if nested, ok := v.(map[string]any); ok {
+				for k, v := range nested {
+					existing[k] = v
+				}
+				continue
+			}
+		}
+		m[last] = v
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+type testSettings struct {
+	Gofumpt bool     `json:"gofumpt" description:"Format with gofumpt." default:"true"`
+	Level   string   `json:"level" enum:"off,info,debug" default:"info" scope:"window"`
+	Flags   []string `json:"buildFlags"`
+	UI      struct {
+		Matcher string `json:"matcher" default:"fuzzy"`
+		Budget  int    `json:"budget" default:"100"`
+	} `json:"ui"`
+	Analyses map[string]bool `json:"analyses"`
+	internal int
+}
+
+func TestSettingsContribution(t *testing.T) {
+	c, err := lsp.SettingsContribution[testSettings]("Test", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{
+	"title": "Test",
+	"properties": {
+		"test.analyses": {
+			"type": "object",
+			"additionalProperties": {
+				"type": "boolean"
+			}
+		},
+		"test.buildFlags": {
+			"type": "array",
+			"items": {
+				"type": "string"
+			}
+		},
+		"test.gofumpt": {
+			"type": "boolean",
+			"description": "Format with gofumpt.",
+			"default": true
+		},
+		"test.level": {
+			"type": "string",
+			"default": "info",
+			"enum": [
+				"off",
+				"info",
+				"debug"
+			],
+			"scope": "window"
+		},
+		"test.ui.budget": {
+			"type": "integer",
+			"default": 100
+		},
+		"test.ui.matcher": {
+			"type": "string",
+			"default": "fuzzy"
+		}
+	}
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("Unexpected contribution (-want +got):\n%s", diff)
+	}
+
+	if _, err := lsp.SettingsContribution[struct {
+		Level int `default:"high"`
+	}]("Test", "test"); err == nil {
+		t.Error("Expected an error for an invalid default")
+	}
+}
+
+func TestDecodeSettings(t *testing.T) {
+	// As returned by workspace/configuration, with a dotted key.
+	var value any
+	if err := json.Unmarshal([]byte(`{"level": "debug", "ui.budget": 5, "analyses": {"unusedparams": true}}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	var got testSettings
+	if err := lsp.DecodeSettings(value, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := testSettings{Gofumpt: true, Level: "debug", Analyses: map[string]bool{"unusedparams": true}}
+	want.UI.Matcher, want.UI.Budget = "fuzzy", 5
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(testSettings{})); diff != "" {
+		t.Errorf("Unexpected settings (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import "sync"
+
+// SignatureHelpRetriggers preserve the signature that the user selected
+// among the overloads of a signature help, as the client re-triggers it
+// on each keystroke, rather than reset it to the signature that the
+// server finds best every time:
+//
+//	help := signatures(pkg, params.Position)
+//	r.Preserve(params, help)
+//	return help, nil
+//
+// They remember, for each document, the signature the server made active
+// last, so that they can tell whether the user selected another one.
+// A signature is identified by its label, as the signatures of a
+// re-triggered help may differ from those showing, as when an argument
+// typed rules out an overload. Its zero value is ready to use.
+type SignatureHelpRetriggers struct {
+	mu     sync.Mutex
+	chosen map[DocumentURI]string // label of the signature made active by the server
+}
+
+// Preserve makes the signature that the user selected in the signature
+// help that is showing the active signature of help, the signature help
+// about to be returned for params, if the request re-triggers the
+// signature help, and help has that signature. Otherwise, the active
+// signature of help is left alone. The client sends the signature help
+// that is showing only if it declares signatureHelp.contextSupport.
+func (r *SignatureHelpRetriggers) Preserve(params *SignatureHelpParams, help *SignatureHelp) {
+	uri := params.TextDocument.URI
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chosen, ok := r.chosen[uri]
+	label, active := activeSignature(help)
+	if !active {
+		delete(r.chosen, uri)
+		return
+	}
+	if r.chosen == nil {
+		r.chosen = make(map[DocumentURI]string)
+	}
+	r.chosen[uri] = label
+
+	c := params.Context
+	if c == nil || !c.IsRetrigger {
+		return
+	}
+	selected, showing := activeSignature(c.ActiveSignatureHelp)
+	if !showing || ok && selected == chosen {
+		return // the user did not select another signature
+	}
+	for i, sig := range help.Signatures {
+		if sig.Label == selected {
+			help.ActiveSignature = uint32(i)
+			return
+		}
+	}
+}
+
+// Forget forgets the signature made active in a document, as when it is
+// closed.
+func (r *SignatureHelpRetriggers) Forget(uri DocumentURI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.chosen, uri)
+}
+
+// activeSignature returns the label of the active signature of help, if
+// it has one.
+func activeSignature(help *SignatureHelp) (string, bool) {
+	if help == nil || len(help.Signatures) == 0 {
+		return "", false
+	}
+	i := help.ActiveSignature
+	if int(i) >= len(help.Signatures) {
+		i = 0 // as clients default to
+	}
+	return help.Signatures[i].Label, true
+}
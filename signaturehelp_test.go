@@ -0,0 +1,69 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestSignatureHelpRetriggers(t *testing.T) {
+	help := func(active uint32, labels ...string) *lsp.SignatureHelp {
+		h := &lsp.SignatureHelp{ActiveSignature: active}
+		for _, label := range labels {
+			h.Signatures = append(h.Signatures, lsp.SignatureInformation{Label: label})
+		}
+		return h
+	}
+	params := func(showing *lsp.SignatureHelp) *lsp.SignatureHelpParams {
+		p := &lsp.SignatureHelpParams{
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+			},
+		}
+		if showing != nil {
+			p.Context = &lsp.SignatureHelpContext{TriggerKind: lsp.SigContentChange, IsRetrigger: true, ActiveSignatureHelp: showing}
+		}
+		return p
+	}
+	var r lsp.SignatureHelpRetriggers
+
+	first := help(0, "f(a)", "f(a, b)", "f(a, b, c)")
+	r.Preserve(params(nil), first)
+	if first.ActiveSignature != 0 {
+		t.Fatalf("Expected the first help to be left alone, got %d", first.ActiveSignature)
+	}
+
+	// The user selects the third overload, then types.
+	next := help(0, "f(a)", "f(a, b)", "f(a, b, c)")
+	r.Preserve(params(help(2, "f(a)", "f(a, b)", "f(a, b, c)")), next)
+	if next.ActiveSignature != 2 {
+		t.Errorf("Expected the selected signature 2 to be preserved, got %d", next.ActiveSignature)
+	}
+
+	// An argument rules out the first overload.
+	next = help(0, "f(a, b)", "f(a, b, c)")
+	r.Preserve(params(help(2, "f(a)", "f(a, b)", "f(a, b, c)")), next)
+	if next.ActiveSignature != 1 {
+		t.Errorf("Expected the selected signature to be found at 1, got %d", next.ActiveSignature)
+	}
+
+	// The user did not select another signature than the server's
+	// choice, which now differs.
+	r.Preserve(params(nil), help(0, "g(x)", "g(x, y)"))
+	next = help(1, "g(x)", "g(x, y)")
+	r.Preserve(params(help(0, "g(x)", "g(x, y)")), next)
+	if next.ActiveSignature != 1 {
+		t.Errorf("Expected the choice of the server to be kept, got %d", next.ActiveSignature)
+	}
+
+	// The selected signature is gone.
+	next = help(0, "h()")
+	r.Preserve(params(help(1, "g(x)", "g(x, y)", "g(x, y, z)")), next)
+	if next.ActiveSignature != 0 {
+		t.Errorf("Expected the choice of the server for another function, got %d", next.ActiveSignature)
+	}
+}
@@ -0,0 +1,550 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+// This file reads and writes traces of the messages of a session in the
+// format of the output channel of a VS Code language client whose
+// "trace.server" setting is "verbose":
+//
+//	[Trace - 10:23:45 AM] Sending request 'textDocument/hover - (3)'.
+//	Params: {
+//	    "textDocument": {
+//	        "uri": "file:///home/user/a.go"
+//	    },
+//	    "position": {
+//	        "line": 2,
+//	        "character": 7
+//	    }
+//	}
+//
+//
+//	[Trace - 10:23:45 AM] Received response 'textDocument/hover - (3)' in 12ms.
+//	Result: null
+//
+// A trace takes the point of view of the client, which sends its own
+// messages and receives those of the server. Users paste such traces
+// into bug reports; a server may replay them with [ReplayTrace].
+//
+// Recent versions of VS Code prefix the lines of a trace with a date
+// and a level instead, as in "2026-05-02 10:23:45.123 [trace] Sending
+// request ...", which ReadTrace reads as well. Lines of other levels,
+// such as "[Info - 10:23:45 AM] ...", are ignored, as are messages
+// traced without their content (at the "messages" level).
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A TraceEntry is a message of a trace.
+type TraceEntry struct {
+	// Time is when the message was traced. Traces in the format of
+	// the output channel only hold the time of day.
+	Time time.Time
+	// FromServer reports whether the message was sent by the server.
+	FromServer bool
+	// Method is the method of the message, or, for a response, that of
+	// its request, if known.
+	Method string
+	// Duration is the time a request took, for a response.
+	Duration time.Duration
+	Message  jsonrpc2.Message
+}
+
+var (
+	traceHeader    = regexp.MustCompile(`^\[(\w+) - ([^\]]*)\] (.*)$`)
+	traceLogHeader = regexp.MustCompile(`^(\d{4}-\d\d-\d\d \d\d:\d\d:\d\d(?:\.\d+)?) \[(\w+)\] (.*)$`)
+	traceMessage   = regexp.MustCompile(`^(Sending|Received) (request|notification|response) '(.*?)(?: - \(([^)]*)\))?'(.*)$`)
+	traceOrphan    = regexp.MustCompile(`^Received response (\S+) without active response promise\.`)
+	traceDuration  = regexp.MustCompile(`(?:in|took) (\d+)ms`)
+	traceFailure   = regexp.MustCompile(`Request failed: (.*) \((-?\d+)\)\.$`)
+)
+
+// traceTimeLayouts are the layouts of the times of trace lines: those of
+// the output channel, in the locales that do and do not use AM/PM, and
+// that of recent versions.
+var traceTimeLayouts = []string{"3:04:05 PM", "15:04:05", "2006-01-02 15:04:05.999"}
+
+// ReadTrace reads the messages of a trace.
+func ReadTrace(r io.Reader) ([]TraceEntry, error) {
+	var (
+		entries []TraceEntry
+		header  string   // of the current trace line, if any
+		body    []string // the lines that follow it
+		when    time.Time
+		line    int // of the header
+	)
+	flush := func() error {
+		if header == "" {
+			return nil
+		}
+		entry, ok, err := parseTraceEntry(header, body)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		if ok {
+			entry.Time = when
+			entries = append(entries, entry)
+		}
+		header, body = "", nil
+		return nil
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<30)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		var level, clock, rest string
+		if m := traceHeader.FindStringSubmatch(text); m != nil {
+			level, clock, rest = m[1], m[2], m[3]
+		} else if m := traceLogHeader.FindStringSubmatch(text); m != nil {
+			level, clock, rest = m[2], m[1], m[3]
+		} else {
+			if header != "" {
+				body = append(body, text)
+			}
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(level, "trace") {
+			header, line, when = rest, n, time.Time{}
+			for _, layout := range traceTimeLayouts {
+				if t, err := time.ParseInLocation(layout, clock, time.Local); err == nil {
+					when = t
+					break
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseTraceEntry parses the trace of a message, whose header line
+// (without its time) and following lines are given. It reports false
+// for trace lines that are not about a message.
+func parseTraceEntry(header string, body []string) (TraceEntry, bool, error) {
+	var entry TraceEntry
+	var kind, id, rest string
+	if m := traceMessage.FindStringSubmatch(header); m != nil {
+		entry.FromServer = m[1] == "Received"
+		kind, entry.Method, id, rest = m[2], m[3], m[4], m[5]
+	} else if m := traceOrphan.FindStringSubmatch(header); m != nil {
+		entry.FromServer = true
+		kind, id, rest = "response", m[1], header
+	} else {
+		return entry, false, nil
+	}
+	if m := traceDuration.FindStringSubmatch(rest); m != nil {
+		ms, _ := strconv.ParseInt(m[1], 10, 64)
+		entry.Duration = time.Duration(ms) * time.Millisecond
+	}
+
+	var label string
+	data := strings.TrimSpace(strings.Join(body, "\n"))
+	switch data {
+	case "", "No parameters provided.", "No result returned.":
+		data = ""
+	default:
+		for _, l := range []string{"Params:", "Result:", "Error data:"} {
+			if after, ok := strings.CutPrefix(data, l); ok {
+				label, data = l, strings.TrimSpace(after)
+				break
+			}
+		}
+		if label == "" {
+			return entry, false, fmt.Errorf("unexpected text after %q", header)
+		}
+		if !json.Valid([]byte(data)) {
+			return entry, false, fmt.Errorf("invalid JSON in %s", strings.TrimSuffix(label, ":"))
+		}
+	}
+	var raw json.RawMessage
+	if data != "" {
+		raw = json.RawMessage(data)
+	}
+
+	switch kind {
+	case "notification":
+		entry.Message = &jsonrpc2.Request{Method: entry.Method, Params: raw}
+	case "request":
+		if id == "" {
+			return entry, false, fmt.Errorf("request %s without ID", entry.Method)
+		}
+		entry.Message = &jsonrpc2.Request{ID: parseTraceID(id), Method: entry.Method, Params: raw}
+	case "response":
+		if id == "" {
+			return entry, false, fmt.Errorf("response to %s without ID", entry.Method)
+		}
+		resp := &jsonrpc2.Response{ID: parseTraceID(id)}
+		var rerr *ResponseError
+		if m := traceFailure.FindStringSubmatch(rest); m != nil {
+			code, _ := strconv.ParseInt(m[2], 10, 64)
+			rerr = &ResponseError{Code: code, Message: m[1]}
+		} else if label == "Error data:" {
+			// The client traces its failures without their code and message.
+			rerr = NewError(UnknownErrorCode, "request failed")
+		}
+		switch {
+		case rerr != nil:
+			if label == "Error data:" {
+				rerr.Data = raw
+			}
+			resp.Error = rerr.wireError(rerr.Message)
+		case raw == nil:
+			resp.Result = json.RawMessage("null")
+		default:
+			resp.Result = raw
+		}
+		entry.Message = resp
+	}
+	return entry, true, nil
+}
+
+// parseTraceID parses an ID as traced, which does not distinguish
+// numbers from strings of digits.
+func parseTraceID(s string) jsonrpc2.ID {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return jsonrpc2.Int64ID(i)
+	}
+	return jsonrpc2.StringID(s)
+}
+
+// WriteTrace writes the messages of a trace, in the format of the output
+// channel of VS Code.
+func WriteTrace(w io.Writer, entries []TraceEntry) error {
+	var buf []byte
+	for _, e := range entries {
+		buf = appendTraceEntry(buf[:0], e)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendTraceEntry appends the trace of a message to dst.
+func appendTraceEntry(dst []byte, e TraceEntry) []byte {
+	dir := "Sending"
+	if e.FromServer {
+		dir = "Received"
+	}
+	dst = fmt.Appendf(dst, "[Trace - %s] ", e.Time.Format("3:04:05 PM"))
+	var label string // of the data
+	var data json.RawMessage
+	switch msg := e.Message.(type) {
+	case *jsonrpc2.Request:
+		if msg.IsCall() {
+			dst = fmt.Appendf(dst, "%s request '%s - (%v)'.\n", dir, msg.Method, msg.ID.Raw())
+		} else {
+			dst = fmt.Appendf(dst, "%s notification '%s'.\n", dir, msg.Method)
+		}
+		label, data = "Params: ", msg.Params
+		if len(data) == 0 {
+			label = "No parameters provided."
+		}
+	case *jsonrpc2.Response:
+		rerr, failed := ErrorFrom(msg.Error)
+		ms := e.Duration.Milliseconds()
+		if e.FromServer {
+			dst = fmt.Appendf(dst, "Received response '%s - (%v)' in %dms.", e.Method, msg.ID.Raw(), ms)
+			if failed {
+				dst = fmt.Appendf(dst, " Request failed: %s (%d).", rerr.Message, rerr.Code)
+			}
+		} else {
+			dst = fmt.Appendf(dst, "Sending response '%s - (%v)'. Processing request took %dms", e.Method, msg.ID.Raw(), ms)
+		}
+		dst = append(dst, '\n')
+		switch {
+		case failed:
+			if d, ok := rerr.Data.(json.RawMessage); ok {
+				label, data = "Error data: ", d
+			} else if rerr.Data != nil {
+				d, _ := json.Marshal(rerr.Data)
+				label, data = "Error data: ", d
+			}
+		case len(msg.Result) == 0 || string(msg.Result) == "null":
+			label = "No result returned."
+		default:
+			label, data = "Result: ", msg.Result
+		}
+	}
+	if label == "" {
+		return dst
+	}
+	dst = append(dst, label...)
+	if len(data) > 0 {
+		var indented bytes.Buffer
+		if json.Indent(&indented, data, "", "    ") == nil {
+			data = indented.Bytes()
+		}
+		dst = append(dst, data...)
+	}
+	return append(dst, "\n\n\n"...)
+}
+
+// A TraceRecorder records the messages of the connections of a server
+// as a trace, which users may read as they would that of VS Code, and
+// which [ReplayTrace] replays. It is installed through the framer of a
+// connection:
+//
+//	recorder := lsp.NewTraceRecorder(logFile)
+//	opts := jsonrpc2.ConnectionOptions{
+//		Framer:  recorder.Framer(nil),
+//		Handler: lsp.ServerHandler(server),
+//	}
+//
+// Errors writing the trace are reported through the event system, and
+// do not affect the connection.
+type TraceRecorder struct {
+	w   io.Writer
+	now func() time.Time
+
+	mu    sync.Mutex
+	buf   []byte
+	calls map[traceCall]traceStart // requests not yet answered
+}
+
+// A traceCall identifies a request by its sender and ID.
+type traceCall struct {
+	fromServer bool
+	id         jsonrpc2.ID
+}
+
+type traceStart struct {
+	method string
+	time   time.Time
+}
+
+// NewTraceRecorder returns a TraceRecorder that writes the trace to w.
+func NewTraceRecorder(w io.Writer) *TraceRecorder {
+	return &TraceRecorder{w: w, now: time.Now, calls: make(map[traceCall]traceStart)}
+}
+
+// Record records a message sent by the server, if fromServer is set, or
+// else by the client.
+func (t *TraceRecorder) Record(fromServer bool, msg jsonrpc2.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e := TraceEntry{Time: t.now(), FromServer: fromServer, Message: msg}
+	switch msg := msg.(type) {
+	case *jsonrpc2.Request:
+		e.Method = msg.Method
+		if msg.IsCall() {
+			t.calls[traceCall{fromServer, msg.ID}] = traceStart{msg.Method, e.Time}
+		}
+	case *jsonrpc2.Response:
+		call := traceCall{!fromServer, msg.ID}
+		if start, ok := t.calls[call]; ok {
+			delete(t.calls, call)
+			e.Method, e.Duration = start.method, e.Time.Sub(start.time)
+		}
+	}
+	t.buf = appendTraceEntry(t.buf[:0], e)
+	_, err := t.w.Write(t.buf)
+	return err
+}
+
+// Framer returns a framer that encodes messages as f does, and records
+// those read as sent by the client and those written as sent by the
+// server. If f is nil, [jsonrpc2.HeaderFramer] is used.
+func (t *TraceRecorder) Framer(f jsonrpc2.Framer) jsonrpc2.Framer {
+	if f == nil {
+		f = jsonrpc2.HeaderFramer()
+	}
+	return traceFramer{f, t}
+}
+
+type traceFramer struct {
+	framer   jsonrpc2.Framer
+	recorder *TraceRecorder
+}
+
+func (f traceFramer) Reader(r io.Reader) jsonrpc2.Reader {
+	return traceReader{f.framer.Reader(r), f.recorder}
+}
+
+func (f traceFramer) Writer(w io.Writer) jsonrpc2.Writer {
+	return traceWriter{f.framer.Writer(w), f.recorder}
+}
+
+func (f traceFramer) wrapped() jsonrpc2.Framer { return f.framer }
+
+func (f traceFramer) rewrap(framer jsonrpc2.Framer) jsonrpc2.Framer {
+	f.framer = framer
+	return f
+}
+
+type traceReader struct {
+	reader   jsonrpc2.Reader
+	recorder *TraceRecorder
+}
+
+func (r traceReader) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	msg, n, err := r.reader.Read(ctx)
+	if err == nil {
+		if rerr := r.recorder.Record(false, msg); rerr != nil {
+			event.Error(ctx, "trace recording failed", rerr)
+		}
+	}
+	return msg, n, err
+}
+
+type traceWriter struct {
+	writer   jsonrpc2.Writer
+	recorder *TraceRecorder
+}
+
+func (w traceWriter) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	n, err := w.writer.Write(ctx, msg)
+	if err == nil {
+		if rerr := w.recorder.Record(true, msg); rerr != nil {
+			event.Error(ctx, "trace recording failed", rerr)
+		}
+	}
+	return n, err
+}
+
+// ReplayTrace sends the messages the client sent in a trace to the
+// server at the other end of conn, in order. Like the client, it waits
+// for the response to a request only where the trace received it,
+// and, at the end, for those the trace did not receive. It returns the
+// responses of the server, in the order of the requests.
+//
+// The IDs of the requests are those chosen by conn, so the
+// cancellations of requests are translated to them. The requests of the
+// server are answered by the handler of conn, typically [TraceHandler].
+func ReplayTrace(ctx context.Context, conn *jsonrpc2.Connection, entries []TraceEntry) ([]*jsonrpc2.Response, error) {
+	type replayed struct {
+		call *jsonrpc2.AsyncCall
+		resp *jsonrpc2.Response
+	}
+	var (
+		order []*replayed
+		calls = make(map[string]*replayed) // by ID in the trace
+	)
+	await := func(r *replayed) error {
+		if r.resp != nil {
+			return nil
+		}
+		var result json.RawMessage
+		err := r.call.Await(ctx, &result)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.resp = &jsonrpc2.Response{ID: r.call.ID(), Result: result, Error: err}
+		return nil
+	}
+	for _, e := range entries {
+		switch msg := e.Message.(type) {
+		case *jsonrpc2.Request:
+			if e.FromServer {
+				continue
+			}
+			if msg.IsCall() {
+				r := &replayed{call: conn.Call(ctx, msg.Method, msg.Params)}
+				order = append(order, r)
+				calls[fmt.Sprint(msg.ID.Raw())] = r
+				continue
+			}
+			params := msg.Params
+			if msg.Method == "$/cancelRequest" {
+				var cancel struct {
+					ID any `json:"id"`
+				}
+				if UnmarshalJSON(params, &cancel) == nil {
+					if r, ok := calls[fmt.Sprint(cancel.ID)]; ok {
+						cancel.ID = r.call.ID().Raw()
+						params, _ = json.Marshal(cancel)
+					}
+				}
+			}
+			if err := conn.Notify(ctx, msg.Method, params); err != nil {
+				return nil, err
+			}
+		case *jsonrpc2.Response:
+			if !e.FromServer {
+				continue
+			}
+			if r, ok := calls[fmt.Sprint(msg.ID.Raw())]; ok {
+				if err := await(r); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	responses := make([]*jsonrpc2.Response, len(order))
+	for i, r := range order {
+		if err := await(r); err != nil {
+			return nil, err
+		}
+		responses[i] = r.resp
+	}
+	return responses, nil
+}
+
+// TraceHandler returns a handler that answers the requests of a server
+// with the responses the client sent in a trace to the requests of the
+// same method, in order. It fails other requests with MethodNotFound,
+// and ignores notifications.
+func TraceHandler(entries []TraceEntry) jsonrpc2.Handler {
+	var (
+		mu        sync.Mutex
+		responses = make(map[string][]*jsonrpc2.Response) // by method
+		methods   = make(map[string]string)               // of requests of the server, by ID
+	)
+	for _, e := range entries {
+		switch msg := e.Message.(type) {
+		case *jsonrpc2.Request:
+			if e.FromServer && msg.IsCall() {
+				methods[fmt.Sprint(msg.ID.Raw())] = msg.Method
+			}
+		case *jsonrpc2.Response:
+			if e.FromServer {
+				continue
+			}
+			method := e.Method
+			if method == "" {
+				method = methods[fmt.Sprint(msg.ID.Raw())]
+			}
+			responses[method] = append(responses[method], msg)
+		}
+	}
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		if !req.IsCall() {
+			return nil, nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		queue := responses[req.Method]
+		if len(queue) == 0 {
+			return nil, Errorf(MethodNotFound, "no response to %s in the trace", req.Method)
+		}
+		resp := queue[0]
+		responses[req.Method] = queue[1:]
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return resp.Result, nil
+	})
+}
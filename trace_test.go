@@ -0,0 +1,245 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// describeTrace returns a line describing each message of a trace.
+func describeTrace(t *testing.T, entries []lsp.TraceEntry) []string {
+	t.Helper()
+	compact := func(data json.RawMessage) string {
+		var buf bytes.Buffer
+		if len(data) > 0 {
+			if err := json.Compact(&buf, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return buf.String()
+	}
+	var lines []string
+	for _, e := range entries {
+		from := "client"
+		if e.FromServer {
+			from = "server"
+		}
+		switch msg := e.Message.(type) {
+		case *jsonrpc2.Request:
+			id := ""
+			if msg.IsCall() {
+				id = fmt.Sprintf(" (%v)", msg.ID.Raw())
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s%s %s", from, msg.Method, id, compact(msg.Params)))
+		case *jsonrpc2.Response:
+			result := compact(msg.Result)
+			if rerr, ok := lsp.ErrorFrom(msg.Error); ok {
+				data, _ := rerr.Data.(json.RawMessage)
+				result = fmt.Sprintf("error %d %q %s", rerr.Code, rerr.Message, compact(data))
+			}
+			lines = append(lines, fmt.Sprintf("%s: response to %s (%v) %s", from, e.Method, msg.ID.Raw(), result))
+		}
+	}
+	return lines
+}
+
+func TestReadTrace(t *testing.T) {
+	data, err := os.ReadFile("testdata/trace/vscode.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := lsp.ReadTrace(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`client: initialize (0) {"processId":4242,"rootUri":"file:///home/user/mod","capabilities":{}}`,
+		`server: response to initialize (0) {"capabilities":{"hoverProvider":true}}`,
+		`client: initialized {}`,
+		`client: textDocument/hover (1) {"textDocument":{"uri":"file:///home/user/mod/a.go"},"position":{"line":2,"character":7}}`,
+		`server: workspace/configuration (0) {"items":[{"section":"gopls"}]}`,
+		`client: response to workspace/configuration (0) [{"hoverKind":"FullDocumentation"}]`,
+		`server: response to textDocument/hover (1) {"contents":{"kind":"markdown","value":"FullDocumentation"}}`,
+		`client: textDocument/definition (2) `,
+		`server: response to textDocument/definition (2) error -32803 "no identifier found" {"reason":"it's not an identifier"}`,
+		`server: window/logMessage {"type":3,"message":"done"}`,
+	}
+	if diff := cmp.Diff(want, describeTrace(t, entries)); diff != "" {
+		t.Errorf("Unexpected trace (-want +got):\n%s", diff)
+	}
+	if got := entries[1].Duration; got != 25*time.Millisecond {
+		t.Errorf("Expected a duration of 25ms, got %v", got)
+	}
+	if got := entries[0].Time.Format(time.TimeOnly); got != "10:23:45" {
+		t.Errorf("Expected time 10:23:45, got %s", got)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := lsp.WriteTrace(&buf, entries); err != nil {
+			t.Fatal(err)
+		}
+		written := buf.String()
+		again, err := lsp.ReadTrace(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, describeTrace(t, again)); diff != "" {
+			t.Errorf("Unexpected trace after writing (-want +got):\n%s", diff)
+		}
+		// The messages of the client are written exactly as VS Code does.
+		for _, part := range []string{
+			"[Trace - 10:23:45 AM] Sending notification 'initialized'.\nParams: {}\n\n\n",
+			"[Trace - 10:23:47 AM] Sending request 'textDocument/definition - (2)'.\nNo parameters provided.\n\n\n",
+			"[Trace - 10:23:46 AM] Sending response 'workspace/configuration - (0)'. Processing request took 1ms\nResult: [\n    {\n        \"hoverKind\": \"FullDocumentation\"\n    }\n]\n\n\n",
+		} {
+			if !strings.Contains(written, part) {
+				t.Errorf("Expected the trace to contain %q, got:\n%s", part, written)
+			}
+		}
+	})
+
+	t.Run("LogOutputChannel", func(t *testing.T) {
+		const log = "2026-05-02 10:23:45.123 [info] Starting server\n" +
+			"2026-05-02 10:23:45.200 [trace] Sending request 'shutdown - (7)'.\n" +
+			"No parameters provided.\n\n\n" +
+			"2026-05-02 10:23:45.210 [trace] Received response 'shutdown - (7)' in 10ms.\n" +
+			"No result returned.\n\n\n"
+		entries, err := lsp.ReadTrace(strings.NewReader(log))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			`client: shutdown (7) `,
+			`server: response to shutdown (7) null`,
+		}
+		if diff := cmp.Diff(want, describeTrace(t, entries)); diff != "" {
+			t.Errorf("Unexpected trace (-want +got):\n%s", diff)
+		}
+		if got := entries[1].Time.Format(time.DateTime); got != "2026-05-02 10:23:45" {
+			t.Errorf("Expected time 2026-05-02 10:23:45, got %s", got)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		const log = "[Trace - 10:23:45 AM] Sending notification 'initialized'.\n" +
+			"Params: {\n\n\n"
+		if _, err := lsp.ReadTrace(strings.NewReader(log)); err == nil || !strings.HasPrefix(err.Error(), "line 1:") {
+			t.Errorf("Expected an error at line 1, got %v", err)
+		}
+	})
+}
+
+func TestReplayTrace(t *testing.T) {
+	ctx := context.Background()
+	data, err := os.ReadFile("testdata/trace/vscode.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := lsp.ReadTrace(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		server  *jsonrpc2.Connection
+		methods []string
+	)
+	handler := jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		methods = append(methods, req.Method)
+		switch req.Method {
+		case "initialize":
+			return json.RawMessage(`{"capabilities":{"hoverProvider":true}}`), nil
+		case "textDocument/hover":
+			var config []struct {
+				HoverKind string `json:"hoverKind"`
+			}
+			if err := lsp.Call(ctx, server, "workspace/configuration", &lsp.ConfigurationParams{}, &config); err != nil {
+				return nil, err
+			}
+			return map[string]any{"contents": lsp.MarkupContent{Kind: lsp.Markdown, Value: config[0].HoverKind}}, nil
+		case "textDocument/definition":
+			return nil, lsp.NewError(lsp.RequestFailed, "no identifier found")
+		}
+		return nil, nil
+	})
+	var client *jsonrpc2.Connection
+	client, server = connectPair(t, lsp.TraceHandler(entries), handler)
+
+	responses, err := lsp.ReplayTrace(ctx, client, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []lsp.TraceEntry
+	for _, resp := range responses {
+		got = append(got, lsp.TraceEntry{FromServer: true, Message: resp})
+	}
+	want := []string{
+		`server: response to  (1) {"capabilities":{"hoverProvider":true}}`,
+		`server: response to  (2) {"contents":{"kind":"markdown","value":"FullDocumentation"}}`,
+		`server: response to  (3) error -32803 "no identifier found" `,
+	}
+	if diff := cmp.Diff(want, describeTrace(t, got)); diff != "" {
+		t.Errorf("Unexpected responses (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"initialize", "initialized", "textDocument/hover", "textDocument/definition"}, methods); diff != "" {
+		t.Errorf("Unexpected messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestTraceRecorder(t *testing.T) {
+	ctx := context.Background()
+	var trace bytes.Buffer
+	recorder := lsp.NewTraceRecorder(&trace)
+
+	cc, sc := net.Pipe()
+	server, err := jsonrpc2.Dial(ctx, pipeDialer{sc}, jsonrpc2.ConnectionOptions{
+		Framer:  recorder.Framer(nil),
+		Handler: lsp.ServerHandler(hoverServer{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := jsonrpc2.Dial(ctx, pipeDialer{cc}, jsonrpc2.ConnectionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := &lsp.HoverParams{TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+	}}
+	if err := lsp.Call(ctx, client, "textDocument/hover", params, &lsp.Hover{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Notify(ctx, "$/unknown", nil); err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+	server.Wait()
+
+	entries, err := lsp.ReadTrace(&trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`client: textDocument/hover (1) {"textDocument":{"uri":"file:///a.go"},"position":{"line":0,"character":0}}`,
+		`server: response to textDocument/hover (1) {"contents":{"kind":"plaintext","value":"file:///a.go"},"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}}}`,
+		`client: $/unknown `,
+	}
+	if diff := cmp.Diff(want, describeTrace(t, entries)); diff != "" {
+		t.Errorf("Unexpected trace (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+// This file secures the streams of remote connections, by TLS, which may
+// authenticate clients by their certificates, and by a bearer token that
+// clients present before any LSP message.
+//
+// The token handshake takes the form of a header, as in the base
+// protocol: the client writes
+//
+//	Authorization: Bearer <token>\r\n
+//	\r\n
+//
+// and the server answers "200 OK\r\n" if it accepts the token, or
+// "401 Unauthorized\r\n" before closing the stream. The LSP messages
+// follow.
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TLSListener returns a Listener that accepts the TLS connections of l,
+// a network listener. The config must hold the certificate of the
+// server; to also authenticate clients by their certificates (mutual
+// TLS), it must require them and hold the authorities that sign them:
+//
+//	config := &tls.Config{
+//		Certificates: []tls.Certificate{serverCert},
+//		ClientAuth:   tls.RequireAndVerifyClientCert,
+//		ClientCAs:    clientCAs,
+//	}
+//
+// The TLS handshake happens on the first read or write of a stream, so
+// a connection whose client fails it ends before any message.
+func TLSListener(l net.Listener, config *tls.Config) Listener {
+	return &tlsListener{tls.NewListener(l, config)}
+}
+
+type tlsListener struct {
+	l net.Listener
+}
+
+func (l *tlsListener) Accept(ctx context.Context) (io.ReadWriteCloser, error) {
+	c, err := l.l.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return tlsConn{c.(*tls.Conn)}, nil
+}
+
+// A tlsConn is a TLS connection that is closed if its handshake fails,
+// so that its client does not wait for a reply to its first request.
+type tlsConn struct{ *tls.Conn }
+
+func (c tlsConn) Read(p []byte) (int, error) {
+	if err := c.Handshake(); err != nil {
+		c.Close()
+		return 0, err
+	}
+	return c.Conn.Read(p)
+}
+
+func (l *tlsListener) Close() error {
+	return l.l.Close()
+}
+
+// Dialer returns a Dialer that connects to the listener, trusting any
+// certificate, as only tests should.
+func (l *tlsListener) Dialer() Dialer {
+	addr := l.l.Addr()
+	return DialTLS(addr.Network(), addr.String(), &tls.Config{InsecureSkipVerify: true})
+}
+
+// DialTLS returns a Dialer that opens TLS connections to the network
+// address. For mutual TLS, the config must hold the certificate of the
+// client.
+func DialTLS(network, address string, config *tls.Config) Dialer {
+	return DialerFunc(func(ctx context.Context) (io.ReadWriteCloser, error) {
+		d := tls.Dialer{Config: config}
+		return d.DialContext(ctx, network, address)
+	})
+}
+
+// ErrUnauthorized is the error of dialing a server that rejected the
+// token of the client.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// DefaultHandshakeTimeout is the time a client has to complete the
+// token handshake.
+const DefaultHandshakeTimeout = 10 * time.Second
+
+// maxHandshake is the maximum length of the header of the handshake.
+const maxHandshake = 8 << 10
+
+// TokenDialer returns a Dialer that opens streams with d, and
+// authenticates them with the given bearer token.
+func TokenDialer(d Dialer, token string) Dialer {
+	return DialerFunc(func(ctx context.Context) (io.ReadWriteCloser, error) {
+		rwc, err := d.Dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := clientHandshake(rwc, token); err != nil {
+			rwc.Close()
+			return nil, err
+		}
+		return rwc, nil
+	})
+}
+
+func clientHandshake(rwc io.ReadWriter, token string) error {
+	if strings.ContainsAny(token, "\r\n") {
+		return fmt.Errorf("invalid token")
+	}
+	if _, err := fmt.Fprintf(rwc, "Authorization: Bearer %s\r\n\r\n", token); err != nil {
+		return err
+	}
+	status, err := readLine(rwc)
+	if err != nil {
+		return fmt.Errorf("reading the response to the handshake: %w", err)
+	}
+	if status != "200 OK" {
+		return fmt.Errorf("%w: %s", ErrUnauthorized, status)
+	}
+	return nil
+}
+
+// StaticToken returns a function validating a bearer token for
+// [TokenListener] that accepts the given token only. It compares tokens
+// in constant time.
+func StaticToken(token string) func(string) error {
+	return func(got string) error {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return ErrUnauthorized
+		}
+		return nil
+	}
+}
+
+// TokenListener returns a Listener that accepts the streams of l whose
+// clients authenticate with a bearer token that validate accepts, by
+// returning nil; see [TokenDialer]. Other streams are closed, so
+// unauthenticated clients never reach the LSP connection. The
+// handshakes of streams proceed concurrently, and fail after
+// DefaultHandshakeTimeout.
+//
+// Tokens only protect connections whose streams are encrypted, as by
+// [TLSListener].
+func TokenListener(l Listener, validate func(token string) error) Listener {
+	return &tokenListener{
+		l:        l,
+		validate: validate,
+		streams:  make(chan io.ReadWriteCloser),
+		closed:   make(chan struct{}),
+	}
+}
+
+type tokenListener struct {
+	l        Listener
+	validate func(string) error
+
+	start   sync.Once
+	streams chan io.ReadWriteCloser
+	closed  chan struct{}
+	once    sync.Once
+	err     error // of l.Accept, once streams is closed
+}
+
+func (l *tokenListener) Accept(ctx context.Context) (io.ReadWriteCloser, error) {
+	l.start.Do(func() { go l.run() })
+	select {
+	case rwc, ok := <-l.streams:
+		if !ok {
+			select {
+			case <-l.closed:
+				return nil, io.EOF
+			default:
+				return nil, l.err
+			}
+		}
+		return rwc, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-l.closed:
+		return nil, io.EOF
+	}
+}
+
+// run accepts the streams of l, and hands those that authenticate to
+// Accept.
+func (l *tokenListener) run() {
+	for {
+		rwc, err := l.l.Accept(context.Background())
+		if err != nil {
+			l.err = err
+			close(l.streams)
+			return
+		}
+		go func() {
+			if err := l.handshake(rwc); err != nil {
+				rwc.Close()
+				return
+			}
+			select {
+			case l.streams <- rwc:
+			case <-l.closed:
+				rwc.Close()
+			}
+		}()
+	}
+}
+
+// handshake authenticates the client of rwc.
+func (l *tokenListener) handshake(rwc io.ReadWriteCloser) error {
+	if c, ok := rwc.(net.Conn); ok {
+		c.SetDeadline(time.Now().Add(DefaultHandshakeTimeout))
+		defer c.SetDeadline(time.Time{})
+	} else {
+		timer := time.AfterFunc(DefaultHandshakeTimeout, func() { rwc.Close() })
+		defer timer.Stop()
+	}
+	var token string
+	n := 0
+	for {
+		line, err := readLine(rwc)
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			break
+		}
+		if n += len(line); n > maxHandshake {
+			return fmt.Errorf("handshake too large")
+		}
+		name, value, _ := strings.Cut(line, ":")
+		if strings.EqualFold(strings.TrimSpace(name), "Authorization") {
+			scheme, t, _ := strings.Cut(strings.TrimSpace(value), " ")
+			if strings.EqualFold(scheme, "Bearer") {
+				token = strings.TrimSpace(t)
+			}
+		}
+	}
+	if err := l.validate(token); err != nil {
+		io.WriteString(rwc, "401 Unauthorized\r\n")
+		return err
+	}
+	_, err := io.WriteString(rwc, "200 OK\r\n")
+	return err
+}
+
+func (l *tokenListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return l.l.Close()
+}
+
+// Dialer returns the Dialer of the underlying listener, whose streams
+// must then be authenticated, as by TokenDialer.
+func (l *tokenListener) Dialer() Dialer {
+	return l.l.Dialer()
+}
+
+// readLine reads a line ending with "\n" from r, without reading
+// further, and returns it without its line ending.
+func readLine(r io.Reader) (string, error) {
+	var line bytes.Buffer
+	var b [1]byte
+	for line.Len() <= maxHandshake {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			if err == io.EOF && line.Len() > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		if b[0] == '\n' {
+			return strings.TrimSuffix(line.String(), "\r"), nil
+		}
+		line.WriteByte(b[0])
+	}
+	return "", fmt.Errorf("line too long")
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/transport"
+)
+
+// newCert returns a certificate for localhost signed by parent, or
+// self-signed if parent is nil.
+func newCert(t *testing.T, name string, parent *tls.Certificate) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	issuer, signer := template, any(key)
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		issuer, signer = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// serve serves hoverServer on l until the end of the test.
+func serve(t *testing.T, l transport.Listener) {
+	srv, err := jsonrpc2.Serve(context.Background(), l, jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(hoverServer{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		l.Close()
+		srv.Wait()
+	})
+}
+
+func TestTLS(t *testing.T) {
+	ctx := context.Background()
+	ca := newCert(t, "ca", nil)
+	serverCert := newCert(t, "server", &ca)
+	clientCert := newCert(t, "client", &ca)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	nl, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serve(t, transport.TLSListener(nl, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    roots,
+	}))
+
+	for _, test := range []struct {
+		name   string
+		certs  []tls.Certificate
+		wantOK bool
+	}{
+		{"ClientCertificate", []tls.Certificate{clientCert}, true},
+		{"NoClientCertificate", nil, false},
+		{"UnknownAuthority", []tls.Certificate{newCert(t, "intruder", nil)}, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			d := transport.DialTLS("tcp", nl.Addr().String(), &tls.Config{
+				Certificates: test.certs,
+				RootCAs:      roots,
+				ServerName:   "localhost",
+			})
+			// The server closes the connection of a client it rejects as
+			// soon as it reads from it.
+			rwc, err := d.Dial(ctx)
+			if err == nil {
+				defer rwc.Close()
+				err = lsp.WriteMessage(rwc, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///a.go"},"position":{"line":0,"character":0}}}`))
+				if err == nil {
+					_, err = lsp.DecodeMessage(rwc)
+				}
+			}
+			if got := err == nil; got != test.wantOK {
+				t.Errorf("Expected success %v, got error %v", test.wantOK, err)
+			}
+		})
+	}
+}
+
+func TestTokenListener(t *testing.T) {
+	ctx := context.Background()
+	l := transport.NewStreamListener()
+	serve(t, transport.TokenListener(l, transport.StaticToken("s3cret")))
+
+	for _, test := range []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"Valid", "s3cret", nil},
+		{"Invalid", "guess", transport.ErrUnauthorized},
+		{"Empty", "", transport.ErrUnauthorized},
+		{"ValidAgain", "s3cret", nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn, err := transport.ConnectServer(ctx, transport.TokenDialer(l.Dialer(), test.token), nil)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("Expected error %v, got %v", test.want, err)
+			}
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			if got, err := hover(ctx, server); err != nil || got != "file:///a.go" {
+				t.Errorf("Expected file:///a.go, got %q, %v", got, err)
+			}
+		})
+	}
+
+	t.Run("NoHandshake", func(t *testing.T) {
+		// A client that speaks LSP right away is disconnected.
+		server, conn, err := transport.ConnectServer(ctx, l.Dialer(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if _, err := hover(ctx, server); err == nil {
+			t.Errorf("Expected the request to fail")
+		}
+	})
+}
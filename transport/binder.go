@@ -0,0 +1,47 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// ServerBinder returns a Binder that constructs a fresh Server for each
+// connection, by calling newServer with a Client that dispatches to the
+// peer and the Session of the connection, so that servers that keep
+// state per client, such as open documents, cannot share it by
+// accident:
+//
+//	srv, err := jsonrpc2.Serve(ctx, listener, transport.ServerBinder(
+//		func(ctx context.Context, client lsp.ClientCloser, session *lsp.Session) (lsp.Server, error) {
+//			return newServer(client), nil
+//		}))
+//
+// The session is also available to the handlers of the server through
+// [lsp.SessionFromContext]. If the server implements io.Closer, it is
+// closed when the connection ends. The options configure the client
+// dispatcher.
+func ServerBinder(newServer func(ctx context.Context, client lsp.ClientCloser, session *lsp.Session) (lsp.Server, error), opts ...lsp.DispatcherOption) Binder {
+	return BinderFunc(func(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+		session := lsp.NewSession()
+		server, err := newServer(ctx, lsp.ClientDispatcher(conn, opts...), session)
+		if err != nil {
+			return jsonrpc2.ConnectionOptions{}, err
+		}
+		if c, ok := server.(io.Closer); ok {
+			go func() {
+				conn.Wait()
+				c.Close()
+			}()
+		}
+		return jsonrpc2.ConnectionOptions{
+			Handler: lsp.WithSession(lsp.ServerHandler(server), session),
+		}, nil
+	})
+}
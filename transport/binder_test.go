@@ -0,0 +1,90 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/transport"
+)
+
+// countingServer is a stateful Server whose hovers count the hover
+// requests it received.
+type countingServer struct {
+	lsp.Server
+	session *lsp.Session
+	hovers  int
+	closed  chan struct{}
+}
+
+func (s *countingServer) Hover(ctx context.Context, _ *lsp.HoverParams) (*lsp.Hover, error) {
+	if lsp.SessionFromContext(ctx) != s.session {
+		return nil, fmt.Errorf("wrong session")
+	}
+	s.hovers++
+	return &lsp.Hover{Contents: lsp.MarkupContent{Value: fmt.Sprint(s.hovers)}}, nil
+}
+
+func (s *countingServer) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func TestServerBinder(t *testing.T) {
+	ctx := context.Background()
+	servers := make(chan *countingServer, 2)
+	l := transport.NewStreamListener()
+	defer l.Close()
+	_, err := jsonrpc2.Serve(ctx, l, transport.ServerBinder(func(_ context.Context, client lsp.ClientCloser, session *lsp.Session) (lsp.Server, error) {
+		if client == nil || session == nil {
+			return nil, fmt.Errorf("missing client or session")
+		}
+		s := &countingServer{session: session, closed: make(chan struct{})}
+		servers <- s
+		return s, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, conn1, err := transport.ConnectServer(ctx, l.Dialer(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, conn2, err := transport.ConnectServer(ctx, l.Dialer(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn2.Close()
+	for _, want := range []struct {
+		server lsp.Server
+		count  string
+	}{{first, "1"}, {first, "2"}, {second, "1"}, {first, "3"}} {
+		if got, err := hover(ctx, want.server); err != nil || got != want.count {
+			t.Errorf("Expected %s, got %q, %v", want.count, got, err)
+		}
+	}
+
+	s1, s2 := <-servers, <-servers
+	if s1.session == s2.session {
+		t.Errorf("Expected a session per connection")
+	}
+	conn1.Close()
+	select {
+	case <-s1.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the server of a closed connection to be closed")
+	}
+	select {
+	case <-s2.closed:
+		t.Errorf("Expected the server of an open connection to stay open")
+	default:
+	}
+}
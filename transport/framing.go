@@ -0,0 +1,51 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// Framings are the names of the framings of messages known to
+// [Framing], as for a command-line flag.
+var Framings = []string{"header", "ndjson"}
+
+// Framing returns the framer of messages with the given name: "header"
+// for the base protocol, with Content-Length fields, which is the
+// default of connections; and "ndjson" for newline-delimited JSON, as
+// by [lsp.NDJSONFramer].
+func Framing(name string) (jsonrpc2.Framer, error) {
+	switch name {
+	case "header", "":
+		return jsonrpc2.HeaderFramer(), nil
+	case "ndjson":
+		return lsp.NDJSONFramer{}, nil
+	}
+	return nil, fmt.Errorf("unknown framing %q", name)
+}
+
+// WithFramer returns a Binder that configures connections as b does,
+// with the framer f instead of any of its own, so that a transport may
+// use another framing than the base protocol:
+//
+//	srv, err := jsonrpc2.Serve(ctx, listener, transport.WithFramer(binder, lsp.NDJSONFramer{}))
+//
+// Both ends of a connection must use the same framing. The options of a
+// connection are a Binder too, so a client connection may use another
+// framing the same way.
+func WithFramer(b Binder, f jsonrpc2.Framer) Binder {
+	return BinderFunc(func(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+		opts, err := b.Bind(ctx, conn)
+		if err != nil {
+			return opts, err
+		}
+		opts.Framer = f
+		return opts, nil
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/transport"
+)
+
+func TestWithFramer(t *testing.T) {
+	ctx := context.Background()
+	framer, err := transport.Framing("ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := transport.NewStreamListener()
+	srv, err := jsonrpc2.Serve(ctx, l, transport.WithFramer(jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(hoverServer{})}, framer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := jsonrpc2.Dial(ctx, l.Dialer(), transport.WithFramer(jsonrpc2.ConnectionOptions{}, framer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := hover(ctx, lsp.ServerDispatcher(conn)); err != nil || got != "file:///a.go" {
+		t.Errorf("Expected file:///a.go, got %q, %v", got, err)
+	}
+	conn.Close()
+	l.Close()
+	if err := srv.Wait(); err != nil {
+		t.Errorf("Expected the server to stop cleanly, got %v", err)
+	}
+
+	if _, err := transport.Framing("xml"); err == nil {
+		t.Errorf("Expected an error for an unknown framing")
+	}
+}
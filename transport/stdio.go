@@ -0,0 +1,53 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// Stdio returns the stream of the standard input and output of the
+// process, over which a client communicates with the server it started.
+// Closing the stream closes both.
+func Stdio() io.ReadWriteCloser {
+	return stdioStream{os.Stdin, os.Stdout}
+}
+
+type stdioStream struct {
+	in  io.ReadCloser
+	out io.WriteCloser
+}
+
+func (s stdioStream) Read(p []byte) (int, error)  { return s.in.Read(p) }
+func (s stdioStream) Write(p []byte) (int, error) { return s.out.Write(p) }
+
+func (s stdioStream) Close() error {
+	return errors.Join(s.in.Close(), s.out.Close())
+}
+
+// ServeStdio returns a connection over the standard input and output of
+// the process, configured by binder, as a server started by its client
+// serves it:
+//
+//	conn, err := transport.ServeStdio(ctx, transport.ServerBinder(newServer))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = conn.Wait()
+//
+// Messages are framed by Content-Length headers, unless binder chooses
+// another framer. Nothing else may be written to the standard output,
+// which would corrupt the stream: logs go to the standard error, or to
+// the client with window/logMessage.
+func ServeStdio(ctx context.Context, binder Binder) (*jsonrpc2.Connection, error) {
+	return jsonrpc2.Dial(ctx, DialerFunc(func(context.Context) (io.ReadWriteCloser, error) {
+		return Stdio(), nil
+	}), binder)
+}
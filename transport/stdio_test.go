@@ -0,0 +1,48 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/transport"
+)
+
+func TestServeStdio(t *testing.T) {
+	if os.Getenv("LSP_TEST_STDIO_SERVER") != "" {
+		// Serve hover requests as a child process, until the client
+		// closes its end of the stream.
+		conn, err := transport.ServeStdio(context.Background(), jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(hoverServer{})})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn.Wait()
+		os.Exit(0)
+	}
+
+	ctx := context.Background()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	d := transport.CommandDialer(func() *exec.Cmd {
+		cmd := exec.Command(exe, "-test.run=^TestServeStdio$")
+		cmd.Env = append(os.Environ(), "LSP_TEST_STDIO_SERVER=1")
+		return cmd
+	})
+	server, conn, err := transport.ConnectServer(ctx, d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := hover(ctx, server); err != nil || got != "file:///a.go" {
+		t.Errorf("Expected file:///a.go, got %q, %v", got, err)
+	}
+	conn.Close()
+}
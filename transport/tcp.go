@@ -0,0 +1,106 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// A TCPListener is a Listener that accepts TCP connections, as a server
+// started with --port does:
+//
+//	l, err := transport.ListenTCP(ctx, port)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	srv, err := jsonrpc2.Serve(ctx, l, transport.ServerBinder(newServer))
+//	...
+//	l.Close()
+//	err = srv.Wait() // for the connections to end
+//
+// It keeps accepting connections on temporary errors, such as running
+// out of file descriptors, after a delay.
+type TCPListener struct {
+	l net.Listener
+}
+
+// ListenTCP returns a listener on the TCP address, which may be a port
+// alone, such as "4389", to listen on the loopback interface only.
+func ListenTCP(ctx context.Context, address string) (*TCPListener, error) {
+	var lc net.ListenConfig
+	l, err := lc.Listen(ctx, "tcp", tcpAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	return &TCPListener{l}, nil
+}
+
+// maxAcceptDelay is the maximum delay before accepting connections again
+// after a temporary error.
+const maxAcceptDelay = time.Second
+
+// Accept waits for the next connection.
+func (l *TCPListener) Accept(ctx context.Context) (io.ReadWriteCloser, error) {
+	var delay time.Duration
+	for {
+		c, err := l.l.Accept()
+		if err == nil {
+			return c, nil
+		}
+		if te, ok := err.(interface{ Temporary() bool }); !ok || !te.Temporary() {
+			return nil, err
+		}
+		delay = min(max(2*delay, 5*time.Millisecond), maxAcceptDelay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close stops the listener from accepting connections. The connections
+// already accepted are not closed.
+func (l *TCPListener) Close() error {
+	return l.l.Close()
+}
+
+// Addr returns the address of the listener, whose port is chosen by the
+// system if the port of the address was 0.
+func (l *TCPListener) Addr() net.Addr {
+	return l.l.Addr()
+}
+
+// Dialer returns a Dialer that connects to the listener.
+func (l *TCPListener) Dialer() Dialer {
+	return DialTCP(l.l.Addr().String())
+}
+
+// DialTCP returns a Dialer that opens TCP connections to the address,
+// which may be a port alone on the loopback interface. A server started
+// by a client with --socket dials the client at that port, and serves it
+// over the connection:
+//
+//	conn, err := jsonrpc2.Dial(ctx, transport.DialTCP(port), transport.ServerBinder(newServer))
+func DialTCP(address string) Dialer {
+	return DialerFunc(func(ctx context.Context) (io.ReadWriteCloser, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", tcpAddress(address))
+	})
+}
+
+// tcpAddress returns the address of a port alone, as editors pass it to
+// servers, on the loopback interface. Other addresses are returned
+// unchanged.
+func tcpAddress(address string) string {
+	if _, err := strconv.ParseUint(address, 10, 16); err == nil {
+		return net.JoinHostPort("127.0.0.1", address)
+	}
+	return address
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/transport"
+)
+
+func TestTCP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Listen", func(t *testing.T) {
+		l, err := transport.ListenTCP(ctx, "127.0.0.1:0")
+		if err != nil {
+			t.Skip(err)
+		}
+		srv, err := jsonrpc2.Serve(ctx, l, jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(hoverServer{})})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, port, _ := net.SplitHostPort(l.Addr().String())
+		for _, d := range []transport.Dialer{l.Dialer(), transport.DialTCP(port)} {
+			server, conn, err := transport.ConnectServer(ctx, d, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := hover(ctx, server); err != nil || got != "file:///a.go" {
+				t.Errorf("Expected file:///a.go, got %q, %v", got, err)
+			}
+			conn.Close()
+		}
+		l.Close()
+		if err := srv.Wait(); err != nil {
+			t.Errorf("Expected the server to stop cleanly, got %v", err)
+		}
+	})
+
+	t.Run("Socket", func(t *testing.T) {
+		// The client listens, and the server dials it, as with --socket.
+		nl, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skip(err)
+		}
+		defer nl.Close()
+		_, port, _ := net.SplitHostPort(nl.Addr().String())
+		go func() {
+			conn, err := jsonrpc2.Dial(ctx, transport.DialTCP(port), jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(hoverServer{})})
+			if err == nil {
+				conn.Wait()
+			}
+		}()
+		c, err := nl.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+		server, conn, err := transport.ConnectServer(ctx, transport.DialerFunc(func(context.Context) (io.ReadWriteCloser, error) {
+			return c, nil
+		}), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if got, err := hover(ctx, server); err != nil || got != "file:///a.go" {
+			t.Errorf("Expected file:///a.go, got %q, %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package transport provides the means to establish LSP connections
+// over arbitrary byte streams.
+//
+// Connections are made by the jsonrpc2 package: a [Dialer] opens a
+// stream to a server, a [Listener] accepts streams from clients, and a
+// [Binder] configures the connection over each stream, typically with
+// a handler returned by [lsp.ServerHandler] or [lsp.ClientHandler]; a
+// [ServerBinder] gives each connection a server of its own.
+// Custom transports, such as SSH tunnels or the standard streams of a
+// process in a container, only need to provide the streams, using
+// [DialerFunc], [CommandDialer] or a [StreamListener]. Streams that
+// leave the machine may be secured with [TLSListener] and
+// [TokenListener]. Messages are framed by the base protocol, unless
+// [WithFramer] selects another framing, such as newline-delimited JSON.
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+// A Dialer opens streams to a server.
+type Dialer = jsonrpc2.Dialer
+
+// A Listener accepts streams from clients.
+type Listener = jsonrpc2.Listener
+
+// A Binder configures the connection over each stream.
+type Binder = jsonrpc2.Binder
+
+// A DialerFunc is a Dialer that calls a function to open streams.
+type DialerFunc func(context.Context) (io.ReadWriteCloser, error)
+
+// Dial calls f(ctx).
+func (f DialerFunc) Dial(ctx context.Context) (io.ReadWriteCloser, error) {
+	return f(ctx)
+}
+
+// A BinderFunc is a Binder that calls a function to configure
+// connections.
+type BinderFunc func(context.Context, *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error)
+
+// Bind calls f(ctx, conn).
+func (f BinderFunc) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	return f(ctx, conn)
+}
+
+// ConnectServer dials a server, and returns a Server that dispatches
+// requests to it, as [lsp.ServerDispatcher] does, along with the
+// connection. Requests and notifications from the server are handled by
+// client.
+func ConnectServer(ctx context.Context, d Dialer, client lsp.Client, opts ...lsp.DispatcherOption) (lsp.Server, *jsonrpc2.Connection, error) {
+	conn, err := jsonrpc2.Dial(ctx, d, jsonrpc2.ConnectionOptions{Handler: lsp.ClientHandler(client)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return lsp.ServerDispatcher(conn, opts...), conn, nil
+}
+
+// CommandDialer returns a Dialer that starts the command returned by
+// newCmd, and communicates with it over its standard input and output.
+// For example, the following dialer runs a language server in a
+// container:
+//
+//	transport.CommandDialer(func() *exec.Cmd {
+//		return exec.Command("docker", "exec", "-i", container, "gopls")
+//	})
+//
+// Closing the stream closes the standard input of the command, and
+// waits for it to exit, killing it if it does not within a second.
+func CommandDialer(newCmd func() *exec.Cmd) Dialer {
+	return DialerFunc(func(ctx context.Context) (io.ReadWriteCloser, error) {
+		cmd := newCmd()
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return &cmdStream{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+	})
+}
+
+// commandGrace is how long a command may take to exit once its standard
+// input is closed.
+const commandGrace = time.Second
+
+type cmdStream struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (s *cmdStream) Read(p []byte) (int, error)  { return s.stdout.Read(p) }
+func (s *cmdStream) Write(p []byte) (int, error) { return s.stdin.Write(p) }
+
+func (s *cmdStream) Close() error {
+	s.closeOnce.Do(func() {
+		s.stdin.Close()
+		done := make(chan error, 1)
+		go func() { done <- s.cmd.Wait() }()
+		select {
+		case s.closeErr = <-done:
+		case <-time.After(commandGrace):
+			s.cmd.Process.Kill()
+			<-done
+		}
+	})
+	return s.closeErr
+}
+
+// ErrListenerClosed is returned by the methods of a closed
+// StreamListener.
+var ErrListenerClosed = errors.New("listener closed")
+
+// A StreamListener is a Listener that accepts the streams that are
+// handed to it, for transports that accept connections by other means,
+// such as the channels of an SSH server.
+type StreamListener struct {
+	streams chan io.ReadWriteCloser
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// NewStreamListener returns a new StreamListener.
+func NewStreamListener() *StreamListener {
+	return &StreamListener{
+		streams: make(chan io.ReadWriteCloser),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Add hands rwc to the listener, and waits until it is accepted, ctx
+// is done, or the listener is closed.
+func (l *StreamListener) Add(ctx context.Context, rwc io.ReadWriteCloser) error {
+	select {
+	case l.streams <- rwc:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.closed:
+		return ErrListenerClosed
+	}
+}
+
+// Accept waits for the next stream handed to the listener.
+func (l *StreamListener) Accept(ctx context.Context) (io.ReadWriteCloser, error) {
+	select {
+	case rwc := <-l.streams:
+		return rwc, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-l.closed:
+		return nil, io.EOF
+	}
+}
+
+// Close stops the listener from accepting streams.
+func (l *StreamListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+// Dialer returns a Dialer that connects to the listener in memory.
+func (l *StreamListener) Dialer() Dialer {
+	return DialerFunc(func(ctx context.Context) (io.ReadWriteCloser, error) {
+		client, server := net.Pipe()
+		if err := l.Add(ctx, server); err != nil {
+			client.Close()
+			server.Close()
+			return nil, err
+		}
+		return client, nil
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/transport"
+)
+
+// hoverServer is a Server that only implements Hover.
+type hoverServer struct{ lsp.Server }
+
+func (hoverServer) Hover(_ context.Context, params *lsp.HoverParams) (*lsp.Hover, error) {
+	return &lsp.Hover{Contents: lsp.MarkupContent{Kind: lsp.PlainText, Value: string(params.TextDocument.URI)}}, nil
+}
+
+func hover(ctx context.Context, server lsp.Server) (string, error) {
+	hover, err := server.Hover(ctx, &lsp.HoverParams{TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+	}})
+	if err != nil {
+		return "", err
+	}
+	return hover.Contents.Value, nil
+}
+
+func TestStreamListener(t *testing.T) {
+	ctx := context.Background()
+	l := transport.NewStreamListener()
+	srv, err := jsonrpc2.Serve(ctx, l, jsonrpc2.ConnectionOptions{Handler: lsp.ServerHandler(hoverServer{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		server, conn, err := transport.ConnectServer(ctx, l.Dialer(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := hover(ctx, server); err != nil || got != "file:///a.go" {
+			t.Errorf("Expected file:///a.go, got %q, %v", got, err)
+		}
+		conn.Close()
+	}
+
+	l.Close()
+	if err := srv.Wait(); err != nil {
+		t.Errorf("Expected the server to stop cleanly, got %v", err)
+	}
+	if _, err := l.Dialer().Dial(ctx); !errors.Is(err, transport.ErrListenerClosed) {
+		t.Errorf("Expected error %v, got %v", transport.ErrListenerClosed, err)
+	}
+}
+
+func TestCommandDialer(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat not found")
+	}
+	d := transport.CommandDialer(func() *exec.Cmd { return exec.Command("cat") })
+	rwc, err := d.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lsp.WriteMessage(rwc, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := lsp.DecodeMessage(rwc)
+	if err != nil || string(got) != `"ping"` {
+		t.Errorf(`Expected "ping", got %s, %v`, got, err)
+	}
+	if err := rwc.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if _, err := rwc.Read(make([]byte, 1)); err == nil {
+		t.Errorf("Expected reading a closed stream to fail")
+	}
+}
@@ -10,6 +10,42 @@ package lsp
 // https://github.com/microsoft/vscode-languageserver-node/blob/release/protocol/3.18.1/protocol/metaModel.json
 // LSP metaData.version = 3.18.0.
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *CallHierarchyClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *CallHierarchyRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *CallHierarchyRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *CallHierarchyRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // CancelParamsIdFromInt32 wraps a int32 value as a CancelParamsId union.
 func CancelParamsIdFromInt32(v int32) CancelParamsId {
 	return CancelParamsId{Int32: &v}
@@ -20,6 +56,265 @@ func CancelParamsIdFromString(v string) CancelParamsId {
 	return CancelParamsId{String: &v}
 }
 
+// GetGroupsOnLabel returns the GroupsOnLabel field of x, or the zero value if x is nil.
+func (x *ChangeAnnotationsSupportOptions) GetGroupsOnLabel() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.GroupsOnLabel
+}
+
+// GetExperimental returns the Experimental field of x, or the zero value if x is nil.
+func (x *ClientCapabilities) GetExperimental() any {
+	if x == nil {
+		var zero any
+		return zero
+	}
+	return x.Experimental
+}
+
+// GetGeneral returns the General field of x, or the zero value if x is nil.
+func (x *ClientCapabilities) GetGeneral() *GeneralClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.General
+}
+
+// GetNotebookDocument returns the NotebookDocument field of x, or the zero value if x is nil.
+func (x *ClientCapabilities) GetNotebookDocument() *NotebookDocumentClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.NotebookDocument
+}
+
+// GetTextDocument returns the TextDocument field of x, or the zero value if x is nil.
+func (x *ClientCapabilities) GetTextDocument() *TextDocumentClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.TextDocument
+}
+
+// GetWindow returns the Window field of x, or the zero value if x is nil.
+func (x *ClientCapabilities) GetWindow() *WindowClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.Window
+}
+
+// GetWorkspace returns the Workspace field of x, or the zero value if x is nil.
+func (x *ClientCapabilities) GetWorkspace() *WorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.Workspace
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientCodeActionKindOptions) GetValueSet() []CodeActionKind {
+	if x == nil {
+		var zero []CodeActionKind
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetCodeActionKind returns the CodeActionKind field of x, or the zero value if x is nil.
+func (x *ClientCodeActionLiteralOptions) GetCodeActionKind() *ClientCodeActionKindOptions {
+	if x == nil {
+		return nil
+	}
+	return &x.CodeActionKind
+}
+
+// GetProperties returns the Properties field of x, or the zero value if x is nil.
+func (x *ClientCodeActionResolveOptions) GetProperties() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.Properties
+}
+
+// GetProperties returns the Properties field of x, or the zero value if x is nil.
+func (x *ClientCodeLensResolveOptions) GetProperties() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.Properties
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemInsertTextModeOptions) GetValueSet() []InsertTextMode {
+	if x == nil {
+		var zero []InsertTextMode
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetCommitCharactersSupport returns the CommitCharactersSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetCommitCharactersSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.CommitCharactersSupport
+}
+
+// GetDeprecatedSupport returns the DeprecatedSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetDeprecatedSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DeprecatedSupport
+}
+
+// GetDocumentationFormat returns the DocumentationFormat field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetDocumentationFormat() []MarkupKind {
+	if x == nil {
+		var zero []MarkupKind
+		return zero
+	}
+	return x.DocumentationFormat
+}
+
+// GetInsertReplaceSupport returns the InsertReplaceSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetInsertReplaceSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.InsertReplaceSupport
+}
+
+// GetInsertTextModeSupport returns the InsertTextModeSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetInsertTextModeSupport() *ClientCompletionItemInsertTextModeOptions {
+	if x == nil {
+		return nil
+	}
+	return x.InsertTextModeSupport
+}
+
+// GetLabelDetailsSupport returns the LabelDetailsSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetLabelDetailsSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LabelDetailsSupport
+}
+
+// GetPreselectSupport returns the PreselectSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetPreselectSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.PreselectSupport
+}
+
+// GetResolveSupport returns the ResolveSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetResolveSupport() *ClientCompletionItemResolveOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ResolveSupport
+}
+
+// GetSnippetSupport returns the SnippetSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetSnippetSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.SnippetSupport
+}
+
+// GetTagSupport returns the TagSupport field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptions) GetTagSupport() *CompletionItemTagOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TagSupport
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemOptionsKind) GetValueSet() []CompletionItemKind {
+	if x == nil {
+		var zero []CompletionItemKind
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetProperties returns the Properties field of x, or the zero value if x is nil.
+func (x *ClientCompletionItemResolveOptions) GetProperties() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.Properties
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientDiagnosticsTagOptions) GetValueSet() []DiagnosticTag {
+	if x == nil {
+		var zero []DiagnosticTag
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientFoldingRangeKindOptions) GetValueSet() []FoldingRangeKind {
+	if x == nil {
+		var zero []FoldingRangeKind
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetCollapsedText returns the CollapsedText field of x, or the zero value if x is nil.
+func (x *ClientFoldingRangeOptions) GetCollapsedText() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.CollapsedText
+}
+
+// GetProperties returns the Properties field of x, or the zero value if x is nil.
+func (x *ClientInlayHintResolveOptions) GetProperties() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.Properties
+}
+
+// GetFull returns the Full field of x, or the zero value if x is nil.
+func (x *ClientSemanticTokensRequestOptions) GetFull() *ClientSemanticTokensRequestOptionsFull {
+	if x == nil {
+		return nil
+	}
+	return x.Full
+}
+
+// GetRange returns the Range field of x, or the zero value if x is nil.
+func (x *ClientSemanticTokensRequestOptions) GetRange() *ClientSemanticTokensRequestOptionsRange {
+	if x == nil {
+		return nil
+	}
+	return x.Range
+}
+
 // ClientSemanticTokensRequestOptionsFullFromBool wraps a bool value as a ClientSemanticTokensRequestOptionsFull union.
 func ClientSemanticTokensRequestOptionsFullFromBool(v bool) ClientSemanticTokensRequestOptionsFull {
 	return ClientSemanticTokensRequestOptionsFull{Bool: &v}
@@ -40,6 +335,304 @@ func ClientSemanticTokensRequestOptionsRangeFromLit_ClientSemanticTokensRequestO
 	return ClientSemanticTokensRequestOptionsRange{Lit_ClientSemanticTokensRequestOptions_range_Item1: &v}
 }
 
+// GetAdditionalPropertiesSupport returns the AdditionalPropertiesSupport field of x, or the zero value if x is nil.
+func (x *ClientShowMessageActionItemOptions) GetAdditionalPropertiesSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.AdditionalPropertiesSupport
+}
+
+// GetActiveParameterSupport returns the ActiveParameterSupport field of x, or the zero value if x is nil.
+func (x *ClientSignatureInformationOptions) GetActiveParameterSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ActiveParameterSupport
+}
+
+// GetDocumentationFormat returns the DocumentationFormat field of x, or the zero value if x is nil.
+func (x *ClientSignatureInformationOptions) GetDocumentationFormat() []MarkupKind {
+	if x == nil {
+		var zero []MarkupKind
+		return zero
+	}
+	return x.DocumentationFormat
+}
+
+// GetNoActiveParameterSupport returns the NoActiveParameterSupport field of x, or the zero value if x is nil.
+func (x *ClientSignatureInformationOptions) GetNoActiveParameterSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.NoActiveParameterSupport
+}
+
+// GetParameterInformation returns the ParameterInformation field of x, or the zero value if x is nil.
+func (x *ClientSignatureInformationOptions) GetParameterInformation() *ClientSignatureParameterInformationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ParameterInformation
+}
+
+// GetLabelOffsetSupport returns the LabelOffsetSupport field of x, or the zero value if x is nil.
+func (x *ClientSignatureParameterInformationOptions) GetLabelOffsetSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LabelOffsetSupport
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientSymbolKindOptions) GetValueSet() []SymbolKind {
+	if x == nil {
+		var zero []SymbolKind
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetProperties returns the Properties field of x, or the zero value if x is nil.
+func (x *ClientSymbolResolveOptions) GetProperties() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.Properties
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *ClientSymbolTagOptions) GetValueSet() []SymbolTag {
+	if x == nil {
+		var zero []SymbolTag
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetCodeActionLiteralSupport returns the CodeActionLiteralSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetCodeActionLiteralSupport() *ClientCodeActionLiteralOptions {
+	if x == nil {
+		return nil
+	}
+	return &x.CodeActionLiteralSupport
+}
+
+// GetDataSupport returns the DataSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetDataSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DataSupport
+}
+
+// GetDisabledSupport returns the DisabledSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetDisabledSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DisabledSupport
+}
+
+// GetDocumentationSupport returns the DocumentationSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetDocumentationSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DocumentationSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetHonorsChangeAnnotations returns the HonorsChangeAnnotations field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetHonorsChangeAnnotations() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.HonorsChangeAnnotations
+}
+
+// GetIsPreferredSupport returns the IsPreferredSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetIsPreferredSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.IsPreferredSupport
+}
+
+// GetResolveSupport returns the ResolveSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetResolveSupport() *ClientCodeActionResolveOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ResolveSupport
+}
+
+// GetTagSupport returns the TagSupport field of x, or the zero value if x is nil.
+func (x *CodeActionClientCapabilities) GetTagSupport() *CodeActionTagOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TagSupport
+}
+
+// GetCodeActionKinds returns the CodeActionKinds field of x, or the zero value if x is nil.
+func (x *CodeActionOptions) GetCodeActionKinds() []CodeActionKind {
+	if x == nil {
+		var zero []CodeActionKind
+		return zero
+	}
+	return x.CodeActionKinds
+}
+
+// GetDocumentation returns the Documentation field of x, or the zero value if x is nil.
+func (x *CodeActionOptions) GetDocumentation() []CodeActionKindDocumentation {
+	if x == nil {
+		var zero []CodeActionKindDocumentation
+		return zero
+	}
+	return x.Documentation
+}
+
+// GetResolveProvider returns the ResolveProvider field of x, or the zero value if x is nil.
+func (x *CodeActionOptions) GetResolveProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ResolveProvider
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *CodeActionOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *CodeActionTagOptions) GetValueSet() []CodeActionTag {
+	if x == nil {
+		var zero []CodeActionTag
+		return zero
+	}
+	return x.ValueSet
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *CodeLensClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetResolveSupport returns the ResolveSupport field of x, or the zero value if x is nil.
+func (x *CodeLensClientCapabilities) GetResolveSupport() *ClientCodeLensResolveOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ResolveSupport
+}
+
+// GetResolveProvider returns the ResolveProvider field of x, or the zero value if x is nil.
+func (x *CodeLensOptions) GetResolveProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ResolveProvider
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *CodeLensOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetRefreshSupport returns the RefreshSupport field of x, or the zero value if x is nil.
+func (x *CodeLensWorkspaceClientCapabilities) GetRefreshSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RefreshSupport
+}
+
+// GetCompletionItem returns the CompletionItem field of x, or the zero value if x is nil.
+func (x *CompletionClientCapabilities) GetCompletionItem() *ClientCompletionItemOptions {
+	if x == nil {
+		return nil
+	}
+	return &x.CompletionItem
+}
+
+// GetCompletionItemKind returns the CompletionItemKind field of x, or the zero value if x is nil.
+func (x *CompletionClientCapabilities) GetCompletionItemKind() *ClientCompletionItemOptionsKind {
+	if x == nil {
+		return nil
+	}
+	return x.CompletionItemKind
+}
+
+// GetCompletionList returns the CompletionList field of x, or the zero value if x is nil.
+func (x *CompletionClientCapabilities) GetCompletionList() *CompletionListCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.CompletionList
+}
+
+// GetContextSupport returns the ContextSupport field of x, or the zero value if x is nil.
+func (x *CompletionClientCapabilities) GetContextSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ContextSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *CompletionClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetInsertTextMode returns the InsertTextMode field of x, or the zero value if x is nil.
+func (x *CompletionClientCapabilities) GetInsertTextMode() InsertTextMode {
+	if x == nil {
+		var zero InsertTextMode
+		return zero
+	}
+	return x.InsertTextMode
+}
+
 // CompletionItemDefaultsEditRangeFromEditRangeWithInsertReplace wraps a EditRangeWithInsertReplace value as a CompletionItemDefaultsEditRange union.
 func CompletionItemDefaultsEditRangeFromEditRangeWithInsertReplace(v EditRangeWithInsertReplace) CompletionItemDefaultsEditRange {
 	return CompletionItemDefaultsEditRange{EditRangeWithInsertReplace: &v}
@@ -60,6 +653,15 @@ func CompletionItemDocumentationFromString(v string) CompletionItemDocumentation
 	return CompletionItemDocumentation{String: &v}
 }
 
+// GetValueSet returns the ValueSet field of x, or the zero value if x is nil.
+func (x *CompletionItemTagOptions) GetValueSet() []CompletionItemTag {
+	if x == nil {
+		var zero []CompletionItemTag
+		return zero
+	}
+	return x.ValueSet
+}
+
 // CompletionItemTextEditFromInsertReplaceEdit wraps a InsertReplaceEdit value as a CompletionItemTextEdit union.
 func CompletionItemTextEditFromInsertReplaceEdit(v InsertReplaceEdit) CompletionItemTextEdit {
 	return CompletionItemTextEdit{InsertReplaceEdit: &v}
@@ -70,6 +672,113 @@ func CompletionItemTextEditFromTextEdit(v TextEdit) CompletionItemTextEdit {
 	return CompletionItemTextEdit{TextEdit: &v}
 }
 
+// GetApplyKindSupport returns the ApplyKindSupport field of x, or the zero value if x is nil.
+func (x *CompletionListCapabilities) GetApplyKindSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ApplyKindSupport
+}
+
+// GetItemDefaults returns the ItemDefaults field of x, or the zero value if x is nil.
+func (x *CompletionListCapabilities) GetItemDefaults() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.ItemDefaults
+}
+
+// GetAllCommitCharacters returns the AllCommitCharacters field of x, or the zero value if x is nil.
+func (x *CompletionOptions) GetAllCommitCharacters() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.AllCommitCharacters
+}
+
+// GetCompletionItem returns the CompletionItem field of x, or the zero value if x is nil.
+func (x *CompletionOptions) GetCompletionItem() *ServerCompletionItemOptions {
+	if x == nil {
+		return nil
+	}
+	return x.CompletionItem
+}
+
+// GetResolveProvider returns the ResolveProvider field of x, or the zero value if x is nil.
+func (x *CompletionOptions) GetResolveProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ResolveProvider
+}
+
+// GetTriggerCharacters returns the TriggerCharacters field of x, or the zero value if x is nil.
+func (x *CompletionOptions) GetTriggerCharacters() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.TriggerCharacters
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *CompletionOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DeclarationClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetLinkSupport returns the LinkSupport field of x, or the zero value if x is nil.
+func (x *DeclarationClientCapabilities) GetLinkSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LinkSupport
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *DeclarationRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *DeclarationRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DeclarationRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // DefinitionFromLocation wraps a Location value as a Definition union.
 func DefinitionFromLocation(v Location) Definition {
 	return Definition{Location: &v}
@@ -80,6 +789,95 @@ func DefinitionFromLocations(v []Location) Definition {
 	return Definition{Locations: &v}
 }
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DefinitionClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetLinkSupport returns the LinkSupport field of x, or the zero value if x is nil.
+func (x *DefinitionClientCapabilities) GetLinkSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LinkSupport
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DefinitionOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetCodeDescriptionSupport returns the CodeDescriptionSupport field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetCodeDescriptionSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.CodeDescriptionSupport
+}
+
+// GetDataSupport returns the DataSupport field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetDataSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DataSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetMarkupMessageSupport returns the MarkupMessageSupport field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetMarkupMessageSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.MarkupMessageSupport
+}
+
+// GetRelatedDocumentSupport returns the RelatedDocumentSupport field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetRelatedDocumentSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RelatedDocumentSupport
+}
+
+// GetRelatedInformation returns the RelatedInformation field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetRelatedInformation() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RelatedInformation
+}
+
+// GetTagSupport returns the TagSupport field of x, or the zero value if x is nil.
+func (x *DiagnosticClientCapabilities) GetTagSupport() *ClientDiagnosticsTagOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TagSupport
+}
+
 // DiagnosticCodeFromInt32 wraps a int32 value as a DiagnosticCode union.
 func DiagnosticCodeFromInt32(v int32) DiagnosticCode {
 	return DiagnosticCode{Int32: &v}
@@ -100,6 +898,24 @@ func DiagnosticMessageFromString(v string) DiagnosticMessage {
 	return DiagnosticMessage{String: &v}
 }
 
+// GetRefreshSupport returns the RefreshSupport field of x, or the zero value if x is nil.
+func (x *DiagnosticWorkspaceClientCapabilities) GetRefreshSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RefreshSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DidChangeConfigurationClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
 // DidChangeConfigurationRegistrationOptionsSectionFromString wraps a string value as a DidChangeConfigurationRegistrationOptionsSection union.
 func DidChangeConfigurationRegistrationOptionsSectionFromString(v string) DidChangeConfigurationRegistrationOptionsSection {
 	return DidChangeConfigurationRegistrationOptionsSection{String: &v}
@@ -110,6 +926,60 @@ func DidChangeConfigurationRegistrationOptionsSectionFromStrings(v []string) Did
 	return DidChangeConfigurationRegistrationOptionsSection{Strings: &v}
 }
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DidChangeWatchedFilesClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetRelativePatternSupport returns the RelativePatternSupport field of x, or the zero value if x is nil.
+func (x *DidChangeWatchedFilesClientCapabilities) GetRelativePatternSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RelativePatternSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentColorClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *DocumentColorRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *DocumentColorRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DocumentColorRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // DocumentDiagnosticReportFromRelatedFullDocumentDiagnosticReport wraps a RelatedFullDocumentDiagnosticReport value as a DocumentDiagnosticReport union.
 func DocumentDiagnosticReportFromRelatedFullDocumentDiagnosticReport(v RelatedFullDocumentDiagnosticReport) DocumentDiagnosticReport {
 	return DocumentDiagnosticReport{RelatedFullDocumentDiagnosticReport: &v}
@@ -130,6 +1000,461 @@ func DocumentFilterFromTextDocumentFilter(v TextDocumentFilter) DocumentFilter {
 	return DocumentFilter{TextDocumentFilter: &v}
 }
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentFormattingClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DocumentFormattingOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentHighlightClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DocumentHighlightOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentLinkClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetTooltipSupport returns the TooltipSupport field of x, or the zero value if x is nil.
+func (x *DocumentLinkClientCapabilities) GetTooltipSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.TooltipSupport
+}
+
+// GetResolveProvider returns the ResolveProvider field of x, or the zero value if x is nil.
+func (x *DocumentLinkOptions) GetResolveProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ResolveProvider
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DocumentLinkOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentOnTypeFormattingClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetFirstTriggerCharacter returns the FirstTriggerCharacter field of x, or the zero value if x is nil.
+func (x *DocumentOnTypeFormattingOptions) GetFirstTriggerCharacter() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.FirstTriggerCharacter
+}
+
+// GetMoreTriggerCharacter returns the MoreTriggerCharacter field of x, or the zero value if x is nil.
+func (x *DocumentOnTypeFormattingOptions) GetMoreTriggerCharacter() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.MoreTriggerCharacter
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentRangeFormattingClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetRangesSupport returns the RangesSupport field of x, or the zero value if x is nil.
+func (x *DocumentRangeFormattingClientCapabilities) GetRangesSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RangesSupport
+}
+
+// GetRangesSupport returns the RangesSupport field of x, or the zero value if x is nil.
+func (x *DocumentRangeFormattingOptions) GetRangesSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RangesSupport
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DocumentRangeFormattingOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *DocumentSymbolClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetHierarchicalDocumentSymbolSupport returns the HierarchicalDocumentSymbolSupport field of x, or the zero value if x is nil.
+func (x *DocumentSymbolClientCapabilities) GetHierarchicalDocumentSymbolSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.HierarchicalDocumentSymbolSupport
+}
+
+// GetLabelSupport returns the LabelSupport field of x, or the zero value if x is nil.
+func (x *DocumentSymbolClientCapabilities) GetLabelSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LabelSupport
+}
+
+// GetSymbolKind returns the SymbolKind field of x, or the zero value if x is nil.
+func (x *DocumentSymbolClientCapabilities) GetSymbolKind() *ClientSymbolKindOptions {
+	if x == nil {
+		return nil
+	}
+	return x.SymbolKind
+}
+
+// GetTagSupport returns the TagSupport field of x, or the zero value if x is nil.
+func (x *DocumentSymbolClientCapabilities) GetTagSupport() *ClientSymbolTagOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TagSupport
+}
+
+// GetLabel returns the Label field of x, or the zero value if x is nil.
+func (x *DocumentSymbolOptions) GetLabel() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.Label
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *DocumentSymbolOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *ExecuteCommandClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetCommands returns the Commands field of x, or the zero value if x is nil.
+func (x *ExecuteCommandOptions) GetCommands() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.Commands
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *ExecuteCommandOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDidCreate returns the DidCreate field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetDidCreate() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DidCreate
+}
+
+// GetDidDelete returns the DidDelete field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetDidDelete() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DidDelete
+}
+
+// GetDidRename returns the DidRename field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetDidRename() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DidRename
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetWillCreate returns the WillCreate field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetWillCreate() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillCreate
+}
+
+// GetWillDelete returns the WillDelete field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetWillDelete() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillDelete
+}
+
+// GetWillRename returns the WillRename field of x, or the zero value if x is nil.
+func (x *FileOperationClientCapabilities) GetWillRename() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillRename
+}
+
+// GetDidCreate returns the DidCreate field of x, or the zero value if x is nil.
+func (x *FileOperationOptions) GetDidCreate() *FileOperationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DidCreate
+}
+
+// GetDidDelete returns the DidDelete field of x, or the zero value if x is nil.
+func (x *FileOperationOptions) GetDidDelete() *FileOperationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DidDelete
+}
+
+// GetDidRename returns the DidRename field of x, or the zero value if x is nil.
+func (x *FileOperationOptions) GetDidRename() *FileOperationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DidRename
+}
+
+// GetWillCreate returns the WillCreate field of x, or the zero value if x is nil.
+func (x *FileOperationOptions) GetWillCreate() *FileOperationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.WillCreate
+}
+
+// GetWillDelete returns the WillDelete field of x, or the zero value if x is nil.
+func (x *FileOperationOptions) GetWillDelete() *FileOperationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.WillDelete
+}
+
+// GetWillRename returns the WillRename field of x, or the zero value if x is nil.
+func (x *FileOperationOptions) GetWillRename() *FileOperationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.WillRename
+}
+
+// GetFilters returns the Filters field of x, or the zero value if x is nil.
+func (x *FileOperationRegistrationOptions) GetFilters() []FileOperationFilter {
+	if x == nil {
+		var zero []FileOperationFilter
+		return zero
+	}
+	return x.Filters
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *FoldingRangeClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetFoldingRange returns the FoldingRange field of x, or the zero value if x is nil.
+func (x *FoldingRangeClientCapabilities) GetFoldingRange() *ClientFoldingRangeOptions {
+	if x == nil {
+		return nil
+	}
+	return x.FoldingRange
+}
+
+// GetFoldingRangeKind returns the FoldingRangeKind field of x, or the zero value if x is nil.
+func (x *FoldingRangeClientCapabilities) GetFoldingRangeKind() *ClientFoldingRangeKindOptions {
+	if x == nil {
+		return nil
+	}
+	return x.FoldingRangeKind
+}
+
+// GetLineFoldingOnly returns the LineFoldingOnly field of x, or the zero value if x is nil.
+func (x *FoldingRangeClientCapabilities) GetLineFoldingOnly() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LineFoldingOnly
+}
+
+// GetRangeLimit returns the RangeLimit field of x, or the zero value if x is nil.
+func (x *FoldingRangeClientCapabilities) GetRangeLimit() uint32 {
+	if x == nil {
+		var zero uint32
+		return zero
+	}
+	return x.RangeLimit
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *FoldingRangeRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *FoldingRangeRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *FoldingRangeRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetRefreshSupport returns the RefreshSupport field of x, or the zero value if x is nil.
+func (x *FoldingRangeWorkspaceClientCapabilities) GetRefreshSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RefreshSupport
+}
+
+// GetMarkdown returns the Markdown field of x, or the zero value if x is nil.
+func (x *GeneralClientCapabilities) GetMarkdown() *MarkdownClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Markdown
+}
+
+// GetPositionEncodings returns the PositionEncodings field of x, or the zero value if x is nil.
+func (x *GeneralClientCapabilities) GetPositionEncodings() []PositionEncodingKind {
+	if x == nil {
+		var zero []PositionEncodingKind
+		return zero
+	}
+	return x.PositionEncodings
+}
+
+// GetRegularExpressions returns the RegularExpressions field of x, or the zero value if x is nil.
+func (x *GeneralClientCapabilities) GetRegularExpressions() *RegularExpressionsClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.RegularExpressions
+}
+
+// GetStaleRequestSupport returns the StaleRequestSupport field of x, or the zero value if x is nil.
+func (x *GeneralClientCapabilities) GetStaleRequestSupport() *StaleRequestSupportOptions {
+	if x == nil {
+		return nil
+	}
+	return x.StaleRequestSupport
+}
+
 // GlobPatternFromPattern wraps a Pattern value as a GlobPattern union.
 func GlobPatternFromPattern(v Pattern) GlobPattern {
 	return GlobPattern{Pattern: &v}
@@ -140,6 +1465,95 @@ func GlobPatternFromRelativePattern(v RelativePattern) GlobPattern {
 	return GlobPattern{RelativePattern: &v}
 }
 
+// GetContentFormat returns the ContentFormat field of x, or the zero value if x is nil.
+func (x *HoverClientCapabilities) GetContentFormat() []MarkupKind {
+	if x == nil {
+		var zero []MarkupKind
+		return zero
+	}
+	return x.ContentFormat
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *HoverClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *HoverOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *ImplementationClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetLinkSupport returns the LinkSupport field of x, or the zero value if x is nil.
+func (x *ImplementationClientCapabilities) GetLinkSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LinkSupport
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *ImplementationRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *ImplementationRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *ImplementationRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *InlayHintClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetResolveSupport returns the ResolveSupport field of x, or the zero value if x is nil.
+func (x *InlayHintClientCapabilities) GetResolveSupport() *ClientInlayHintResolveOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ResolveSupport
+}
+
 // InlayHintLabelPartTooltipFromMarkupContent wraps a MarkupContent value as a InlayHintLabelPartTooltip union.
 func InlayHintLabelPartTooltipFromMarkupContent(v MarkupContent) InlayHintLabelPartTooltip {
 	return InlayHintLabelPartTooltip{MarkupContent: &v}
@@ -150,6 +1564,42 @@ func InlayHintLabelPartTooltipFromString(v string) InlayHintLabelPartTooltip {
 	return InlayHintLabelPartTooltip{String: &v}
 }
 
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *InlayHintRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *InlayHintRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetResolveProvider returns the ResolveProvider field of x, or the zero value if x is nil.
+func (x *InlayHintRegistrationOptions) GetResolveProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ResolveProvider
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *InlayHintRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // InlayHintTooltipFromMarkupContent wraps a MarkupContent value as a InlayHintTooltip union.
 func InlayHintTooltipFromMarkupContent(v MarkupContent) InlayHintTooltip {
 	return InlayHintTooltip{MarkupContent: &v}
@@ -160,6 +1610,24 @@ func InlayHintTooltipFromString(v string) InlayHintTooltip {
 	return InlayHintTooltip{String: &v}
 }
 
+// GetRefreshSupport returns the RefreshSupport field of x, or the zero value if x is nil.
+func (x *InlayHintWorkspaceClientCapabilities) GetRefreshSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RefreshSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *InlineCompletionClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
 // InlineCompletionItemInsertTextFromString wraps a string value as a InlineCompletionItemInsertText union.
 func InlineCompletionItemInsertTextFromString(v string) InlineCompletionItemInsertText {
 	return InlineCompletionItemInsertText{String: &v}
@@ -170,6 +1638,15 @@ func InlineCompletionItemInsertTextFromStringValue(v StringValue) InlineCompleti
 	return InlineCompletionItemInsertText{StringValue: &v}
 }
 
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *InlineCompletionOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // InlineValueFromInlineValueEvaluatableExpression wraps a InlineValueEvaluatableExpression value as a InlineValue union.
 func InlineValueFromInlineValueEvaluatableExpression(v InlineValueEvaluatableExpression) InlineValue {
 	return InlineValue{InlineValueEvaluatableExpression: &v}
@@ -185,6 +1662,141 @@ func InlineValueFromInlineValueVariableLookup(v InlineValueVariableLookup) Inlin
 	return InlineValue{InlineValueVariableLookup: &v}
 }
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *InlineValueClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *InlineValueRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *InlineValueRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *InlineValueRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetRefreshSupport returns the RefreshSupport field of x, or the zero value if x is nil.
+func (x *InlineValueWorkspaceClientCapabilities) GetRefreshSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RefreshSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *LinkedEditingRangeClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *LinkedEditingRangeRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *LinkedEditingRangeRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *LinkedEditingRangeRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetAllowedTags returns the AllowedTags field of x, or the zero value if x is nil.
+func (x *MarkdownClientCapabilities) GetAllowedTags() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.AllowedTags
+}
+
+// GetParser returns the Parser field of x, or the zero value if x is nil.
+func (x *MarkdownClientCapabilities) GetParser() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.Parser
+}
+
+// GetVersion returns the Version field of x, or the zero value if x is nil.
+func (x *MarkdownClientCapabilities) GetVersion() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.Version
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *MonikerClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *MonikerRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *MonikerRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // NotebookCellTextDocumentFilterNotebookFromNotebookDocumentFilter wraps a NotebookDocumentFilter value as a NotebookCellTextDocumentFilterNotebook union.
 func NotebookCellTextDocumentFilterNotebookFromNotebookDocumentFilter(v NotebookDocumentFilter) NotebookCellTextDocumentFilterNotebook {
 	return NotebookCellTextDocumentFilterNotebook{NotebookDocumentFilter: &v}
@@ -195,6 +1807,14 @@ func NotebookCellTextDocumentFilterNotebookFromString(v string) NotebookCellText
 	return NotebookCellTextDocumentFilterNotebook{String: &v}
 }
 
+// GetSynchronization returns the Synchronization field of x, or the zero value if x is nil.
+func (x *NotebookDocumentClientCapabilities) GetSynchronization() *NotebookDocumentSyncClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.Synchronization
+}
+
 // NotebookDocumentFilterFromNotebookDocumentFilterNotebookType wraps a NotebookDocumentFilterNotebookType value as a NotebookDocumentFilter union.
 func NotebookDocumentFilterFromNotebookDocumentFilterNotebookType(v NotebookDocumentFilterNotebookType) NotebookDocumentFilter {
 	return NotebookDocumentFilter{NotebookDocumentFilterNotebookType: &v}
@@ -230,6 +1850,24 @@ func NotebookDocumentFilterWithNotebookNotebookFromString(v string) NotebookDocu
 	return NotebookDocumentFilterWithNotebookNotebook{String: &v}
 }
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *NotebookDocumentSyncClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetExecutionSummarySupport returns the ExecutionSummarySupport field of x, or the zero value if x is nil.
+func (x *NotebookDocumentSyncClientCapabilities) GetExecutionSummarySupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ExecutionSummarySupport
+}
+
 // NotebookDocumentSyncOptionsNotebookSelectorElemFromNotebookDocumentFilterWithCells wraps a NotebookDocumentFilterWithCells value as a NotebookDocumentSyncOptionsNotebookSelectorElem union.
 func NotebookDocumentSyncOptionsNotebookSelectorElemFromNotebookDocumentFilterWithCells(v NotebookDocumentFilterWithCells) NotebookDocumentSyncOptionsNotebookSelectorElem {
 	return NotebookDocumentSyncOptionsNotebookSelectorElem{NotebookDocumentFilterWithCells: &v}
@@ -240,6 +1878,167 @@ func NotebookDocumentSyncOptionsNotebookSelectorElemFromNotebookDocumentFilterWi
 	return NotebookDocumentSyncOptionsNotebookSelectorElem{NotebookDocumentFilterWithNotebook: &v}
 }
 
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *NotebookDocumentSyncRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetNotebookSelector returns the NotebookSelector field of x, or the zero value if x is nil.
+func (x *NotebookDocumentSyncRegistrationOptions) GetNotebookSelector() []NotebookDocumentSyncOptionsNotebookSelectorElem {
+	if x == nil {
+		var zero []NotebookDocumentSyncOptionsNotebookSelectorElem
+		return zero
+	}
+	return x.NotebookSelector
+}
+
+// GetSave returns the Save field of x, or the zero value if x is nil.
+func (x *NotebookDocumentSyncRegistrationOptions) GetSave() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.Save
+}
+
+// GetCodeDescriptionSupport returns the CodeDescriptionSupport field of x, or the zero value if x is nil.
+func (x *PublishDiagnosticsClientCapabilities) GetCodeDescriptionSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.CodeDescriptionSupport
+}
+
+// GetDataSupport returns the DataSupport field of x, or the zero value if x is nil.
+func (x *PublishDiagnosticsClientCapabilities) GetDataSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DataSupport
+}
+
+// GetRelatedInformation returns the RelatedInformation field of x, or the zero value if x is nil.
+func (x *PublishDiagnosticsClientCapabilities) GetRelatedInformation() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RelatedInformation
+}
+
+// GetTagSupport returns the TagSupport field of x, or the zero value if x is nil.
+func (x *PublishDiagnosticsClientCapabilities) GetTagSupport() *ClientDiagnosticsTagOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TagSupport
+}
+
+// GetVersionSupport returns the VersionSupport field of x, or the zero value if x is nil.
+func (x *PublishDiagnosticsClientCapabilities) GetVersionSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.VersionSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *ReferenceClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *ReferenceOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetEngine returns the Engine field of x, or the zero value if x is nil.
+func (x *RegularExpressionsClientCapabilities) GetEngine() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.Engine
+}
+
+// GetVersion returns the Version field of x, or the zero value if x is nil.
+func (x *RegularExpressionsClientCapabilities) GetVersion() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.Version
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *RenameClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetHonorsChangeAnnotations returns the HonorsChangeAnnotations field of x, or the zero value if x is nil.
+func (x *RenameClientCapabilities) GetHonorsChangeAnnotations() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.HonorsChangeAnnotations
+}
+
+// GetPrepareSupport returns the PrepareSupport field of x, or the zero value if x is nil.
+func (x *RenameClientCapabilities) GetPrepareSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.PrepareSupport
+}
+
+// GetPrepareSupportDefaultBehavior returns the PrepareSupportDefaultBehavior field of x, or the zero value if x is nil.
+func (x *RenameClientCapabilities) GetPrepareSupportDefaultBehavior() PrepareSupportDefaultBehavior {
+	if x == nil || x.PrepareSupportDefaultBehavior == nil {
+		var zero PrepareSupportDefaultBehavior
+		return zero
+	}
+	return *x.PrepareSupportDefaultBehavior
+}
+
+// GetPrepareProvider returns the PrepareProvider field of x, or the zero value if x is nil.
+func (x *RenameOptions) GetPrepareProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.PrepareProvider
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *RenameOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // ResultTextDocumentInlineCompletionFromInlineCompletionItems wraps a []InlineCompletionItem value as a ResultTextDocumentInlineCompletion union.
 func ResultTextDocumentInlineCompletionFromInlineCompletionItems(v []InlineCompletionItem) ResultTextDocumentInlineCompletion {
 	return ResultTextDocumentInlineCompletion{InlineCompletionItems: &v}
@@ -250,6 +2049,149 @@ func ResultTextDocumentInlineCompletionFromInlineCompletionList(v InlineCompleti
 	return ResultTextDocumentInlineCompletion{InlineCompletionList: &v}
 }
 
+// GetIncludeText returns the IncludeText field of x, or the zero value if x is nil.
+func (x *SaveOptions) GetIncludeText() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.IncludeText
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *SelectionRangeClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *SelectionRangeRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *SelectionRangeRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *SelectionRangeRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetAugmentsSyntaxTokens returns the AugmentsSyntaxTokens field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetAugmentsSyntaxTokens() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.AugmentsSyntaxTokens
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetFormats returns the Formats field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetFormats() []TokenFormat {
+	if x == nil {
+		var zero []TokenFormat
+		return zero
+	}
+	return x.Formats
+}
+
+// GetMultilineTokenSupport returns the MultilineTokenSupport field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetMultilineTokenSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.MultilineTokenSupport
+}
+
+// GetOverlappingTokenSupport returns the OverlappingTokenSupport field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetOverlappingTokenSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.OverlappingTokenSupport
+}
+
+// GetRequests returns the Requests field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetRequests() *ClientSemanticTokensRequestOptions {
+	if x == nil {
+		return nil
+	}
+	return &x.Requests
+}
+
+// GetServerCancelSupport returns the ServerCancelSupport field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetServerCancelSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ServerCancelSupport
+}
+
+// GetTokenModifiers returns the TokenModifiers field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetTokenModifiers() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.TokenModifiers
+}
+
+// GetTokenTypes returns the TokenTypes field of x, or the zero value if x is nil.
+func (x *SemanticTokensClientCapabilities) GetTokenTypes() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.TokenTypes
+}
+
+// GetTokenModifiers returns the TokenModifiers field of x, or the zero value if x is nil.
+func (x *SemanticTokensLegend) GetTokenModifiers() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.TokenModifiers
+}
+
+// GetTokenTypes returns the TokenTypes field of x, or the zero value if x is nil.
+func (x *SemanticTokensLegend) GetTokenTypes() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.TokenTypes
+}
+
 // SemanticTokensOptionsFullFromBool wraps a bool value as a SemanticTokensOptionsFull union.
 func SemanticTokensOptionsFullFromBool(v bool) SemanticTokensOptionsFull {
 	return SemanticTokensOptionsFull{Bool: &v}
@@ -270,6 +2212,356 @@ func SemanticTokensOptionsRangeFromPRangeESemanticTokensOptions(v PRangeESemanti
 	return SemanticTokensOptionsRange{PRangeESemanticTokensOptions: &v}
 }
 
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *SemanticTokensRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetFull returns the Full field of x, or the zero value if x is nil.
+func (x *SemanticTokensRegistrationOptions) GetFull() *SemanticTokensOptionsFull {
+	if x == nil {
+		return nil
+	}
+	return x.Full
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *SemanticTokensRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetLegend returns the Legend field of x, or the zero value if x is nil.
+func (x *SemanticTokensRegistrationOptions) GetLegend() *SemanticTokensLegend {
+	if x == nil {
+		return nil
+	}
+	return &x.Legend
+}
+
+// GetRange returns the Range field of x, or the zero value if x is nil.
+func (x *SemanticTokensRegistrationOptions) GetRange() *SemanticTokensOptionsRange {
+	if x == nil {
+		return nil
+	}
+	return x.Range
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *SemanticTokensRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetRefreshSupport returns the RefreshSupport field of x, or the zero value if x is nil.
+func (x *SemanticTokensWorkspaceClientCapabilities) GetRefreshSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RefreshSupport
+}
+
+// GetCallHierarchyProvider returns the CallHierarchyProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetCallHierarchyProvider() *CallHierarchyRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.CallHierarchyProvider
+}
+
+// GetCodeActionProvider returns the CodeActionProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetCodeActionProvider() *CodeActionOptions {
+	if x == nil {
+		return nil
+	}
+	return x.CodeActionProvider
+}
+
+// GetCodeLensProvider returns the CodeLensProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetCodeLensProvider() *CodeLensOptions {
+	if x == nil {
+		return nil
+	}
+	return x.CodeLensProvider
+}
+
+// GetColorProvider returns the ColorProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetColorProvider() *DocumentColorRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ColorProvider
+}
+
+// GetCompletionProvider returns the CompletionProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetCompletionProvider() *CompletionOptions {
+	if x == nil {
+		return nil
+	}
+	return x.CompletionProvider
+}
+
+// GetDeclarationProvider returns the DeclarationProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDeclarationProvider() *DeclarationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DeclarationProvider
+}
+
+// GetDefinitionProvider returns the DefinitionProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDefinitionProvider() *DefinitionOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DefinitionProvider
+}
+
+// GetDiagnosticProvider returns the DiagnosticProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDiagnosticProvider() *ServerCapabilitiesDiagnosticProvider {
+	if x == nil {
+		return nil
+	}
+	return x.DiagnosticProvider
+}
+
+// GetDocumentFormattingProvider returns the DocumentFormattingProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDocumentFormattingProvider() *DocumentFormattingOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentFormattingProvider
+}
+
+// GetDocumentHighlightProvider returns the DocumentHighlightProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDocumentHighlightProvider() *DocumentHighlightOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentHighlightProvider
+}
+
+// GetDocumentLinkProvider returns the DocumentLinkProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDocumentLinkProvider() *DocumentLinkOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentLinkProvider
+}
+
+// GetDocumentOnTypeFormattingProvider returns the DocumentOnTypeFormattingProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDocumentOnTypeFormattingProvider() *DocumentOnTypeFormattingOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentOnTypeFormattingProvider
+}
+
+// GetDocumentRangeFormattingProvider returns the DocumentRangeFormattingProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDocumentRangeFormattingProvider() *DocumentRangeFormattingOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentRangeFormattingProvider
+}
+
+// GetDocumentSymbolProvider returns the DocumentSymbolProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetDocumentSymbolProvider() *DocumentSymbolOptions {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentSymbolProvider
+}
+
+// GetExecuteCommandProvider returns the ExecuteCommandProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetExecuteCommandProvider() *ExecuteCommandOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ExecuteCommandProvider
+}
+
+// GetExperimental returns the Experimental field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetExperimental() any {
+	if x == nil {
+		var zero any
+		return zero
+	}
+	return x.Experimental
+}
+
+// GetFoldingRangeProvider returns the FoldingRangeProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetFoldingRangeProvider() *FoldingRangeRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.FoldingRangeProvider
+}
+
+// GetHoverProvider returns the HoverProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetHoverProvider() *HoverOptions {
+	if x == nil {
+		return nil
+	}
+	return x.HoverProvider
+}
+
+// GetImplementationProvider returns the ImplementationProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetImplementationProvider() *ImplementationRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ImplementationProvider
+}
+
+// GetInlayHintProvider returns the InlayHintProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetInlayHintProvider() *InlayHintRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.InlayHintProvider
+}
+
+// GetInlineCompletionProvider returns the InlineCompletionProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetInlineCompletionProvider() *InlineCompletionOptions {
+	if x == nil {
+		return nil
+	}
+	return x.InlineCompletionProvider
+}
+
+// GetInlineValueProvider returns the InlineValueProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetInlineValueProvider() *InlineValueRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.InlineValueProvider
+}
+
+// GetLinkedEditingRangeProvider returns the LinkedEditingRangeProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetLinkedEditingRangeProvider() *LinkedEditingRangeRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.LinkedEditingRangeProvider
+}
+
+// GetMonikerProvider returns the MonikerProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetMonikerProvider() *MonikerRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.MonikerProvider
+}
+
+// GetNotebookDocumentSync returns the NotebookDocumentSync field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetNotebookDocumentSync() *NotebookDocumentSyncRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.NotebookDocumentSync
+}
+
+// GetPositionEncoding returns the PositionEncoding field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetPositionEncoding() PositionEncodingKind {
+	if x == nil || x.PositionEncoding == nil {
+		var zero PositionEncodingKind
+		return zero
+	}
+	return *x.PositionEncoding
+}
+
+// GetReferencesProvider returns the ReferencesProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetReferencesProvider() *ReferenceOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ReferencesProvider
+}
+
+// GetRenameProvider returns the RenameProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetRenameProvider() *RenameOptions {
+	if x == nil {
+		return nil
+	}
+	return x.RenameProvider
+}
+
+// GetSelectionRangeProvider returns the SelectionRangeProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetSelectionRangeProvider() *SelectionRangeRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.SelectionRangeProvider
+}
+
+// GetSemanticTokensProvider returns the SemanticTokensProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetSemanticTokensProvider() *SemanticTokensRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.SemanticTokensProvider
+}
+
+// GetSignatureHelpProvider returns the SignatureHelpProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetSignatureHelpProvider() *SignatureHelpOptions {
+	if x == nil {
+		return nil
+	}
+	return x.SignatureHelpProvider
+}
+
+// GetTextDocumentSync returns the TextDocumentSync field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetTextDocumentSync() *TextDocumentSyncOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TextDocumentSync
+}
+
+// GetTypeDefinitionProvider returns the TypeDefinitionProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetTypeDefinitionProvider() *TypeDefinitionRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TypeDefinitionProvider
+}
+
+// GetTypeHierarchyProvider returns the TypeHierarchyProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetTypeHierarchyProvider() *TypeHierarchyRegistrationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TypeHierarchyProvider
+}
+
+// GetWorkspace returns the Workspace field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetWorkspace() *WorkspaceOptions {
+	if x == nil {
+		return nil
+	}
+	return x.Workspace
+}
+
+// GetWorkspaceSymbolProvider returns the WorkspaceSymbolProvider field of x, or the zero value if x is nil.
+func (x *ServerCapabilities) GetWorkspaceSymbolProvider() *WorkspaceSymbolOptions {
+	if x == nil {
+		return nil
+	}
+	return x.WorkspaceSymbolProvider
+}
+
 // ServerCapabilitiesDiagnosticProviderFromDiagnosticOptions wraps a DiagnosticOptions value as a ServerCapabilitiesDiagnosticProvider union.
 func ServerCapabilitiesDiagnosticProviderFromDiagnosticOptions(v DiagnosticOptions) ServerCapabilitiesDiagnosticProvider {
 	return ServerCapabilitiesDiagnosticProvider{DiagnosticOptions: &v}
@@ -280,6 +2572,85 @@ func ServerCapabilitiesDiagnosticProviderFromDiagnosticRegistrationOptions(v Dia
 	return ServerCapabilitiesDiagnosticProvider{DiagnosticRegistrationOptions: &v}
 }
 
+// GetLabelDetailsSupport returns the LabelDetailsSupport field of x, or the zero value if x is nil.
+func (x *ServerCompletionItemOptions) GetLabelDetailsSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LabelDetailsSupport
+}
+
+// GetSupport returns the Support field of x, or the zero value if x is nil.
+func (x *ShowDocumentClientCapabilities) GetSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.Support
+}
+
+// GetMessageActionItem returns the MessageActionItem field of x, or the zero value if x is nil.
+func (x *ShowMessageRequestClientCapabilities) GetMessageActionItem() *ClientShowMessageActionItemOptions {
+	if x == nil {
+		return nil
+	}
+	return x.MessageActionItem
+}
+
+// GetContextSupport returns the ContextSupport field of x, or the zero value if x is nil.
+func (x *SignatureHelpClientCapabilities) GetContextSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ContextSupport
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *SignatureHelpClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetSignatureInformation returns the SignatureInformation field of x, or the zero value if x is nil.
+func (x *SignatureHelpClientCapabilities) GetSignatureInformation() *ClientSignatureInformationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.SignatureInformation
+}
+
+// GetRetriggerCharacters returns the RetriggerCharacters field of x, or the zero value if x is nil.
+func (x *SignatureHelpOptions) GetRetriggerCharacters() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.RetriggerCharacters
+}
+
+// GetTriggerCharacters returns the TriggerCharacters field of x, or the zero value if x is nil.
+func (x *SignatureHelpOptions) GetTriggerCharacters() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.TriggerCharacters
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *SignatureHelpOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
 // SignatureInformationDocumentationFromMarkupContent wraps a MarkupContent value as a SignatureInformationDocumentation union.
 func SignatureInformationDocumentationFromMarkupContent(v MarkupContent) SignatureInformationDocumentation {
 	return SignatureInformationDocumentation{MarkupContent: &v}
@@ -290,6 +2661,289 @@ func SignatureInformationDocumentationFromString(v string) SignatureInformationD
 	return SignatureInformationDocumentation{String: &v}
 }
 
+// GetCancel returns the Cancel field of x, or the zero value if x is nil.
+func (x *StaleRequestSupportOptions) GetCancel() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.Cancel
+}
+
+// GetRetryOnContentModified returns the RetryOnContentModified field of x, or the zero value if x is nil.
+func (x *StaleRequestSupportOptions) GetRetryOnContentModified() []string {
+	if x == nil {
+		var zero []string
+		return zero
+	}
+	return x.RetryOnContentModified
+}
+
+// GetCallHierarchy returns the CallHierarchy field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetCallHierarchy() *CallHierarchyClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.CallHierarchy
+}
+
+// GetCodeAction returns the CodeAction field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetCodeAction() *CodeActionClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.CodeAction
+}
+
+// GetCodeLens returns the CodeLens field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetCodeLens() *CodeLensClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.CodeLens
+}
+
+// GetColorProvider returns the ColorProvider field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetColorProvider() *DocumentColorClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.ColorProvider
+}
+
+// GetCompletion returns the Completion field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetCompletion() *CompletionClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.Completion
+}
+
+// GetDeclaration returns the Declaration field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetDeclaration() *DeclarationClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Declaration
+}
+
+// GetDefinition returns the Definition field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetDefinition() *DefinitionClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Definition
+}
+
+// GetDiagnostic returns the Diagnostic field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetDiagnostic() *DiagnosticClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Diagnostic
+}
+
+// GetDocumentHighlight returns the DocumentHighlight field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetDocumentHighlight() *DocumentHighlightClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentHighlight
+}
+
+// GetDocumentLink returns the DocumentLink field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetDocumentLink() *DocumentLinkClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.DocumentLink
+}
+
+// GetDocumentSymbol returns the DocumentSymbol field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetDocumentSymbol() *DocumentSymbolClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.DocumentSymbol
+}
+
+// GetFilters returns the Filters field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetFilters() *TextDocumentFilterClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Filters
+}
+
+// GetFoldingRange returns the FoldingRange field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetFoldingRange() *FoldingRangeClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.FoldingRange
+}
+
+// GetFormatting returns the Formatting field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetFormatting() *DocumentFormattingClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Formatting
+}
+
+// GetHover returns the Hover field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetHover() *HoverClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Hover
+}
+
+// GetImplementation returns the Implementation field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetImplementation() *ImplementationClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Implementation
+}
+
+// GetInlayHint returns the InlayHint field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetInlayHint() *InlayHintClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.InlayHint
+}
+
+// GetInlineCompletion returns the InlineCompletion field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetInlineCompletion() *InlineCompletionClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.InlineCompletion
+}
+
+// GetInlineValue returns the InlineValue field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetInlineValue() *InlineValueClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.InlineValue
+}
+
+// GetLinkedEditingRange returns the LinkedEditingRange field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetLinkedEditingRange() *LinkedEditingRangeClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.LinkedEditingRange
+}
+
+// GetMoniker returns the Moniker field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetMoniker() *MonikerClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Moniker
+}
+
+// GetOnTypeFormatting returns the OnTypeFormatting field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetOnTypeFormatting() *DocumentOnTypeFormattingClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.OnTypeFormatting
+}
+
+// GetPublishDiagnostics returns the PublishDiagnostics field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetPublishDiagnostics() *PublishDiagnosticsClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.PublishDiagnostics
+}
+
+// GetRangeFormatting returns the RangeFormatting field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetRangeFormatting() *DocumentRangeFormattingClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.RangeFormatting
+}
+
+// GetReferences returns the References field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetReferences() *ReferenceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.References
+}
+
+// GetRename returns the Rename field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetRename() *RenameClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Rename
+}
+
+// GetSelectionRange returns the SelectionRange field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetSelectionRange() *SelectionRangeClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.SelectionRange
+}
+
+// GetSemanticTokens returns the SemanticTokens field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetSemanticTokens() *SemanticTokensClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.SemanticTokens
+}
+
+// GetSignatureHelp returns the SignatureHelp field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetSignatureHelp() *SignatureHelpClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.SignatureHelp
+}
+
+// GetSynchronization returns the Synchronization field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetSynchronization() *TextDocumentSyncClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Synchronization
+}
+
+// GetTypeDefinition returns the TypeDefinition field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetTypeDefinition() *TypeDefinitionClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.TypeDefinition
+}
+
+// GetTypeHierarchy returns the TypeHierarchy field of x, or the zero value if x is nil.
+func (x *TextDocumentClientCapabilities) GetTypeHierarchy() *TypeHierarchyClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.TypeHierarchy
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *TextDocumentContentClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
 // TextDocumentEditEditsElemFromAnnotatedTextEdit wraps a AnnotatedTextEdit value as a TextDocumentEditEditsElem union.
 func TextDocumentEditEditsElemFromAnnotatedTextEdit(v AnnotatedTextEdit) TextDocumentEditEditsElem {
 	return TextDocumentEditEditsElem{AnnotatedTextEdit: &v}
@@ -320,6 +2974,332 @@ func TextDocumentFilterFromTextDocumentFilterScheme(v TextDocumentFilterScheme)
 	return TextDocumentFilter{TextDocumentFilterScheme: &v}
 }
 
+// GetRelativePatternSupport returns the RelativePatternSupport field of x, or the zero value if x is nil.
+func (x *TextDocumentFilterClientCapabilities) GetRelativePatternSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.RelativePatternSupport
+}
+
+// GetDidSave returns the DidSave field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncClientCapabilities) GetDidSave() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DidSave
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetWillSave returns the WillSave field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncClientCapabilities) GetWillSave() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillSave
+}
+
+// GetWillSaveWaitUntil returns the WillSaveWaitUntil field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncClientCapabilities) GetWillSaveWaitUntil() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillSaveWaitUntil
+}
+
+// GetChange returns the Change field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncOptions) GetChange() TextDocumentSyncKind {
+	if x == nil {
+		var zero TextDocumentSyncKind
+		return zero
+	}
+	return x.Change
+}
+
+// GetOpenClose returns the OpenClose field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncOptions) GetOpenClose() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.OpenClose
+}
+
+// GetSave returns the Save field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncOptions) GetSave() *SaveOptions {
+	if x == nil {
+		return nil
+	}
+	return x.Save
+}
+
+// GetWillSave returns the WillSave field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncOptions) GetWillSave() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillSave
+}
+
+// GetWillSaveWaitUntil returns the WillSaveWaitUntil field of x, or the zero value if x is nil.
+func (x *TextDocumentSyncOptions) GetWillSaveWaitUntil() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WillSaveWaitUntil
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *TypeDefinitionClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetLinkSupport returns the LinkSupport field of x, or the zero value if x is nil.
+func (x *TypeDefinitionClientCapabilities) GetLinkSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.LinkSupport
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *TypeDefinitionRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *TypeDefinitionRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *TypeDefinitionRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *TypeHierarchyClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetDocumentSelector returns the DocumentSelector field of x, or the zero value if x is nil.
+func (x *TypeHierarchyRegistrationOptions) GetDocumentSelector() []DocumentFilter {
+	if x == nil {
+		var zero []DocumentFilter
+		return zero
+	}
+	return x.DocumentSelector
+}
+
+// GetID returns the ID field of x, or the zero value if x is nil.
+func (x *TypeHierarchyRegistrationOptions) GetID() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ID
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *TypeHierarchyRegistrationOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetShowDocument returns the ShowDocument field of x, or the zero value if x is nil.
+func (x *WindowClientCapabilities) GetShowDocument() *ShowDocumentClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.ShowDocument
+}
+
+// GetShowMessage returns the ShowMessage field of x, or the zero value if x is nil.
+func (x *WindowClientCapabilities) GetShowMessage() *ShowMessageRequestClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.ShowMessage
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *WindowClientCapabilities) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
+
+// GetApplyEdit returns the ApplyEdit field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetApplyEdit() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ApplyEdit
+}
+
+// GetCodeLens returns the CodeLens field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetCodeLens() *CodeLensWorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.CodeLens
+}
+
+// GetConfiguration returns the Configuration field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetConfiguration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.Configuration
+}
+
+// GetDiagnostics returns the Diagnostics field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetDiagnostics() *DiagnosticWorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Diagnostics
+}
+
+// GetDidChangeConfiguration returns the DidChangeConfiguration field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetDidChangeConfiguration() *DidChangeConfigurationClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.DidChangeConfiguration
+}
+
+// GetDidChangeWatchedFiles returns the DidChangeWatchedFiles field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetDidChangeWatchedFiles() *DidChangeWatchedFilesClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return &x.DidChangeWatchedFiles
+}
+
+// GetExecuteCommand returns the ExecuteCommand field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetExecuteCommand() *ExecuteCommandClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.ExecuteCommand
+}
+
+// GetFileOperations returns the FileOperations field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetFileOperations() *FileOperationClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.FileOperations
+}
+
+// GetFoldingRange returns the FoldingRange field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetFoldingRange() *FoldingRangeWorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.FoldingRange
+}
+
+// GetInlayHint returns the InlayHint field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetInlayHint() *InlayHintWorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.InlayHint
+}
+
+// GetInlineValue returns the InlineValue field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetInlineValue() *InlineValueWorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.InlineValue
+}
+
+// GetSemanticTokens returns the SemanticTokens field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetSemanticTokens() *SemanticTokensWorkspaceClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.SemanticTokens
+}
+
+// GetSymbol returns the Symbol field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetSymbol() *WorkspaceSymbolClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.Symbol
+}
+
+// GetTextDocumentContent returns the TextDocumentContent field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetTextDocumentContent() *TextDocumentContentClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.TextDocumentContent
+}
+
+// GetWorkspaceEdit returns the WorkspaceEdit field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetWorkspaceEdit() *WorkspaceEditClientCapabilities {
+	if x == nil {
+		return nil
+	}
+	return x.WorkspaceEdit
+}
+
+// GetWorkspaceFolders returns the WorkspaceFolders field of x, or the zero value if x is nil.
+func (x *WorkspaceClientCapabilities) GetWorkspaceFolders() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkspaceFolders
+}
+
 // WorkspaceDocumentDiagnosticReportFromWorkspaceFullDocumentDiagnosticReport wraps a WorkspaceFullDocumentDiagnosticReport value as a WorkspaceDocumentDiagnosticReport union.
 func WorkspaceDocumentDiagnosticReportFromWorkspaceFullDocumentDiagnosticReport(v WorkspaceFullDocumentDiagnosticReport) WorkspaceDocumentDiagnosticReport {
 	return WorkspaceDocumentDiagnosticReport{WorkspaceFullDocumentDiagnosticReport: &v}
@@ -330,6 +3310,110 @@ func WorkspaceDocumentDiagnosticReportFromWorkspaceUnchangedDocumentDiagnosticRe
 	return WorkspaceDocumentDiagnosticReport{WorkspaceUnchangedDocumentDiagnosticReport: &v}
 }
 
+// GetChangeAnnotationSupport returns the ChangeAnnotationSupport field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetChangeAnnotationSupport() *ChangeAnnotationsSupportOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ChangeAnnotationSupport
+}
+
+// GetDocumentChanges returns the DocumentChanges field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetDocumentChanges() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DocumentChanges
+}
+
+// GetFailureHandling returns the FailureHandling field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetFailureHandling() FailureHandlingKind {
+	if x == nil || x.FailureHandling == nil {
+		var zero FailureHandlingKind
+		return zero
+	}
+	return *x.FailureHandling
+}
+
+// GetMetadataSupport returns the MetadataSupport field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetMetadataSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.MetadataSupport
+}
+
+// GetNormalizesLineEndings returns the NormalizesLineEndings field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetNormalizesLineEndings() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.NormalizesLineEndings
+}
+
+// GetResourceOperations returns the ResourceOperations field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetResourceOperations() []ResourceOperationKind {
+	if x == nil {
+		var zero []ResourceOperationKind
+		return zero
+	}
+	return x.ResourceOperations
+}
+
+// GetSnippetEditSupport returns the SnippetEditSupport field of x, or the zero value if x is nil.
+func (x *WorkspaceEditClientCapabilities) GetSnippetEditSupport() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.SnippetEditSupport
+}
+
+// GetChangeNotifications returns the ChangeNotifications field of x, or the zero value if x is nil.
+func (x *WorkspaceFolders5Gn) GetChangeNotifications() string {
+	if x == nil {
+		var zero string
+		return zero
+	}
+	return x.ChangeNotifications
+}
+
+// GetSupported returns the Supported field of x, or the zero value if x is nil.
+func (x *WorkspaceFolders5Gn) GetSupported() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.Supported
+}
+
+// GetFileOperations returns the FileOperations field of x, or the zero value if x is nil.
+func (x *WorkspaceOptions) GetFileOperations() *FileOperationOptions {
+	if x == nil {
+		return nil
+	}
+	return x.FileOperations
+}
+
+// GetTextDocumentContent returns the TextDocumentContent field of x, or the zero value if x is nil.
+func (x *WorkspaceOptions) GetTextDocumentContent() *WorkspaceOptionsTextDocumentContent {
+	if x == nil {
+		return nil
+	}
+	return x.TextDocumentContent
+}
+
+// GetWorkspaceFolders returns the WorkspaceFolders field of x, or the zero value if x is nil.
+func (x *WorkspaceOptions) GetWorkspaceFolders() *WorkspaceFolders5Gn {
+	if x == nil {
+		return nil
+	}
+	return x.WorkspaceFolders
+}
+
 // WorkspaceOptionsTextDocumentContentFromTextDocumentContentOptions wraps a TextDocumentContentOptions value as a WorkspaceOptionsTextDocumentContent union.
 func WorkspaceOptionsTextDocumentContentFromTextDocumentContentOptions(v TextDocumentContentOptions) WorkspaceOptionsTextDocumentContent {
 	return WorkspaceOptionsTextDocumentContent{TextDocumentContentOptions: &v}
@@ -340,6 +3424,39 @@ func WorkspaceOptionsTextDocumentContentFromTextDocumentContentRegistrationOptio
 	return WorkspaceOptionsTextDocumentContent{TextDocumentContentRegistrationOptions: &v}
 }
 
+// GetDynamicRegistration returns the DynamicRegistration field of x, or the zero value if x is nil.
+func (x *WorkspaceSymbolClientCapabilities) GetDynamicRegistration() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.DynamicRegistration
+}
+
+// GetResolveSupport returns the ResolveSupport field of x, or the zero value if x is nil.
+func (x *WorkspaceSymbolClientCapabilities) GetResolveSupport() *ClientSymbolResolveOptions {
+	if x == nil {
+		return nil
+	}
+	return x.ResolveSupport
+}
+
+// GetSymbolKind returns the SymbolKind field of x, or the zero value if x is nil.
+func (x *WorkspaceSymbolClientCapabilities) GetSymbolKind() *ClientSymbolKindOptions {
+	if x == nil {
+		return nil
+	}
+	return x.SymbolKind
+}
+
+// GetTagSupport returns the TagSupport field of x, or the zero value if x is nil.
+func (x *WorkspaceSymbolClientCapabilities) GetTagSupport() *ClientSymbolTagOptions {
+	if x == nil {
+		return nil
+	}
+	return x.TagSupport
+}
+
 // WorkspaceSymbolLocationFromLocation wraps a Location value as a WorkspaceSymbolLocation union.
 func WorkspaceSymbolLocationFromLocation(v Location) WorkspaceSymbolLocation {
 	return WorkspaceSymbolLocation{Location: &v}
@@ -349,3 +3466,21 @@ func WorkspaceSymbolLocationFromLocation(v Location) WorkspaceSymbolLocation {
 func WorkspaceSymbolLocationFromLocationUriOnly(v LocationUriOnly) WorkspaceSymbolLocation {
 	return WorkspaceSymbolLocation{LocationUriOnly: &v}
 }
+
+// GetResolveProvider returns the ResolveProvider field of x, or the zero value if x is nil.
+func (x *WorkspaceSymbolOptions) GetResolveProvider() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.ResolveProvider
+}
+
+// GetWorkDoneProgress returns the WorkDoneProgress field of x, or the zero value if x is nil.
+func (x *WorkspaceSymbolOptions) GetWorkDoneProgress() bool {
+	if x == nil {
+		var zero bool
+		return zero
+	}
+	return x.WorkDoneProgress
+}
@@ -12,6 +12,7 @@ package lsp
 
 import (
 	"context"
+	"encoding/json"
 
 	"golang.org/x/exp/jsonrpc2"
 )
@@ -61,67 +62,68 @@ type Client interface {
 	WorkspaceFolders(context.Context) ([]WorkspaceFolder, error)
 }
 
-func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (interface{}, error) {
-	switch req.Method {
-	case "$/logTrace":
+// clientMethods maps each method handled by a Client to a function
+// that decodes the params of the request and invokes the Client.
+var clientMethods = map[string]func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error){
+	"$/logTrace": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params LogTraceParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.LogTrace(ctx, &params)
 		return nil, err
-
-	case "$/progress":
+	},
+	"$/progress": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params ProgressParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.Progress(ctx, &params)
 		return nil, err
-
-	case "client/registerCapability":
+	},
+	"client/registerCapability": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params RegistrationParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.RegisterCapability(ctx, &params)
 		return nil, err
-
-	case "client/unregisterCapability":
+	},
+	"client/unregisterCapability": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params UnregistrationParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.UnregisterCapability(ctx, &params)
 		return nil, err
-
-	case "telemetry/event":
+	},
+	"telemetry/event": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params any
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.Event(ctx, &params)
 		return nil, err
-
-	case "textDocument/publishDiagnostics":
+	},
+	"textDocument/publishDiagnostics": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params PublishDiagnosticsParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.PublishDiagnostics(ctx, &params)
 		return nil, err
-
-	case "window/logMessage":
+	},
+	"window/logMessage": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params LogMessageParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.LogMessage(ctx, &params)
 		return nil, err
-
-	case "window/showDocument":
+	},
+	"window/showDocument": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params ShowDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := client.ShowDocument(ctx, &params)
@@ -129,18 +131,18 @@ func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "window/showMessage":
+	},
+	"window/showMessage": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params ShowMessageParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.ShowMessage(ctx, &params)
 		return nil, err
-
-	case "window/showMessageRequest":
+	},
+	"window/showMessageRequest": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params ShowMessageRequestParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := client.ShowMessageRequest(ctx, &params)
@@ -148,18 +150,18 @@ func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "window/workDoneProgress/create":
+	},
+	"window/workDoneProgress/create": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params WorkDoneProgressCreateParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.WorkDoneProgressCreate(ctx, &params)
 		return nil, err
-
-	case "workspace/applyEdit":
+	},
+	"workspace/applyEdit": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params ApplyWorkspaceEditParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := client.ApplyEdit(ctx, &params)
@@ -167,14 +169,14 @@ func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/codeLens/refresh":
+	},
+	"workspace/codeLens/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		err := client.CodeLensRefresh(ctx)
 		return nil, err
-
-	case "workspace/configuration":
+	},
+	"workspace/configuration": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params ParamConfiguration
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := client.Configuration(ctx, &params)
@@ -182,45 +184,49 @@ func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/diagnostic/refresh":
+	},
+	"workspace/diagnostic/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		err := client.DiagnosticRefresh(ctx)
 		return nil, err
-
-	case "workspace/foldingRange/refresh":
+	},
+	"workspace/foldingRange/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		err := client.FoldingRangeRefresh(ctx)
 		return nil, err
-
-	case "workspace/inlayHint/refresh":
+	},
+	"workspace/inlayHint/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		err := client.InlayHintRefresh(ctx)
 		return nil, err
-
-	case "workspace/inlineValue/refresh":
+	},
+	"workspace/inlineValue/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		err := client.InlineValueRefresh(ctx)
 		return nil, err
-
-	case "workspace/semanticTokens/refresh":
+	},
+	"workspace/semanticTokens/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		err := client.SemanticTokensRefresh(ctx)
 		return nil, err
-
-	case "workspace/textDocumentContent/refresh":
+	},
+	"workspace/textDocumentContent/refresh": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		var params TextDocumentContentRefreshParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := client.TextDocumentContentRefresh(ctx, &params)
 		return nil, err
-
-	case "workspace/workspaceFolders":
+	},
+	"workspace/workspaceFolders": func(ctx context.Context, client Client, raw json.RawMessage) (interface{}, error) {
 		resp, err := client.WorkspaceFolders(ctx)
 		if err != nil {
 			return nil, err
 		}
 		return resp, nil
+	},
+}
 
-	default:
-		return nil, jsonrpc2.ErrMethodNotFound
+func clientDispatch(ctx context.Context, client Client, req *jsonrpc2.Request) (interface{}, error) {
+	if dispatch, ok := clientMethods[req.Method]; ok {
+		return dispatch(ctx, client, req.Params)
 	}
+	return nil, jsonrpc2.ErrMethodNotFound
 }
 
 func (s *clientDispatcher) LogTrace(ctx context.Context, params *LogTraceParams) error {
@@ -12,6 +12,7 @@ package lsp
 
 import (
 	"context"
+	"encoding/json"
 
 	"golang.org/x/exp/jsonrpc2"
 )
@@ -167,27 +168,28 @@ type Server interface {
 	ResolveWorkspaceSymbol(context.Context, *WorkspaceSymbol) (*WorkspaceSymbol, error)
 }
 
-func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (interface{}, error) {
-	switch req.Method {
-	case "$/progress":
+// serverMethods maps each method handled by a Server to a function
+// that decodes the params of the request and invokes the Server.
+var serverMethods = map[string]func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error){
+	"$/progress": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params ProgressParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.Progress(ctx, &params)
 		return nil, err
-
-	case "$/setTrace":
+	},
+	"$/setTrace": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params SetTraceParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.SetTrace(ctx, &params)
 		return nil, err
-
-	case "callHierarchy/incomingCalls":
+	},
+	"callHierarchy/incomingCalls": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CallHierarchyIncomingCallsParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.IncomingCalls(ctx, &params)
@@ -195,10 +197,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "callHierarchy/outgoingCalls":
+	},
+	"callHierarchy/outgoingCalls": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CallHierarchyOutgoingCallsParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.OutgoingCalls(ctx, &params)
@@ -206,10 +208,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "codeAction/resolve":
+	},
+	"codeAction/resolve": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CodeAction
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ResolveCodeAction(ctx, &params)
@@ -217,10 +219,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "codeLens/resolve":
+	},
+	"codeLens/resolve": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CodeLens
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ResolveCodeLens(ctx, &params)
@@ -228,10 +230,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "completionItem/resolve":
+	},
+	"completionItem/resolve": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CompletionItem
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ResolveCompletionItem(ctx, &params)
@@ -239,10 +241,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "documentLink/resolve":
+	},
+	"documentLink/resolve": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentLink
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ResolveDocumentLink(ctx, &params)
@@ -250,14 +252,14 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "exit":
+	},
+	"exit": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		err := server.Exit(ctx)
 		return nil, err
-
-	case "initialize":
+	},
+	"initialize": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params ParamInitialize
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Initialize(ctx, &params)
@@ -265,18 +267,18 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "initialized":
+	},
+	"initialized": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params InitializedParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.Initialized(ctx, &params)
 		return nil, err
-
-	case "inlayHint/resolve":
+	},
+	"inlayHint/resolve": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params InlayHint
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Resolve(ctx, &params)
@@ -284,46 +286,46 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "notebookDocument/didChange":
+	},
+	"notebookDocument/didChange": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidChangeNotebookDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidChangeNotebookDocument(ctx, &params)
 		return nil, err
-
-	case "notebookDocument/didClose":
+	},
+	"notebookDocument/didClose": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidCloseNotebookDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidCloseNotebookDocument(ctx, &params)
 		return nil, err
-
-	case "notebookDocument/didOpen":
+	},
+	"notebookDocument/didOpen": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidOpenNotebookDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidOpenNotebookDocument(ctx, &params)
 		return nil, err
-
-	case "notebookDocument/didSave":
+	},
+	"notebookDocument/didSave": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidSaveNotebookDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidSaveNotebookDocument(ctx, &params)
 		return nil, err
-
-	case "shutdown":
+	},
+	"shutdown": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		err := server.Shutdown(ctx)
 		return nil, err
-
-	case "textDocument/codeAction":
+	},
+	"textDocument/codeAction": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CodeActionParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.CodeAction(ctx, &params)
@@ -331,10 +333,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/codeLens":
+	},
+	"textDocument/codeLens": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CodeLensParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.CodeLens(ctx, &params)
@@ -342,10 +344,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/colorPresentation":
+	},
+	"textDocument/colorPresentation": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params ColorPresentationParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ColorPresentation(ctx, &params)
@@ -353,10 +355,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/completion":
+	},
+	"textDocument/completion": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CompletionParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Completion(ctx, &params)
@@ -364,10 +366,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/declaration":
+	},
+	"textDocument/declaration": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DeclarationParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Declaration(ctx, &params)
@@ -375,10 +377,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/definition":
+	},
+	"textDocument/definition": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DefinitionParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Definition(ctx, &params)
@@ -386,10 +388,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/diagnostic":
+	},
+	"textDocument/diagnostic": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentDiagnosticParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Diagnostic(ctx, &params)
@@ -397,42 +399,42 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/didChange":
+	},
+	"textDocument/didChange": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidChangeTextDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidChange(ctx, &params)
 		return nil, err
-
-	case "textDocument/didClose":
+	},
+	"textDocument/didClose": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidCloseTextDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidClose(ctx, &params)
 		return nil, err
-
-	case "textDocument/didOpen":
+	},
+	"textDocument/didOpen": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidOpenTextDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidOpen(ctx, &params)
 		return nil, err
-
-	case "textDocument/didSave":
+	},
+	"textDocument/didSave": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidSaveTextDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidSave(ctx, &params)
 		return nil, err
-
-	case "textDocument/documentColor":
+	},
+	"textDocument/documentColor": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentColorParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.DocumentColor(ctx, &params)
@@ -440,10 +442,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/documentHighlight":
+	},
+	"textDocument/documentHighlight": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentHighlightParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.DocumentHighlight(ctx, &params)
@@ -451,10 +453,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/documentLink":
+	},
+	"textDocument/documentLink": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentLinkParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.DocumentLink(ctx, &params)
@@ -462,10 +464,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/documentSymbol":
+	},
+	"textDocument/documentSymbol": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentSymbolParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.DocumentSymbol(ctx, &params)
@@ -473,10 +475,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/foldingRange":
+	},
+	"textDocument/foldingRange": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params FoldingRangeParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.FoldingRange(ctx, &params)
@@ -484,10 +486,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/formatting":
+	},
+	"textDocument/formatting": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentFormattingParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Formatting(ctx, &params)
@@ -495,10 +497,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/hover":
+	},
+	"textDocument/hover": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params HoverParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Hover(ctx, &params)
@@ -506,10 +508,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/implementation":
+	},
+	"textDocument/implementation": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params ImplementationParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Implementation(ctx, &params)
@@ -517,10 +519,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/inlayHint":
+	},
+	"textDocument/inlayHint": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params InlayHintParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.InlayHint(ctx, &params)
@@ -528,10 +530,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/inlineCompletion":
+	},
+	"textDocument/inlineCompletion": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params InlineCompletionParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.InlineCompletion(ctx, &params)
@@ -539,10 +541,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/inlineValue":
+	},
+	"textDocument/inlineValue": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params InlineValueParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.InlineValue(ctx, &params)
@@ -550,10 +552,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/linkedEditingRange":
+	},
+	"textDocument/linkedEditingRange": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params LinkedEditingRangeParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.LinkedEditingRange(ctx, &params)
@@ -561,10 +563,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/moniker":
+	},
+	"textDocument/moniker": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params MonikerParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Moniker(ctx, &params)
@@ -572,10 +574,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/onTypeFormatting":
+	},
+	"textDocument/onTypeFormatting": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentOnTypeFormattingParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.OnTypeFormatting(ctx, &params)
@@ -583,10 +585,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/prepareCallHierarchy":
+	},
+	"textDocument/prepareCallHierarchy": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CallHierarchyPrepareParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.PrepareCallHierarchy(ctx, &params)
@@ -594,10 +596,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/prepareRename":
+	},
+	"textDocument/prepareRename": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params PrepareRenameParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.PrepareRename(ctx, &params)
@@ -605,10 +607,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/prepareTypeHierarchy":
+	},
+	"textDocument/prepareTypeHierarchy": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params TypeHierarchyPrepareParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.PrepareTypeHierarchy(ctx, &params)
@@ -616,10 +618,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/rangeFormatting":
+	},
+	"textDocument/rangeFormatting": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentRangeFormattingParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.RangeFormatting(ctx, &params)
@@ -627,10 +629,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/rangesFormatting":
+	},
+	"textDocument/rangesFormatting": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DocumentRangesFormattingParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.RangesFormatting(ctx, &params)
@@ -638,10 +640,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/references":
+	},
+	"textDocument/references": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params ReferenceParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.References(ctx, &params)
@@ -649,10 +651,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/rename":
+	},
+	"textDocument/rename": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params RenameParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Rename(ctx, &params)
@@ -660,10 +662,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/selectionRange":
+	},
+	"textDocument/selectionRange": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params SelectionRangeParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.SelectionRange(ctx, &params)
@@ -671,10 +673,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/semanticTokens/full":
+	},
+	"textDocument/semanticTokens/full": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params SemanticTokensParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.SemanticTokensFull(ctx, &params)
@@ -682,10 +684,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/semanticTokens/full/delta":
+	},
+	"textDocument/semanticTokens/full/delta": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params SemanticTokensDeltaParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.SemanticTokensFullDelta(ctx, &params)
@@ -693,10 +695,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/semanticTokens/range":
+	},
+	"textDocument/semanticTokens/range": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params SemanticTokensRangeParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.SemanticTokensRange(ctx, &params)
@@ -704,10 +706,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/signatureHelp":
+	},
+	"textDocument/signatureHelp": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params SignatureHelpParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.SignatureHelp(ctx, &params)
@@ -715,10 +717,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/typeDefinition":
+	},
+	"textDocument/typeDefinition": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params TypeDefinitionParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.TypeDefinition(ctx, &params)
@@ -726,18 +728,18 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "textDocument/willSave":
+	},
+	"textDocument/willSave": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params WillSaveTextDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.WillSave(ctx, &params)
 		return nil, err
-
-	case "textDocument/willSaveWaitUntil":
+	},
+	"textDocument/willSaveWaitUntil": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params WillSaveTextDocumentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.WillSaveWaitUntil(ctx, &params)
@@ -745,10 +747,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "typeHierarchy/subtypes":
+	},
+	"typeHierarchy/subtypes": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params TypeHierarchySubtypesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Subtypes(ctx, &params)
@@ -756,10 +758,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "typeHierarchy/supertypes":
+	},
+	"typeHierarchy/supertypes": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params TypeHierarchySupertypesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Supertypes(ctx, &params)
@@ -767,18 +769,18 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "window/workDoneProgress/cancel":
+	},
+	"window/workDoneProgress/cancel": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params WorkDoneProgressCancelParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.WorkDoneProgressCancel(ctx, &params)
 		return nil, err
-
-	case "workspace/diagnostic":
+	},
+	"workspace/diagnostic": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params WorkspaceDiagnosticParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.DiagnosticWorkspace(ctx, &params)
@@ -786,58 +788,58 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/didChangeConfiguration":
+	},
+	"workspace/didChangeConfiguration": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidChangeConfigurationParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidChangeConfiguration(ctx, &params)
 		return nil, err
-
-	case "workspace/didChangeWatchedFiles":
+	},
+	"workspace/didChangeWatchedFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidChangeWatchedFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidChangeWatchedFiles(ctx, &params)
 		return nil, err
-
-	case "workspace/didChangeWorkspaceFolders":
+	},
+	"workspace/didChangeWorkspaceFolders": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DidChangeWorkspaceFoldersParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidChangeWorkspaceFolders(ctx, &params)
 		return nil, err
-
-	case "workspace/didCreateFiles":
+	},
+	"workspace/didCreateFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CreateFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidCreateFiles(ctx, &params)
 		return nil, err
-
-	case "workspace/didDeleteFiles":
+	},
+	"workspace/didDeleteFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DeleteFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidDeleteFiles(ctx, &params)
 		return nil, err
-
-	case "workspace/didRenameFiles":
+	},
+	"workspace/didRenameFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params RenameFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		err := server.DidRenameFiles(ctx, &params)
 		return nil, err
-
-	case "workspace/executeCommand":
+	},
+	"workspace/executeCommand": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params ExecuteCommandParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ExecuteCommand(ctx, &params)
@@ -845,10 +847,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/symbol":
+	},
+	"workspace/symbol": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params WorkspaceSymbolParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.Symbol(ctx, &params)
@@ -856,10 +858,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/textDocumentContent":
+	},
+	"workspace/textDocumentContent": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params TextDocumentContentParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.TextDocumentContent(ctx, &params)
@@ -867,10 +869,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/willCreateFiles":
+	},
+	"workspace/willCreateFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params CreateFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.WillCreateFiles(ctx, &params)
@@ -878,10 +880,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/willDeleteFiles":
+	},
+	"workspace/willDeleteFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params DeleteFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.WillDeleteFiles(ctx, &params)
@@ -889,10 +891,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspace/willRenameFiles":
+	},
+	"workspace/willRenameFiles": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params RenameFilesParams
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.WillRenameFiles(ctx, &params)
@@ -900,10 +902,10 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
-
-	case "workspaceSymbol/resolve":
+	},
+	"workspaceSymbol/resolve": func(ctx context.Context, server Server, raw json.RawMessage) (interface{}, error) {
 		var params WorkspaceSymbol
-		if err := UnmarshalJSON(req.Params, &params); err != nil {
+		if err := UnmarshalJSON(raw, &params); err != nil {
 			return nil, err
 		}
 		resp, err := server.ResolveWorkspaceSymbol(ctx, &params)
@@ -911,10 +913,14 @@ func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (
 			return nil, err
 		}
 		return resp, nil
+	},
+}
 
-	default:
-		return nil, jsonrpc2.ErrMethodNotFound
+func serverDispatch(ctx context.Context, server Server, req *jsonrpc2.Request) (interface{}, error) {
+	if dispatch, ok := serverMethods[req.Method]; ok {
+		return dispatch(ctx, server, req.Params)
 	}
+	return nil, jsonrpc2.ErrMethodNotFound
 }
 
 func (s *serverDispatcher) Progress(ctx context.Context, params *ProgressParams) error {
@@ -0,0 +1,159 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// DefaultMaxUnknownMethods is the number of methods tracked by an
+// UnknownMethods whose MaxMethods is zero.
+const DefaultMaxUnknownMethods = 100
+
+// OtherUnknownMethods is the method under which [UnknownMethods] counts
+// the messages of the methods it does not track, beyond its MaxMethods,
+// so that a peer cannot grow its memory by making up methods.
+const OtherUnknownMethods = "(other)"
+
+// maxUnknownMethodLen is the length of the longest method tracked by an
+// UnknownMethods; longer ones are counted as OtherUnknownMethods.
+const maxUnknownMethodLen = 256
+
+// UnknownMethods counts the requests and notifications that the
+// handler of a connection does not handle, failing calls with
+// MethodNotFound and dropping notifications, and logs them
+// periodically, which helps diagnose the mismatches between the
+// capabilities of a client and a server in the field:
+//
+//	unknown := &lsp.UnknownMethods{Interval: time.Minute}
+//	handler := lsp.ServerHandler(server, unknown.Handler)
+//
+// The methods of an UnknownMethods are safe for concurrent use. Its
+// settings must not be changed after Handler is called.
+type UnknownMethods struct {
+	// Interval is the shortest time between two logs of the unknown
+	// methods received, through the event system, one event per method
+	// received since the previous log. If zero, the methods are only
+	// counted.
+	Interval time.Duration
+	// MaxMethods is the maximum number of methods tracked, or
+	// DefaultMaxUnknownMethods if zero. The messages of further methods
+	// are counted under OtherUnknownMethods.
+	MaxMethods int
+
+	mu        sync.Mutex
+	stats     map[string]*UnknownMethodStats
+	recent    map[string]int64 // messages received since the previous log
+	scheduled bool             // a log is scheduled
+}
+
+// UnknownMethodStats are the counters of an unknown method.
+type UnknownMethodStats struct {
+	Method        string
+	Calls         int64     // requests received
+	Notifications int64     // notifications received
+	LastSize      int       // size of the params of the last message, in bytes
+	MaxSize       int       // size of the largest params, in bytes
+	Last          time.Time // when the last message was received
+}
+
+// Handler returns a handler that invokes handler, and counts the
+// messages for which it reports that their method is not handled.
+func (u *UnknownMethods) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		result, err := handler.Handle(ctx, req)
+		if isMethodNotFound(err) {
+			u.record(ctx, req)
+		}
+		return result, err
+	})
+}
+
+// Stats returns the counters of the unknown methods received, sorted by
+// method.
+func (u *UnknownMethods) Stats() []UnknownMethodStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	stats := make([]UnknownMethodStats, 0, len(u.stats))
+	for _, st := range u.stats {
+		stats = append(stats, *st)
+	}
+	slices.SortFunc(stats, func(a, b UnknownMethodStats) int { return strings.Compare(a.Method, b.Method) })
+	return stats
+}
+
+// record counts a message whose method is not handled, and schedules a
+// log of the recent ones if needed.
+func (u *UnknownMethods) record(ctx context.Context, req *jsonrpc2.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	maxMethods := u.MaxMethods
+	if maxMethods <= 0 {
+		maxMethods = DefaultMaxUnknownMethods
+	}
+	method := req.Method
+	st, ok := u.stats[method]
+	if !ok && (len(u.stats) >= maxMethods || len(method) > maxUnknownMethodLen) {
+		method = OtherUnknownMethods
+		st, ok = u.stats[method]
+	}
+	if !ok {
+		if u.stats == nil {
+			u.stats = make(map[string]*UnknownMethodStats)
+			u.recent = make(map[string]int64)
+		}
+		st = &UnknownMethodStats{Method: method}
+		u.stats[method] = st
+	}
+	if req.IsCall() {
+		st.Calls++
+	} else {
+		st.Notifications++
+	}
+	st.LastSize = len(req.Params)
+	st.MaxSize = max(st.MaxSize, st.LastSize)
+	st.Last = time.Now()
+
+	if u.Interval <= 0 {
+		return
+	}
+	u.recent[method]++
+	if !u.scheduled {
+		u.scheduled = true
+		ctx = detach(ctx) // the log outlives the message
+		time.AfterFunc(u.Interval, func() { u.log(ctx) })
+	}
+}
+
+// log logs the unknown methods received since the previous log.
+func (u *UnknownMethods) log(ctx context.Context) {
+	u.mu.Lock()
+	var logs [][]event.Label
+	for _, method := range slices.Sorted(maps.Keys(u.recent)) {
+		st := u.stats[method]
+		logs = append(logs, []event.Label{
+			event.String("method", method),
+			event.Int64("count", u.recent[method]),
+			event.Int64("total", st.Calls+st.Notifications),
+			event.Int64("size", int64(st.LastSize)),
+			event.Int64("max.size", int64(st.MaxSize)),
+		})
+	}
+	clear(u.recent)
+	u.scheduled = false
+	u.mu.Unlock()
+
+	for _, labels := range logs {
+		event.Log(ctx, "unknown method", labels...)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest/eventtest"
+)
+
+func TestUnknownMethods(t *testing.T) {
+	ctx, capture := eventtest.NewCapture(context.Background())
+	unknown := &lsp.UnknownMethods{Interval: 10 * time.Millisecond}
+	handler := lsp.ServerHandler(hoverServer{}, unknown.Handler)
+
+	handle := func(req *jsonrpc2.Request, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		handler.Handle(ctx, req)
+	}
+	handle(newHoverCall(t), nil)
+	handle(jsonrpc2.NewCall(jsonrpc2.Int64ID(1), "vendor/query", map[string]string{"q": "x"}))
+	handle(jsonrpc2.NewCall(jsonrpc2.Int64ID(2), "vendor/query", map[string]string{"q": "longer"}))
+	handle(jsonrpc2.NewNotification("vendor/event", nil))
+
+	want := []lsp.UnknownMethodStats{
+		{Method: "vendor/event", Notifications: 1},
+		{Method: "vendor/query", Calls: 2, LastSize: len(`{"q":"longer"}`), MaxSize: len(`{"q":"longer"}`)},
+	}
+	stats := unknown.Stats()
+	for i := range stats {
+		if stats[i].Last.IsZero() {
+			t.Errorf("Expected the time of the last %s", stats[i].Method)
+		}
+		stats[i].Last = time.Time{}
+	}
+	if diff := cmp.Diff(want, stats); diff != "" {
+		t.Errorf("Unexpected stats (-want +got):\n%s", diff)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(capture.Logs()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	var got []string
+	for _, ev := range capture.Events() {
+		method, _ := eventtest.Find(ev, "method")
+		count, _ := eventtest.Find(ev, "count")
+		got = append(got, fmt.Sprintf("%s %d", method.String(), count.Int64()))
+	}
+	if diff := cmp.Diff([]string{"vendor/event 1", "vendor/query 2"}, got); diff != "" {
+		t.Errorf("Unexpected logs (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnknownMethodsLimit(t *testing.T) {
+	unknown := &lsp.UnknownMethods{MaxMethods: 2}
+	handler := lsp.ServerHandler(hoverServer{}, unknown.Handler)
+	for i := range 10 {
+		req, err := jsonrpc2.NewNotification(fmt.Sprintf("vendor/event%d", i), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		handler.Handle(context.Background(), req)
+	}
+	var got []string
+	for _, st := range unknown.Stats() {
+		got = append(got, fmt.Sprintf("%s %d", st.Method, st.Notifications))
+	}
+	want := []string{lsp.OtherUnknownMethods + " 8", "vendor/event0 1", "vendor/event1 1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected stats (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+// This file checks the structure of messages against the protocol,
+// as transcribed into the Go types generated from the metaModel.
+//
+// A property is required if its field lacks the omitempty option,
+// which the generator adds to every optional property (except for a
+// few optional integers; see optionalNumbers).
+//
+// The checks are exactly as strict as the Go types, so where those narrow
+// a type of the metaModel (for example, the result of textDocument/definition
+// is always a []DefinitionLink) a message that conforms to the specification
+// but could not be handled by this package is reported as well.
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// A Violation describes a way in which a message does not conform
+// to the protocol.
+type Violation struct {
+	Path    string // location of the offending value, e.g. "params.position.line"
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// ValidateRequest reports the ways in which req, a request or notification
+// sent by the client (or by the server, if fromServer is set), does not
+// conform to the protocol: an unknown method, a method sent in the wrong
+// direction or of the wrong kind, or params of the wrong structure.
+//
+// Methods starting with "$/" are implementation-dependent; unknown ones
+// are not reported.
+func ValidateRequest(req *jsonrpc2.Request, fromServer bool) []Violation {
+	info, ok := methods[req.Method]
+	if !ok {
+		if strings.HasPrefix(req.Method, "$/") {
+			return nil
+		}
+		return []Violation{{Path: "method", Message: fmt.Sprintf("unknown method %q", req.Method)}}
+	}
+	var vs []Violation
+	if fromServer && !info.toClient || !fromServer && !info.toServer {
+		vs = append(vs, Violation{Path: "method", Message: fmt.Sprintf("%q sent in the wrong direction", req.Method)})
+	}
+	if info.notification && req.IsCall() {
+		vs = append(vs, Violation{Path: "id", Message: fmt.Sprintf("notification %q has an ID", req.Method)})
+	} else if !info.notification && !req.IsCall() {
+		vs = append(vs, Violation{Path: "id", Message: fmt.Sprintf("request %q has no ID", req.Method)})
+	}
+	switch {
+	case info.params == nil:
+		if len(req.Params) > 0 && !bytes.Equal(req.Params, []byte("null")) {
+			vs = append(vs, Violation{Path: "params", Message: "unexpected params"})
+		}
+	case len(req.Params) == 0:
+		vs = append(vs, Violation{Path: "params", Message: "missing params"})
+	default:
+		vs = append(vs, validateJSON("params", req.Params, info.params)...)
+	}
+	return vs
+}
+
+// ValidateResult reports the ways in which result does not conform to
+// the protocol as the result of a request for method.
+func ValidateResult(method string, result json.RawMessage) []Violation {
+	info, ok := methods[method]
+	if !ok || info.notification {
+		return nil
+	}
+	if info.result == nil {
+		if len(result) > 0 && !bytes.Equal(result, []byte("null")) {
+			return []Violation{{Path: "result", Message: "unexpected result"}}
+		}
+		return nil
+	}
+	return validateJSON("result", result, info.result)
+}
+
+func validateJSON(path string, data json.RawMessage, t reflect.Type) []Violation {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return []Violation{{Path: path, Message: err.Error()}}
+	}
+	var vs []Violation
+	validateValue(&vs, path, v, t)
+	return vs
+}
+
+// optionalNumbers lists the optional integer properties that the
+// generator declares without omitempty, keyed by "Struct.property".
+var optionalNumbers = map[string]bool{
+	"ApplyWorkspaceEditResult.failedChange":     true,
+	"FoldingRangeClientCapabilities.rangeLimit": true,
+	"SignatureHelp.activeSignature":             true,
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// validateValue appends to vs the ways in which the decoded JSON value v
+// does not conform to the Go type t.
+func validateValue(vs *[]Violation, path string, v any, t reflect.Type) {
+	report := func(format string, args ...any) {
+		*vs = append(*vs, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if t.Kind() == reflect.Pointer {
+		if v == nil {
+			return
+		}
+		t = t.Elem()
+	}
+	// Union types and the like decode themselves; defer to them.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		data, _ := json.Marshal(v)
+		if err := json.Unmarshal(data, reflect.New(t).Interface()); err != nil {
+			report("invalid %s: %v", t.Name(), err)
+		}
+		return
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		// any value is acceptable
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			report("got %s, want boolean", jsonKind(v))
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			report("got %s, want string", jsonKind(v))
+		}
+	case reflect.Int32, reflect.Uint32, reflect.Int, reflect.Int64, reflect.Uint64:
+		n, ok := v.(json.Number)
+		if !ok {
+			report("got %s, want integer", jsonKind(v))
+			return
+		}
+		i, err := n.Int64()
+		if err != nil {
+			report("got %s, want integer", n)
+			return
+		}
+		lo, hi := int64(math.MinInt32), int64(math.MaxInt32)
+		if t.Kind() == reflect.Uint32 {
+			lo, hi = 0, math.MaxUint32
+		}
+		if (t.Kind() == reflect.Int32 || t.Kind() == reflect.Uint32) && (i < lo || i > hi) {
+			report("%d out of range for %s", i, t.Kind())
+		}
+	case reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			report("got %s, want number", jsonKind(v))
+		}
+	case reflect.Slice:
+		if v == nil {
+			return // nil slices encode as null
+		}
+		arr, ok := v.([]any)
+		if !ok {
+			report("got %s, want array", jsonKind(v))
+			return
+		}
+		for i, elem := range arr {
+			validateValue(vs, fmt.Sprintf("%s[%d]", path, i), elem, t.Elem())
+		}
+	case reflect.Map:
+		if v == nil {
+			return
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			report("got %s, want object", jsonKind(v))
+			return
+		}
+		for _, k := range slices.Sorted(maps.Keys(obj)) {
+			validateValue(vs, path+"."+k, obj[k], t.Elem())
+		}
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			report("got %s, want object", jsonKind(v))
+			return
+		}
+		known := make(map[string]bool)
+		validateStruct(vs, path, obj, t, t.Name(), known)
+		for _, k := range slices.Sorted(maps.Keys(obj)) {
+			if !known[k] {
+				*vs = append(*vs, Violation{Path: path + "." + k, Message: fmt.Sprintf("unknown property of %s", t.Name())})
+			}
+		}
+	default:
+		report("cannot validate Go type %s", t)
+	}
+}
+
+// validateStruct validates the properties of obj declared by struct type t,
+// including those of embedded structs, recording their names in known.
+// Violations are reported against the outermost struct, named owner.
+func validateStruct(vs *[]Violation, path string, obj map[string]any, t reflect.Type, owner string, known map[string]bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("json")
+		if f.Anonymous && !hasTag {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			validateStruct(vs, path, obj, ft, owner, known)
+			continue
+		}
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		known[name] = true
+		v, present := obj[name]
+		if !present {
+			optional := strings.Contains(opts, "omitempty") || optionalNumbers[t.Name()+"."+name]
+			if !optional {
+				*vs = append(*vs, Violation{Path: path + "." + name, Message: fmt.Sprintf("missing required property of %s", owner)})
+			}
+			continue
+		}
+		validateValue(vs, path+"."+name, v, f.Type)
+	}
+}
+
+// jsonKind returns the JSON kind of a decoded value, for error messages.
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		call       bool
+		fromServer bool
+		params     string
+		want       []string
+	}{
+		{
+			name:   "Valid",
+			method: "textDocument/hover",
+			call:   true,
+			params: `{"textDocument":{"uri":"file:///a.go"},"position":{"line":1,"character":2}}`,
+		},
+		{
+			name:   "MissingRequired",
+			method: "textDocument/hover",
+			call:   true,
+			params: `{"textDocument":{"uri":"file:///a.go"}}`,
+			want:   []string{"params.position: missing required property of HoverParams"},
+		},
+		{
+			name:   "WrongType",
+			method: "textDocument/hover",
+			call:   true,
+			params: `{"textDocument":{"uri":"file:///a.go"},"position":{"line":-1,"character":"2"}}`,
+			want: []string{
+				"params.position.line: -1 out of range for uint32",
+				"params.position.character: got string, want integer",
+			},
+		},
+		{
+			name:   "UnknownProperty",
+			method: "textDocument/hover",
+			call:   true,
+			params: `{"textDocument":{"uri":"file:///a.go"},"position":{"line":1,"character":2},"extra":true}`,
+			want:   []string{"params.extra: unknown property of HoverParams"},
+		},
+		{
+			name:   "Array",
+			method: "textDocument/didChange",
+			params: `{"textDocument":{"uri":"file:///a.go","version":2},"contentChanges":[{"text":"x"},42]}`,
+			want:   []string{"params.contentChanges[1]: got number, want object"},
+		},
+		{
+			name:   "UnknownMethod",
+			method: "textDocument/frobnicate",
+			call:   true,
+			params: `{}`,
+			want:   []string{`method: unknown method "textDocument/frobnicate"`},
+		},
+		{
+			name:   "ImplementationDependent",
+			method: "$/frobnicate",
+			params: `{}`,
+		},
+		{
+			name:       "WrongDirection",
+			method:     "textDocument/hover",
+			call:       true,
+			fromServer: true,
+			params:     `{"textDocument":{"uri":"file:///a.go"},"position":{"line":1,"character":2}}`,
+			want:       []string{`method: "textDocument/hover" sent in the wrong direction`},
+		},
+		{
+			name:   "NotificationWithID",
+			method: "initialized",
+			call:   true,
+			params: `{}`,
+			want:   []string{`id: notification "initialized" has an ID`},
+		},
+		{
+			name:   "UnexpectedParams",
+			method: "shutdown",
+			call:   true,
+			params: `{}`,
+			want:   []string{"params: unexpected params"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := json.RawMessage(test.params)
+			var req *jsonrpc2.Request
+			var err error
+			if test.call {
+				req, err = jsonrpc2.NewCall(jsonrpc2.Int64ID(1), test.method, params)
+			} else {
+				req, err = jsonrpc2.NewNotification(test.method, params)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, v := range lsp.ValidateRequest(req, test.fromServer) {
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Expected violations %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateResult(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		result := `{"contents":{"kind":"markdown","value":"x"},"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}}}`
+		if vs := lsp.ValidateResult("textDocument/hover", json.RawMessage(result)); len(vs) > 0 {
+			t.Errorf("Expected no violations, got %v", vs)
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		if vs := lsp.ValidateResult("textDocument/hover", json.RawMessage("null")); len(vs) > 0 {
+			t.Errorf("Expected no violations, got %v", vs)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		vs := lsp.ValidateResult("textDocument/hover", json.RawMessage(`{"contents":42}`))
+		if len(vs) != 1 || vs[0].String() != "result.contents: got number, want object" {
+			t.Errorf("Expected a violation for result.contents, got %v", vs)
+		}
+	})
+}
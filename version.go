@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Protocol versions, in the form accepted by [AtLeast].
+const (
+	Version3_15 = "3.15"
+	Version3_16 = "3.16"
+	Version3_17 = "3.17"
+	Version3_18 = "3.18"
+)
+
+// ProtocolVersion is the version of the protocol implemented by this package.
+const ProtocolVersion = Version3_18
+
+// AtLeast reports whether version is the same as or later than min.
+//
+// Versions are dotted sequences of decimal numbers such as "3.17" or
+// "3.17.0"; missing trailing components are treated as zero. AtLeast
+// reports false if either version is malformed.
+func AtLeast(version, min string) bool {
+	v, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	m, ok := parseVersion(min)
+	if !ok {
+		return false
+	}
+	for len(v) < len(m) {
+		v = append(v, 0)
+	}
+	for len(m) < len(v) {
+		m = append(m, 0)
+	}
+	for i := range v {
+		if v[i] != m[i] {
+			return v[i] > m[i]
+		}
+	}
+	return true
+}
+
+func parseVersion(version string) ([]int, bool) {
+	if version == "" {
+		return nil, false
+	}
+	var nums []int
+	for f := range strings.SplitSeq(version, ".") {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		nums = append(nums, n)
+	}
+	return nums, true
+}
+
+// ClientVersion returns the latest protocol version, in "major.minor"
+// form, that introduced a capability present in caps.
+//
+// Clients do not announce the version of the protocol they implement, so
+// this is only a lower bound: a client may implement a later version but
+// support none of its optional features. Use it with [AtLeast] or
+// [Feature.SupportedBy] to branch behavior for older clients, and prefer
+// testing for the specific capability where there is one.
+func ClientVersion(caps *ClientCapabilities) string {
+	if caps == nil {
+		return Version3_15
+	}
+	td, ws := &caps.TextDocument, &caps.Workspace
+	switch {
+	case td.InlineCompletion != nil,
+		td.Filters != nil,
+		ws.TextDocumentContent != nil,
+		ws.FoldingRange != nil:
+		return Version3_18
+	case td.Diagnostic != nil,
+		td.InlayHint != nil,
+		td.InlineValue != nil,
+		td.TypeHierarchy != nil,
+		caps.NotebookDocument != nil,
+		ws.Diagnostics != nil,
+		ws.InlayHint != nil,
+		ws.InlineValue != nil,
+		caps.General != nil && len(caps.General.PositionEncodings) > 0:
+		return Version3_17
+	case td.CallHierarchy != nil,
+		td.LinkedEditingRange != nil,
+		td.Moniker != nil,
+		len(td.SemanticTokens.TokenTypes) > 0,
+		ws.SemanticTokens != nil,
+		ws.CodeLens != nil,
+		ws.FileOperations != nil,
+		caps.Window.ShowDocument != nil,
+		caps.General != nil:
+		return Version3_16
+	}
+	return Version3_15
+}
+
+// A Feature is a part of the protocol introduced in a particular version.
+type Feature struct {
+	Name  string
+	Since string // the version that introduced the feature
+}
+
+// SupportedBy reports whether a peer implementing the given version of
+// the protocol may support f.
+func (f Feature) SupportedBy(version string) bool {
+	return AtLeast(version, f.Since)
+}
+
+// Features whose availability depends on the version of the protocol.
+var (
+	FeatureCallHierarchy      = Feature{"callHierarchy", Version3_16}
+	FeatureFileOperations     = Feature{"fileOperations", Version3_16}
+	FeatureLinkedEditingRange = Feature{"linkedEditingRange", Version3_16}
+	FeatureMoniker            = Feature{"moniker", Version3_16}
+	FeatureSemanticTokens     = Feature{"semanticTokens", Version3_16}
+	FeatureShowDocument       = Feature{"showDocument", Version3_16}
+
+	FeatureCompletionItemDefaults = Feature{"completionList.itemDefaults", Version3_17}
+	FeatureInlayHint              = Feature{"inlayHint", Version3_17}
+	FeatureInlineValue            = Feature{"inlineValue", Version3_17}
+	FeatureLabelDetails           = Feature{"completionItem.labelDetails", Version3_17}
+	FeatureNotebookDocument       = Feature{"notebookDocument", Version3_17}
+	FeaturePositionEncoding       = Feature{"positionEncoding", Version3_17}
+	FeaturePullDiagnostics        = Feature{"diagnostic", Version3_17}
+	FeatureTypeHierarchy          = Feature{"typeHierarchy", Version3_17}
+
+	FeatureInlineCompletion    = Feature{"inlineCompletion", Version3_18}
+	FeatureTextDocumentContent = Feature{"textDocumentContent", Version3_18}
+)
@@ -0,0 +1,76 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"typefox.dev/lsp"
+)
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		version, min string
+		want         bool
+	}{
+		{"3.17", "3.17", true},
+		{"3.17.0", "3.17", true},
+		{"3.17", "3.17.1", false},
+		{"3.18", "3.17", true},
+		{"3.16.5", "3.17", false},
+		{"4.0", "3.17", true},
+		{"3.9", "3.10", false},
+		{"", "3.17", false},
+		{"3.x", "3.17", false},
+		{"3.17", "", false},
+	}
+	for _, test := range tests {
+		if got := lsp.AtLeast(test.version, test.min); got != test.want {
+			t.Errorf("AtLeast(%q, %q) = %v, want %v", test.version, test.min, got, test.want)
+		}
+	}
+}
+
+func TestClientVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		caps *lsp.ClientCapabilities
+		want string
+	}{
+		{"Nil", nil, lsp.Version3_15},
+		{"Empty", &lsp.ClientCapabilities{}, lsp.Version3_15},
+		{"CallHierarchy", &lsp.ClientCapabilities{
+			TextDocument: lsp.TextDocumentClientCapabilities{CallHierarchy: &lsp.CallHierarchyClientCapabilities{}},
+		}, lsp.Version3_16},
+		{"PositionEncodings", &lsp.ClientCapabilities{
+			General: &lsp.GeneralClientCapabilities{PositionEncodings: []lsp.PositionEncodingKind{lsp.UTF8}},
+		}, lsp.Version3_17},
+		{"InlineCompletion", &lsp.ClientCapabilities{
+			TextDocument: lsp.TextDocumentClientCapabilities{
+				InlayHint:        &lsp.InlayHintClientCapabilities{},
+				InlineCompletion: &lsp.InlineCompletionClientCapabilities{},
+			},
+		}, lsp.Version3_18},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := lsp.ClientVersion(test.caps); got != test.want {
+				t.Errorf("Expected version %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFeatureSupportedBy(t *testing.T) {
+	version := lsp.ClientVersion(&lsp.ClientCapabilities{
+		TextDocument: lsp.TextDocumentClientCapabilities{Moniker: &lsp.MonikerClientCapabilities{}},
+	})
+	if !lsp.FeatureSemanticTokens.SupportedBy(version) {
+		t.Errorf("Expected %s to be supported by %s", lsp.FeatureSemanticTokens.Name, version)
+	}
+	if lsp.FeatureInlayHint.SupportedBy(version) {
+		t.Errorf("Expected %s not to be supported by %s", lsp.FeatureInlayHint.Name, version)
+	}
+}
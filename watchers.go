@@ -0,0 +1,256 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// FolderWatchers returns the file system watchers of the files matching
+// the glob patterns within a workspace folder, such as "**/*.go", to
+// register with [NewWatchedFilesRegistration].
+//
+// As the specification recommends, the watchers have relative patterns
+// based on the folder if the client supports them, so that the client
+// need only watch the folder. Otherwise their patterns are absolute,
+// made of the path of the folder, with its glob metacharacters quoted,
+// and the pattern.
+func FolderWatchers(caps *ClientCapabilities, folder WorkspaceFolder, patterns ...string) []FileSystemWatcher {
+	relative := caps != nil && caps.Workspace.DidChangeWatchedFiles.RelativePatternSupport
+	base := DocumentURI(folder.URI)
+	watchers := make([]FileSystemWatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimLeft(pattern, "/")
+		var p GlobPattern
+		if relative {
+			p.RelativePattern = &RelativePattern{BaseURI: base, Pattern: pattern}
+		} else {
+			abs := strings.TrimSuffix(quoteGlob(filepath.ToSlash(base.Path())), "/") + "/" + pattern
+			p.Pattern = &abs
+		}
+		watchers = append(watchers, FileSystemWatcher{GlobPattern: p})
+	}
+	return watchers
+}
+
+// quoteGlob returns a glob pattern that matches the name s only.
+func quoteGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', '{', '}':
+			b.WriteByte('[')
+			b.WriteRune(r)
+			b.WriteByte(']')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// WorkspaceFolders tracks the workspace folders of a client, and keeps
+// file system watchers of the files matching its patterns registered
+// for each folder, adding and removing them along with the folders.
+//
+// Its Handler follows the folders through the initialize request and
+// the workspace/didChangeWorkspaceFolders notifications, and registers
+// the watchers once the client is initialized:
+//
+//	folders := lsp.NewWorkspaceFolders(lsp.NewRegistrar(client), "**/*.go", "**/go.mod")
+//	handler := folders.Handler(lsp.ServerHandler(server))
+//
+// Servers that handle these messages themselves call Initialize, Watch
+// and Update instead. The watchers are registered only if the client
+// supports their dynamic registration.
+type WorkspaceFolders struct {
+	registrar *Registrar
+	patterns  []string
+
+	mu       sync.Mutex
+	caps     *ClientCapabilities
+	watching bool
+	folders  []WorkspaceFolder
+	watchers map[string]string // registration IDs, by folder URI
+}
+
+// NewWorkspaceFolders returns a WorkspaceFolders that registers with
+// registrar the watchers of the files matching the glob patterns within
+// each folder. Without patterns, it only tracks the folders.
+func NewWorkspaceFolders(registrar *Registrar, patterns ...string) *WorkspaceFolders {
+	return &WorkspaceFolders{
+		registrar: registrar,
+		patterns:  patterns,
+		watchers:  make(map[string]string),
+	}
+}
+
+// Folders returns the workspace folders, in the order they were added.
+func (w *WorkspaceFolders) Folders() []WorkspaceFolder {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.folders)
+}
+
+// Initialize records the capabilities of the client, and the workspace
+// folders as given by [WorkspaceRoots], from the parameters of the
+// initialize request.
+func (w *WorkspaceFolders) Initialize(params *ParamInitialize) error {
+	roots, err := WorkspaceRoots(params)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.caps = &params.Capabilities
+	w.folders = roots
+	return nil
+}
+
+// Watch registers the watchers of the current folders, and of those
+// added later. It is called once the client is initialized, as a server
+// may not register capabilities earlier.
+func (w *WorkspaceFolders) Watch(ctx context.Context) error {
+	w.mu.Lock()
+	if w.watching {
+		w.mu.Unlock()
+		return nil
+	}
+	w.watching = true
+	folders := slices.Clone(w.folders)
+	w.mu.Unlock()
+	return w.watch(ctx, folders)
+}
+
+// Update applies a change of the workspace folders, as notified by
+// workspace/didChangeWorkspaceFolders, unregistering the watchers of
+// the folders removed and registering those of the folders added.
+func (w *WorkspaceFolders) Update(ctx context.Context, change WorkspaceFoldersChangeEvent) error {
+	var added []WorkspaceFolder
+	var ids []string
+	w.mu.Lock()
+	for _, f := range change.Removed {
+		uri, err := workspaceRoot(f.URI)
+		if err != nil {
+			continue // not one of ours
+		}
+		w.folders = slices.DeleteFunc(w.folders, func(g WorkspaceFolder) bool { return g.URI == string(uri) })
+		if id, ok := w.watchers[string(uri)]; ok {
+			ids = append(ids, id)
+			delete(w.watchers, string(uri))
+		}
+	}
+	var errs []error
+	for _, f := range change.Added {
+		uri, err := workspaceRoot(f.URI)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("workspace folder %q: %v", f.Name, err))
+			continue
+		}
+		if slices.ContainsFunc(w.folders, func(g WorkspaceFolder) bool { return g.URI == string(uri) }) {
+			continue
+		}
+		if f.Name == "" {
+			f.Name = uri.Base()
+		}
+		f.URI = string(uri)
+		w.folders = append(w.folders, f)
+		added = append(added, f)
+	}
+	watching := w.watching
+	w.mu.Unlock()
+
+	if len(ids) > 0 {
+		errs = append(errs, w.registrar.Unregister(ctx, ids...))
+	}
+	if watching {
+		errs = append(errs, w.watch(ctx, added))
+	}
+	return errors.Join(errs...)
+}
+
+// watch registers the watchers of folders, if the client supports it.
+func (w *WorkspaceFolders) watch(ctx context.Context, folders []WorkspaceFolder) error {
+	w.mu.Lock()
+	caps := w.caps
+	w.mu.Unlock()
+	if len(w.patterns) == 0 || len(folders) == 0 || caps == nil || !caps.Workspace.DidChangeWatchedFiles.DynamicRegistration {
+		return nil
+	}
+	var regs []Registration
+	for _, f := range folders {
+		reg, err := NewWatchedFilesRegistration(FolderWatchers(caps, f, w.patterns...)...)
+		if err != nil {
+			return err
+		}
+		regs = append(regs, reg)
+	}
+	regs, err := w.registrar.Register(ctx, regs...)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var stale []string // of folders removed meanwhile
+	for i, f := range folders {
+		if slices.ContainsFunc(w.folders, func(g WorkspaceFolder) bool { return g.URI == f.URI }) {
+			w.watchers[f.URI] = regs[i].ID
+		} else {
+			stale = append(stale, regs[i].ID)
+		}
+	}
+	if len(stale) > 0 {
+		ctx := detach(ctx)
+		go func() {
+			if err := w.registrar.Unregister(ctx, stale...); err != nil {
+				event.Error(ctx, "unregistering watchers failed", err)
+			}
+		}()
+	}
+	return nil
+}
+
+// Handler returns a handler that invokes handler, after calling
+// Initialize with the parameters of the initialize request, and Update
+// with those of workspace/didChangeWorkspaceFolders notifications, and
+// that calls Watch once handler has handled the initialized
+// notification. As the client does not await notifications, their
+// errors are reported through the event system.
+func (w *WorkspaceFolders) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case "initialize":
+			var params ParamInitialize
+			if err := UnmarshalJSON(req.Params, &params); err == nil {
+				if err := w.Initialize(&params); err != nil {
+					event.Error(ctx, "invalid workspace folders", err, RequestLabels(ctx)...)
+				}
+			}
+		case "workspace/didChangeWorkspaceFolders":
+			var params DidChangeWorkspaceFoldersParams
+			if err := UnmarshalJSON(req.Params, &params); err == nil {
+				if err := w.Update(ctx, params.Event); err != nil {
+					event.Error(ctx, "updating workspace folders failed", err, RequestLabels(ctx)...)
+				}
+			}
+		case "initialized":
+			result, err := handler.Handle(ctx, req)
+			if err := w.Watch(ctx); err != nil {
+				event.Error(ctx, "registering watchers failed", err, RequestLabels(ctx)...)
+			}
+			return result, err
+		}
+		return handler.Handle(ctx, req)
+	})
+}
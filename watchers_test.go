@@ -0,0 +1,135 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestFolderWatchers(t *testing.T) {
+	folder := lsp.WorkspaceFolder{URI: "file:///home/user/a%5Bb%5D%7Bc%7D", Name: "a[b]{c}"}
+	relative := &lsp.ClientCapabilities{}
+	relative.Workspace.DidChangeWatchedFiles = lsp.DidChangeWatchedFilesClientCapabilities{RelativePatternSupport: true}
+
+	for _, test := range []struct {
+		name string
+		caps *lsp.ClientCapabilities
+		want string
+	}{
+		{"Relative", relative, `[{"globPattern":{"baseUri":"file:///home/user/a%5Bb%5D%7Bc%7D","pattern":"**/*.go"}}]`},
+		{"Absolute", &lsp.ClientCapabilities{}, `[{"globPattern":"/home/user/a[[]b][{]c[}]/**/*.go"}]`},
+		{"NoCapabilities", nil, `[{"globPattern":"/home/user/a[[]b][{]c[}]/**/*.go"}]`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			watchers := lsp.FolderWatchers(test.caps, folder, "**/*.go")
+			data, err := json.Marshal(watchers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(data); got != test.want {
+				t.Errorf("Expected %s, got %s", test.want, got)
+			}
+			for uri, want := range map[lsp.DocumentURI]bool{
+				"file:///home/user/a%5Bb%5D%7Bc%7D/pkg/x.go": true,
+				"file:///home/user/a%5Bb%5D%7Bc%7D/x.txt":    false,
+				"file:///home/user/other/x.go":               false,
+			} {
+				if got := lsp.MatchGlobPattern(watchers[0].GlobPattern, uri); got != want {
+					t.Errorf("Expected match of %s to be %v, got %v", uri, want, got)
+				}
+			}
+			if _, err := lsp.NewWatchedFilesRegistration(watchers...); err != nil {
+				t.Errorf("Expected valid watchers, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceFolders(t *testing.T) {
+	ctx := context.Background()
+	client := &registrationRecorder{}
+	folders := lsp.NewWorkspaceFolders(lsp.NewRegistrar(client), "**/*.go")
+	handler := folders.Handler(jsonrpc2.HandlerFunc(func(context.Context, *jsonrpc2.Request) (any, error) {
+		return nil, nil
+	}))
+	handle := func(method string, params any) {
+		t.Helper()
+		var req *jsonrpc2.Request
+		var err error
+		if method == "initialize" {
+			req, err = jsonrpc2.NewCall(jsonrpc2.Int64ID(1), method, params)
+		} else {
+			req, err = jsonrpc2.NewNotification(method, params)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := handler.Handle(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// watched returns the base URIs of the registered watchers.
+	watched := func() []string {
+		var uris []string
+		for _, reg := range client.registered {
+			for _, w := range reg.RegisterOptions.(*lsp.DidChangeWatchedFilesRegistrationOptions).Watchers {
+				uris = append(uris, string(w.GlobPattern.RelativePattern.BaseURI))
+			}
+		}
+		return uris
+	}
+
+	params := &lsp.ParamInitialize{}
+	params.WorkspaceFolders = []lsp.WorkspaceFolder{{URI: "file:///a"}, {URI: "file:///b/"}}
+	params.Capabilities.Workspace.DidChangeWatchedFiles = lsp.DidChangeWatchedFilesClientCapabilities{
+		DynamicRegistration:    true,
+		RelativePatternSupport: true,
+	}
+	handle("initialize", params)
+	if len(client.registered) != 0 {
+		t.Fatalf("Expected no registration before initialized, got %v", client.registered)
+	}
+	handle("initialized", &lsp.InitializedParams{})
+	if diff := cmp.Diff([]string{"file:///a", "file:///b"}, watched()); diff != "" {
+		t.Errorf("Unexpected watched folders (-want +got):\n%s", diff)
+	}
+
+	handle("workspace/didChangeWorkspaceFolders", &lsp.DidChangeWorkspaceFoldersParams{Event: lsp.WorkspaceFoldersChangeEvent{
+		Added:   []lsp.WorkspaceFolder{{URI: "file:///c", Name: "c"}, {URI: "file:///a"}},
+		Removed: []lsp.WorkspaceFolder{{URI: "file:///b", Name: "b"}},
+	}})
+	if diff := cmp.Diff([]string{"file:///a", "file:///b", "file:///c"}, watched()); diff != "" {
+		t.Errorf("Unexpected watched folders (-want +got):\n%s", diff)
+	}
+	if len(client.unregistered) != 1 || client.unregistered[0].ID != client.registered[1].ID {
+		t.Errorf("Expected the watchers of b to be unregistered, got %v", client.unregistered)
+	}
+	want := []lsp.WorkspaceFolder{{URI: "file:///a", Name: "a"}, {URI: "file:///c", Name: "c"}}
+	if diff := cmp.Diff(want, folders.Folders()); diff != "" {
+		t.Errorf("Unexpected folders (-want +got):\n%s", diff)
+	}
+
+	t.Run("NoDynamicRegistration", func(t *testing.T) {
+		client := &registrationRecorder{}
+		folders := lsp.NewWorkspaceFolders(lsp.NewRegistrar(client), "**/*.go")
+		params := &lsp.ParamInitialize{}
+		params.WorkspaceFolders = []lsp.WorkspaceFolder{{URI: "file:///a"}}
+		if err := folders.Initialize(params); err != nil {
+			t.Fatal(err)
+		}
+		if err := folders.Watch(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(client.registered) != 0 {
+			t.Errorf("Expected no registration, got %v", client.registered)
+		}
+	})
+}
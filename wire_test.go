@@ -0,0 +1,66 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"typefox.dev/lsp"
+	"typefox.dev/lsp/lsptest"
+)
+
+// TestWireFormat locks the JSON encoding of a few representative
+// protocol values, in particular of unions and optional fields.
+// Run with LSPTEST_UPDATE_GOLDEN=1 to regenerate the golden files.
+func TestWireFormat(t *testing.T) {
+	annotation := lsp.ChangeAnnotationIdentifier("rename")
+	message := "undefined: x"
+	golden := func(name string) string {
+		return filepath.Join("testdata", "wire", name+".json")
+	}
+	t.Run("Hover", func(t *testing.T) {
+		lsptest.CheckGolden(t, golden("hover"), &lsp.Hover{
+			Contents: lsp.MarkupContent{Kind: lsp.Markdown, Value: "`func F()`"},
+			Range:    rng(1, 5, 6),
+		})
+	})
+	t.Run("WorkspaceEdit", func(t *testing.T) {
+		lsptest.CheckGolden(t, golden("workspaceedit"), &lsp.WorkspaceEdit{
+			DocumentChanges: []lsp.DocumentChange{
+				{CreateFile: &lsp.CreateFile{Kind: "create", URI: "file:///b.go"}},
+				{TextDocumentEdit: &lsp.TextDocumentEdit{
+					TextDocument: lsp.OptionalVersionedTextDocumentIdentifier{
+						Version:                3,
+						TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: "file:///a.go"},
+					},
+					Edits: []lsp.TextDocumentEditEditsElem{
+						{TextEdit: &lsp.TextEdit{Range: rng(0, 0, 1), NewText: "x"}},
+						{AnnotatedTextEdit: &lsp.AnnotatedTextEdit{
+							AnnotationID: &annotation,
+							TextEdit:     lsp.TextEdit{Range: rng(2, 4, 5), NewText: "y"},
+						}},
+					},
+				}},
+			},
+			ChangeAnnotations: map[lsp.ChangeAnnotationIdentifier]lsp.ChangeAnnotation{
+				annotation: {Label: "Rename x to y", NeedsConfirmation: true},
+			},
+		})
+	})
+	t.Run("PublishDiagnostics", func(t *testing.T) {
+		lsptest.CheckGolden(t, golden("publishdiagnostics"), &lsp.PublishDiagnosticsParams{
+			URI:     "file:///a.go",
+			Version: 2,
+			Diagnostics: []lsp.Diagnostic{{
+				Range:    rng(3, 1, 4),
+				Severity: lsp.SeverityError,
+				Source:   "compiler",
+				Message:  lsp.DiagnosticMessage{String: &message},
+				Tags:     []lsp.DiagnosticTag{lsp.Unnecessary},
+			}},
+		})
+	})
+}
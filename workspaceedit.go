@@ -0,0 +1,330 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Normalize returns a normalized copy of edit, leaving edit unchanged.
+// For each document, the text edits are sorted by position, edits that
+// replace nothing with nothing are dropped, and each pair of abutting
+// edits with the same change annotation is merged into a single edit.
+// Empty TextDocumentEdits are dropped. TextDocumentEdits of the same
+// document are kept apart, as the edits of a later one apply to the
+// result of the earlier one.
+//
+// If caps is not nil, the edit is also expressed in the form the client
+// supports: Changes are converted into DocumentChanges if the client
+// supports them and version reports the current version of every changed
+// document (the protocol requires it); DocumentChanges are converted into
+// Changes if the client does not support them and they contain only text
+// edits. See [DowngradeWorkspaceEdit] for edits with resource operations.
+func Normalize(edit *WorkspaceEdit, caps *WorkspaceEditClientCapabilities, version func(DocumentURI) (int32, bool)) *WorkspaceEdit {
+	result := &WorkspaceEdit{ChangeAnnotations: maps.Clone(edit.ChangeAnnotations)}
+
+	if edit.Changes != nil {
+		result.Changes = make(map[DocumentURI][]TextEdit, len(edit.Changes))
+		for uri, edits := range edit.Changes {
+			if edits := normalizeTextEdits(edits); len(edits) > 0 {
+				result.Changes[uri] = edits
+			}
+		}
+	}
+
+	for _, change := range edit.DocumentChanges {
+		tde := change.TextDocumentEdit
+		if tde == nil {
+			result.DocumentChanges = append(result.DocumentChanges, change)
+			continue
+		}
+		result.DocumentChanges = append(result.DocumentChanges, DocumentChange{
+			TextDocumentEdit: &TextDocumentEdit{
+				TextDocument: tde.TextDocument,
+				Edits:        slices.Clone(tde.Edits),
+			},
+		})
+	}
+	result.DocumentChanges = slices.DeleteFunc(result.DocumentChanges, func(change DocumentChange) bool {
+		tde := change.TextDocumentEdit
+		if tde == nil {
+			return false
+		}
+		tde.Edits = normalizeEdits(tde.Edits)
+		return len(tde.Edits) == 0
+	})
+
+	if caps == nil {
+		return result
+	}
+	if caps.DocumentChanges && len(result.Changes) > 0 && version != nil {
+		var changes []DocumentChange
+		for _, uri := range slices.Sorted(maps.Keys(result.Changes)) {
+			v, ok := version(uri)
+			if !ok {
+				return result // can't convert
+			}
+			changes = append(changes, DocumentChange{
+				TextDocumentEdit: &TextDocumentEdit{
+					TextDocument: OptionalVersionedTextDocumentIdentifier{
+						Version:                v,
+						TextDocumentIdentifier: TextDocumentIdentifier{URI: uri},
+					},
+					Edits: AsAnnotatedTextEdits(result.Changes[uri]),
+				},
+			})
+		}
+		result.DocumentChanges = append(result.DocumentChanges, changes...)
+		result.Changes = nil
+	} else if !caps.DocumentChanges && len(result.DocumentChanges) > 0 {
+		changes, ok := textChanges(result.DocumentChanges)
+		if !ok {
+			return result // can't convert
+		}
+		if result.Changes == nil {
+			result.Changes = make(map[DocumentURI][]TextEdit)
+		}
+		for uri, edits := range changes {
+			result.Changes[uri] = normalizeTextEdits(append(result.Changes[uri], edits...))
+		}
+		result.DocumentChanges = nil
+	}
+	return result
+}
+
+// AsAnnotatedTextEdits converts a TextEdit slice to the edits of a
+// TextDocumentEdit, which may also be annotated or snippet edits.
+func AsAnnotatedTextEdits(edits []TextEdit) []TextDocumentEditEditsElem {
+	if edits == nil {
+		return nil
+	}
+	result := make([]TextDocumentEditEditsElem, len(edits))
+	for i := range edits {
+		result[i] = TextDocumentEditEditsElem{TextEdit: &edits[i]}
+	}
+	return result
+}
+
+// textChanges returns the edits of changes as plain text edits by
+// document, if changes consist only of text edits that are not snippets,
+// with at most one TextDocumentEdit per document (edits of a later one
+// would apply to the result of the earlier one).
+func textChanges(changes []DocumentChange) (map[DocumentURI][]TextEdit, bool) {
+	result := make(map[DocumentURI][]TextEdit)
+	for _, change := range changes {
+		tde := change.TextDocumentEdit
+		if tde == nil {
+			return nil, false
+		}
+		uri := tde.TextDocument.URI
+		if _, dup := result[uri]; dup {
+			return nil, false
+		}
+		result[uri] = []TextEdit{}
+		for _, e := range tde.Edits {
+			switch {
+			case e.TextEdit != nil:
+				result[uri] = append(result[uri], *e.TextEdit)
+			case e.AnnotatedTextEdit != nil:
+				result[uri] = append(result[uri], e.AnnotatedTextEdit.TextEdit)
+			default:
+				return nil, false
+			}
+		}
+	}
+	return result, true
+}
+
+// normalizeTextEdits is like normalizeEdits, for plain text edits.
+func normalizeTextEdits(edits []TextEdit) []TextEdit {
+	var result []TextEdit
+	for _, e := range normalizeEdits(AsAnnotatedTextEdits(edits)) {
+		result = append(result, *e.TextEdit)
+	}
+	return result
+}
+
+// normalizeEdits returns the edits of a document sorted by position,
+// without no-op edits, and with abutting compatible edits merged.
+// Edits at the same position keep their relative order.
+func normalizeEdits(edits []TextDocumentEditEditsElem) []TextDocumentEditEditsElem {
+	sorted := slices.Clone(edits)
+	slices.SortStableFunc(sorted, func(x, y TextDocumentEditEditsElem) int {
+		return ComparePosition(editRange(x).Start, editRange(y).Start)
+	})
+	var result []TextDocumentEditEditsElem
+	for _, e := range sorted {
+		if e.SnippetTextEdit == nil && editRange(e).Empty() && editText(e) == "" {
+			continue // no-op
+		}
+		if n := len(result); n > 0 {
+			if merged, ok := mergeEdits(result[n-1], e); ok {
+				result[n-1] = merged
+				continue
+			}
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// mergeEdits returns a single edit equivalent to x followed by y,
+// if y begins where x ends and both are text edits with the same annotation.
+func mergeEdits(x, y TextDocumentEditEditsElem) (TextDocumentEditEditsElem, bool) {
+	if x.SnippetTextEdit != nil || y.SnippetTextEdit != nil {
+		return TextDocumentEditEditsElem{}, false
+	}
+	xr, yr := editRange(x), editRange(y)
+	if xr.End != yr.Start || editAnnotation(x) != editAnnotation(y) {
+		return TextDocumentEditEditsElem{}, false
+	}
+	edit := TextEdit{Range: Range{Start: xr.Start, End: yr.End}, NewText: editText(x) + editText(y)}
+	if x.AnnotatedTextEdit != nil {
+		return TextDocumentEditEditsElem{AnnotatedTextEdit: &AnnotatedTextEdit{
+			AnnotationID: x.AnnotatedTextEdit.AnnotationID,
+			TextEdit:     edit,
+		}}, true
+	}
+	return TextDocumentEditEditsElem{TextEdit: &edit}, true
+}
+
+func editRange(e TextDocumentEditEditsElem) Range {
+	switch {
+	case e.TextEdit != nil:
+		return e.TextEdit.Range
+	case e.AnnotatedTextEdit != nil:
+		return e.AnnotatedTextEdit.Range
+	case e.SnippetTextEdit != nil:
+		return e.SnippetTextEdit.Range
+	}
+	return Range{}
+}
+
+func editText(e TextDocumentEditEditsElem) string {
+	switch {
+	case e.TextEdit != nil:
+		return e.TextEdit.NewText
+	case e.AnnotatedTextEdit != nil:
+		return e.AnnotatedTextEdit.NewText
+	case e.SnippetTextEdit != nil:
+		return e.SnippetTextEdit.Snippet.Value
+	}
+	return ""
+}
+
+// editAnnotation returns the change annotation of e, or "" if none.
+func editAnnotation(e TextDocumentEditEditsElem) ChangeAnnotationIdentifier {
+	switch {
+	case e.AnnotatedTextEdit != nil && e.AnnotatedTextEdit.AnnotationID != nil:
+		return *e.AnnotatedTextEdit.AnnotationID
+	case e.SnippetTextEdit != nil && e.SnippetTextEdit.AnnotationID != nil:
+		return *e.SnippetTextEdit.AnnotationID
+	}
+	return ""
+}
+
+// DowngradeWorkspaceEdit returns edit in a form the client described by
+// caps can apply, or an error if there is none.
+//
+// If the client supports DocumentChanges, edit is returned unchanged
+// unless it contains resource operations (create, rename and delete)
+// the client does not support. Otherwise its DocumentChanges are
+// converted into Changes, which is possible only if they consist of text
+// edits, with at most one TextDocumentEdit per document, and no snippet
+// edits.
+//
+// The error is an *UnsupportedChangesError, listing every change that
+// cannot be sent, so that a server may report the problem to the user
+// instead of sending changes the client would ignore or reject.
+func DowngradeWorkspaceEdit(edit *WorkspaceEdit, caps *WorkspaceEditClientCapabilities) (*WorkspaceEdit, error) {
+	var unsupported []DocumentChange
+	if caps.DocumentChanges {
+		for _, change := range edit.DocumentChanges {
+			if kind := resourceOperationKind(change); kind != "" && !slices.Contains(caps.ResourceOperations, kind) {
+				unsupported = append(unsupported, change)
+			}
+		}
+		if len(unsupported) > 0 {
+			return nil, &UnsupportedChangesError{Changes: unsupported}
+		}
+		return edit, nil
+	}
+
+	if len(edit.DocumentChanges) == 0 {
+		return edit, nil
+	}
+	result := &WorkspaceEdit{Changes: make(map[DocumentURI][]TextEdit)}
+	for uri, edits := range edit.Changes {
+		result.Changes[uri] = slices.Clone(edits)
+	}
+	seen := make(map[DocumentURI]bool)
+	for _, change := range edit.DocumentChanges {
+		tde := change.TextDocumentEdit
+		if tde == nil || seen[tde.TextDocument.URI] {
+			unsupported = append(unsupported, change)
+			continue
+		}
+		uri := tde.TextDocument.URI
+		seen[uri] = true
+		for _, e := range tde.Edits {
+			switch {
+			case e.TextEdit != nil:
+				result.Changes[uri] = append(result.Changes[uri], *e.TextEdit)
+			case e.AnnotatedTextEdit != nil:
+				result.Changes[uri] = append(result.Changes[uri], e.AnnotatedTextEdit.TextEdit)
+			default: // snippet edits are only valid in DocumentChanges
+				unsupported = append(unsupported, change)
+			}
+		}
+	}
+	if len(unsupported) > 0 {
+		return nil, &UnsupportedChangesError{Changes: slices.Compact(unsupported)}
+	}
+	return result, nil
+}
+
+// An UnsupportedChangesError reports the changes of a WorkspaceEdit
+// that a client does not support.
+type UnsupportedChangesError struct {
+	Changes []DocumentChange
+}
+
+func (e *UnsupportedChangesError) Error() string {
+	var b strings.Builder
+	b.WriteString("client does not support workspace edit changes: ")
+	for i, change := range e.Changes {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		switch {
+		case change.CreateFile != nil:
+			fmt.Fprintf(&b, "create %s", change.CreateFile.URI)
+		case change.RenameFile != nil:
+			fmt.Fprintf(&b, "rename %s to %s", change.RenameFile.OldURI, change.RenameFile.NewURI)
+		case change.DeleteFile != nil:
+			fmt.Fprintf(&b, "delete %s", change.DeleteFile.URI)
+		case change.TextDocumentEdit != nil:
+			fmt.Fprintf(&b, "edit %s", change.TextDocumentEdit.TextDocument.URI)
+		}
+	}
+	return b.String()
+}
+
+// resourceOperationKind returns the kind of the resource operation
+// change, or "" if it is a text edit.
+func resourceOperationKind(change DocumentChange) ResourceOperationKind {
+	switch {
+	case change.CreateFile != nil:
+		return Create
+	case change.RenameFile != nil:
+		return Rename
+	case change.DeleteFile != nil:
+		return Delete
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func rng(line, start, end uint32) lsp.Range {
+	return lsp.Range{Start: lsp.Position{Line: line, Character: start}, End: lsp.Position{Line: line, Character: end}}
+}
+
+func TestNormalize(t *testing.T) {
+	const a, b lsp.DocumentURI = "file:///a.go", "file:///b.go"
+	versions := map[lsp.DocumentURI]int32{a: 3, b: 7}
+	version := func(uri lsp.DocumentURI) (int32, bool) {
+		v, ok := versions[uri]
+		return v, ok
+	}
+
+	t.Run("Changes", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+			a: {
+				{Range: rng(2, 0, 1), NewText: "x"},
+				{Range: rng(1, 4, 4), NewText: ""}, // no-op
+				{Range: rng(1, 0, 2), NewText: "p"},
+				{Range: rng(1, 2, 3), NewText: "q"}, // abuts the previous one
+				{Range: rng(1, 3, 3), NewText: "r"},
+			},
+			b: {{Range: rng(0, 0, 0), NewText: ""}},
+		}}
+		got := lsp.Normalize(edit, nil, nil)
+		want := &lsp.WorkspaceEdit{Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+			a: {
+				{Range: rng(1, 0, 3), NewText: "pqr"},
+				{Range: rng(2, 0, 1), NewText: "x"},
+			},
+		}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Normalize mismatch (-want +got):\n%s", diff)
+		}
+		if len(edit.Changes[a]) != 5 {
+			t.Errorf("Normalize modified its argument")
+		}
+	})
+
+	t.Run("ToDocumentChanges", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+			b: {{Range: rng(0, 0, 1), NewText: "y"}},
+			a: {{Range: rng(0, 0, 1), NewText: "x"}},
+		}}
+		got := lsp.Normalize(edit, &lsp.WorkspaceEditClientCapabilities{DocumentChanges: true}, version)
+		if got.Changes != nil || len(got.DocumentChanges) != 2 {
+			t.Fatalf("Expected only DocumentChanges, got %+v", got)
+		}
+		tde := got.DocumentChanges[1].TextDocumentEdit
+		if tde.TextDocument.URI != b || tde.TextDocument.Version != 7 || tde.Edits[0].TextEdit.NewText != "y" {
+			t.Errorf("Expected version 7 of %s, got %+v", b, tde)
+		}
+
+		// Without the version of every document, Changes are kept.
+		delete(versions, b)
+		defer func() { versions[b] = 7 }()
+		if got := lsp.Normalize(edit, &lsp.WorkspaceEditClientCapabilities{DocumentChanges: true}, version); got.DocumentChanges != nil {
+			t.Errorf("Expected no DocumentChanges, got %+v", got.DocumentChanges)
+		}
+	})
+
+	t.Run("DocumentChanges", func(t *testing.T) {
+		ident := lsp.OptionalVersionedTextDocumentIdentifier{Version: 3, TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: a}}
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{
+			{TextDocumentEdit: &lsp.TextDocumentEdit{TextDocument: ident, Edits: lsp.AsAnnotatedTextEdits([]lsp.TextEdit{
+				{Range: rng(1, 1, 2), NewText: "b"},
+				{Range: rng(1, 0, 1), NewText: "a"},
+			})}},
+		}}
+		want := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{
+			{TextDocumentEdit: &lsp.TextDocumentEdit{TextDocument: ident, Edits: lsp.AsAnnotatedTextEdits([]lsp.TextEdit{{Range: rng(1, 0, 2), NewText: "ab"}})}},
+		}}
+		if diff := cmp.Diff(want, lsp.Normalize(edit, nil, nil)); diff != "" {
+			t.Errorf("Normalize mismatch (-want +got):\n%s", diff)
+		}
+
+		// A client without support for DocumentChanges gets Changes.
+		got := lsp.Normalize(edit, &lsp.WorkspaceEditClientCapabilities{}, nil)
+		wantChanges := map[lsp.DocumentURI][]lsp.TextEdit{a: {{Range: rng(1, 0, 2), NewText: "ab"}}}
+		if diff := cmp.Diff(wantChanges, got.Changes); diff != "" || got.DocumentChanges != nil {
+			t.Errorf("Normalize mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("SequentialDocumentChanges", func(t *testing.T) {
+		// The edits of the second TextDocumentEdit apply to the result of
+		// the first, so they are not merged: "abc" becomes "XYbc", where
+		// merging them would make it "XaYc".
+		ident := lsp.OptionalVersionedTextDocumentIdentifier{Version: 3, TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: a}}
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{
+			{TextDocumentEdit: &lsp.TextDocumentEdit{TextDocument: ident, Edits: lsp.AsAnnotatedTextEdits([]lsp.TextEdit{{Range: rng(0, 0, 0), NewText: "X"}})}},
+			{TextDocumentEdit: &lsp.TextDocumentEdit{TextDocument: ident, Edits: lsp.AsAnnotatedTextEdits([]lsp.TextEdit{{Range: rng(0, 1, 2), NewText: "Y"}})}},
+		}}
+		if diff := cmp.Diff(edit, lsp.Normalize(edit, nil, nil)); diff != "" {
+			t.Errorf("Normalize mismatch (-want +got):\n%s", diff)
+		}
+		// Nor can they be expressed as Changes.
+		if got := lsp.Normalize(edit, &lsp.WorkspaceEditClientCapabilities{}, nil); got.Changes != nil || len(got.DocumentChanges) != 2 {
+			t.Errorf("Expected the DocumentChanges to be kept, got %+v", got)
+		}
+	})
+
+	t.Run("ResourceOperations", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{
+			{CreateFile: &lsp.CreateFile{Kind: "create", URI: b}},
+		}}
+		got := lsp.Normalize(edit, &lsp.WorkspaceEditClientCapabilities{}, nil)
+		if len(got.DocumentChanges) != 1 || got.Changes != nil {
+			t.Errorf("Expected resource operations to be kept, got %+v", got)
+		}
+	})
+}
+
+func TestDowngradeWorkspaceEdit(t *testing.T) {
+	const a, b lsp.DocumentURI = "file:///a.go", "file:///b.go"
+	textEdit := func(uri lsp.DocumentURI) lsp.DocumentChange {
+		return lsp.DocumentChange{TextDocumentEdit: &lsp.TextDocumentEdit{
+			TextDocument: lsp.OptionalVersionedTextDocumentIdentifier{TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: uri}},
+			Edits:        lsp.AsAnnotatedTextEdits([]lsp.TextEdit{{Range: rng(0, 0, 1), NewText: "x"}}),
+		}}
+	}
+	create := lsp.DocumentChange{CreateFile: &lsp.CreateFile{Kind: "create", URI: b}}
+	rename := lsp.DocumentChange{RenameFile: &lsp.RenameFile{Kind: "rename", OldURI: a, NewURI: b}}
+
+	t.Run("Supported", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{create, textEdit(b)}}
+		caps := &lsp.WorkspaceEditClientCapabilities{DocumentChanges: true, ResourceOperations: []lsp.ResourceOperationKind{lsp.Create}}
+		got, err := lsp.DowngradeWorkspaceEdit(edit, caps)
+		if err != nil || got != edit {
+			t.Errorf("Expected the edit to be unchanged, got (%+v, %v)", got, err)
+		}
+	})
+
+	t.Run("UnsupportedOperation", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{create, rename}}
+		caps := &lsp.WorkspaceEditClientCapabilities{DocumentChanges: true, ResourceOperations: []lsp.ResourceOperationKind{lsp.Create}}
+		_, err := lsp.DowngradeWorkspaceEdit(edit, caps)
+		want := "client does not support workspace edit changes: rename file:///a.go to file:///b.go"
+		if err == nil || err.Error() != want {
+			t.Errorf("Expected error %q, got %v", want, err)
+		}
+	})
+
+	t.Run("ToChanges", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{textEdit(a), textEdit(b)}}
+		got, err := lsp.DowngradeWorkspaceEdit(edit, &lsp.WorkspaceEditClientCapabilities{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[lsp.DocumentURI][]lsp.TextEdit{
+			a: {{Range: rng(0, 0, 1), NewText: "x"}},
+			b: {{Range: rng(0, 0, 1), NewText: "x"}},
+		}
+		if diff := cmp.Diff(want, got.Changes); diff != "" || got.DocumentChanges != nil {
+			t.Errorf("DowngradeWorkspaceEdit mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NoDocumentChanges", func(t *testing.T) {
+		edit := &lsp.WorkspaceEdit{DocumentChanges: []lsp.DocumentChange{create, textEdit(b), textEdit(b)}}
+		_, err := lsp.DowngradeWorkspaceEdit(edit, &lsp.WorkspaceEditClientCapabilities{})
+		want := "client does not support workspace edit changes: create file:///b.go, edit file:///b.go"
+		if err == nil || err.Error() != want {
+			t.Errorf("Expected error %q, got %v", want, err)
+		}
+	})
+}
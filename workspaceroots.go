@@ -0,0 +1,83 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkspaceRoots returns the workspace folders described by the
+// parameters of an initialize request.
+//
+// As the specification requires, params.WorkspaceFolders takes
+// precedence over the deprecated RootURI, which takes precedence over
+// the deprecated RootPath. A root given by RootURI or RootPath becomes
+// a single folder named after the last element of its path.
+//
+// The URIs of the folders are normalized as by [ParseDocumentURI], and
+// without a trailing slash. A folder whose URI duplicates that of an
+// earlier one is omitted, and a folder without a name is named after
+// the last element of its path. WorkspaceRoots returns nil if the
+// client opened no folder, and an error if a URI is not a valid file
+// URI.
+func WorkspaceRoots(params *ParamInitialize) ([]WorkspaceFolder, error) {
+	if len(params.WorkspaceFolders) > 0 {
+		var folders []WorkspaceFolder
+		seen := make(map[DocumentURI]bool)
+		for _, f := range params.WorkspaceFolders {
+			uri, err := workspaceRoot(f.URI)
+			if err != nil {
+				return nil, fmt.Errorf("workspace folder %q: %v", f.Name, err)
+			}
+			if seen[uri] {
+				continue
+			}
+			seen[uri] = true
+			name := f.Name
+			if name == "" {
+				name = uri.Base()
+			}
+			folders = append(folders, WorkspaceFolder{URI: string(uri), Name: name})
+		}
+		return folders, nil
+	}
+
+	var uri DocumentURI
+	switch {
+	case params.RootURI != "":
+		var err error
+		if uri, err = workspaceRoot(string(params.RootURI)); err != nil {
+			return nil, fmt.Errorf("rootUri: %v", err)
+		}
+	case params.RootPath != "":
+		uri = trimSlash(URIFromPath(params.RootPath))
+	default:
+		return nil, nil
+	}
+	return []WorkspaceFolder{{URI: string(uri), Name: uri.Base()}}, nil
+}
+
+// workspaceRoot returns the normalized form of the URI of a workspace
+// root.
+func workspaceRoot(s string) (DocumentURI, error) {
+	if s == "" {
+		return "", fmt.Errorf("empty URI")
+	}
+	uri, err := ParseDocumentURI(s)
+	if err != nil {
+		return "", err
+	}
+	return trimSlash(uri), nil
+}
+
+// trimSlash removes any trailing slash from uri, unless it denotes the
+// root directory, such as file:/// or file:///C:/.
+func trimSlash(uri DocumentURI) DocumentURI {
+	for strings.HasSuffix(string(uri), "/") && !strings.HasSuffix(string(uri), ":/") && uri != "file:///" {
+		uri = uri[:len(uri)-1]
+	}
+	return uri
+}
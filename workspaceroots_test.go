@@ -0,0 +1,86 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"typefox.dev/lsp"
+)
+
+func TestWorkspaceRoots(t *testing.T) {
+	params := func(rootPath string, rootURI lsp.DocumentURI, folders ...lsp.WorkspaceFolder) *lsp.ParamInitialize {
+		p := new(lsp.ParamInitialize)
+		p.RootPath = rootPath
+		p.RootURI = rootURI
+		p.WorkspaceFolders = folders
+		return p
+	}
+	for _, test := range []struct {
+		name   string
+		params *lsp.ParamInitialize
+		want   []lsp.WorkspaceFolder
+	}{
+		{
+			name:   "None",
+			params: params("", ""),
+		},
+		{
+			name:   "RootPath",
+			params: params("/home/user/proj/", ""),
+			want:   []lsp.WorkspaceFolder{{URI: "file:///home/user/proj", Name: "proj"}},
+		},
+		{
+			name:   "RootURIWins",
+			params: params("/home/user/old", "file:///home/user/proj"),
+			want:   []lsp.WorkspaceFolder{{URI: "file:///home/user/proj", Name: "proj"}},
+		},
+		{
+			name: "FoldersWin",
+			params: params("/home/user/old", "file:///home/user/old",
+				lsp.WorkspaceFolder{URI: "file:///home/user/a", Name: "A"},
+				lsp.WorkspaceFolder{URI: "file:///home/user/b/"}),
+			want: []lsp.WorkspaceFolder{
+				{URI: "file:///home/user/a", Name: "A"},
+				{URI: "file:///home/user/b", Name: "b"},
+			},
+		},
+		{
+			name: "Normalized",
+			params: params("", "",
+				lsp.WorkspaceFolder{URI: "file:///c%3A/src/", Name: "src"},
+				lsp.WorkspaceFolder{URI: "file:///C:/src", Name: "again"},
+				lsp.WorkspaceFolder{URI: "file:///C:/"}),
+			want: []lsp.WorkspaceFolder{
+				{URI: "file:///C:/src", Name: "src"},
+				{URI: "file:///C:/", Name: "C:"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := lsp.WorkspaceRoots(test.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("WorkspaceRoots mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, p := range []*lsp.ParamInitialize{
+			params("", "", lsp.WorkspaceFolder{URI: "https://example.com/repo", Name: "repo"}),
+			params("", "", lsp.WorkspaceFolder{Name: "empty"}),
+		} {
+			if got, err := lsp.WorkspaceRoots(p); err == nil {
+				t.Errorf("Expected an error for %v, got %v", p.WorkspaceFolders, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+// This file implements workspace trust, as an experimental capability.
+// A client that supports it declares
+//
+//	"experimental": {"workspaceTrust": {"trusted": false, "didChangeTrust": true, "trustRequest": true}}
+//
+// and a server that supports a restricted mode, in which some of its
+// features are disabled until the workspace is trusted, declares
+//
+//	"experimental": {"workspaceTrust": {"restrictedMode": true, "restrictedMethods": ["workspace/executeCommand"]}}
+//
+// The server may then query the trust of the workspace with the
+// workspace/trust request, and the client notifies it of changes with
+// workspace/didChangeTrust.
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"golang.org/x/exp/event"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// WorkspaceTrustCapability is the key of the workspace trust capability
+// within the experimental capabilities of clients and servers.
+const WorkspaceTrustCapability = "workspaceTrust"
+
+// The methods of workspace trust.
+const (
+	// WorkspaceTrustMethod is the request by which the server queries
+	// the trust of the workspace. It has no parameters, and its result
+	// is a WorkspaceTrustResult.
+	WorkspaceTrustMethod = "workspace/trust"
+	// DidChangeWorkspaceTrustMethod is the notification by which the
+	// client tells the server that the trust of the workspace changed.
+	// Its parameters are DidChangeWorkspaceTrustParams.
+	DidChangeWorkspaceTrustMethod = "workspace/didChangeTrust"
+)
+
+// WorkspaceTrustClientCapabilities are the experimental workspace trust
+// capabilities of a client.
+type WorkspaceTrustClientCapabilities struct {
+	// Whether the workspace is trusted when the server is initialized.
+	Trusted bool `json:"trusted"`
+	// Whether the client sends workspace/didChangeTrust notifications.
+	DidChangeTrust bool `json:"didChangeTrust,omitempty"`
+	// Whether the client answers workspace/trust requests.
+	TrustRequest bool `json:"trustRequest,omitempty"`
+}
+
+// WorkspaceTrustServerCapabilities are the experimental workspace trust
+// capabilities of a server.
+type WorkspaceTrustServerCapabilities struct {
+	// Whether the server supports untrusted workspaces, with some of its
+	// features disabled.
+	RestrictedMode bool `json:"restrictedMode"`
+	// The methods the server disables until the workspace is trusted.
+	RestrictedMethods []string `json:"restrictedMethods,omitempty"`
+}
+
+// WorkspaceTrustResult is the result of a workspace/trust request.
+type WorkspaceTrustResult struct {
+	Trusted bool `json:"trusted"`
+}
+
+// DidChangeWorkspaceTrustParams are the parameters of a
+// workspace/didChangeTrust notification.
+type DidChangeWorkspaceTrustParams struct {
+	Trusted bool `json:"trusted"`
+}
+
+// ClientWorkspaceTrust returns the workspace trust capabilities of a
+// client, and whether it declares them.
+func ClientWorkspaceTrust(caps *ClientCapabilities) (WorkspaceTrustClientCapabilities, bool) {
+	var trust WorkspaceTrustClientCapabilities
+	ok := experimentalCapability(caps.GetExperimental(), WorkspaceTrustCapability, &trust)
+	return trust, ok
+}
+
+// SetServerWorkspaceTrust declares the workspace trust capabilities of a
+// server, keeping its other experimental capabilities.
+func SetServerWorkspaceTrust(caps *ServerCapabilities, trust WorkspaceTrustServerCapabilities) {
+	caps.Experimental = setExperimentalCapability(caps.Experimental, WorkspaceTrustCapability, trust)
+}
+
+// experimentalCapability decodes the capability of the given key from
+// experimental capabilities into v, and reports whether it is present.
+func experimentalCapability(experimental any, key string, v any) bool {
+	if experimental == nil {
+		return false
+	}
+	data, err := json.Marshal(experimental)
+	if err != nil {
+		return false
+	}
+	var caps map[string]json.RawMessage
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return false
+	}
+	raw, ok := caps[key]
+	return ok && json.Unmarshal(raw, v) == nil
+}
+
+// setExperimentalCapability returns experimental capabilities with the
+// capability of the given key set to v.
+func setExperimentalCapability(experimental any, key string, v any) any {
+	caps := make(map[string]any)
+	if experimental != nil {
+		if data, err := json.Marshal(experimental); err == nil {
+			var old map[string]json.RawMessage
+			if json.Unmarshal(data, &old) == nil {
+				for k, raw := range old {
+					caps[k] = raw
+				}
+			}
+		}
+	}
+	caps[key] = v
+	return caps
+}
+
+// QueryWorkspaceTrust asks the client at the other end of conn whether
+// the workspace is trusted, with a workspace/trust request. Only clients
+// that declare TrustRequest answer it.
+func QueryWorkspaceTrust(ctx context.Context, conn *jsonrpc2.Connection) (bool, error) {
+	var result WorkspaceTrustResult
+	if err := Call(ctx, conn, WorkspaceTrustMethod, nil, &result); err != nil {
+		return false, err
+	}
+	return result.Trusted, nil
+}
+
+// DefaultRestrictedMethods are the methods a WorkspaceTrust disables in
+// untrusted workspaces if its Restricted field is nil: those that run
+// commands, and those that compute edits to the files of the workspace
+// in response to operations on files.
+var DefaultRestrictedMethods = []string{
+	"workspace/executeCommand",
+	"workspace/willCreateFiles",
+	"workspace/willRenameFiles",
+	"workspace/willDeleteFiles",
+}
+
+// A WorkspaceTrust tracks the trust of the workspace of a server, and
+// disables the features that could run code or modify files until the
+// workspace is trusted:
+//
+//	trust := &lsp.WorkspaceTrust{}
+//	client := trust.Client(lsp.ClientDispatcher(conn))
+//	handler := trust.Handler(lsp.ServerHandler(newServer(client)))
+//
+// The server should declare the capabilities returned by
+// [WorkspaceTrust.Capabilities] with [SetServerWorkspaceTrust].
+//
+// The workspace is initially trusted if the client declares so, or if
+// it does not declare the workspace trust capability, as clients unaware
+// of it do not restrict servers.
+type WorkspaceTrust struct {
+	// Restricted lists the methods that are disabled until the workspace
+	// is trusted, or prefixes of methods followed by "*", as for
+	// [MethodFilter]. If nil, it is DefaultRestrictedMethods.
+	Restricted []string
+	// OnChange, if not nil, is called when the trust of the workspace
+	// changes, for example to register capabilities once it is trusted.
+	OnChange func(ctx context.Context, trusted bool)
+
+	mu      sync.Mutex
+	trusted bool
+}
+
+// restricted returns the methods disabled until the workspace is
+// trusted.
+func (w *WorkspaceTrust) restricted() []string {
+	if w.Restricted == nil {
+		return DefaultRestrictedMethods
+	}
+	return w.Restricted
+}
+
+// Capabilities returns the workspace trust capabilities of a server that
+// uses w.
+func (w *WorkspaceTrust) Capabilities() WorkspaceTrustServerCapabilities {
+	return WorkspaceTrustServerCapabilities{RestrictedMode: true, RestrictedMethods: w.restricted()}
+}
+
+// Trusted reports whether the workspace is trusted.
+func (w *WorkspaceTrust) Trusted() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.trusted
+}
+
+// SetTrusted records whether the workspace is trusted, and calls
+// OnChange if that changed.
+func (w *WorkspaceTrust) SetTrusted(ctx context.Context, trusted bool) {
+	w.mu.Lock()
+	changed := w.trusted != trusted
+	w.trusted = trusted
+	w.mu.Unlock()
+	if changed && w.OnChange != nil {
+		w.OnChange(ctx, trusted)
+	}
+}
+
+// Initialize records the trust of the workspace declared by the
+// parameters of an initialize request.
+func (w *WorkspaceTrust) Initialize(ctx context.Context, params *ParamInitialize) {
+	trust, ok := ClientWorkspaceTrust(&params.Capabilities)
+	w.SetTrusted(ctx, !ok || trust.Trusted)
+}
+
+// Allowed reports whether method may be invoked, which it may unless it
+// is restricted and the workspace is not trusted.
+func (w *WorkspaceTrust) Allowed(method string) bool {
+	return w.Trusted() || !matchMethod(w.restricted(), method)
+}
+
+// Handler returns a handler that invokes handler, after calling
+// Initialize with the parameters of the initialize request. It handles
+// workspace/didChangeTrust notifications itself. While the workspace is
+// not trusted, requests for restricted methods fail with RequestFailed,
+// and notifications are dropped; both are logged through the event
+// system.
+func (w *WorkspaceTrust) Handler(handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case "initialize":
+			var params ParamInitialize
+			if err := UnmarshalJSON(req.Params, &params); err == nil {
+				w.Initialize(ctx, &params)
+			}
+		case DidChangeWorkspaceTrustMethod:
+			var params DidChangeWorkspaceTrustParams
+			if err := UnmarshalJSON(req.Params, &params); err != nil {
+				return nil, err
+			}
+			w.SetTrusted(ctx, params.Trusted)
+			return nil, nil
+		}
+		if w.Allowed(req.Method) {
+			return handler.Handle(ctx, req)
+		}
+		ctx = withRequest(ctx, req)
+		event.Log(ctx, "method disabled in untrusted workspace", RequestLabels(ctx)...)
+		if !req.IsCall() {
+			return nil, nil
+		}
+		return nil, Errorf(RequestFailed, "%s is disabled until the workspace is trusted", req.Method)
+	})
+}
+
+// Client returns a Client that invokes client, except that it does not
+// ask the client to apply workspace edits while the workspace is not
+// trusted: it reports them as not applied instead.
+func (w *WorkspaceTrust) Client(client Client) Client {
+	return &trustClient{Client: client, trust: w}
+}
+
+type trustClient struct {
+	Client
+	trust *WorkspaceTrust
+}
+
+func (c *trustClient) ApplyEdit(ctx context.Context, params *ApplyWorkspaceEditParams) (*ApplyWorkspaceEditResult, error) {
+	if !c.trust.Trusted() {
+		return &ApplyWorkspaceEditResult{FailureReason: "the workspace is not trusted"}, nil
+	}
+	return c.Client.ApplyEdit(ctx, params)
+}
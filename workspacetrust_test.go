@@ -0,0 +1,133 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/jsonrpc2"
+	"typefox.dev/lsp"
+)
+
+func TestWorkspaceTrustCapabilities(t *testing.T) {
+	var caps lsp.ClientCapabilities
+	if err := json.Unmarshal([]byte(`{"experimental":{"other":1,"workspaceTrust":{"trusted":true,"trustRequest":true}}}`), &caps); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := lsp.ClientWorkspaceTrust(&caps)
+	want := lsp.WorkspaceTrustClientCapabilities{Trusted: true, TrustRequest: true}
+	if !ok || got != want {
+		t.Errorf("Expected %+v, got %+v (%v)", want, got, ok)
+	}
+	if _, ok := lsp.ClientWorkspaceTrust(&lsp.ClientCapabilities{}); ok {
+		t.Errorf("Expected no workspace trust capabilities")
+	}
+
+	server := lsp.ServerCapabilities{Experimental: map[string]any{"other": 1}}
+	lsp.SetServerWorkspaceTrust(&server, (&lsp.WorkspaceTrust{}).Capabilities())
+	data, err := json.Marshal(server.Experimental)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const wantJSON = `{"other":1,"workspaceTrust":{"restrictedMode":true,"restrictedMethods":["workspace/executeCommand","workspace/willCreateFiles","workspace/willRenameFiles","workspace/willDeleteFiles"]}}`
+	if string(data) != wantJSON {
+		t.Errorf("Expected %s, got %s", wantJSON, data)
+	}
+}
+
+func TestWorkspaceTrust(t *testing.T) {
+	ctx := context.Background()
+	var changes []bool
+	trust := &lsp.WorkspaceTrust{
+		OnChange: func(_ context.Context, trusted bool) { changes = append(changes, trusted) },
+	}
+	var handled []string
+	handler := trust.Handler(jsonrpc2.HandlerFunc(func(_ context.Context, req *jsonrpc2.Request) (any, error) {
+		handled = append(handled, req.Method)
+		return nil, nil
+	}))
+	handle := func(method string, params any) error {
+		data, err := json.Marshal(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), method, json.RawMessage(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = handler.Handle(ctx, req)
+		return err
+	}
+
+	initialize := &lsp.ParamInitialize{}
+	initialize.Capabilities.Experimental = map[string]any{
+		lsp.WorkspaceTrustCapability: lsp.WorkspaceTrustClientCapabilities{Trusted: false, DidChangeTrust: true},
+	}
+	if err := handle("initialize", initialize); err != nil {
+		t.Fatal(err)
+	}
+	if trust.Trusted() {
+		t.Fatalf("Expected the workspace not to be trusted")
+	}
+	if err := handle("workspace/executeCommand", &lsp.ExecuteCommandParams{Command: "run"}); !errors.Is(err, lsp.ErrRequestFailed) {
+		t.Errorf("Expected RequestFailed, got %v", err)
+	}
+	if err := handle("textDocument/hover", &lsp.HoverParams{}); err != nil {
+		t.Errorf("Expected hover to be allowed, got %v", err)
+	}
+
+	client := &editRecorder{}
+	result, err := trust.Client(client).ApplyEdit(ctx, &lsp.ApplyWorkspaceEditParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applied || len(client.edits) != 0 {
+		t.Errorf("Expected no edit applied in an untrusted workspace")
+	}
+
+	if err := handle(lsp.DidChangeWorkspaceTrustMethod, &lsp.DidChangeWorkspaceTrustParams{Trusted: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle("workspace/executeCommand", &lsp.ExecuteCommandParams{Command: "run"}); err != nil {
+		t.Errorf("Expected executeCommand to be allowed once trusted, got %v", err)
+	}
+	if result, err := trust.Client(client).ApplyEdit(ctx, &lsp.ApplyWorkspaceEditParams{}); err != nil || !result.Applied {
+		t.Errorf("Expected the edit to be applied once trusted, got %+v, %v", result, err)
+	}
+	if diff := cmp.Diff([]string{"initialize", "textDocument/hover", "workspace/executeCommand"}, handled); diff != "" {
+		t.Errorf("Unexpected handled methods (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]bool{true}, changes); diff != "" {
+		t.Errorf("Unexpected changes (-want +got):\n%s", diff)
+	}
+
+	t.Run("UnawareClient", func(t *testing.T) {
+		trust := &lsp.WorkspaceTrust{}
+		trust.Initialize(ctx, &lsp.ParamInitialize{})
+		if !trust.Trusted() {
+			t.Errorf("Expected a client unaware of trust to trust the workspace")
+		}
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		_, server := connectPair(t, jsonrpc2.HandlerFunc(func(_ context.Context, req *jsonrpc2.Request) (any, error) {
+			if req.Method != lsp.WorkspaceTrustMethod {
+				return nil, jsonrpc2.ErrNotHandled
+			}
+			return &lsp.WorkspaceTrustResult{Trusted: true}, nil
+		}), nil)
+		trusted, err := lsp.QueryWorkspaceTrust(ctx, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !trusted {
+			t.Errorf("Expected the workspace to be trusted")
+		}
+	})
+}